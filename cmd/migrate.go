@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	migrateEventId     uint64
+	migrateStorageFile string
+)
+
+// migrateStorageCmd represents the migrate-storage command
+var migrateStorageCmd = &cobra.Command{
+	Use:   "migrate-storage",
+	Short: "Migrate an events media to a new storage",
+	Long: `Copies all pictures, videos and thumbnails of an event into a new storage, then switches the event over to it.
+
+The new storage is read from a JSON file, configured the same as when creating an event, e.g.
+	{"s3": {"bucket": "my-bucket", "accessKey": "...", "secretKey": "...", "region": "us-east-1", "endpoint": "s3.amazonaws.com"}}`,
+	Run: runMigrateStorage,
+}
+
+func runMigrateStorage(cmd *cobra.Command, args []string) {
+	logger := initLogger()
+	defer logger.Sync() // flushes buffer, if any
+
+	b, err := os.ReadFile(migrateStorageFile)
+	if err != nil {
+		logger.Fatal("reading storage file", zap.Error(err))
+	}
+	var req picturev1.MigrateEventStorageRequest
+	if err := protojson.Unmarshal(b, &req); err != nil {
+		logger.Fatal("parsing storage file", zap.Error(err))
+	}
+	req.EventId = migrateEventId
+
+	migrator, cleanup, err := initializeStorageMigrator(cfg, logger)
+	if err != nil {
+		logger.Fatal("creating storage migrator", zap.Error(err))
+	}
+	defer cleanup()
+
+	migration, err := migrator.Migrate(context.Background(), &req)
+	if err != nil {
+		logger.Fatal("migrating storage", zap.Error(err))
+	}
+	logger.Info("migrated storage", zap.Uint64("event", migration.GetEventId()), zap.Int64("files", migration.GetCompleted()))
+}
+
+func init() {
+	rootCmd.AddCommand(migrateStorageCmd)
+
+	migrateStorageCmd.Flags().Uint64Var(&migrateEventId, "event", 0, "ID of the event to migrate")
+	migrateStorageCmd.Flags().StringVar(&migrateStorageFile, "storage", "", "JSON file of the storage to migrate to")
+	migrateStorageCmd.MarkFlagRequired("event")
+	migrateStorageCmd.MarkFlagRequired("storage")
+}
@@ -28,14 +28,22 @@ var serverCmd = &cobra.Command{
 type serverApp struct {
 	server      *http.Server
 	thumbnailer *service.Thumbnailer
+	migrator    *service.StorageMigrator
+	webhooks    *service.WebhookDispatcher
+	scheduler   *service.EventScheduler
+	purger      *service.TrashPurger
 }
 
 // builds the final app to run for the server command.
 // This handles running an in-memory nats server and thumbnailer based on the config
-func newServerApp(cfg *config.Config, logger *zap.Logger, nc *nats.Conn, srv *http.Server, cache cache.Cache) (*serverApp, func(), error) {
+func newServerApp(cfg *config.Config, logger *zap.Logger, nc *nats.Conn, srv *http.Server, cache cache.Cache, migrator *service.StorageMigrator, webhooks *service.WebhookDispatcher, scheduler *service.EventScheduler, purger *service.TrashPurger) (*serverApp, func(), error) {
 	app := &serverApp{
 		server:      srv,
 		thumbnailer: nil,
+		migrator:    migrator,
+		webhooks:    webhooks,
+		scheduler:   scheduler,
+		purger:      purger,
 	}
 
 	var thumbnailer *service.Thumbnailer
@@ -65,6 +73,20 @@ func runServer(cmd *cobra.Command, args []string) {
 	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGTERM, syscall.SIGINT)
 	defer cancel()
 
+	// any storage migrations running when the server last stopped will never finish
+	if err := app.migrator.Recover(ctx); err != nil {
+		logger.Error("recovering storage migrations", zap.Error(err))
+	}
+	// likewise webhook deliveries being retried
+	if err := app.webhooks.Recover(ctx); err != nil {
+		logger.Error("recovering webhook deliveries", zap.Error(err))
+	}
+	if err := app.webhooks.Start(ctx); err != nil {
+		logger.Fatal("failed to start webhook dispatcher", zap.Error(err))
+	}
+	app.scheduler.Start(ctx)
+	app.purger.Start(ctx)
+
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
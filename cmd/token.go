@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	tokenUsername string
+	tokenExpiry   time.Duration
+)
+
+// apiTokenCmd represents the api-token command
+var apiTokenCmd = &cobra.Command{
+	Use:   "api-token",
+	Short: "Create a token to use the API as a user",
+	Long: `Creates a token to authenticate with the PictureService Connect/gRPC API as the given user.
+
+Send the token in the Authorization header, e.g.
+	curl -H "Authorization: Bearer $TOKEN" -H "Content-Type: application/json" -d '{}' \
+		http://localhost:8080/picture.v1.PictureService/GetEvents`,
+	Run: runApiToken,
+}
+
+func runApiToken(cmd *cobra.Command, args []string) {
+	logger := initLogger()
+	defer logger.Sync() // flushes buffer, if any
+
+	d, cleanup, err := initializeDb(cfg, logger)
+	if err != nil {
+		logger.Fatal("opening db", zap.Error(err))
+	}
+	defer cleanup()
+	user, err := d.GetUser(cmd.Context(), tokenUsername)
+	if err != nil {
+		logger.Fatal("getting user", zap.Error(err))
+	}
+
+	// tied to the users token version, so logging out everywhere revokes it too
+	token, err := auth.CreateTokenWithExpiry(cfg.Server.SecretKey, user.Username, user.TokenVersion, tokenExpiry)
+	if err != nil {
+		logger.Fatal("creating token", zap.Error(err))
+	}
+	fmt.Println(token)
+}
+
+func init() {
+	rootCmd.AddCommand(apiTokenCmd)
+
+	apiTokenCmd.Flags().StringVar(&tokenUsername, "username", "", "user to create the token for")
+	apiTokenCmd.Flags().DurationVar(&tokenExpiry, "expiry", time.Hour*24*30, "how long the token is valid for")
+	apiTokenCmd.MarkFlagRequired("username")
+}
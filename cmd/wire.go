@@ -11,7 +11,10 @@ import (
 	"github.com/jj-style/eventpix/internal/config"
 	"github.com/jj-style/eventpix/internal/data/db"
 	"github.com/jj-style/eventpix/internal/pkg/imagor"
+	"github.com/jj-style/eventpix/internal/pkg/mailer"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
 	"github.com/jj-style/eventpix/internal/pkg/validate"
+	"github.com/jj-style/eventpix/internal/ratelimit"
 	"github.com/jj-style/eventpix/internal/server"
 	"github.com/jj-style/eventpix/internal/service"
 	"github.com/nats-io/nats.go"
@@ -19,7 +22,7 @@ import (
 )
 
 func initializeServer(cfg *config.Config, logger *zap.Logger) (*serverApp, func(), error) {
-	panic(wire.Build(config.Provider, newGoogleDriveConfig, newNats, newHtmx, newCache, db.NewDb, validate.NewValidator, service.NewEventpixService, service.NewStorageService, service.NewAuthService, server.NewHttpServer, newServerApp))
+	panic(wire.Build(config.Provider, newGoogleDriveConfig, newNats, newHtmx, newCache, db.NewDb, validate.NewValidator, service.NewEventpixService, service.NewStorageService, service.NewAuthService, service.NewSettings, service.NewAccounts, service.NewAuditor, mailer.NewMailer, ratelimit.NewLimiter, service.NewStorageMigrator, service.NewWebhookDispatcher, service.NewEventScheduler, service.NewTrashPurger, auth.NewSessions, server.NewHttpServer, newServerApp))
 }
 
 func initializeThumbnailer(cfg *config.Config, logger *zap.Logger) (*service.Thumbnailer, func(), error) {
@@ -29,3 +32,11 @@ func initializeThumbnailer(cfg *config.Config, logger *zap.Logger) (*service.Thu
 func initializeThumbnailerInProc(cfg *config.Config, logger *zap.Logger, nc *nats.Conn, cache cache.Cache) (*service.Thumbnailer, func(), error) {
 	panic(wire.Build(config.Provider, newGoogleDriveConfig, db.NewDb, imagor.NewImagor, service.NewThumbnailer))
 }
+
+func initializeStorageMigrator(cfg *config.Config, logger *zap.Logger) (*service.StorageMigrator, func(), error) {
+	panic(wire.Build(config.Provider, newGoogleDriveConfig, db.NewDb, service.NewAuditor, service.NewStorageMigrator))
+}
+
+func initializeDb(cfg *config.Config, logger *zap.Logger) (db.DB, func(), error) {
+	panic(wire.Build(config.Provider, newGoogleDriveConfig, db.NewDb))
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/jj-style/eventpix/internal/config"
 	"github.com/jj-style/eventpix/internal/data/db"
 	"github.com/jj-style/eventpix/internal/pkg/imagor"
+	"github.com/jj-style/eventpix/internal/pkg/mailer"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
 	"github.com/jj-style/eventpix/internal/pkg/validate"
+	"github.com/jj-style/eventpix/internal/ratelimit"
 	"github.com/jj-style/eventpix/internal/server"
 	"github.com/jj-style/eventpix/internal/service"
 	"github.com/nats-io/nats.go"
@@ -46,11 +49,36 @@ func initializeServer(cfg2 *config.Config, logger *zap.Logger) (*serverApp, func
 		return nil, nil, err
 	}
 	storageService := service.NewStorageService(dbDB, logger, cacheCache)
-	authService := service.NewAuthService(cfg2, dbDB, htmx)
+	sessions := auth.NewSessions(cfg2)
+	settings, err := service.NewSettings(cfg2, dbDB)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	auditor := service.NewAuditor(dbDB, logger)
+	authService := service.NewAuthService(cfg2, dbDB, sessions, settings, htmx, auditor)
 	validator := validate.NewValidator()
-	eventpixService := service.NewEventpixService(logger, dbDB, conn, validator, cacheCache)
-	httpServer := server.NewHttpServer(cfg2, htmx, storageService, authService, eventpixService, dbDB, conn, logger, oauth2Config, validator)
-	cmdServerApp, cleanup3, err := newServerApp(cfg2, logger, conn, httpServer, cacheCache)
+	eventpixService := service.NewEventpixService(logger, dbDB, conn, validator, cacheCache, auditor)
+	storageMigrator := service.NewStorageMigrator(dbDB, logger, oauth2Config, auditor)
+	webhookDispatcher := service.NewWebhookDispatcher(dbDB, conn, logger)
+	mailerMailer, err := mailer.NewMailer(cfg2, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	accounts := service.NewAccounts(cfg2, dbDB, eventpixService, mailerMailer, logger, auditor)
+	limiter, err := ratelimit.NewLimiter(cache)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	httpServer := server.NewHttpServer(cfg2, htmx, storageService, authService, eventpixService, storageMigrator, webhookDispatcher, sessions, settings, accounts, auditor, limiter, dbDB, conn, logger, oauth2Config, validator)
+	eventScheduler := service.NewEventScheduler(dbDB, conn, logger, auditor)
+	trashPurger := service.NewTrashPurger(dbDB, cfg2, logger, auditor)
+	cmdServerApp, cleanup3, err := newServerApp(cfg2, logger, conn, httpServer, cacheCache, storageMigrator, webhookDispatcher, eventScheduler, trashPurger)
 	if err != nil {
 		cleanup2()
 		cleanup()
@@ -119,3 +147,35 @@ func initializeThumbnailerInProc(cfg2 *config.Config, logger *zap.Logger, nc *na
 		cleanup()
 	}, nil
 }
+
+func initializeStorageMigrator(cfg2 *config.Config, logger *zap.Logger) (*service.StorageMigrator, func(), error) {
+	database := config.DatabaseProvider(cfg2)
+	oauth2Config, err := newGoogleDriveConfig(cfg2)
+	if err != nil {
+		return nil, nil, err
+	}
+	dbDB, cleanup, err := db.NewDb(database, logger, oauth2Config)
+	if err != nil {
+		return nil, nil, err
+	}
+	auditor := service.NewAuditor(dbDB, logger)
+	storageMigrator := service.NewStorageMigrator(dbDB, logger, oauth2Config, auditor)
+	return storageMigrator, func() {
+		cleanup()
+	}, nil
+}
+
+func initializeDb(cfg2 *config.Config, logger *zap.Logger) (db.DB, func(), error) {
+	database := config.DatabaseProvider(cfg2)
+	oauth2Config, err := newGoogleDriveConfig(cfg2)
+	if err != nil {
+		return nil, nil, err
+	}
+	dbDB, cleanup, err := db.NewDb(database, logger, oauth2Config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dbDB, func() {
+		cleanup()
+	}, nil
+}
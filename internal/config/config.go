@@ -1,7 +1,11 @@
 // defines config for the application
 package config
 
-import "github.com/google/wire"
+import (
+	"time"
+
+	"github.com/google/wire"
+)
 
 type Config struct {
 	Server       *Server       `mapstructure:"server"`
@@ -10,6 +14,14 @@ type Config struct {
 	Nats         *Nats         `mapstructure:"nats"`
 	OauthSecrets *OauthSecrets `mapstructure:"oauth"`
 	Cache        *Cache        `mapstructure:"cache"`
+	// OpenID Connect providers owners can sign in with
+	Oidc []*OidcProvider `mapstructure:"oidc"`
+	// how emails are sent, logged instead if not set
+	Mail *Mail `mapstructure:"mail"`
+	// overrides the default rate limits by name: login, register, guestlogin, passwordreset, upload and shortlink
+	RateLimits map[string]*RateLimit `mapstructure:"rateLimits"`
+	// how long deleted events are kept in the trash
+	Trash *Trash `mapstructure:"trash"`
 }
 
 type Server struct {
@@ -21,6 +33,12 @@ type Server struct {
 	FormbeeKey        string `mapstructure:"formbeeKey"`
 	SingleEventMode   bool   `mapstructure:"singleEventMode"`
 	DisableSignups    bool   `mapstructure:"disableSignups"`
+	// how long a login lasts without being used, 24h if not set
+	SessionTtl time.Duration `mapstructure:"sessionTtl"`
+	// how long a login can be kept alive for by using it, 30 days if not set
+	SessionMaxAge time.Duration `mapstructure:"sessionMaxAge"`
+	// origins allowed to make cross origin requests, e.g. https://example.com, none if not set
+	CorsOrigins []string `mapstructure:"corsOrigins"`
 }
 
 type Database struct {
@@ -43,6 +61,31 @@ type Cache struct {
 	Ttl      int64 `mapstructure:"ttl"`
 }
 
+type Mail struct {
+	// log or smtp
+	Mode string `mapstructure:"mode"`
+	Host string `mapstructure:"host"`
+	// 25 if not set
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	// address emails are sent from
+	From string `mapstructure:"from"`
+}
+
+type RateLimit struct {
+	// requests allowed in the window, unlimited if 0
+	Requests int           `mapstructure:"requests"`
+	Window   time.Duration `mapstructure:"window"`
+}
+
+type Trash struct {
+	// how long deleted events can be restored for before they're purged, 30 days if not set
+	Retention time.Duration `mapstructure:"retention"`
+	// also delete the events photos and videos from its storage when it's purged
+	DeleteMedia bool `mapstructure:"deleteMedia"`
+}
+
 type Imagor struct {
 	Url string `mapstructure:"url"`
 }
@@ -57,6 +100,23 @@ type GoogleOauth struct {
 	RedirectUri string `mapstructure:"redirectUri"`
 }
 
+type OidcProvider struct {
+	// identifies the provider in its login and callback URLs, /auth/oidc/<name>/callback
+	Name string `mapstructure:"name"`
+	// shown on the sign in button, the name if not set
+	DisplayName  string `mapstructure:"displayName"`
+	Issuer       string `mapstructure:"issuer"`
+	ClientId     string `mapstructure:"clientId"`
+	ClientSecret string `mapstructure:"clientSecret"`
+	// requested as well as openid, profile and email
+	Scopes []string `mapstructure:"scopes"`
+	// claim with the users groups in the ID token, groups if not set
+	GroupsClaim string `mapstructure:"groupsClaim"`
+	// members of the group are made admins, and everyone else isn't.
+	// Admins aren't managed by the provider if not set.
+	AdminGroup string `mapstructure:"adminGroup"`
+}
+
 func DatabaseProvider(cfg *Config) *Database {
 	return cfg.Database
 }
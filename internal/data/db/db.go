@@ -2,11 +2,16 @@
 package db
 
 import (
+	"cmp"
 	"context"
+	"crypto/rand"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/jj-style/eventpix/internal/config"
 	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
@@ -22,6 +27,18 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// ErrUserOwnsEvents is returned deleting a user whose events haven't been transferred or deleted
+var ErrUserOwnsEvents = errors.New("user still owns events, transfer or delete them first")
+
+// ErrSlugTaken is returned changing an events slug to one another event has, or had
+var ErrSlugTaken = errors.New("slug is already taken")
+
+// ErrUserExists is returned creating or renaming a user to a username or email someone else has
+var ErrUserExists = errors.New("user already exists")
+
+// ErrMediaNotMigrated is returned switching an events storage while it has media that wasn't copied to the new one
+var ErrMediaNotMigrated = errors.New("event has media which hasn't been migrated")
+
 //go:generate go tool mockery
 type DB interface {
 	CreateEvent(context.Context, *Event) (uint, error)
@@ -32,17 +49,95 @@ type DB interface {
 	SetActiveEvent(context.Context, uint64) error
 	AddFileInfo(context.Context, *FileInfo) error
 	GetFileInfo(context.Context, string) (*FileInfo, error)
+	AddPendingUpload(context.Context, *PendingUpload) error
+	TakePendingUpload(ctx context.Context, eventId uint, key string) error
 	AddThumbnailInfo(context.Context, *ThumbnailInfo) error
-	GetThumbnails(ctx context.Context, eventId uint, limit int, offset int) ([]*ThumbnailInfo, error)
+	GetThumbnails(ctx context.Context, eventId uint, limit int, offset int, includeHidden bool) ([]*ThumbnailInfo, error)
 	GetThumbnailInfo(context.Context, string) (*ThumbnailInfo, error)
 	SetEventLive(context.Context, uint64, bool) (*Event, error)
+	UpdateEvent(ctx context.Context, id uint64, update *EventUpdate) (*Event, error)
+	CreateAuditLog(context.Context, *AuditLog) error
+	GetAuditLogs(ctx context.Context, eventId *uint, limit int) ([]*AuditLog, error)
+	SetEventSchedule(ctx context.Context, id uint64, startsAt, endsAt, expiresAt *time.Time) (*Event, error)
+	RunEventSchedules(ctx context.Context, now time.Time) ([]*Event, error)
 	DeleteEvent(context.Context, uint64) error
+	GetTrashedEvents(ctx context.Context, userId uint) ([]*Event, error)
+	RestoreEvent(ctx context.Context, id uint64) (*Event, error)
+	GetTrashedEventsBefore(ctx context.Context, before time.Time) ([]*Event, error)
+	GetTrashedEvent(ctx context.Context, id uint64) (*Event, error)
+	PurgeEvent(ctx context.Context, id uint64) error
 	CreateUser(context.Context, string, string) error
 	GetUser(context.Context, string) (*User, error)
-	UserAuthorizedForEvent(context.Context, uint, uint) (bool, error)
+	GetUserByID(ctx context.Context, userId uint) (*User, error)
+	UpdateUser(ctx context.Context, userId uint, username, email string) error
+	RevokeUserSessions(ctx context.Context, userId uint) error
+	SetUserAdmin(ctx context.Context, userId uint, admin bool) error
+	GetOidcIdentity(ctx context.Context, issuer, subject string) (*OidcIdentity, error)
+	CreateOidcIdentity(context.Context, *OidcIdentity) error
+	CreateOidcUser(ctx context.Context, username string, identity *OidcIdentity) (*User, error)
+	DeleteOidcIdentity(ctx context.Context, userId, identityId uint) error
+	GetSetting(ctx context.Context, name string) (string, error)
+	SetSetting(ctx context.Context, name, value string) error
+	GetUsers(context.Context) ([]*User, error)
+	GetEventUsages(context.Context) ([]*EventUsage, error)
+	SetUserDisabled(ctx context.Context, userId uint, disabled bool) error
+	DeleteUser(ctx context.Context, userId uint) error
+	ResetUserPassword(ctx context.Context, userId uint, password string) error
+	RecordLoginFailure(ctx context.Context, userId uint) (*time.Time, error)
+	ResetLoginFailures(ctx context.Context, userId uint) error
+	TransferEvent(ctx context.Context, eventId uint64, userId uint) error
+	UserAuthorizedForEvent(ctx context.Context, userId, eventId uint, role string) (bool, error)
+	GetEventRole(ctx context.Context, userId, eventId uint) (string, error)
+	FindUser(ctx context.Context, usernameOrEmail string) (*User, error)
+	GetEventMembers(ctx context.Context, eventId uint) ([]*EventMember, error)
+	CreateEventMember(context.Context, *EventMember) error
+	SetEventMemberRole(ctx context.Context, eventId, memberId uint, role string) error
+	DeleteEventMember(ctx context.Context, eventId, memberId uint) error
+	GetEventInvites(ctx context.Context, userId uint) ([]*EventMember, error)
+	AcceptEventInvite(ctx context.Context, userId, eventId uint) error
+	LeaveEvent(ctx context.Context, userId, eventId uint) error
 	StoreGoogleToken(ctx context.Context, userId uint, token []byte) error
 	GetGoogleToken(ctx context.Context, uideId uint) ([]byte, error)
 	DeleteGoogleToken(ctx context.Context, userId uint) error
+	GetFileInfos(ctx context.Context, eventId uint) ([]*FileInfo, error)
+	SetFileHidden(ctx context.Context, eventId uint, id string, hidden bool) error
+	DeleteFileInfo(ctx context.Context, eventId uint, id string) ([]*ThumbnailInfo, error)
+	CreateStorageMigration(context.Context, *StorageMigration) error
+	UpdateStorageMigration(context.Context, *StorageMigration) error
+	GetStorageMigration(ctx context.Context, eventId uint) (*StorageMigration, error)
+	FailRunningStorageMigrations(context.Context) error
+	SwitchEventStorage(ctx context.Context, migration *StorageMigration, target *Event, fileIds, thumbnailIds, coverIds map[string]string) error
+	CreateApiToken(context.Context, *ApiToken) error
+	GetApiTokens(ctx context.Context, userId uint) ([]*ApiToken, error)
+	GetApiToken(ctx context.Context, hash string) (*ApiToken, error)
+	DeleteApiToken(ctx context.Context, userId, tokenId uint) error
+	CreateWebhook(context.Context, *Webhook) error
+	GetWebhooks(ctx context.Context, userId uint) ([]*Webhook, error)
+	GetEventWebhooks(ctx context.Context, userId, eventId uint) ([]*Webhook, error)
+	DeleteWebhook(ctx context.Context, userId, webhookId uint) error
+	CreateWebhookDelivery(context.Context, *WebhookDelivery) error
+	UpdateWebhookDelivery(context.Context, *WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, userId, webhookId uint, limit int) ([]*WebhookDelivery, error)
+	FailPendingWebhookDeliveries(context.Context) error
+	CreateGuestToken(context.Context, *GuestToken) error
+	GetGuestTokens(ctx context.Context, eventId uint) ([]*GuestToken, error)
+	GetGuestToken(ctx context.Context, eventId, tokenId uint) (*GuestToken, error)
+	RotateGuestToken(ctx context.Context, eventId, tokenId uint) (*GuestToken, error)
+	DeleteGuestToken(ctx context.Context, eventId, tokenId uint) error
+	GetEventPasswordHash(ctx context.Context, eventId uint64) (string, error)
+	CreateEventTemplate(context.Context, *EventTemplate) error
+	GetEventTemplates(ctx context.Context, userId uint) ([]*EventTemplate, error)
+	GetEventTemplate(ctx context.Context, userId, templateId uint) (*EventTemplate, error)
+	DeleteEventTemplate(ctx context.Context, userId, templateId uint) error
+	SaveEventBranding(context.Context, *EventBranding) error
+	CreateShortLink(context.Context, *ShortLink) error
+	GetShortLinks(ctx context.Context, eventId uint) ([]*ShortLink, error)
+	GetShortLink(ctx context.Context, code string) (*ShortLink, error)
+	CountShortLinkScan(ctx context.Context, linkId uint) error
+	DeleteShortLink(ctx context.Context, eventId, linkId uint) error
+	AddGuestVisit(ctx context.Context, eventId uint, session string) error
+	CountFileView(ctx context.Context, id string, download bool) error
+	GetEventStats(ctx context.Context, eventId uint, top int) (*EventStats, error)
 }
 
 type dbImpl struct {
@@ -86,14 +181,32 @@ func NewDb(cfg *config.Database, logger *zap.Logger, googleOauthConfig *oauth2.C
 		&GoogleDriveToken{},
 		&Event{},
 		&FileInfo{},
+		&PendingUpload{},
 		&ThumbnailInfo{},
 		&FileSystemStorage{},
 		&S3Storage{},
 		&GoogleDriveStorage{},
 		&FtpStorage{},
+		&StorageMigration{},
+		&ApiToken{},
+		&Webhook{},
+		&WebhookDelivery{},
+		&GuestToken{},
+		&OidcIdentity{},
+		&Setting{},
+		&EventMember{},
+		&EventSlugRedirect{},
+		&AuditLog{},
+		&EventTemplate{},
+		&EventBranding{},
+		&ShortLink{},
+		&GuestVisit{},
 	); err != nil {
 		return nil, func() {}, fmt.Errorf("migrating db: %w", err)
 	}
+	if err := hashEventPasswords(db); err != nil {
+		return nil, func() {}, fmt.Errorf("hashing event passwords: %w", err)
+	}
 
 	// create initial admin user
 	var admin User
@@ -111,6 +224,27 @@ func NewDb(cfg *config.Database, logger *zap.Logger, googleOauthConfig *oauth2.C
 	return &dbImpl{db, logger.Sugar(), googleOauthConfig}, func() {}, nil
 }
 
+// hashes event passwords older versions stored reversibly, then forgets them
+func hashEventPasswords(db *gorm.DB) error {
+	var events []Event
+	if err := db.Select("id", "password").Where("password IS NOT NULL").Find(&events).Error; err != nil {
+		return err
+	}
+	for _, evt := range events {
+		pwd, _ := evt.Password.Raw.(string)
+		hash, err := auth.EncryptPassword(pwd)
+		if err != nil {
+			return err
+		}
+		if err := db.Model(&Event{}).
+			Where("id = ?", evt.ID).
+			Updates(map[string]any{"password_hash": hash, "password": nil}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (d *dbImpl) CreateEvent(ctx context.Context, evt *Event) (uint, error) {
 	result := d.db.WithContext(ctx).Create(evt)
 	if result.Error != nil {
@@ -136,10 +270,324 @@ func (d *dbImpl) SetEventLive(ctx context.Context, id uint64, live bool) (*Event
 	return &events[0], nil
 }
 
+// SetEventSchedule changes when the event is live and archived. The scheduler
+// starts over with the new times, so an archived event can be brought back.
+func (d *dbImpl) SetEventSchedule(ctx context.Context, id uint64, startsAt, endsAt, expiresAt *time.Time) (*Event, error) {
+	var events []Event
+	result := d.db.WithContext(ctx).
+		Model(&events).
+		Clauses(clause.Returning{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"starts_at":  startsAt,
+			"ends_at":    endsAt,
+			"expires_at": expiresAt,
+			"started":    false,
+			"ended":      false,
+			"archived":   false,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected != 1 {
+		return nil, fmt.Errorf("%d events affected", result.RowsAffected)
+	}
+	return &events[0], nil
+}
+
+// RunEventSchedules sets events live, not live or archives them as their schedules say to by now,
+// returning the events which were changed. Archived events are no longer active.
+// Each step is only taken once, even with many servers running schedules at the same time.
+func (d *dbImpl) RunEventSchedules(ctx context.Context, now time.Time) ([]*Event, error) {
+	var due []Event
+	if err := d.db.WithContext(ctx).
+		Where("(starts_at <= ? AND NOT started) OR (ends_at <= ? AND NOT ended) OR (expires_at <= ? AND NOT archived)", now, now, now).
+		Find(&due).Error; err != nil {
+		return nil, err
+	}
+
+	var changed []*Event
+	for _, evt := range due {
+		live, archived, ok := evt.Schedule(now)
+		if !ok {
+			continue
+		}
+		updates := map[string]any{
+			"live":     live,
+			"started":  evt.Started || evt.StartsAt != nil && !now.Before(*evt.StartsAt),
+			"ended":    evt.Ended || evt.EndsAt != nil && !now.Before(*evt.EndsAt),
+			"archived": archived,
+		}
+		if archived {
+			updates["active"] = false
+		}
+		// only if another server hasn't got to it first
+		result := d.db.WithContext(ctx).
+			Model(&Event{}).
+			Where("id = ? AND started = ? AND ended = ? AND archived = ?", evt.ID, evt.Started, evt.Ended, evt.Archived).
+			Updates(updates)
+		if result.Error != nil {
+			return changed, result.Error
+		}
+		if result.RowsAffected != 1 {
+			continue
+		}
+		if live != evt.Live || archived {
+			wasLive := evt.Live
+			evt.Live, evt.Archived = live, archived
+			if archived {
+				evt.Active = false
+			}
+			d.log.Infof("scheduled event %d from live=%t to live=%t archived=%t", evt.ID, wasLive, live, archived)
+			changed = append(changed, &evt)
+		}
+	}
+	return changed, nil
+}
+
+// DeleteEvent moves the event to the trash, taking it down until it's restored or purged.
+// Its slug is moved aside so other events can use it meanwhile, but its old slugs are kept
+// so they lead to it again if it's restored.
 func (d *dbImpl) DeleteEvent(ctx context.Context, id uint64) error {
-	return d.db.WithContext(ctx).Unscoped().Delete(&Event{}, id).Error
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var event Event
+		if err := tx.Select("id", "slug").First(&event, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&event).Updates(map[string]any{
+			"live":         false,
+			"active":       false,
+			"trashed_slug": event.Slug,
+			// can never be a real slug
+			"slug": fmt.Sprintf("trashed:%d", event.ID),
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&event).Error
+	})
+}
+
+// GetTrashedEvents gets the events in the trash the user is an owner of, most recently deleted first
+func (d *dbImpl) GetTrashedEvents(ctx context.Context, userId uint) ([]*Event, error) {
+	owned := d.db.Model(&EventMember{}).Select("event_id").Where(&EventMember{UserID: userId, Role: RoleOwner, Accepted: true})
+	var events []*Event
+	if err := d.db.WithContext(ctx).
+		Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Where("user_id = ? OR id IN (?)", userId, owned).
+		Order("deleted_at DESC").
+		Find(&events).Error; err != nil {
+		d.log.Errorf("getting user(%d) trashed events from db: %v", userId, err)
+		return nil, err
+	}
+	return events, nil
+}
+
+// RestoreEvent takes the event back out of the trash. If another event has taken its slug
+// in the meantime it gets the slug with -restored on the end.
+func (d *dbImpl) RestoreEvent(ctx context.Context, id uint64) (*Event, error) {
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var event Event
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&event, id).Error; err != nil {
+			return err
+		}
+		slug := event.TrashedSlug
+		for {
+			var taken int64
+			if err := tx.Unscoped().Model(&Event{}).Where("slug = ?", slug).Count(&taken).Error; err != nil {
+				return err
+			}
+			var redirects int64
+			if err := tx.Model(&EventSlugRedirect{}).Where("slug = ? AND event_id != ?", slug, id).Count(&redirects).Error; err != nil {
+				return err
+			}
+			if taken+redirects == 0 {
+				break
+			}
+			slug += "-restored"
+		}
+		return tx.Unscoped().Model(&event).Updates(map[string]any{
+			"slug":         slug,
+			"trashed_slug": "",
+			"deleted_at":   nil,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d.GetEvent(ctx, id)
+}
+
+// GetTrashedEventsBefore gets the events deleted before the time with their media and storage,
+// so they can be purged. Storage is left unset for events whose storage can't be set up.
+func (d *dbImpl) GetTrashedEventsBefore(ctx context.Context, before time.Time) ([]*Event, error) {
+	var events []*Event
+	if err := d.db.WithContext(ctx).
+		Unscoped().
+		Preload(clause.Associations).
+		Preload("User.GoogleDriveToken").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if err := ExtractEventStorage(event, d.googleOauthConfig); err != nil {
+			d.log.Errorf("extracting trashed event(%d) storage: %v", event.ID, err)
+		}
+	}
+	return events, nil
+}
+
+// GetTrashedEvent gets the event in the trash with its media and storage
+func (d *dbImpl) GetTrashedEvent(ctx context.Context, id uint64) (*Event, error) {
+	var event Event
+	if err := d.db.WithContext(ctx).
+		Unscoped().
+		Preload(clause.Associations).
+		Preload("User.GoogleDriveToken").
+		Where("deleted_at IS NOT NULL").
+		First(&event, id).Error; err != nil {
+		return nil, err
+	}
+	if err := ExtractEventStorage(&event, d.googleOauthConfig); err != nil {
+		d.log.Errorf("extracting trashed event(%d) storage: %v", id, err)
+	}
+	return &event, nil
+}
+
+// PurgeEvent permanently deletes an event in the trash along with everything that belongs to it
+func (d *dbImpl) PurgeEvent(ctx context.Context, id uint64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("deleted_at IS NOT NULL").Delete(&Event{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		webhooks := tx.Unscoped().Model(&Webhook{}).Select("id").Where("event_id = ?", id)
+		if err := tx.Unscoped().Where("webhook_id IN (?)", webhooks).Delete(&WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		for _, model := range []any{
+			&ThumbnailInfo{}, &FileInfo{}, &PendingUpload{},
+			&FileSystemStorage{}, &S3Storage{}, &GoogleDriveStorage{}, &FtpStorage{},
+			&StorageMigration{}, &GuestToken{}, &EventMember{}, &EventSlugRedirect{}, &Webhook{}, &EventBranding{},
+			&ShortLink{}, &GuestVisit{},
+		} {
+			if err := tx.Unscoped().Where("event_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateEvent changes the event. When the slug changes the old one is kept redirecting to the event,
+// so the slug can't be one another event has or had.
+func (d *dbImpl) UpdateEvent(ctx context.Context, id uint64, update *EventUpdate) (*Event, error) {
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var event Event
+		if err := tx.Preload("S3Storage").Preload("FtpStorage").First(&event, id).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]any{}
+		if update.Name != nil {
+			updates["name"] = *update.Name
+		}
+		if update.Slug != nil && *update.Slug != event.Slug {
+			var taken int64
+			// trashed events move their slug aside, so only live events can have it
+			if err := tx.Unscoped().Model(&Event{}).Where("slug = ? AND id != ?", *update.Slug, id).Count(&taken).Error; err != nil {
+				return err
+			}
+			var redirect EventSlugRedirect
+			err := tx.First(&redirect, "slug = ?", *update.Slug).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if taken > 0 || (err == nil && redirect.EventID != uint(id)) {
+				return ErrSlugTaken
+			}
+			// changing back to an old slug, which no longer needs redirecting
+			if err == nil {
+				if err := tx.Delete(&redirect).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Save(&EventSlugRedirect{Slug: event.Slug, EventID: uint(id)}).Error; err != nil {
+				return err
+			}
+			updates["slug"] = *update.Slug
+		}
+		if update.PasswordHash != nil {
+			if *update.PasswordHash == "" {
+				updates["password_hash"] = nil
+			} else {
+				updates["password_hash"] = *update.PasswordHash
+			}
+		}
+		if update.Cache != nil {
+			updates["cache"] = *update.Cache
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&event).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		if update.AccessKey != nil || update.SecretKey != nil {
+			if event.S3Storage == nil {
+				return errors.New("event storage isn't S3")
+			}
+			event.S3Storage.AccessKey = gormcrypto.EncryptedValue{Raw: lo.FromPtr(update.AccessKey)}
+			event.S3Storage.SecretKey = gormcrypto.EncryptedValue{Raw: lo.FromPtr(update.SecretKey)}
+			if err := tx.Save(event.S3Storage).Error; err != nil {
+				return err
+			}
+		}
+		if update.Username != nil || update.Password != nil {
+			if event.FtpStorage == nil {
+				return errors.New("event storage isn't FTP")
+			}
+			event.FtpStorage.Username = gormcrypto.EncryptedValue{Raw: lo.FromPtr(update.Username)}
+			event.FtpStorage.Password = gormcrypto.EncryptedValue{Raw: lo.FromPtr(update.Password)}
+			if err := tx.Save(event.FtpStorage).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d.GetEvent(ctx, id)
+}
+
+// CreateAuditLog records the entry in the audit log
+func (d *dbImpl) CreateAuditLog(ctx context.Context, entry *AuditLog) error {
+	return d.db.WithContext(ctx).Create(entry).Error
 }
 
+// GetAuditLogs gets the audit log, newest first, only for the event if it's set.
+// Everything is got if the limit is 0.
+func (d *dbImpl) GetAuditLogs(ctx context.Context, eventId *uint, limit int) ([]*AuditLog, error) {
+	query := d.db.WithContext(ctx).Order("created_at DESC, id DESC")
+	if eventId != nil {
+		query = query.Where("event_id = ?", *eventId)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var entries []*AuditLog
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetEvents gets the events the user owns or is a member of, with their role in each
 func (d *dbImpl) GetEvents(ctx context.Context, userId uint) ([]*Event, error) {
 	var events []Event
 	result := d.db.WithContext(ctx).Where(&Event{UserID: userId}).Find(&events)
@@ -147,7 +595,28 @@ func (d *dbImpl) GetEvents(ctx context.Context, userId uint) ([]*Event, error) {
 		d.log.Errorf("error querying events in db: %w", result.Error)
 		return nil, result.Error
 	}
-	return lo.Map(events, func(e Event, _ int) *Event { return &e }), nil
+	var members []EventMember
+	if err := d.db.WithContext(ctx).
+		Preload("Event").
+		Where(&EventMember{UserID: userId, Accepted: true}).
+		Find(&members).Error; err != nil {
+		d.log.Errorf("error querying user(%d) event memberships in db: %v", userId, err)
+		return nil, err
+	}
+
+	ret := lo.Map(events, func(e Event, _ int) *Event {
+		e.Role = RoleOwner
+		return &e
+	})
+	for _, m := range members {
+		// the event may have been deleted
+		if m.Event.ID != 0 {
+			m.Event.Role = m.Role
+			ret = append(ret, &m.Event)
+		}
+	}
+	slices.SortFunc(ret, func(a, b *Event) int { return cmp.Compare(a.ID, b.ID) })
+	return ret, nil
 }
 
 func (d *dbImpl) GetEvent(ctx context.Context, id uint64) (*Event, error) {
@@ -180,6 +649,13 @@ func (d *dbImpl) GetEventBySlug(ctx context.Context, slug string) (*Event, error
 		Preload(clause.Associations).
 		Preload("User.GoogleDriveToken").
 		First(&event, "slug = ?", slug)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		// the events slug may have been changed
+		var redirect EventSlugRedirect
+		if err := d.db.WithContext(ctx).First(&redirect, "slug = ?", slug).Error; err == nil {
+			return d.GetEvent(ctx, uint64(redirect.EventID))
+		}
+	}
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			d.log.Errorf("event with slug(%s) not found in db", slug)
@@ -253,11 +729,38 @@ func (d *dbImpl) AddFileInfo(ctx context.Context, fi *FileInfo) error {
 	return nil
 }
 
+// AddPendingUpload records the key handed out for an upload, clearing out ones which have expired.
+// Handing out the same key again gives it longer to be uploaded.
+func (d *dbImpl) AddPendingUpload(ctx context.Context, upload *PendingUpload) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", time.Now()).Delete(&PendingUpload{}).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(upload).Error
+	})
+}
+
+// TakePendingUpload checks the key was handed out for an upload to the event and hasn't expired,
+// so it can only be added to the event once. gorm.ErrRecordNotFound if it wasn't.
+func (d *dbImpl) TakePendingUpload(ctx context.Context, eventId uint, key string) error {
+	result := d.db.WithContext(ctx).
+		Where("event_id = ? AND object_key = ? AND expires_at >= ?", eventId, key, time.Now()).
+		Delete(&PendingUpload{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 func (d *dbImpl) GetFileInfo(ctx context.Context, id string) (*FileInfo, error) {
 	var fi FileInfo
 	result := d.db.
 		WithContext(ctx).
 		Preload(clause.Associations).
+		Preload("Event.S3Storage").
 		First(&fi, "id = ?", id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -280,14 +783,19 @@ func (d *dbImpl) AddThumbnailInfo(ctx context.Context, ti *ThumbnailInfo) error
 	return nil
 }
 
-func (d *dbImpl) GetThumbnails(ctx context.Context, eventId uint, limit int, offset int) ([]*ThumbnailInfo, error) {
+// GetThumbnails pages through the events thumbnails, newest first.
+// Media moderators have hidden from the gallery is left out unless asked for.
+func (d *dbImpl) GetThumbnails(ctx context.Context, eventId uint, limit int, offset int, includeHidden bool) ([]*ThumbnailInfo, error) {
 	var thumbnails []ThumbnailInfo
-	result := d.db.WithContext(ctx).
+	query := d.db.WithContext(ctx).
 		Offset(offset).
 		Limit(limit).
 		Preload(clause.Associations).
-		Order("created_at desc").
-		Find(&thumbnails, ThumbnailInfo{EventID: eventId})
+		Order("created_at desc")
+	if !includeHidden {
+		query = query.Where("file_info_id NOT IN (?)", d.db.Model(&FileInfo{}).Select("id").Where("event_id = ? AND hidden = ?", eventId, true))
+	}
+	result := query.Find(&thumbnails, ThumbnailInfo{EventID: eventId})
 	if result.Error != nil {
 		d.log.Errorf("error querying thumbnails in event(%d): %w", eventId, result.Error)
 		return nil, result.Error
@@ -300,6 +808,7 @@ func (d *dbImpl) GetThumbnailInfo(ctx context.Context, id string) (*ThumbnailInf
 	result := d.db.
 		WithContext(ctx).
 		Preload(clause.Associations).
+		Preload("Event.S3Storage").
 		First(&ti, "id = ?", id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -317,7 +826,10 @@ func (d *dbImpl) CreateUser(ctx context.Context, username string, password strin
 	var existingUser User
 	result := d.db.WithContext(ctx).Where("username = ?", username).First(&existingUser)
 	if result.RowsAffected > 0 {
-		return errors.New("user already exists")
+		return ErrUserExists
+	}
+	if err := auth.CheckPasswordStrength(username, password); err != nil {
+		return err
 	}
 
 	password, err := auth.EncryptPassword(password)
@@ -339,18 +851,180 @@ func (d *dbImpl) GetUser(ctx context.Context, username string) (*User, error) {
 	return &user, nil
 }
 
-func (d *dbImpl) UserAuthorizedForEvent(ctx context.Context, userId, eventId uint) (bool, error) {
+func (d *dbImpl) GetUserByID(ctx context.Context, userId uint) (*User, error) {
+	var user User
+	if err := d.db.WithContext(ctx).First(&user, userId).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateUser changes the users username and email, which nobody else can already have
+func (d *dbImpl) UpdateUser(ctx context.Context, userId uint, username, email string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		query := tx.Model(&User{}).Where("id <> ?", userId)
+		if email != "" {
+			query = query.Where("username = ? OR email = ?", username, email)
+		} else {
+			query = query.Where("username = ?", username)
+		}
+		if err := query.Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrUserExists
+		}
+		result := tx.Model(&User{}).Where("id = ?", userId).Updates(map[string]any{"username": username, "email": email})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// UserAuthorizedForEvent is whether the user has at least the role on the event
+func (d *dbImpl) UserAuthorizedForEvent(ctx context.Context, userId, eventId uint, role string) (bool, error) {
+	have, err := d.GetEventRole(ctx, userId, eventId)
+	if err != nil {
+		return false, err
+	}
+	return RoleAtLeast(have, role), nil
+}
+
+// GetEventRole gets the users role on the event, empty if they have none.
+// The user who created the event is always an owner.
+func (d *dbImpl) GetEventRole(ctx context.Context, userId, eventId uint) (string, error) {
 	var event Event
-	err := d.db.WithContext(ctx).Where(&Event{Model: gorm.Model{ID: eventId}, UserID: userId}).First(&event).Error
-	if err == nil {
-		return true, nil
-	} else {
+	// including events in the trash, so their owners can restore them
+	if err := d.db.WithContext(ctx).Unscoped().Select("id", "user_id").First(&event, eventId).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return false, nil
-		} else {
-			return false, err
+			return "", nil
+		}
+		return "", err
+	}
+	if event.UserID == userId {
+		return RoleOwner, nil
+	}
+	var member EventMember
+	if err := d.db.WithContext(ctx).
+		Where(&EventMember{EventID: eventId, UserID: userId, Accepted: true}).
+		First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
 		}
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// FindUser finds a user by their username, their email, or the email of an account they sign in with
+func (d *dbImpl) FindUser(ctx context.Context, usernameOrEmail string) (*User, error) {
+	if usernameOrEmail == "" {
+		return nil, gorm.ErrRecordNotFound
+	}
+	var user User
+	err := d.db.WithContext(ctx).
+		Where("username = ?", usernameOrEmail).
+		Or("email = ?", usernameOrEmail).
+		Or("id IN (?)", d.db.Model(&OidcIdentity{}).Select("user_id").Where("email = ?", usernameOrEmail)).
+		First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetEventMembers gets the events members, including those yet to accept their invite
+func (d *dbImpl) GetEventMembers(ctx context.Context, eventId uint) ([]*EventMember, error) {
+	var members []*EventMember
+	if err := d.db.WithContext(ctx).
+		Preload("User").
+		Where(&EventMember{EventID: eventId}).
+		Order("created_at").
+		Find(&members).Error; err != nil {
+		d.log.Errorf("getting event(%d) members from db: %v", eventId, err)
+		return nil, err
+	}
+	return members, nil
+}
+
+func (d *dbImpl) CreateEventMember(ctx context.Context, member *EventMember) error {
+	return d.db.WithContext(ctx).Create(member).Error
+}
+
+func (d *dbImpl) SetEventMemberRole(ctx context.Context, eventId, memberId uint, role string) error {
+	result := d.db.WithContext(ctx).
+		Model(&EventMember{}).
+		Where("id = ? AND event_id = ?", memberId, eventId).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteEventMember removes the member from the event, along with any webhooks they set up for it
+func (d *dbImpl) DeleteEventMember(ctx context.Context, eventId, memberId uint) error {
+	return d.deleteEventMember(ctx, "id = ? AND event_id = ?", memberId, eventId)
+}
+
+// GetEventInvites gets the invites the user is yet to accept, with the event and who owns it
+func (d *dbImpl) GetEventInvites(ctx context.Context, userId uint) ([]*EventMember, error) {
+	var invites []*EventMember
+	if err := d.db.WithContext(ctx).
+		Preload("Event.User").
+		Where("user_id = ? AND accepted = ?", userId, false).
+		Order("created_at").
+		Find(&invites).Error; err != nil {
+		d.log.Errorf("getting user(%d) event invites from db: %v", userId, err)
+		return nil, err
+	}
+	// the event may have been deleted since
+	return lo.Filter(invites, func(m *EventMember, _ int) bool { return m.Event.ID != 0 }), nil
+}
+
+func (d *dbImpl) AcceptEventInvite(ctx context.Context, userId, eventId uint) error {
+	result := d.db.WithContext(ctx).
+		Model(&EventMember{}).
+		Where("event_id = ? AND user_id = ? AND accepted = ?", eventId, userId, false).
+		Update("accepted", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
+	return nil
+}
+
+// LeaveEvent declines the users invite to an event, or takes them off it if they'd accepted
+func (d *dbImpl) LeaveEvent(ctx context.Context, userId, eventId uint) error {
+	return d.deleteEventMember(ctx, "event_id = ? AND user_id = ?", eventId, userId)
+}
+
+func (d *dbImpl) deleteEventMember(ctx context.Context, query string, args ...any) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var member EventMember
+		if err := tx.Where(query, args...).First(&member).Error; err != nil {
+			return err
+		}
+		webhooks := tx.Model(&Webhook{}).Select("id").Where("user_id = ? AND event_id = ?", member.UserID, member.EventID)
+		if err := tx.Unscoped().Where("webhook_id IN (?)", webhooks).Delete(&WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ? AND event_id = ?", member.UserID, member.EventID).Delete(&Webhook{}).Error; err != nil {
+			return err
+		}
+		// unscoped so they can be invited again
+		return tx.Unscoped().Delete(&member).Error
+	})
 }
 
 func (d *dbImpl) StoreGoogleToken(ctx context.Context, userId uint, token []byte) error {
@@ -381,3 +1055,871 @@ func (d *dbImpl) DeleteGoogleToken(ctx context.Context, userId uint) error {
 		return nil
 	}
 }
+
+func (d *dbImpl) GetFileInfos(ctx context.Context, eventId uint) ([]*FileInfo, error) {
+	var fileInfos []FileInfo
+	result := d.db.WithContext(ctx).
+		Order("created_at asc").
+		Find(&fileInfos, FileInfo{EventID: eventId})
+	if result.Error != nil {
+		d.log.Errorf("error querying file infos in event(%d): %v", eventId, result.Error)
+		return nil, result.Error
+	}
+	return lo.Map(fileInfos, func(e FileInfo, _ int) *FileInfo { return &e }), nil
+}
+
+// SetFileHidden hides the events media from the gallery, or shows it again
+func (d *dbImpl) SetFileHidden(ctx context.Context, eventId uint, id string, hidden bool) error {
+	result := d.db.WithContext(ctx).
+		Model(&FileInfo{}).
+		Where("event_id = ? AND id = ?", eventId, id).
+		Update("hidden", hidden)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteFileInfo permanently deletes the events media along with its thumbnails,
+// returning the thumbnails so they can be deleted from the storage too
+func (d *dbImpl) DeleteFileInfo(ctx context.Context, eventId uint, id string) ([]*ThumbnailInfo, error) {
+	var thumbnails []*ThumbnailInfo
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ? AND file_info_id = ?", eventId, id).Find(&thumbnails).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("event_id = ? AND file_info_id = ?", eventId, id).Delete(&ThumbnailInfo{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("event_id = ? AND id = ?", eventId, id).Delete(&FileInfo{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return thumbnails, nil
+}
+
+// Records a new migration and marks the event as migrating,
+// failing if the event is already being migrated.
+func (d *dbImpl) CreateStorageMigration(ctx context.Context, m *StorageMigration) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Event{}).
+			Where("id = ? AND migrating = ?", m.EventID, false).
+			Update("migrating", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return fmt.Errorf("event(%d) is already being migrated", m.EventID)
+		}
+		return tx.Create(m).Error
+	})
+}
+
+// Saves the progress of the migration.
+// Once it is no longer running, the event is no longer marked as migrating.
+func (d *dbImpl) UpdateStorageMigration(ctx context.Context, m *StorageMigration) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(m).Error; err != nil {
+			return err
+		}
+		if m.Status == MigrationRunning {
+			return nil
+		}
+		return tx.Model(&Event{}).Where("id = ?", m.EventID).Update("migrating", false).Error
+	})
+}
+
+func (d *dbImpl) GetStorageMigration(ctx context.Context, eventId uint) (*StorageMigration, error) {
+	var m StorageMigration
+	result := d.db.WithContext(ctx).
+		Order("id desc").
+		First(&m, StorageMigration{EventID: eventId})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &m, nil
+}
+
+// Fails any migrations left running, e.g. by the server being restarted part way through one.
+func (d *dbImpl) FailRunningStorageMigrations(ctx context.Context) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var eventIds []uint
+		if err := tx.Model(&StorageMigration{}).
+			Where(&StorageMigration{Status: MigrationRunning}).
+			Pluck("event_id", &eventIds).Error; err != nil {
+			return err
+		}
+		if len(eventIds) == 0 {
+			return nil
+		}
+		if err := tx.Model(&StorageMigration{}).
+			Where(&StorageMigration{Status: MigrationRunning}).
+			Updates(StorageMigration{Status: MigrationFailed, Error: "interrupted"}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Event{}).Where("id IN ?", eventIds).Update("migrating", false).Error
+	})
+}
+
+// Replaces the events storage configuration with the one set on target,
+// rewrites the IDs of the events files, thumbnails and cover image to the IDs they were given
+// in the new storage and marks the migration complete. All or nothing.
+// Fails with ErrMediaNotMigrated if the event has files or thumbnails which aren't in
+// the IDs, e.g. an upload that was in flight as the migration started.
+func (d *dbImpl) SwitchEventStorage(ctx context.Context, m *StorageMigration, target *Event, fileIds, thumbnailIds, coverIds map[string]string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var files, thumbnails []string
+		if err := tx.Model(&FileInfo{}).Where("event_id = ?", m.EventID).Pluck("id", &files).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&ThumbnailInfo{}).Where("event_id = ?", m.EventID).Pluck("id", &thumbnails).Error; err != nil {
+			return err
+		}
+		if lo.SomeBy(files, func(id string) bool { _, ok := fileIds[id]; return !ok }) ||
+			lo.SomeBy(thumbnails, func(id string) bool { _, ok := thumbnailIds[id]; return !ok }) {
+			return ErrMediaNotMigrated
+		}
+
+		for _, old := range []any{&FileSystemStorage{}, &S3Storage{}, &GoogleDriveStorage{}, &FtpStorage{}} {
+			if err := tx.Unscoped().Where("event_id = ?", m.EventID).Delete(old).Error; err != nil {
+				return err
+			}
+		}
+
+		var err error
+		switch {
+		case target.FileSystemStorage != nil:
+			target.FileSystemStorage.EventID = m.EventID
+			err = tx.Create(target.FileSystemStorage).Error
+		case target.S3Storage != nil:
+			target.S3Storage.EventID = m.EventID
+			err = tx.Create(target.S3Storage).Error
+		case target.GoogleDriveStorage != nil:
+			target.GoogleDriveStorage.EventID = m.EventID
+			err = tx.Create(target.GoogleDriveStorage).Error
+		case target.FtpStorage != nil:
+			target.FtpStorage.EventID = m.EventID
+			err = tx.Create(target.FtpStorage).Error
+		default:
+			err = ErrNoStorage
+		}
+		if err != nil {
+			return err
+		}
+
+		for oldId, newId := range fileIds {
+			if oldId == newId {
+				continue
+			}
+			if err := tx.Model(&FileInfo{}).Where("id = ?", oldId).Update("id", newId).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&ThumbnailInfo{}).Where("file_info_id = ?", oldId).Update("file_info_id", newId).Error; err != nil {
+				return err
+			}
+		}
+		for oldId, newId := range thumbnailIds {
+			if oldId == newId {
+				continue
+			}
+			if err := tx.Model(&ThumbnailInfo{}).Where("id = ?", oldId).Update("id", newId).Error; err != nil {
+				return err
+			}
+		}
+		for oldId, newId := range coverIds {
+			if err := tx.Model(&EventBranding{}).
+				Where("event_id = ? AND cover_image = ?", m.EventID, oldId).
+				Update("cover_image", newId).Error; err != nil {
+				return err
+			}
+		}
+
+		m.Status = MigrationComplete
+		if err := tx.Save(m).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Event{}).Where("id = ?", m.EventID).Update("migrating", false).Error
+	})
+}
+
+func (d *dbImpl) CreateApiToken(ctx context.Context, token *ApiToken) error {
+	return d.db.WithContext(ctx).Create(token).Error
+}
+
+func (d *dbImpl) GetApiTokens(ctx context.Context, userId uint) ([]*ApiToken, error) {
+	var tokens []*ApiToken
+	if err := d.db.WithContext(ctx).
+		Where(&ApiToken{UserID: userId}).
+		Order("created_at").
+		Find(&tokens).Error; err != nil {
+		d.log.Errorf("getting user(%d) api tokens from db: %v", userId, err)
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Gets the token with the hash, along with the user it belongs to
+func (d *dbImpl) GetApiToken(ctx context.Context, hash string) (*ApiToken, error) {
+	var token ApiToken
+	if err := d.db.WithContext(ctx).
+		Preload("User").
+		Preload("User.GoogleDriveToken").
+		First(&token, "hash = ?", hash).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (d *dbImpl) DeleteApiToken(ctx context.Context, userId, tokenId uint) error {
+	result := d.db.WithContext(ctx).
+		Unscoped().
+		Where("id = ? AND user_id = ?", tokenId, userId).
+		Delete(&ApiToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (d *dbImpl) CreateEventTemplate(ctx context.Context, template *EventTemplate) error {
+	return d.db.WithContext(ctx).Create(template).Error
+}
+
+func (d *dbImpl) GetEventTemplates(ctx context.Context, userId uint) ([]*EventTemplate, error) {
+	var templates []*EventTemplate
+	if err := d.db.WithContext(ctx).
+		Where(&EventTemplate{UserID: userId}).
+		Order("name").
+		Find(&templates).Error; err != nil {
+		d.log.Errorf("getting user(%d) event templates from db: %v", userId, err)
+		return nil, err
+	}
+	return templates, nil
+}
+
+// GetEventTemplate gets one of the users templates
+func (d *dbImpl) GetEventTemplate(ctx context.Context, userId, templateId uint) (*EventTemplate, error) {
+	var template EventTemplate
+	if err := d.db.WithContext(ctx).
+		First(&template, "id = ? AND user_id = ?", templateId, userId).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (d *dbImpl) DeleteEventTemplate(ctx context.Context, userId, templateId uint) error {
+	result := d.db.WithContext(ctx).
+		Unscoped().
+		Where("id = ? AND user_id = ?", templateId, userId).
+		Delete(&EventTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SaveEventBranding creates or updates the events branding, which there's one of for the event
+func (d *dbImpl) SaveEventBranding(ctx context.Context, branding *EventBranding) error {
+	return d.db.WithContext(ctx).Save(branding).Error
+}
+
+// characters short link codes are made of, leaving out ones which look alike
+const shortCodeAlphabet = "23456789abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// ShortCodeLength is how many characters new short link codes have
+const ShortCodeLength = 6
+
+func newShortCode() (string, error) {
+	b := make([]byte, ShortCodeLength)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(shortCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = shortCodeAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// CreateShortLink creates the link with a random code no other link has
+func (d *dbImpl) CreateShortLink(ctx context.Context, link *ShortLink) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for range 5 {
+			code, err := newShortCode()
+			if err != nil {
+				return err
+			}
+			var taken int64
+			if err := tx.Unscoped().Model(&ShortLink{}).Where("code = ?", code).Count(&taken).Error; err != nil {
+				return err
+			}
+			if taken == 0 {
+				link.Code = code
+				return tx.Create(link).Error
+			}
+		}
+		return errors.New("couldn't find an unused short link code")
+	})
+}
+
+func (d *dbImpl) GetShortLinks(ctx context.Context, eventId uint) ([]*ShortLink, error) {
+	var links []*ShortLink
+	if err := d.db.WithContext(ctx).
+		Where(&ShortLink{EventID: eventId}).
+		Order("created_at").
+		Find(&links).Error; err != nil {
+		d.log.Errorf("getting event(%d) short links from db: %v", eventId, err)
+		return nil, err
+	}
+	return links, nil
+}
+
+// GetShortLink gets the link with the code
+func (d *dbImpl) GetShortLink(ctx context.Context, code string) (*ShortLink, error) {
+	var link ShortLink
+	if err := d.db.WithContext(ctx).Where("code = ?", code).First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// CountShortLinkScan adds one to the times the link has been followed
+func (d *dbImpl) CountShortLinkScan(ctx context.Context, linkId uint) error {
+	return d.db.WithContext(ctx).
+		Model(&ShortLink{}).
+		Where("id = ?", linkId).
+		UpdateColumn("scans", gorm.Expr("scans + ?", 1)).Error
+}
+
+func (d *dbImpl) DeleteShortLink(ctx context.Context, eventId, linkId uint) error {
+	result := d.db.WithContext(ctx).
+		Unscoped().
+		Where("id = ? AND event_id = ?", linkId, eventId).
+		Delete(&ShortLink{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// AddGuestVisit records the guests browser opening the events gallery, if it hasn't already
+func (d *dbImpl) AddGuestVisit(ctx context.Context, eventId uint, session string) error {
+	return d.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&GuestVisit{EventID: eventId, Session: session}).Error
+}
+
+// CountFileView adds one to the times the media has been viewed, or downloaded
+func (d *dbImpl) CountFileView(ctx context.Context, id string, download bool) error {
+	column := "views"
+	if download {
+		column = "downloads"
+	}
+	return d.db.WithContext(ctx).
+		Model(&FileInfo{}).
+		Where("id = ?", id).
+		UpdateColumn(column, gorm.Expr(column+" + ?", 1)).Error
+}
+
+// events uploaded to over longer than this have their uploads counted by day rather than hour
+const hourlyUploadsSpan = 72 * time.Hour
+
+// GetEventStats works out what guests have done in the event, with the top few media and uploaders
+func (d *dbImpl) GetEventStats(ctx context.Context, eventId uint, top int) (*EventStats, error) {
+	tx := d.db.WithContext(ctx)
+	media := func() *gorm.DB { return tx.Model(&FileInfo{}).Where("event_id = ?", eventId) }
+
+	var totals struct {
+		Photos, Videos, Bytes, Views, Downloads, UploadingSessions int64
+	}
+	if err := media().
+		Select("COALESCE(SUM(CASE WHEN video THEN 0 ELSE 1 END), 0) AS photos, " +
+			"COALESCE(SUM(CASE WHEN video THEN 1 ELSE 0 END), 0) AS videos, " +
+			"COALESCE(SUM(size), 0) AS bytes, " +
+			"COALESCE(SUM(views), 0) AS views, " +
+			"COALESCE(SUM(downloads), 0) AS downloads, " +
+			"COUNT(DISTINCT NULLIF(guest_session, '')) AS uploading_sessions").
+		Scan(&totals).Error; err != nil {
+		d.log.Errorf("getting event(%d) media stats from db: %v", eventId, err)
+		return nil, err
+	}
+	stats := EventStats{
+		TopMedia:          []MediaStats{},
+		TopUploaders:      []UploaderStats{},
+		Photos:            totals.Photos,
+		Videos:            totals.Videos,
+		Bytes:             totals.Bytes,
+		Views:             totals.Views,
+		Downloads:         totals.Downloads,
+		UploadingSessions: totals.UploadingSessions,
+	}
+	if err := tx.Model(&GuestVisit{}).Where("event_id = ?", eventId).Count(&stats.GuestSessions).Error; err != nil {
+		return nil, err
+	}
+
+	var uploadTimes []time.Time
+	if err := media().Order("created_at").Pluck("created_at", &uploadTimes).Error; err != nil {
+		return nil, err
+	}
+	stats.Uploads = countUploads(uploadTimes)
+	for i, count := range stats.Uploads {
+		if stats.Peak == nil || count.Count > stats.Peak.Count {
+			stats.Peak = &stats.Uploads[i]
+		}
+	}
+
+	if err := media().
+		Select("id, name, video, views, downloads").
+		Where("views > 0 OR downloads > 0").
+		Order("views + downloads DESC, created_at").
+		Limit(top).
+		Scan(&stats.TopMedia).Error; err != nil {
+		return nil, err
+	}
+	if err := media().
+		Select("uploader AS name, COUNT(*) AS uploads, COALESCE(SUM(size), 0) AS bytes").
+		Where("uploader <> ''").
+		Group("uploader").
+		Order("uploads DESC, name").
+		Limit(top).
+		Scan(&stats.TopUploaders).Error; err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// countUploads counts the uploads at the sorted times in each hour, or each day if they're spread
+// out over more than a few days, including the periods in between with none
+func countUploads(times []time.Time) []UploadCount {
+	if len(times) == 0 {
+		return []UploadCount{}
+	}
+	period := time.Hour
+	if times[len(times)-1].Sub(times[0]) > hourlyUploadsSpan {
+		period = 24 * time.Hour
+	}
+	first, last := times[0].UTC().Truncate(period), times[len(times)-1].UTC().Truncate(period)
+	counts := make([]UploadCount, 0, int(last.Sub(first)/period)+1)
+	for at := first; !at.After(last); at = at.Add(period) {
+		counts = append(counts, UploadCount{Time: at})
+	}
+	for _, t := range times {
+		counts[int(t.UTC().Truncate(period).Sub(first)/period)].Count++
+	}
+	return counts
+}
+
+func (d *dbImpl) CreateWebhook(ctx context.Context, webhook *Webhook) error {
+	return d.db.WithContext(ctx).Create(webhook).Error
+}
+
+func (d *dbImpl) GetWebhooks(ctx context.Context, userId uint) ([]*Webhook, error) {
+	var webhooks []*Webhook
+	if err := d.db.WithContext(ctx).
+		Where(&Webhook{UserID: userId}).
+		Order("created_at").
+		Find(&webhooks).Error; err != nil {
+		d.log.Errorf("getting user(%d) webhooks from db: %v", userId, err)
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Gets the webhooks which deliver for the event, the owner's for all their events and any
+// set up for the event alone, which its members can do
+func (d *dbImpl) GetEventWebhooks(ctx context.Context, userId, eventId uint) ([]*Webhook, error) {
+	var webhooks []*Webhook
+	if err := d.db.WithContext(ctx).
+		Where("(user_id = ? AND event_id IS NULL) OR event_id = ?", userId, eventId).
+		Find(&webhooks).Error; err != nil {
+		d.log.Errorf("getting event(%d) webhooks from db: %v", eventId, err)
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (d *dbImpl) DeleteWebhook(ctx context.Context, userId, webhookId uint) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Where("id = ? AND user_id = ?", webhookId, userId).
+			Delete(&Webhook{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Unscoped().Where("webhook_id = ?", webhookId).Delete(&WebhookDelivery{}).Error
+	})
+}
+
+func (d *dbImpl) CreateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	return d.db.WithContext(ctx).Create(delivery).Error
+}
+
+func (d *dbImpl) UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	return d.db.WithContext(ctx).Save(delivery).Error
+}
+
+// Gets the latest deliveries to the user's webhook, newest first
+func (d *dbImpl) GetWebhookDeliveries(ctx context.Context, userId, webhookId uint, limit int) ([]*WebhookDelivery, error) {
+	var deliveries []*WebhookDelivery
+	if err := d.db.WithContext(ctx).
+		Select("webhook_deliveries.*").
+		Joins("JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id").
+		Where("webhooks.id = ? AND webhooks.user_id = ?", webhookId, userId).
+		Order("webhook_deliveries.id desc").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		d.log.Errorf("getting webhook(%d) deliveries from db: %v", webhookId, err)
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// Fails any deliveries left pending, e.g. by the server being restarted part way through retrying them.
+func (d *dbImpl) FailPendingWebhookDeliveries(ctx context.Context) error {
+	return d.db.WithContext(ctx).
+		Model(&WebhookDelivery{}).
+		Where(&WebhookDelivery{Status: DeliveryPending}).
+		Updates(&WebhookDelivery{Status: DeliveryFailed, Error: "interrupted by server restart"}).Error
+}
+
+func (d *dbImpl) CreateGuestToken(ctx context.Context, token *GuestToken) error {
+	return d.db.WithContext(ctx).Create(token).Error
+}
+
+func (d *dbImpl) GetGuestTokens(ctx context.Context, eventId uint) ([]*GuestToken, error) {
+	var tokens []*GuestToken
+	if err := d.db.WithContext(ctx).
+		Where(&GuestToken{EventID: eventId}).
+		Order("created_at").
+		Find(&tokens).Error; err != nil {
+		d.log.Errorf("getting event(%d) guest tokens from db: %v", eventId, err)
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Gets the event's guest token, so long as it hasn't been revoked or expired
+func (d *dbImpl) GetGuestToken(ctx context.Context, eventId, tokenId uint) (*GuestToken, error) {
+	var token GuestToken
+	if err := d.db.WithContext(ctx).
+		Where("id = ? AND event_id = ?", tokenId, eventId).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Replaces the token with a new one with the same settings, so links with the old one stop working
+func (d *dbImpl) RotateGuestToken(ctx context.Context, eventId, tokenId uint) (*GuestToken, error) {
+	var rotated GuestToken
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var old GuestToken
+		if err := tx.First(&old, "id = ? AND event_id = ?", tokenId, eventId).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&old).Error; err != nil {
+			return err
+		}
+		rotated = GuestToken{
+			EventID:    old.EventID,
+			Name:       old.Name,
+			Capability: old.Capability,
+			ExpiresAt:  old.ExpiresAt,
+		}
+		return tx.Create(&rotated).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rotated, nil
+}
+
+func (d *dbImpl) DeleteGuestToken(ctx context.Context, eventId, tokenId uint) error {
+	result := d.db.WithContext(ctx).
+		Unscoped().
+		Where("id = ? AND event_id = ?", tokenId, eventId).
+		Delete(&GuestToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetEventPasswordHash gets the hash of the events password, empty if it doesn't have one
+func (d *dbImpl) GetEventPasswordHash(ctx context.Context, eventId uint64) (string, error) {
+	var event Event
+	if err := d.db.WithContext(ctx).Select("id", "password_hash").First(&event, eventId).Error; err != nil {
+		return "", err
+	}
+	return lo.FromPtr(event.PasswordHash), nil
+}
+
+// RevokeUserSessions logs the user out everywhere by invalidating all their session tokens
+func (d *dbImpl) RevokeUserSessions(ctx context.Context, userId uint) error {
+	return d.db.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", userId).
+		Update("token_version", gorm.Expr("token_version + 1")).Error
+}
+
+func (d *dbImpl) SetUserAdmin(ctx context.Context, userId uint, admin bool) error {
+	return d.db.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", userId).
+		Update("admin", admin).Error
+}
+
+// GetOidcIdentity gets the identity the provider issued, with the user it's linked to
+func (d *dbImpl) GetOidcIdentity(ctx context.Context, issuer, subject string) (*OidcIdentity, error) {
+	var identity OidcIdentity
+	if err := d.db.WithContext(ctx).Preload("User").First(&identity, "issuer = ? AND subject = ?", issuer, subject).Error; err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// CreateOidcIdentity links the identity to an existing user
+func (d *dbImpl) CreateOidcIdentity(ctx context.Context, identity *OidcIdentity) error {
+	return d.db.WithContext(ctx).Create(identity).Error
+}
+
+// CreateOidcUser creates a user who signs in with the identity, without a password
+func (d *dbImpl) CreateOidcUser(ctx context.Context, username string, identity *OidcIdentity) (*User, error) {
+	user := User{Username: username, Email: identity.Email}
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&User{}).Where("username = ?", username).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrUserExists
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (d *dbImpl) DeleteOidcIdentity(ctx context.Context, userId, identityId uint) error {
+	result := d.db.WithContext(ctx).
+		Unscoped().
+		Where("id = ? AND user_id = ?", identityId, userId).
+		Delete(&OidcIdentity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetSetting gets the value of the instance setting, gorm.ErrRecordNotFound if it's never been set
+func (d *dbImpl) GetSetting(ctx context.Context, name string) (string, error) {
+	var setting Setting
+	if err := d.db.WithContext(ctx).First(&setting, "name = ?", name).Error; err != nil {
+		return "", err
+	}
+	return setting.Value, nil
+}
+
+func (d *dbImpl) SetSetting(ctx context.Context, name, value string) error {
+	return d.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&Setting{Name: name, Value: value}).Error
+}
+
+func (d *dbImpl) GetUsers(ctx context.Context) ([]*User, error) {
+	var users []*User
+	if err := d.db.WithContext(ctx).Order("username").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetEventUsages gets every event, with its owner and storage, and the media stored in it
+func (d *dbImpl) GetEventUsages(ctx context.Context) ([]*EventUsage, error) {
+	var events []*Event
+	if err := d.db.WithContext(ctx).
+		Preload("User").
+		Preload("FileSystemStorage").
+		Preload("S3Storage").
+		Preload("GoogleDriveStorage").
+		Preload("FtpStorage").
+		Order("id").
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	var counts []struct {
+		EventID uint
+		Files   int64
+		Bytes   int64
+	}
+	if err := d.db.WithContext(ctx).
+		Model(&FileInfo{}).
+		Select("event_id, COUNT(*) AS files, COALESCE(SUM(size), 0) AS bytes").
+		Group("event_id").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	usages := lo.SliceToMap(events, func(e *Event) (uint, *EventUsage) { return e.ID, &EventUsage{Event: e} })
+	for _, count := range counts {
+		if usage, ok := usages[count.EventID]; ok {
+			usage.Files = count.Files
+			usage.Bytes = count.Bytes
+		}
+	}
+	return lo.Map(events, func(e *Event, _ int) *EventUsage { return usages[e.ID] }), nil
+}
+
+func (d *dbImpl) SetUserDisabled(ctx context.Context, userId uint, disabled bool) error {
+	return d.db.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", userId).
+		Update("disabled", disabled).Error
+}
+
+// DeleteUser deletes the user and everything only they use, once their events have been
+// transferred to someone else or deleted
+func (d *dbImpl) DeleteUser(ctx context.Context, userId uint) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var events int64
+		// events in the trash still belong to them until they're purged
+		if err := tx.Unscoped().Model(&Event{}).Where("user_id = ?", userId).Count(&events).Error; err != nil {
+			return err
+		}
+		if events > 0 {
+			return ErrUserOwnsEvents
+		}
+		webhooks := tx.Model(&Webhook{}).Select("id").Where("user_id = ?", userId)
+		if err := tx.Unscoped().Where("webhook_id IN (?)", webhooks).Delete(&WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		for _, model := range []any{&ApiToken{}, &Webhook{}, &OidcIdentity{}, &GoogleDriveToken{}, &EventMember{}, &EventTemplate{}} {
+			if err := tx.Unscoped().Where("user_id = ?", userId).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		result := tx.Delete(&User{}, userId)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// ResetUserPassword sets a new password for the user, logging them out everywhere
+func (d *dbImpl) ResetUserPassword(ctx context.Context, userId uint, password string) error {
+	user, err := d.GetUserByID(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if err := auth.CheckPasswordStrength(user.Username, password); err != nil {
+		return err
+	}
+	hash, err := auth.EncryptPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt password: %v", err)
+	}
+	return d.db.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", userId).
+		Updates(map[string]any{
+			"password":      hash,
+			"token_version": gorm.Expr("token_version + 1"),
+			// whoever got locked out has got back in
+			"failed_logins": 0,
+			"locked_until":  nil,
+		}).Error
+}
+
+// RecordLoginFailure counts a failed login for the user, locking the account once there have been
+// too many in a row. Returns when the account is locked until, nil if it isn't.
+func (d *dbImpl) RecordLoginFailure(ctx context.Context, userId uint) (*time.Time, error) {
+	var lockedUntil *time.Time
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&User{}).Where("id = ?", userId).Update("failed_logins", gorm.Expr("failed_logins + 1")).Error; err != nil {
+			return err
+		}
+		var user User
+		if err := tx.Select("id", "failed_logins").First(&user, userId).Error; err != nil {
+			return err
+		}
+		if lockout := auth.LockoutDuration(user.FailedLogins); lockout > 0 {
+			until := time.Now().Add(lockout)
+			lockedUntil = &until
+		}
+		return tx.Model(&User{}).Where("id = ?", userId).Update("locked_until", lockedUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lockedUntil, nil
+}
+
+// ResetLoginFailures forgets the users failed logins, once they've logged in
+func (d *dbImpl) ResetLoginFailures(ctx context.Context, userId uint) error {
+	return d.db.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", userId).
+		Updates(map[string]any{"failed_logins": 0, "locked_until": nil}).Error
+}
+
+// TransferEvent makes the user the owner of the event
+func (d *dbImpl) TransferEvent(ctx context.Context, eventId uint64, userId uint) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&User{}, userId).Error; err != nil {
+			return err
+		}
+		// the new owner doesn't need to be a member too
+		if err := tx.Unscoped().Where("event_id = ? AND user_id = ?", eventId, userId).Delete(&EventMember{}).Error; err != nil {
+			return err
+		}
+		// events in the trash can be handed over too, for the new owner to restore
+		result := tx.Unscoped().Model(&Event{}).Where("id = ?", eventId).Update("user_id", userId)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
@@ -3,12 +3,17 @@ package db_test
 import (
 	"encoding/base64"
 	"testing"
+	"time"
 
 	"github.com/jj-style/eventpix/internal/config"
 	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	gormcrypto "github.com/pkasila/gorm-crypto"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
+	"gorm.io/gorm"
 )
 
 func TestCanCreateEventWithSameSlugAfterDeletion_Fix_35(t *testing.T) {
@@ -28,3 +33,1055 @@ func TestCanCreateEventWithSameSlugAfterDeletion_Fix_35(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, id1+1, id2)
 }
+
+func TestSwitchEventStorage(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file::memory:?cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	eventId, err := d.CreateEvent(t.Context(), &db.Event{
+		Name:              "migrate",
+		Slug:              "migrate",
+		FileSystemStorage: &db.FileSystemStorage{Directory: "/old"},
+	})
+	is.NoError(err)
+	is.NoError(d.AddFileInfo(t.Context(), &db.FileInfo{ID: "old-file", Name: "file.jpg", EventID: eventId}))
+	is.NoError(d.AddThumbnailInfo(t.Context(), &db.ThumbnailInfo{ID: "old-thumb", Name: "thumb_file.webp", EventID: eventId, FileInfoID: "old-file"}))
+	is.NoError(d.SaveEventBranding(t.Context(), &db.EventBranding{EventID: eventId, CoverImage: "old-cover"}))
+
+	migration := &db.StorageMigration{EventID: eventId, Status: db.MigrationRunning}
+	is.NoError(d.CreateStorageMigration(t.Context(), migration))
+	// can't migrate an event twice at the same time
+	is.Error(d.CreateStorageMigration(t.Context(), &db.StorageMigration{EventID: eventId, Status: db.MigrationRunning}))
+
+	// an upload which wasn't copied stops the switch, leaving the event as it was
+	is.ErrorIs(d.SwitchEventStorage(
+		t.Context(),
+		migration,
+		&db.Event{FileSystemStorage: &db.FileSystemStorage{Directory: "/new"}},
+		map[string]string{},
+		map[string]string{"old-thumb": "new-thumb"},
+		map[string]string{"old-cover": "new-cover"},
+	), db.ErrMediaNotMigrated)
+	evt, err := d.GetEvent(t.Context(), uint64(eventId))
+	is.NoError(err)
+	is.True(evt.Migrating)
+	is.Equal("/old", evt.FileSystemStorage.Directory)
+	is.Equal("old-cover", evt.Branding.CoverImage)
+
+	is.NoError(d.SwitchEventStorage(
+		t.Context(),
+		migration,
+		&db.Event{FileSystemStorage: &db.FileSystemStorage{Directory: "/new"}},
+		map[string]string{"old-file": "new-file"},
+		map[string]string{"old-thumb": "new-thumb"},
+		map[string]string{"old-cover": "new-cover"},
+	))
+
+	evt, err = d.GetEvent(t.Context(), uint64(eventId))
+	is.NoError(err)
+	is.False(evt.Migrating)
+	is.Equal("/new", evt.FileSystemStorage.Directory)
+	is.Equal("new-cover", evt.Branding.CoverImage)
+
+	got, err := d.GetStorageMigration(t.Context(), eventId)
+	is.NoError(err)
+	is.Equal(db.MigrationComplete, got.Status)
+
+	_, err = d.GetFileInfo(t.Context(), "old-file")
+	is.Error(err)
+	thumb, err := d.GetThumbnailInfo(t.Context(), "new-thumb")
+	is.NoError(err)
+	is.Equal("new-file", thumb.FileInfoID)
+	is.Equal("file.jpg", thumb.FileInfo.Name)
+}
+
+func TestApiTokens(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file::memory:?cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	is.NoError(d.CreateUser(t.Context(), "tokens", "hunter2hunter2"))
+	user, err := d.GetUser(t.Context(), "tokens")
+	is.NoError(err)
+
+	token := &db.ApiToken{UserID: user.ID, Name: "ci", Hash: "hash", Prefix: "epx_abcdef", Scopes: "events:read,upload"}
+	is.NoError(d.CreateApiToken(t.Context(), token))
+
+	got, err := d.GetApiToken(t.Context(), "hash")
+	is.NoError(err)
+	is.Equal("tokens", got.User.Username)
+	is.Equal("events:read,upload", got.Scopes)
+
+	tokens, err := d.GetApiTokens(t.Context(), user.ID)
+	is.NoError(err)
+	is.Len(tokens, 1)
+
+	// can't delete another user's token
+	is.ErrorIs(d.DeleteApiToken(t.Context(), user.ID+1, token.ID), gorm.ErrRecordNotFound)
+	is.NoError(d.DeleteApiToken(t.Context(), user.ID, token.ID))
+	_, err = d.GetApiToken(t.Context(), "hash")
+	is.Error(err)
+}
+
+func TestWebhooks(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file::memory:?cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	eventId := uint(7)
+	all := &db.Webhook{UserID: 5, Url: "https://example.com/all", Secret: gormcrypto.EncryptedValue{Raw: "secret"}, Subjects: "new-photo"}
+	one := &db.Webhook{UserID: 5, EventID: &eventId, Url: "https://example.com/one", Secret: gormcrypto.EncryptedValue{Raw: "secret"}, Subjects: "new-photo"}
+	other := &db.Webhook{UserID: 5, EventID: new(uint), Url: "https://example.com/other", Secret: gormcrypto.EncryptedValue{Raw: "secret"}, Subjects: "new-photo"}
+	*other.EventID = 8
+	for _, w := range []*db.Webhook{all, one, other} {
+		is.NoError(d.CreateWebhook(t.Context(), w))
+	}
+
+	got, err := d.GetEventWebhooks(t.Context(), 5, eventId)
+	is.NoError(err)
+	is.Len(got, 2)
+	is.Equal("secret", got[0].Secret.Raw)
+
+	delivery := &db.WebhookDelivery{WebhookID: one.ID, Subject: "new-photo", Status: db.DeliveryPending}
+	is.NoError(d.CreateWebhookDelivery(t.Context(), delivery))
+	is.NoError(d.FailPendingWebhookDeliveries(t.Context()))
+
+	deliveries, err := d.GetWebhookDeliveries(t.Context(), 5, one.ID, 10)
+	is.NoError(err)
+	is.Len(deliveries, 1)
+	is.Equal(db.DeliveryFailed, deliveries[0].Status)
+	// only the owner can see them
+	deliveries, err = d.GetWebhookDeliveries(t.Context(), 6, one.ID, 10)
+	is.NoError(err)
+	is.Empty(deliveries)
+
+	is.ErrorIs(d.DeleteWebhook(t.Context(), 6, one.ID), gorm.ErrRecordNotFound)
+	is.NoError(d.DeleteWebhook(t.Context(), 5, one.ID))
+	got, err = d.GetEventWebhooks(t.Context(), 5, eventId)
+	is.NoError(err)
+	is.Len(got, 1)
+}
+
+func TestGuestTokens(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file::memory:?cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	expired := time.Now().Add(-time.Hour)
+	token := &db.GuestToken{EventID: 9, Name: "family", Capability: "upload"}
+	old := &db.GuestToken{EventID: 9, Name: "old", Capability: "view", ExpiresAt: &expired}
+	is.NoError(d.CreateGuestToken(t.Context(), token))
+	is.NoError(d.CreateGuestToken(t.Context(), old))
+
+	_, err = d.GetGuestToken(t.Context(), 9, token.ID)
+	is.NoError(err)
+	_, err = d.GetGuestToken(t.Context(), 10, token.ID)
+	is.Error(err)
+	_, err = d.GetGuestToken(t.Context(), 9, old.ID)
+	is.Error(err)
+
+	rotated, err := d.RotateGuestToken(t.Context(), 9, token.ID)
+	is.NoError(err)
+	is.NotEqual(token.ID, rotated.ID)
+	is.Equal("family", rotated.Name)
+	_, err = d.GetGuestToken(t.Context(), 9, token.ID)
+	is.Error(err)
+
+	is.NoError(d.DeleteGuestToken(t.Context(), 9, rotated.ID))
+	tokens, err := d.GetGuestTokens(t.Context(), 9)
+	is.NoError(err)
+	is.Len(tokens, 1)
+}
+
+func TestEventPasswordsHashed(t *testing.T) {
+	is := require.New(t)
+	cfg := &config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:passwords?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}
+	d, _, err := db.NewDb(cfg, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	// stored reversibly by an older version
+	legacy, err := d.CreateEvent(t.Context(), &db.Event{
+		Name:              "legacy",
+		Slug:              "legacy",
+		Password:          &gormcrypto.EncryptedValue{Raw: "pwd"},
+		FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()},
+	})
+	is.NoError(err)
+	open, err := d.CreateEvent(t.Context(), &db.Event{Name: "open", Slug: "open"})
+	is.NoError(err)
+
+	// reopening hashes it
+	d, _, err = db.NewDb(cfg, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	hash, err := d.GetEventPasswordHash(t.Context(), uint64(legacy))
+	is.NoError(err)
+	is.True(auth.ComparePassword("pwd", hash))
+	evt, err := d.GetEvent(t.Context(), uint64(legacy))
+	is.NoError(err)
+	is.Nil(evt.Password)
+
+	hash, err = d.GetEventPasswordHash(t.Context(), uint64(open))
+	is.NoError(err)
+	is.Empty(hash)
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file::memory:?cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	is.NoError(d.CreateUser(t.Context(), "sessions", "hunter2hunter2"))
+	user, err := d.GetUser(t.Context(), "sessions")
+	is.NoError(err)
+	is.Zero(user.TokenVersion)
+
+	is.NoError(d.RevokeUserSessions(t.Context(), user.ID))
+	user, err = d.GetUser(t.Context(), "sessions")
+	is.NoError(err)
+	is.Equal(uint(1), user.TokenVersion)
+}
+
+func TestOidcIdentities(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file::memory:?cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	user, err := d.CreateOidcUser(t.Context(), "oidc-user", &db.OidcIdentity{Provider: "corp", Issuer: "https://idp", Subject: "1"})
+	is.NoError(err)
+	_, err = d.CreateOidcUser(t.Context(), "oidc-user", &db.OidcIdentity{Provider: "corp", Issuer: "https://idp", Subject: "2"})
+	is.Error(err)
+
+	identity, err := d.GetOidcIdentity(t.Context(), "https://idp", "1")
+	is.NoError(err)
+	is.Equal(user.ID, identity.UserID)
+	is.Equal("oidc-user", identity.User.Username)
+	_, err = d.GetOidcIdentity(t.Context(), "https://other-idp", "1")
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	is.NoError(d.SetUserAdmin(t.Context(), user.ID, true))
+	got, err := d.GetUser(t.Context(), "oidc-user")
+	is.NoError(err)
+	is.True(got.Admin)
+	is.Len(got.OidcIdentities, 1)
+
+	is.Error(d.DeleteOidcIdentity(t.Context(), user.ID+1, identity.ID))
+	is.NoError(d.DeleteOidcIdentity(t.Context(), user.ID, identity.ID))
+	// can be linked again after unlinking
+	is.NoError(d.CreateOidcIdentity(t.Context(), &db.OidcIdentity{UserID: user.ID, Provider: "corp", Issuer: "https://idp", Subject: "1"}))
+}
+
+func TestAdmin(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:admin?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	_, err = d.GetSetting(t.Context(), "disableSignups")
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+	is.NoError(d.SetSetting(t.Context(), "disableSignups", "true"))
+	is.NoError(d.SetSetting(t.Context(), "disableSignups", "false"))
+	value, err := d.GetSetting(t.Context(), "disableSignups")
+	is.NoError(err)
+	is.Equal("false", value)
+
+	is.NoError(d.CreateUser(t.Context(), "owner", "hunter2hunter2"))
+	is.NoError(d.CreateUser(t.Context(), "other", "hunter2hunter2"))
+	users, err := d.GetUsers(t.Context())
+	is.NoError(err)
+	owner, ok := lo.Find(users, func(u *db.User) bool { return u.Username == "owner" })
+	is.True(ok)
+	other, ok := lo.Find(users, func(u *db.User) bool { return u.Username == "other" })
+	is.True(ok)
+
+	eventId, err := d.CreateEvent(t.Context(), &db.Event{
+		Name:              "party",
+		Slug:              "party",
+		UserID:            owner.ID,
+		FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()},
+	})
+	is.NoError(err)
+	is.NoError(d.AddFileInfo(t.Context(), &db.FileInfo{ID: "a", EventID: eventId, Size: 100}))
+	is.NoError(d.AddFileInfo(t.Context(), &db.FileInfo{ID: "b", EventID: eventId, Size: 50}))
+
+	usages, err := d.GetEventUsages(t.Context())
+	is.NoError(err)
+	is.Len(usages, 1)
+	is.Equal(int64(2), usages[0].Files)
+	is.Equal(int64(150), usages[0].Bytes)
+	is.Equal("owner", usages[0].Event.User.Username)
+	is.NotNil(usages[0].Event.FileSystemStorage)
+
+	is.NoError(d.SetUserDisabled(t.Context(), other.ID, true))
+	got, err := d.GetUser(t.Context(), "other")
+	is.NoError(err)
+	is.True(got.Disabled)
+
+	is.NoError(d.ResetUserPassword(t.Context(), other.ID, "new password"))
+	got, err = d.GetUser(t.Context(), "other")
+	is.NoError(err)
+	is.True(auth.ComparePassword("new password", got.Password))
+	is.Equal(uint(1), got.TokenVersion)
+
+	is.ErrorIs(d.DeleteUser(t.Context(), owner.ID), db.ErrUserOwnsEvents)
+	is.ErrorIs(d.TransferEvent(t.Context(), uint64(eventId), owner.ID+100), gorm.ErrRecordNotFound)
+	is.NoError(d.TransferEvent(t.Context(), uint64(eventId), other.ID))
+	// events in the trash are still theirs
+	trashedId, err := d.CreateEvent(t.Context(), &db.Event{
+		Name:              "old party",
+		Slug:              "old-party",
+		UserID:            owner.ID,
+		FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()},
+	})
+	is.NoError(err)
+	is.NoError(d.DeleteEvent(t.Context(), uint64(trashedId)))
+	is.ErrorIs(d.DeleteUser(t.Context(), owner.ID), db.ErrUserOwnsEvents)
+	trashed, err := d.GetTrashedEvent(t.Context(), uint64(trashedId))
+	is.NoError(err)
+	is.NotNil(trashed.Storage)
+	// and can be handed over while they're there
+	is.NoError(d.TransferEvent(t.Context(), uint64(trashedId), other.ID))
+	trashed, err = d.GetTrashedEvent(t.Context(), uint64(trashedId))
+	is.NoError(err)
+	is.Equal(other.ID, trashed.UserID)
+	is.NoError(d.DeleteUser(t.Context(), owner.ID))
+	is.ErrorIs(d.DeleteUser(t.Context(), owner.ID), gorm.ErrRecordNotFound)
+	users, err = d.GetUsers(t.Context())
+	is.NoError(err)
+	is.False(lo.ContainsBy(users, func(u *db.User) bool { return u.Username == "owner" }))
+}
+
+func TestEventMembers(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:members?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	is.NoError(d.CreateUser(t.Context(), "couple", "hunter2hunter2"))
+	is.NoError(d.CreateUser(t.Context(), "photographer", "hunter2hunter2"))
+	owner, err := d.GetUser(t.Context(), "couple")
+	is.NoError(err)
+	_, err = d.CreateOidcUser(t.Context(), "planner", &db.OidcIdentity{Provider: "corp", Issuer: "https://idp", Subject: "planner", Email: "planner@example.com"})
+	is.NoError(err)
+
+	photographer, err := d.FindUser(t.Context(), "photographer")
+	is.NoError(err)
+	planner, err := d.FindUser(t.Context(), "planner@example.com")
+	is.NoError(err)
+	is.Equal("planner", planner.Username)
+	_, err = d.FindUser(t.Context(), "nobody")
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	eventId, err := d.CreateEvent(t.Context(), &db.Event{
+		Name:              "wedding",
+		Slug:              "wedding",
+		UserID:            owner.ID,
+		FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()},
+	})
+	is.NoError(err)
+
+	role, err := d.GetEventRole(t.Context(), owner.ID, eventId)
+	is.NoError(err)
+	is.Equal(db.RoleOwner, role)
+
+	member := &db.EventMember{EventID: eventId, UserID: photographer.ID, Role: db.RoleModerator}
+	is.NoError(d.CreateEventMember(t.Context(), member))
+	is.Error(d.CreateEventMember(t.Context(), &db.EventMember{EventID: eventId, UserID: photographer.ID, Role: db.RoleOwner}))
+
+	// invites don't give a role until they're accepted
+	ok, err := d.UserAuthorizedForEvent(t.Context(), photographer.ID, eventId, db.RoleModerator)
+	is.NoError(err)
+	is.False(ok)
+	invites, err := d.GetEventInvites(t.Context(), photographer.ID)
+	is.NoError(err)
+	is.Len(invites, 1)
+	is.Equal("couple", invites[0].Event.User.Username)
+
+	is.NoError(d.AcceptEventInvite(t.Context(), photographer.ID, eventId))
+	is.ErrorIs(d.AcceptEventInvite(t.Context(), photographer.ID, eventId), gorm.ErrRecordNotFound)
+	ok, err = d.UserAuthorizedForEvent(t.Context(), photographer.ID, eventId, db.RoleModerator)
+	is.NoError(err)
+	is.True(ok)
+	ok, err = d.UserAuthorizedForEvent(t.Context(), photographer.ID, eventId, db.RoleManager)
+	is.NoError(err)
+	is.False(ok)
+
+	is.NoError(d.SetEventMemberRole(t.Context(), eventId, member.ID, db.RoleManager))
+	events, err := d.GetEvents(t.Context(), photographer.ID)
+	is.NoError(err)
+	is.Len(events, 1)
+	is.Equal(db.RoleManager, events[0].Role)
+	events, err = d.GetEvents(t.Context(), owner.ID)
+	is.NoError(err)
+	is.Equal(db.RoleOwner, events[0].Role)
+
+	// members can set up webhooks for the event, which go when they do
+	is.NoError(d.CreateWebhook(t.Context(), &db.Webhook{UserID: photographer.ID, EventID: &eventId, Url: "https://example.com", Secret: gormcrypto.EncryptedValue{Raw: "secret"}, Subjects: "new-photo"}))
+	webhooks, err := d.GetEventWebhooks(t.Context(), owner.ID, eventId)
+	is.NoError(err)
+	is.Len(webhooks, 1)
+
+	members, err := d.GetEventMembers(t.Context(), eventId)
+	is.NoError(err)
+	is.Len(members, 1)
+	is.Equal("photographer", members[0].User.Username)
+
+	is.ErrorIs(d.DeleteEventMember(t.Context(), eventId+1, member.ID), gorm.ErrRecordNotFound)
+	is.NoError(d.LeaveEvent(t.Context(), photographer.ID, eventId))
+	webhooks, err = d.GetEventWebhooks(t.Context(), owner.ID, eventId)
+	is.NoError(err)
+	is.Empty(webhooks)
+	role, err = d.GetEventRole(t.Context(), photographer.ID, eventId)
+	is.NoError(err)
+	is.Empty(role)
+
+	// can be invited again, and becomes the owner when the event is transferred to them
+	is.NoError(d.CreateEventMember(t.Context(), &db.EventMember{EventID: eventId, UserID: planner.ID, Role: db.RoleOwner, Accepted: true}))
+	is.NoError(d.TransferEvent(t.Context(), uint64(eventId), planner.ID))
+	members, err = d.GetEventMembers(t.Context(), eventId)
+	is.NoError(err)
+	is.Empty(members)
+}
+
+func TestUserAccounts(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:accounts?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	is.ErrorIs(d.CreateUser(t.Context(), "weak", "password"), auth.ErrWeakPassword)
+	is.ErrorIs(d.CreateUser(t.Context(), "alice", "alice"), auth.ErrWeakPassword)
+	is.NoError(d.CreateUser(t.Context(), "alice", "hunter2hunter2"))
+	is.ErrorIs(d.CreateUser(t.Context(), "alice", "hunter2hunter2"), db.ErrUserExists)
+	is.NoError(d.CreateUser(t.Context(), "bob", "hunter2hunter2"))
+	alice, err := d.GetUser(t.Context(), "alice")
+	is.NoError(err)
+	bob, err := d.GetUser(t.Context(), "bob")
+	is.NoError(err)
+
+	got, err := d.GetUserByID(t.Context(), alice.ID)
+	is.NoError(err)
+	is.Equal("alice", got.Username)
+
+	// renaming
+	is.NoError(d.UpdateUser(t.Context(), alice.ID, "alicia", "alicia@example.com"))
+	is.ErrorIs(d.UpdateUser(t.Context(), bob.ID, "alicia", ""), db.ErrUserExists)
+	is.ErrorIs(d.UpdateUser(t.Context(), bob.ID, "bob", "alicia@example.com"), db.ErrUserExists)
+	is.NoError(d.UpdateUser(t.Context(), bob.ID, "bob", ""))
+	is.ErrorIs(d.UpdateUser(t.Context(), alice.ID+100, "nobody", ""), gorm.ErrRecordNotFound)
+
+	found, err := d.FindUser(t.Context(), "alicia@example.com")
+	is.NoError(err)
+	is.Equal(alice.ID, found.ID)
+	// users without an email can't be found by it
+	_, err = d.FindUser(t.Context(), "")
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	is.ErrorIs(d.ResetUserPassword(t.Context(), bob.ID, "bob"), auth.ErrWeakPassword)
+
+	// locked out after too many failed logins
+	for range auth.LockoutThreshold - 1 {
+		lockedUntil, err := d.RecordLoginFailure(t.Context(), bob.ID)
+		is.NoError(err)
+		is.Nil(lockedUntil)
+	}
+	lockedUntil, err := d.RecordLoginFailure(t.Context(), bob.ID)
+	is.NoError(err)
+	is.NotNil(lockedUntil)
+	is.WithinDuration(time.Now().Add(time.Minute), *lockedUntil, 5*time.Second)
+	got, err = d.GetUserByID(t.Context(), bob.ID)
+	is.NoError(err)
+	is.Equal(uint(auth.LockoutThreshold), got.FailedLogins)
+	is.NotNil(got.LockedUntil)
+
+	is.NoError(d.ResetLoginFailures(t.Context(), bob.ID))
+	got, err = d.GetUserByID(t.Context(), bob.ID)
+	is.NoError(err)
+	is.Zero(got.FailedLogins)
+	is.Nil(got.LockedUntil)
+}
+
+func TestEventSchedules(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:schedules?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	now := time.Now().Truncate(time.Second)
+	at := func(d time.Duration) *time.Time { return lo.ToPtr(now.Add(d)) }
+
+	wedding, err := d.CreateEvent(t.Context(), &db.Event{Name: "wedding", Slug: "wedding", FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()}, StartsAt: at(time.Hour), EndsAt: at(2 * time.Hour), ExpiresAt: at(3 * time.Hour)})
+	is.NoError(err)
+	party, err := d.CreateEvent(t.Context(), &db.Event{Name: "party", Slug: "party", Live: true})
+	is.NoError(err)
+	is.NoError(d.SetActiveEvent(t.Context(), uint64(wedding)))
+
+	// nothing due yet
+	changed, err := d.RunEventSchedules(t.Context(), now)
+	is.NoError(err)
+	is.Empty(changed)
+
+	// starts
+	changed, err = d.RunEventSchedules(t.Context(), now.Add(time.Hour))
+	is.NoError(err)
+	is.Len(changed, 1)
+	is.Equal(wedding, changed[0].ID)
+	is.True(changed[0].Live)
+	// only once
+	changed, err = d.RunEventSchedules(t.Context(), now.Add(time.Hour))
+	is.NoError(err)
+	is.Empty(changed)
+
+	// turned off by hand in the window stays off
+	_, err = d.SetEventLive(t.Context(), uint64(wedding), false)
+	is.NoError(err)
+	changed, err = d.RunEventSchedules(t.Context(), now.Add(90*time.Minute))
+	is.NoError(err)
+	is.Empty(changed)
+
+	// ending when already not live changes nothing
+	_, err = d.SetEventLive(t.Context(), uint64(wedding), true)
+	is.NoError(err)
+	changed, err = d.RunEventSchedules(t.Context(), now.Add(2*time.Hour))
+	is.NoError(err)
+	is.Len(changed, 1)
+	is.False(changed[0].Live)
+
+	// archived and no longer active
+	changed, err = d.RunEventSchedules(t.Context(), now.Add(3*time.Hour))
+	is.NoError(err)
+	is.Len(changed, 1)
+	is.True(changed[0].Archived)
+	got, err := d.GetEvent(t.Context(), uint64(wedding))
+	is.NoError(err)
+	is.True(got.Archived)
+	is.False(got.Active)
+	is.False(got.Live)
+
+	// rescheduling brings it back, skipping straight to the latest step
+	evt, err := d.SetEventSchedule(t.Context(), uint64(wedding), at(-2*time.Hour), at(4*time.Hour), nil)
+	is.NoError(err)
+	is.False(evt.Archived)
+	is.Nil(evt.ExpiresAt)
+	changed, err = d.RunEventSchedules(t.Context(), now.Add(3*time.Hour))
+	is.NoError(err)
+	is.Len(changed, 1)
+	is.True(changed[0].Live)
+	is.False(changed[0].Archived)
+
+	// a whole schedule in the past goes straight to archived
+	_, err = d.SetEventSchedule(t.Context(), uint64(party), at(-3*time.Hour), at(-2*time.Hour), at(-time.Hour))
+	is.NoError(err)
+	changed, err = d.RunEventSchedules(t.Context(), now)
+	is.NoError(err)
+	is.Len(changed, 1)
+	is.Equal(party, changed[0].ID)
+	is.False(changed[0].Live)
+	is.True(changed[0].Archived)
+
+	_, err = d.SetEventSchedule(t.Context(), 1000, nil, nil, nil)
+	is.Error(err)
+}
+
+func TestUpdateEvent(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:updateevent?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	hash := "hash"
+	wedding, err := d.CreateEvent(t.Context(), &db.Event{Name: "weding", Slug: "weding", PasswordHash: &hash, S3Storage: &db.S3Storage{
+		Bucket:    "photos",
+		Endpoint:  "s3.example.com",
+		AccessKey: gormcrypto.EncryptedValue{Raw: "old access"},
+		SecretKey: gormcrypto.EncryptedValue{Raw: "old secret"},
+	}})
+	is.NoError(err)
+	party, err := d.CreateEvent(t.Context(), &db.Event{Name: "party", Slug: "party", FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()}})
+	is.NoError(err)
+
+	// fixing a typo
+	evt, err := d.UpdateEvent(t.Context(), uint64(wedding), &db.EventUpdate{
+		Name:         lo.ToPtr("wedding"),
+		Slug:         lo.ToPtr("wedding"),
+		PasswordHash: lo.ToPtr(""),
+		Cache:        lo.ToPtr(true),
+		AccessKey:    lo.ToPtr("new access"),
+		SecretKey:    lo.ToPtr("new secret"),
+	})
+	is.NoError(err)
+	is.Equal("wedding", evt.Name)
+	is.Equal("wedding", evt.Slug)
+	is.Nil(evt.PasswordHash)
+	is.True(evt.Cache)
+	is.Equal("new access", evt.S3Storage.AccessKey.Raw)
+	is.Equal("new secret", evt.S3Storage.SecretKey.Raw)
+	is.Equal("photos", evt.S3Storage.Bucket)
+
+	// the old slug still leads to the event
+	got, err := d.GetEventBySlug(t.Context(), "weding")
+	is.NoError(err)
+	is.Equal(wedding, got.ID)
+	is.Equal("wedding", got.Slug)
+
+	// slugs other events have or had are taken
+	_, err = d.UpdateEvent(t.Context(), uint64(party), &db.EventUpdate{Slug: lo.ToPtr("wedding")})
+	is.ErrorIs(err, db.ErrSlugTaken)
+	_, err = d.UpdateEvent(t.Context(), uint64(party), &db.EventUpdate{Slug: lo.ToPtr("weding")})
+	is.ErrorIs(err, db.ErrSlugTaken)
+
+	// but an event can go back to its old slug
+	_, err = d.UpdateEvent(t.Context(), uint64(wedding), &db.EventUpdate{Slug: lo.ToPtr("weding")})
+	is.NoError(err)
+	got, err = d.GetEventBySlug(t.Context(), "wedding")
+	is.NoError(err)
+	is.Equal("weding", got.Slug)
+
+	// credentials must be for the storage the event has
+	_, err = d.UpdateEvent(t.Context(), uint64(party), &db.EventUpdate{Username: lo.ToPtr("ftp")})
+	is.Error(err)
+
+	// redirects go with the event
+	is.NoError(d.DeleteEvent(t.Context(), uint64(wedding)))
+	_, err = d.GetEventBySlug(t.Context(), "wedding")
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	_, err = d.UpdateEvent(t.Context(), 1000, &db.EventUpdate{Name: lo.ToPtr("nothing")})
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func TestTrash(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:trash?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	is.NoError(d.CreateUser(t.Context(), "couple", "hunter2hunter2"))
+	is.NoError(d.CreateUser(t.Context(), "planner", "hunter2hunter2"))
+	owner, err := d.GetUser(t.Context(), "couple")
+	is.NoError(err)
+	planner, err := d.GetUser(t.Context(), "planner")
+	is.NoError(err)
+
+	wedding, err := d.CreateEvent(t.Context(), &db.Event{Name: "wedding", Slug: "weding", UserID: owner.ID, Live: true, FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()}})
+	is.NoError(err)
+	_, err = d.UpdateEvent(t.Context(), uint64(wedding), &db.EventUpdate{Slug: lo.ToPtr("wedding")})
+	is.NoError(err)
+	is.NoError(d.AddFileInfo(t.Context(), &db.FileInfo{ID: "photo", EventID: wedding, Name: "photo.jpg"}))
+	is.NoError(d.AddThumbnailInfo(t.Context(), &db.ThumbnailInfo{ID: "thumb", EventID: wedding, FileInfoID: "photo"}))
+	is.NoError(d.CreateEventMember(t.Context(), &db.EventMember{EventID: wedding, UserID: planner.ID, Role: db.RoleOwner, Accepted: true}))
+
+	is.NoError(d.DeleteEvent(t.Context(), uint64(wedding)))
+	is.ErrorIs(d.DeleteEvent(t.Context(), uint64(wedding)), gorm.ErrRecordNotFound)
+	_, err = d.GetEvent(t.Context(), uint64(wedding))
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+	_, err = d.GetEventBySlug(t.Context(), "weding")
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+	events, err := d.GetEvents(t.Context(), owner.ID)
+	is.NoError(err)
+	is.Empty(events)
+
+	// co-owners can see it in the trash and restore it too
+	for _, user := range []uint{owner.ID, planner.ID} {
+		trashed, err := d.GetTrashedEvents(t.Context(), user)
+		is.NoError(err)
+		is.Len(trashed, 1)
+		is.Equal("wedding", trashed[0].TrashedSlug)
+		role, err := d.GetEventRole(t.Context(), user, wedding)
+		is.NoError(err)
+		is.Equal(db.RoleOwner, role)
+	}
+
+	// its slug is free whilst it's in the trash, so it's restored under another
+	other, err := d.CreateEvent(t.Context(), &db.Event{Name: "other wedding", Slug: "wedding", FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()}})
+	is.NoError(err)
+	evt, err := d.RestoreEvent(t.Context(), uint64(wedding))
+	is.NoError(err)
+	is.Equal("wedding-restored", evt.Slug)
+	is.False(evt.Live)
+	is.Len(evt.FileInfos, 1)
+	// old links still lead to it
+	got, err := d.GetEventBySlug(t.Context(), "weding")
+	is.NoError(err)
+	is.Equal(wedding, got.ID)
+	_, err = d.RestoreEvent(t.Context(), uint64(wedding))
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	// only events in the trash long enough are purged
+	is.NoError(d.DeleteEvent(t.Context(), uint64(wedding)))
+	is.ErrorIs(d.PurgeEvent(t.Context(), uint64(other)), gorm.ErrRecordNotFound)
+	due, err := d.GetTrashedEventsBefore(t.Context(), time.Now().Add(-time.Hour))
+	is.NoError(err)
+	is.Empty(due)
+	due, err = d.GetTrashedEventsBefore(t.Context(), time.Now().Add(time.Hour))
+	is.NoError(err)
+	is.Len(due, 1)
+	is.Len(due[0].ThumbnailInfos, 1)
+	is.NotNil(due[0].Storage)
+
+	is.NoError(d.PurgeEvent(t.Context(), uint64(wedding)))
+	_, err = d.GetThumbnailInfo(t.Context(), "thumb")
+	is.Error(err)
+	_, err = d.GetFileInfo(t.Context(), "photo")
+	is.Error(err)
+	members, err := d.GetEventMembers(t.Context(), wedding)
+	is.NoError(err)
+	is.Empty(members)
+	trashed, err := d.GetTrashedEvents(t.Context(), owner.ID)
+	is.NoError(err)
+	is.Empty(trashed)
+	_, err = d.RestoreEvent(t.Context(), uint64(wedding))
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+	// along with its old slugs
+	_, err = d.UpdateEvent(t.Context(), uint64(other), &db.EventUpdate{Slug: lo.ToPtr("weding")})
+	is.NoError(err)
+}
+
+func TestAuditLogs(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:auditlogs?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	is.NoError(d.CreateAuditLog(t.Context(), &db.AuditLog{ActorName: "admin", Action: "admin.signups"}))
+	is.NoError(d.CreateAuditLog(t.Context(), &db.AuditLog{ActorID: 1, ActorName: "bob", Action: "event.create", EventID: lo.ToPtr(uint(1))}))
+	is.NoError(d.CreateAuditLog(t.Context(), &db.AuditLog{ActorID: 1, ActorName: "bob", Action: "event.update", EventID: lo.ToPtr(uint(1)), Before: `{"name":"weding"}`, After: `{"name":"wedding"}`}))
+	is.NoError(d.CreateAuditLog(t.Context(), &db.AuditLog{ActorID: 2, ActorName: "alice", Action: "event.create", EventID: lo.ToPtr(uint(2))}))
+
+	// newest first
+	all, err := d.GetAuditLogs(t.Context(), nil, 0)
+	is.NoError(err)
+	is.Equal([]string{"event.create", "event.update", "event.create", "admin.signups"}, lo.Map(all, func(e *db.AuditLog, _ int) string { return e.Action }))
+
+	latest, err := d.GetAuditLogs(t.Context(), nil, 2)
+	is.NoError(err)
+	is.Len(latest, 2)
+	is.Equal("alice", latest[0].ActorName)
+
+	wedding, err := d.GetAuditLogs(t.Context(), lo.ToPtr(uint(1)), 0)
+	is.NoError(err)
+	is.Len(wedding, 2)
+	is.Equal("event.update", wedding[0].Action)
+	is.Equal(`{"name":"wedding"}`, wedding[0].After)
+}
+
+func TestEventTemplates(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:eventtemplates?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	is.NoError(d.CreateUser(t.Context(), "bob", "hunter2hunter2"))
+	bob, err := d.GetUser(t.Context(), "bob")
+	is.NoError(err)
+
+	weddings := &db.EventTemplate{UserID: bob.ID, Name: "weddings", Cache: true, Storage: gormcrypto.EncryptedValue{Raw: `{"s3":{"bucket":"photos","secretKey":"secret"}}`}}
+	is.NoError(d.CreateEventTemplate(t.Context(), weddings))
+	is.NoError(d.CreateEventTemplate(t.Context(), &db.EventTemplate{UserID: bob.ID, Name: "birthdays"}))
+	is.NoError(d.CreateEventTemplate(t.Context(), &db.EventTemplate{UserID: bob.ID + 1, Name: "someone elses"}))
+
+	templates, err := d.GetEventTemplates(t.Context(), bob.ID)
+	is.NoError(err)
+	is.Equal([]string{"birthdays", "weddings"}, lo.Map(templates, func(t *db.EventTemplate, _ int) string { return t.Name }))
+
+	// storage credentials are decrypted
+	template, err := d.GetEventTemplate(t.Context(), bob.ID, weddings.ID)
+	is.NoError(err)
+	is.Equal(`{"s3":{"bucket":"photos","secretKey":"secret"}}`, template.Storage.Raw)
+	_, err = d.GetEventTemplate(t.Context(), bob.ID+1, weddings.ID)
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	is.ErrorIs(d.DeleteEventTemplate(t.Context(), bob.ID+1, weddings.ID), gorm.ErrRecordNotFound)
+	is.NoError(d.DeleteEventTemplate(t.Context(), bob.ID, weddings.ID))
+	templates, err = d.GetEventTemplates(t.Context(), bob.ID)
+	is.NoError(err)
+	is.Len(templates, 1)
+
+	// deleted with the user
+	is.NoError(d.DeleteUser(t.Context(), bob.ID))
+	templates, err = d.GetEventTemplates(t.Context(), bob.ID)
+	is.NoError(err)
+	is.Empty(templates)
+}
+
+func TestEventBranding(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:eventbranding?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	eventId, err := d.CreateEvent(t.Context(), &db.Event{Name: "wedding", Slug: "wedding", FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()}})
+	is.NoError(err)
+	evt, err := d.GetEvent(t.Context(), uint64(eventId))
+	is.NoError(err)
+	is.Nil(evt.Branding)
+
+	branding := &db.EventBranding{EventID: eventId, PrimaryColor: "#aa0000", Font: "Lora", WelcomeText: "**welcome**"}
+	is.NoError(d.SaveEventBranding(t.Context(), branding))
+	evt, err = d.GetEvent(t.Context(), uint64(eventId))
+	is.NoError(err)
+	is.NotNil(evt.Branding)
+	is.Equal("#aa0000", evt.Branding.PrimaryColor)
+
+	// saved again it's updated rather than added
+	evt.Branding.Footer = "thanks for coming"
+	is.NoError(d.SaveEventBranding(t.Context(), evt.Branding))
+	evt, err = d.GetEvent(t.Context(), uint64(eventId))
+	is.NoError(err)
+	is.Equal(branding.ID, evt.Branding.ID)
+	is.Equal("Lora", evt.Branding.Font)
+	is.Equal("thanks for coming", evt.Branding.Footer)
+
+	// there's only one for the event
+	is.Error(d.SaveEventBranding(t.Context(), &db.EventBranding{EventID: eventId}))
+}
+
+func TestShortLinks(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:shortlinks?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	eventId, err := d.CreateEvent(t.Context(), &db.Event{Name: "wedding", Slug: "wedding", FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()}})
+	is.NoError(err)
+
+	poster := &db.ShortLink{EventID: eventId, Name: "poster"}
+	is.NoError(d.CreateShortLink(t.Context(), poster))
+	is.Len(poster.Code, db.ShortCodeLength)
+	tables := &db.ShortLink{EventID: eventId, Name: "tables"}
+	is.NoError(d.CreateShortLink(t.Context(), tables))
+	is.NotEqual(poster.Code, tables.Code)
+
+	is.NoError(d.CountShortLinkScan(t.Context(), poster.ID))
+	is.NoError(d.CountShortLinkScan(t.Context(), poster.ID))
+	link, err := d.GetShortLink(t.Context(), poster.Code)
+	is.NoError(err)
+	is.Equal(uint(2), link.Scans)
+	is.Equal(eventId, link.EventID)
+
+	links, err := d.GetShortLinks(t.Context(), eventId)
+	is.NoError(err)
+	is.Len(links, 2)
+	is.Equal("poster", links[0].Name)
+
+	// only the events own links can be deleted
+	is.ErrorIs(d.DeleteShortLink(t.Context(), eventId+1, tables.ID), gorm.ErrRecordNotFound)
+	is.NoError(d.DeleteShortLink(t.Context(), eventId, tables.ID))
+	_, err = d.GetShortLink(t.Context(), tables.Code)
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func TestEventStats(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:eventstats?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	eventId, err := d.CreateEvent(t.Context(), &db.Event{Name: "wedding", Slug: "wedding", FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()}})
+	is.NoError(err)
+	otherId, err := d.CreateEvent(t.Context(), &db.Event{Name: "party", Slug: "party", FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()}})
+	is.NoError(err)
+
+	stats, err := d.GetEventStats(t.Context(), eventId, 5)
+	is.NoError(err)
+	is.Zero(stats.Photos)
+	is.Empty(stats.Uploads)
+	is.Nil(stats.Peak)
+
+	start := time.Date(2026, 6, 20, 14, 10, 0, 0, time.UTC)
+	media := []*db.FileInfo{
+		{ID: "a", Name: "a.jpg", Size: 100, Uploader: "family", GuestSession: "s1"},
+		{ID: "b", Name: "b.jpg", Size: 50, Uploader: "family", GuestSession: "s1"},
+		{ID: "c", Name: "c.mp4", Size: 1000, Video: true, GuestSession: "s2"},
+		{ID: "d", Name: "d.jpg", Uploader: "friends", GuestSession: "s3"},
+	}
+	// uploads at 14:10, 14:20 and two at 16:xx, none at 15:00
+	for i, at := range []time.Time{start, start.Add(10 * time.Minute), start.Add(2 * time.Hour), start.Add(2*time.Hour + 5*time.Minute)} {
+		media[i].EventID = eventId
+		media[i].CreatedAt = at
+		is.NoError(d.AddFileInfo(t.Context(), media[i]))
+	}
+	is.NoError(d.AddFileInfo(t.Context(), &db.FileInfo{ID: "other", EventID: otherId, Size: 5, GuestSession: "s9"}))
+
+	for range 3 {
+		is.NoError(d.CountFileView(t.Context(), "c", false))
+	}
+	is.NoError(d.CountFileView(t.Context(), "a", false))
+	is.NoError(d.CountFileView(t.Context(), "a", true))
+	is.NoError(d.CountFileView(t.Context(), "other", true))
+
+	// visiting again doesn't count twice
+	for _, session := range []string{"s1", "s2", "s1", "s4"} {
+		is.NoError(d.AddGuestVisit(t.Context(), eventId, session))
+	}
+	is.NoError(d.AddGuestVisit(t.Context(), otherId, "s1"))
+
+	stats, err = d.GetEventStats(t.Context(), eventId, 5)
+	is.NoError(err)
+	is.Equal(int64(3), stats.Photos)
+	is.Equal(int64(1), stats.Videos)
+	is.Equal(int64(1150), stats.Bytes)
+	is.Equal(int64(3), stats.GuestSessions)
+	is.Equal(int64(3), stats.UploadingSessions)
+	is.Equal(int64(4), stats.Views)
+	is.Equal(int64(1), stats.Downloads)
+
+	is.Equal([]db.UploadCount{
+		{Time: time.Date(2026, 6, 20, 14, 0, 0, 0, time.UTC), Count: 2},
+		{Time: time.Date(2026, 6, 20, 15, 0, 0, 0, time.UTC), Count: 0},
+		{Time: time.Date(2026, 6, 20, 16, 0, 0, 0, time.UTC), Count: 2},
+	}, stats.Uploads)
+	is.Equal(time.Date(2026, 6, 20, 14, 0, 0, 0, time.UTC), stats.Peak.Time)
+
+	is.Equal([]db.MediaStats{
+		{ID: "c", Name: "c.mp4", Video: true, Views: 3},
+		{ID: "a", Name: "a.jpg", Views: 1, Downloads: 1},
+	}, stats.TopMedia)
+	is.Equal([]db.UploaderStats{
+		{Name: "family", Uploads: 2, Bytes: 150},
+		{Name: "friends", Uploads: 1},
+	}, stats.TopUploaders)
+
+	// spread over more than a few days they're counted by day
+	late := &db.FileInfo{ID: "e", EventID: eventId, Name: "e.jpg"}
+	late.CreatedAt = start.AddDate(0, 0, 5)
+	is.NoError(d.AddFileInfo(t.Context(), late))
+	stats, err = d.GetEventStats(t.Context(), eventId, 1)
+	is.NoError(err)
+	is.Len(stats.Uploads, 6)
+	is.Equal(time.Date(2026, 6, 20, 0, 0, 0, 0, time.UTC), stats.Uploads[0].Time)
+	is.Equal(int64(4), stats.Uploads[0].Count)
+	is.Equal(int64(1), stats.Uploads[5].Count)
+	is.Len(stats.TopMedia, 1)
+	is.Len(stats.TopUploaders, 1)
+}
+
+func TestPendingUploads(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:pendinguploads?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	expires := time.Now().Add(time.Minute)
+	is.NoError(d.AddPendingUpload(t.Context(), &db.PendingUpload{EventID: 1, ObjectKey: "wedding/cake.jpg", ExpiresAt: expires}))
+	is.NoError(d.AddPendingUpload(t.Context(), &db.PendingUpload{EventID: 1, ObjectKey: "wedding/old.jpg", ExpiresAt: time.Now().Add(-time.Minute)}))
+
+	// only for the event it was handed out for
+	is.ErrorIs(d.TakePendingUpload(t.Context(), 2, "wedding/cake.jpg"), gorm.ErrRecordNotFound)
+	is.NoError(d.TakePendingUpload(t.Context(), 1, "wedding/cake.jpg"))
+	// and only once
+	is.ErrorIs(d.TakePendingUpload(t.Context(), 1, "wedding/cake.jpg"), gorm.ErrRecordNotFound)
+	is.ErrorIs(d.TakePendingUpload(t.Context(), 1, "wedding/old.jpg"), gorm.ErrRecordNotFound)
+}
+
+func TestModerateMedia(t *testing.T) {
+	is := require.New(t)
+	d, _, err := db.NewDb(&config.Database{
+		Driver:        "sqlite",
+		Uri:           "file:moderatemedia?mode=memory&cache=shared",
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte("supersecretkeysupersecretkey1234")),
+	}, zap.NewNop(), &oauth2.Config{})
+	is.NoError(err)
+
+	eventId, err := d.CreateEvent(t.Context(), &db.Event{Name: "wedding", Slug: "wedding", FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()}})
+	is.NoError(err)
+	otherId, err := d.CreateEvent(t.Context(), &db.Event{Name: "party", Slug: "party", FileSystemStorage: &db.FileSystemStorage{Directory: t.TempDir()}})
+	is.NoError(err)
+	for _, id := range []string{"cake", "rude"} {
+		is.NoError(d.AddFileInfo(t.Context(), &db.FileInfo{ID: id, EventID: eventId, Name: id + ".jpg"}))
+		is.NoError(d.AddThumbnailInfo(t.Context(), &db.ThumbnailInfo{ID: "thumb_" + id, EventID: eventId, FileInfoID: id, Name: "thumb_" + id + ".webp"}))
+	}
+
+	// hidden media is left out of the gallery, but can still be moderated
+	is.NoError(d.SetFileHidden(t.Context(), eventId, "rude", true))
+	thumbnails, err := d.GetThumbnails(t.Context(), eventId, 10, 0, false)
+	is.NoError(err)
+	is.Len(thumbnails, 1)
+	is.Equal("thumb_cake", thumbnails[0].ID)
+	thumbnails, err = d.GetThumbnails(t.Context(), eventId, 10, 0, true)
+	is.NoError(err)
+	is.Len(thumbnails, 2)
+	fi, err := d.GetFileInfo(t.Context(), "rude")
+	is.NoError(err)
+	is.True(fi.Hidden)
+
+	// only the events own media
+	is.ErrorIs(d.SetFileHidden(t.Context(), otherId, "cake", true), gorm.ErrRecordNotFound)
+	_, err = d.DeleteFileInfo(t.Context(), otherId, "cake")
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	deleted, err := d.DeleteFileInfo(t.Context(), eventId, "rude")
+	is.NoError(err)
+	is.Len(deleted, 1)
+	is.Equal("thumb_rude", deleted[0].ID)
+	_, err = d.GetFileInfo(t.Context(), "rude")
+	is.ErrorIs(err, gorm.ErrRecordNotFound)
+	thumbnails, err = d.GetThumbnails(t.Context(), eventId, 10, 0, true)
+	is.NoError(err)
+	is.Len(thumbnails, 1)
+}
@@ -7,6 +7,8 @@ import (
 
 	db "github.com/jj-style/eventpix/internal/data/db"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockDB is an autogenerated mock type for the DB type
@@ -22,6 +24,54 @@ func (_m *MockDB) EXPECT() *MockDB_Expecter {
 	return &MockDB_Expecter{mock: &_m.Mock}
 }
 
+// AcceptEventInvite provides a mock function with given fields: ctx, userId, eventId
+func (_m *MockDB) AcceptEventInvite(ctx context.Context, userId uint, eventId uint) error {
+	ret := _m.Called(ctx, userId, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptEventInvite")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userId, eventId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_AcceptEventInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptEventInvite'
+type MockDB_AcceptEventInvite_Call struct {
+	*mock.Call
+}
+
+// AcceptEventInvite is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - eventId uint
+func (_e *MockDB_Expecter) AcceptEventInvite(ctx interface{}, userId interface{}, eventId interface{}) *MockDB_AcceptEventInvite_Call {
+	return &MockDB_AcceptEventInvite_Call{Call: _e.mock.On("AcceptEventInvite", ctx, userId, eventId)}
+}
+
+func (_c *MockDB_AcceptEventInvite_Call) Run(run func(ctx context.Context, userId uint, eventId uint)) *MockDB_AcceptEventInvite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_AcceptEventInvite_Call) Return(_a0 error) *MockDB_AcceptEventInvite_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_AcceptEventInvite_Call) RunAndReturn(run func(context.Context, uint, uint) error) *MockDB_AcceptEventInvite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddFileInfo provides a mock function with given fields: _a0, _a1
 func (_m *MockDB) AddFileInfo(_a0 context.Context, _a1 *db.FileInfo) error {
 	ret := _m.Called(_a0, _a1)
@@ -69,17 +119,17 @@ func (_c *MockDB_AddFileInfo_Call) RunAndReturn(run func(context.Context, *db.Fi
 	return _c
 }
 
-// AddThumbnailInfo provides a mock function with given fields: _a0, _a1
-func (_m *MockDB) AddThumbnailInfo(_a0 context.Context, _a1 *db.ThumbnailInfo) error {
-	ret := _m.Called(_a0, _a1)
+// AddGuestVisit provides a mock function with given fields: ctx, eventId, session
+func (_m *MockDB) AddGuestVisit(ctx context.Context, eventId uint, session string) error {
+	ret := _m.Called(ctx, eventId, session)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddThumbnailInfo")
+		panic("no return value specified for AddGuestVisit")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *db.ThumbnailInfo) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, eventId, session)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -87,103 +137,94 @@ func (_m *MockDB) AddThumbnailInfo(_a0 context.Context, _a1 *db.ThumbnailInfo) e
 	return r0
 }
 
-// MockDB_AddThumbnailInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddThumbnailInfo'
-type MockDB_AddThumbnailInfo_Call struct {
+// MockDB_AddGuestVisit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddGuestVisit'
+type MockDB_AddGuestVisit_Call struct {
 	*mock.Call
 }
 
-// AddThumbnailInfo is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 *db.ThumbnailInfo
-func (_e *MockDB_Expecter) AddThumbnailInfo(_a0 interface{}, _a1 interface{}) *MockDB_AddThumbnailInfo_Call {
-	return &MockDB_AddThumbnailInfo_Call{Call: _e.mock.On("AddThumbnailInfo", _a0, _a1)}
+// AddGuestVisit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - session string
+func (_e *MockDB_Expecter) AddGuestVisit(ctx interface{}, eventId interface{}, session interface{}) *MockDB_AddGuestVisit_Call {
+	return &MockDB_AddGuestVisit_Call{Call: _e.mock.On("AddGuestVisit", ctx, eventId, session)}
 }
 
-func (_c *MockDB_AddThumbnailInfo_Call) Run(run func(_a0 context.Context, _a1 *db.ThumbnailInfo)) *MockDB_AddThumbnailInfo_Call {
+func (_c *MockDB_AddGuestVisit_Call) Run(run func(ctx context.Context, eventId uint, session string)) *MockDB_AddGuestVisit_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*db.ThumbnailInfo))
+		run(args[0].(context.Context), args[1].(uint), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockDB_AddThumbnailInfo_Call) Return(_a0 error) *MockDB_AddThumbnailInfo_Call {
+func (_c *MockDB_AddGuestVisit_Call) Return(_a0 error) *MockDB_AddGuestVisit_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_AddThumbnailInfo_Call) RunAndReturn(run func(context.Context, *db.ThumbnailInfo) error) *MockDB_AddThumbnailInfo_Call {
+func (_c *MockDB_AddGuestVisit_Call) RunAndReturn(run func(context.Context, uint, string) error) *MockDB_AddGuestVisit_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateEvent provides a mock function with given fields: _a0, _a1
-func (_m *MockDB) CreateEvent(_a0 context.Context, _a1 *db.Event) (uint, error) {
+// AddPendingUpload provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) AddPendingUpload(_a0 context.Context, _a1 *db.PendingUpload) error {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateEvent")
+		panic("no return value specified for AddPendingUpload")
 	}
 
-	var r0 uint
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *db.Event) (uint, error)); ok {
-		return rf(_a0, _a1)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, *db.Event) uint); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.PendingUpload) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Get(0).(uint)
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, *db.Event) error); ok {
-		r1 = rf(_a0, _a1)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDB_CreateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEvent'
-type MockDB_CreateEvent_Call struct {
+// MockDB_AddPendingUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPendingUpload'
+type MockDB_AddPendingUpload_Call struct {
 	*mock.Call
 }
 
-// CreateEvent is a helper method to define mock.On call
+// AddPendingUpload is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *db.Event
-func (_e *MockDB_Expecter) CreateEvent(_a0 interface{}, _a1 interface{}) *MockDB_CreateEvent_Call {
-	return &MockDB_CreateEvent_Call{Call: _e.mock.On("CreateEvent", _a0, _a1)}
+//   - _a1 *db.PendingUpload
+func (_e *MockDB_Expecter) AddPendingUpload(_a0 interface{}, _a1 interface{}) *MockDB_AddPendingUpload_Call {
+	return &MockDB_AddPendingUpload_Call{Call: _e.mock.On("AddPendingUpload", _a0, _a1)}
 }
 
-func (_c *MockDB_CreateEvent_Call) Run(run func(_a0 context.Context, _a1 *db.Event)) *MockDB_CreateEvent_Call {
+func (_c *MockDB_AddPendingUpload_Call) Run(run func(_a0 context.Context, _a1 *db.PendingUpload)) *MockDB_AddPendingUpload_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*db.Event))
+		run(args[0].(context.Context), args[1].(*db.PendingUpload))
 	})
 	return _c
 }
 
-func (_c *MockDB_CreateEvent_Call) Return(_a0 uint, _a1 error) *MockDB_CreateEvent_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDB_AddPendingUpload_Call) Return(_a0 error) *MockDB_AddPendingUpload_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_CreateEvent_Call) RunAndReturn(run func(context.Context, *db.Event) (uint, error)) *MockDB_CreateEvent_Call {
+func (_c *MockDB_AddPendingUpload_Call) RunAndReturn(run func(context.Context, *db.PendingUpload) error) *MockDB_AddPendingUpload_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUser provides a mock function with given fields: _a0, _a1, _a2
-func (_m *MockDB) CreateUser(_a0 context.Context, _a1 string, _a2 string) error {
-	ret := _m.Called(_a0, _a1, _a2)
+// AddThumbnailInfo provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) AddThumbnailInfo(_a0 context.Context, _a1 *db.ThumbnailInfo) error {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for AddThumbnailInfo")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *db.ThumbnailInfo) error); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -191,47 +232,46 @@ func (_m *MockDB) CreateUser(_a0 context.Context, _a1 string, _a2 string) error
 	return r0
 }
 
-// MockDB_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
-type MockDB_CreateUser_Call struct {
+// MockDB_AddThumbnailInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddThumbnailInfo'
+type MockDB_AddThumbnailInfo_Call struct {
 	*mock.Call
 }
 
-// CreateUser is a helper method to define mock.On call
+// AddThumbnailInfo is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 string
-//   - _a2 string
-func (_e *MockDB_Expecter) CreateUser(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockDB_CreateUser_Call {
-	return &MockDB_CreateUser_Call{Call: _e.mock.On("CreateUser", _a0, _a1, _a2)}
+//   - _a1 *db.ThumbnailInfo
+func (_e *MockDB_Expecter) AddThumbnailInfo(_a0 interface{}, _a1 interface{}) *MockDB_AddThumbnailInfo_Call {
+	return &MockDB_AddThumbnailInfo_Call{Call: _e.mock.On("AddThumbnailInfo", _a0, _a1)}
 }
 
-func (_c *MockDB_CreateUser_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockDB_CreateUser_Call {
+func (_c *MockDB_AddThumbnailInfo_Call) Run(run func(_a0 context.Context, _a1 *db.ThumbnailInfo)) *MockDB_AddThumbnailInfo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(*db.ThumbnailInfo))
 	})
 	return _c
 }
 
-func (_c *MockDB_CreateUser_Call) Return(_a0 error) *MockDB_CreateUser_Call {
+func (_c *MockDB_AddThumbnailInfo_Call) Return(_a0 error) *MockDB_AddThumbnailInfo_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_CreateUser_Call) RunAndReturn(run func(context.Context, string, string) error) *MockDB_CreateUser_Call {
+func (_c *MockDB_AddThumbnailInfo_Call) RunAndReturn(run func(context.Context, *db.ThumbnailInfo) error) *MockDB_AddThumbnailInfo_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteEvent provides a mock function with given fields: _a0, _a1
-func (_m *MockDB) DeleteEvent(_a0 context.Context, _a1 uint64) error {
-	ret := _m.Called(_a0, _a1)
+// CountFileView provides a mock function with given fields: ctx, id, download
+func (_m *MockDB) CountFileView(ctx context.Context, id string, download bool) error {
+	ret := _m.Called(ctx, id, download)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteEvent")
+		panic("no return value specified for CountFileView")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, id, download)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -239,46 +279,47 @@ func (_m *MockDB) DeleteEvent(_a0 context.Context, _a1 uint64) error {
 	return r0
 }
 
-// MockDB_DeleteEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEvent'
-type MockDB_DeleteEvent_Call struct {
+// MockDB_CountFileView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountFileView'
+type MockDB_CountFileView_Call struct {
 	*mock.Call
 }
 
-// DeleteEvent is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 uint64
-func (_e *MockDB_Expecter) DeleteEvent(_a0 interface{}, _a1 interface{}) *MockDB_DeleteEvent_Call {
-	return &MockDB_DeleteEvent_Call{Call: _e.mock.On("DeleteEvent", _a0, _a1)}
+// CountFileView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - download bool
+func (_e *MockDB_Expecter) CountFileView(ctx interface{}, id interface{}, download interface{}) *MockDB_CountFileView_Call {
+	return &MockDB_CountFileView_Call{Call: _e.mock.On("CountFileView", ctx, id, download)}
 }
 
-func (_c *MockDB_DeleteEvent_Call) Run(run func(_a0 context.Context, _a1 uint64)) *MockDB_DeleteEvent_Call {
+func (_c *MockDB_CountFileView_Call) Run(run func(ctx context.Context, id string, download bool)) *MockDB_CountFileView_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64))
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *MockDB_DeleteEvent_Call) Return(_a0 error) *MockDB_DeleteEvent_Call {
+func (_c *MockDB_CountFileView_Call) Return(_a0 error) *MockDB_CountFileView_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_DeleteEvent_Call) RunAndReturn(run func(context.Context, uint64) error) *MockDB_DeleteEvent_Call {
+func (_c *MockDB_CountFileView_Call) RunAndReturn(run func(context.Context, string, bool) error) *MockDB_CountFileView_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteGoogleToken provides a mock function with given fields: ctx, userId
-func (_m *MockDB) DeleteGoogleToken(ctx context.Context, userId uint) error {
-	ret := _m.Called(ctx, userId)
+// CountShortLinkScan provides a mock function with given fields: ctx, linkId
+func (_m *MockDB) CountShortLinkScan(ctx context.Context, linkId uint) error {
+	ret := _m.Called(ctx, linkId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteGoogleToken")
+		panic("no return value specified for CountShortLinkScan")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
-		r0 = rf(ctx, userId)
+		r0 = rf(ctx, linkId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -286,174 +327,149 @@ func (_m *MockDB) DeleteGoogleToken(ctx context.Context, userId uint) error {
 	return r0
 }
 
-// MockDB_DeleteGoogleToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGoogleToken'
-type MockDB_DeleteGoogleToken_Call struct {
+// MockDB_CountShortLinkScan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountShortLinkScan'
+type MockDB_CountShortLinkScan_Call struct {
 	*mock.Call
 }
 
-// DeleteGoogleToken is a helper method to define mock.On call
+// CountShortLinkScan is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userId uint
-func (_e *MockDB_Expecter) DeleteGoogleToken(ctx interface{}, userId interface{}) *MockDB_DeleteGoogleToken_Call {
-	return &MockDB_DeleteGoogleToken_Call{Call: _e.mock.On("DeleteGoogleToken", ctx, userId)}
+//   - linkId uint
+func (_e *MockDB_Expecter) CountShortLinkScan(ctx interface{}, linkId interface{}) *MockDB_CountShortLinkScan_Call {
+	return &MockDB_CountShortLinkScan_Call{Call: _e.mock.On("CountShortLinkScan", ctx, linkId)}
 }
 
-func (_c *MockDB_DeleteGoogleToken_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_DeleteGoogleToken_Call {
+func (_c *MockDB_CountShortLinkScan_Call) Run(run func(ctx context.Context, linkId uint)) *MockDB_CountShortLinkScan_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *MockDB_DeleteGoogleToken_Call) Return(_a0 error) *MockDB_DeleteGoogleToken_Call {
+func (_c *MockDB_CountShortLinkScan_Call) Return(_a0 error) *MockDB_CountShortLinkScan_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_DeleteGoogleToken_Call) RunAndReturn(run func(context.Context, uint) error) *MockDB_DeleteGoogleToken_Call {
+func (_c *MockDB_CountShortLinkScan_Call) RunAndReturn(run func(context.Context, uint) error) *MockDB_CountShortLinkScan_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetActiveEvent provides a mock function with given fields: _a0
-func (_m *MockDB) GetActiveEvent(_a0 context.Context) (*db.Event, error) {
-	ret := _m.Called(_a0)
+// CreateApiToken provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) CreateApiToken(_a0 context.Context, _a1 *db.ApiToken) error {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetActiveEvent")
-	}
-
-	var r0 *db.Event
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*db.Event, error)); ok {
-		return rf(_a0)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) *db.Event); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.Event)
-		}
+		panic("no return value specified for CreateApiToken")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(_a0)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.ApiToken) error); ok {
+		r0 = rf(_a0, _a1)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDB_GetActiveEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveEvent'
-type MockDB_GetActiveEvent_Call struct {
+// MockDB_CreateApiToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateApiToken'
+type MockDB_CreateApiToken_Call struct {
 	*mock.Call
 }
 
-// GetActiveEvent is a helper method to define mock.On call
+// CreateApiToken is a helper method to define mock.On call
 //   - _a0 context.Context
-func (_e *MockDB_Expecter) GetActiveEvent(_a0 interface{}) *MockDB_GetActiveEvent_Call {
-	return &MockDB_GetActiveEvent_Call{Call: _e.mock.On("GetActiveEvent", _a0)}
+//   - _a1 *db.ApiToken
+func (_e *MockDB_Expecter) CreateApiToken(_a0 interface{}, _a1 interface{}) *MockDB_CreateApiToken_Call {
+	return &MockDB_CreateApiToken_Call{Call: _e.mock.On("CreateApiToken", _a0, _a1)}
 }
 
-func (_c *MockDB_GetActiveEvent_Call) Run(run func(_a0 context.Context)) *MockDB_GetActiveEvent_Call {
+func (_c *MockDB_CreateApiToken_Call) Run(run func(_a0 context.Context, _a1 *db.ApiToken)) *MockDB_CreateApiToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(*db.ApiToken))
 	})
 	return _c
 }
 
-func (_c *MockDB_GetActiveEvent_Call) Return(_a0 *db.Event, _a1 error) *MockDB_GetActiveEvent_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDB_CreateApiToken_Call) Return(_a0 error) *MockDB_CreateApiToken_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_GetActiveEvent_Call) RunAndReturn(run func(context.Context) (*db.Event, error)) *MockDB_GetActiveEvent_Call {
+func (_c *MockDB_CreateApiToken_Call) RunAndReturn(run func(context.Context, *db.ApiToken) error) *MockDB_CreateApiToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEvent provides a mock function with given fields: _a0, _a1
-func (_m *MockDB) GetEvent(_a0 context.Context, _a1 uint64) (*db.Event, error) {
+// CreateAuditLog provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) CreateAuditLog(_a0 context.Context, _a1 *db.AuditLog) error {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEvent")
+		panic("no return value specified for CreateAuditLog")
 	}
 
-	var r0 *db.Event
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*db.Event, error)); ok {
-		return rf(_a0, _a1)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) *db.Event); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.AuditLog) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.Event)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
-		r1 = rf(_a0, _a1)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDB_GetEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEvent'
-type MockDB_GetEvent_Call struct {
+// MockDB_CreateAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuditLog'
+type MockDB_CreateAuditLog_Call struct {
 	*mock.Call
 }
 
-// GetEvent is a helper method to define mock.On call
+// CreateAuditLog is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 uint64
-func (_e *MockDB_Expecter) GetEvent(_a0 interface{}, _a1 interface{}) *MockDB_GetEvent_Call {
-	return &MockDB_GetEvent_Call{Call: _e.mock.On("GetEvent", _a0, _a1)}
+//   - _a1 *db.AuditLog
+func (_e *MockDB_Expecter) CreateAuditLog(_a0 interface{}, _a1 interface{}) *MockDB_CreateAuditLog_Call {
+	return &MockDB_CreateAuditLog_Call{Call: _e.mock.On("CreateAuditLog", _a0, _a1)}
 }
 
-func (_c *MockDB_GetEvent_Call) Run(run func(_a0 context.Context, _a1 uint64)) *MockDB_GetEvent_Call {
+func (_c *MockDB_CreateAuditLog_Call) Run(run func(_a0 context.Context, _a1 *db.AuditLog)) *MockDB_CreateAuditLog_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64))
+		run(args[0].(context.Context), args[1].(*db.AuditLog))
 	})
 	return _c
 }
 
-func (_c *MockDB_GetEvent_Call) Return(_a0 *db.Event, _a1 error) *MockDB_GetEvent_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDB_CreateAuditLog_Call) Return(_a0 error) *MockDB_CreateAuditLog_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_GetEvent_Call) RunAndReturn(run func(context.Context, uint64) (*db.Event, error)) *MockDB_GetEvent_Call {
+func (_c *MockDB_CreateAuditLog_Call) RunAndReturn(run func(context.Context, *db.AuditLog) error) *MockDB_CreateAuditLog_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEventBySlug provides a mock function with given fields: _a0, _a1
-func (_m *MockDB) GetEventBySlug(_a0 context.Context, _a1 string) (*db.Event, error) {
+// CreateEvent provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) CreateEvent(_a0 context.Context, _a1 *db.Event) (uint, error) {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventBySlug")
+		panic("no return value specified for CreateEvent")
 	}
 
-	var r0 *db.Event
+	var r0 uint
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.Event, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *db.Event) (uint, error)); ok {
 		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *db.Event); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *db.Event) uint); ok {
 		r0 = rf(_a0, _a1)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.Event)
-		}
+		r0 = ret.Get(0).(uint)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *db.Event) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -462,21 +478,1547 @@ func (_m *MockDB) GetEventBySlug(_a0 context.Context, _a1 string) (*db.Event, er
 	return r0, r1
 }
 
-// MockDB_GetEventBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventBySlug'
-type MockDB_GetEventBySlug_Call struct {
+// MockDB_CreateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEvent'
+type MockDB_CreateEvent_Call struct {
 	*mock.Call
 }
 
-// GetEventBySlug is a helper method to define mock.On call
+// CreateEvent is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 string
-func (_e *MockDB_Expecter) GetEventBySlug(_a0 interface{}, _a1 interface{}) *MockDB_GetEventBySlug_Call {
-	return &MockDB_GetEventBySlug_Call{Call: _e.mock.On("GetEventBySlug", _a0, _a1)}
+//   - _a1 *db.Event
+func (_e *MockDB_Expecter) CreateEvent(_a0 interface{}, _a1 interface{}) *MockDB_CreateEvent_Call {
+	return &MockDB_CreateEvent_Call{Call: _e.mock.On("CreateEvent", _a0, _a1)}
 }
 
-func (_c *MockDB_GetEventBySlug_Call) Run(run func(_a0 context.Context, _a1 string)) *MockDB_GetEventBySlug_Call {
+func (_c *MockDB_CreateEvent_Call) Run(run func(_a0 context.Context, _a1 *db.Event)) *MockDB_CreateEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(*db.Event))
+	})
+	return _c
+}
+
+func (_c *MockDB_CreateEvent_Call) Return(_a0 uint, _a1 error) *MockDB_CreateEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_CreateEvent_Call) RunAndReturn(run func(context.Context, *db.Event) (uint, error)) *MockDB_CreateEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEventMember provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) CreateEventMember(_a0 context.Context, _a1 *db.EventMember) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEventMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.EventMember) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_CreateEventMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEventMember'
+type MockDB_CreateEventMember_Call struct {
+	*mock.Call
+}
+
+// CreateEventMember is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *db.EventMember
+func (_e *MockDB_Expecter) CreateEventMember(_a0 interface{}, _a1 interface{}) *MockDB_CreateEventMember_Call {
+	return &MockDB_CreateEventMember_Call{Call: _e.mock.On("CreateEventMember", _a0, _a1)}
+}
+
+func (_c *MockDB_CreateEventMember_Call) Run(run func(_a0 context.Context, _a1 *db.EventMember)) *MockDB_CreateEventMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.EventMember))
+	})
+	return _c
+}
+
+func (_c *MockDB_CreateEventMember_Call) Return(_a0 error) *MockDB_CreateEventMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_CreateEventMember_Call) RunAndReturn(run func(context.Context, *db.EventMember) error) *MockDB_CreateEventMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEventTemplate provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) CreateEventTemplate(_a0 context.Context, _a1 *db.EventTemplate) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEventTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.EventTemplate) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_CreateEventTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEventTemplate'
+type MockDB_CreateEventTemplate_Call struct {
+	*mock.Call
+}
+
+// CreateEventTemplate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *db.EventTemplate
+func (_e *MockDB_Expecter) CreateEventTemplate(_a0 interface{}, _a1 interface{}) *MockDB_CreateEventTemplate_Call {
+	return &MockDB_CreateEventTemplate_Call{Call: _e.mock.On("CreateEventTemplate", _a0, _a1)}
+}
+
+func (_c *MockDB_CreateEventTemplate_Call) Run(run func(_a0 context.Context, _a1 *db.EventTemplate)) *MockDB_CreateEventTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.EventTemplate))
+	})
+	return _c
+}
+
+func (_c *MockDB_CreateEventTemplate_Call) Return(_a0 error) *MockDB_CreateEventTemplate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_CreateEventTemplate_Call) RunAndReturn(run func(context.Context, *db.EventTemplate) error) *MockDB_CreateEventTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateGuestToken provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) CreateGuestToken(_a0 context.Context, _a1 *db.GuestToken) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGuestToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.GuestToken) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_CreateGuestToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGuestToken'
+type MockDB_CreateGuestToken_Call struct {
+	*mock.Call
+}
+
+// CreateGuestToken is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *db.GuestToken
+func (_e *MockDB_Expecter) CreateGuestToken(_a0 interface{}, _a1 interface{}) *MockDB_CreateGuestToken_Call {
+	return &MockDB_CreateGuestToken_Call{Call: _e.mock.On("CreateGuestToken", _a0, _a1)}
+}
+
+func (_c *MockDB_CreateGuestToken_Call) Run(run func(_a0 context.Context, _a1 *db.GuestToken)) *MockDB_CreateGuestToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.GuestToken))
+	})
+	return _c
+}
+
+func (_c *MockDB_CreateGuestToken_Call) Return(_a0 error) *MockDB_CreateGuestToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_CreateGuestToken_Call) RunAndReturn(run func(context.Context, *db.GuestToken) error) *MockDB_CreateGuestToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOidcIdentity provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) CreateOidcIdentity(_a0 context.Context, _a1 *db.OidcIdentity) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOidcIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.OidcIdentity) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_CreateOidcIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOidcIdentity'
+type MockDB_CreateOidcIdentity_Call struct {
+	*mock.Call
+}
+
+// CreateOidcIdentity is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *db.OidcIdentity
+func (_e *MockDB_Expecter) CreateOidcIdentity(_a0 interface{}, _a1 interface{}) *MockDB_CreateOidcIdentity_Call {
+	return &MockDB_CreateOidcIdentity_Call{Call: _e.mock.On("CreateOidcIdentity", _a0, _a1)}
+}
+
+func (_c *MockDB_CreateOidcIdentity_Call) Run(run func(_a0 context.Context, _a1 *db.OidcIdentity)) *MockDB_CreateOidcIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.OidcIdentity))
+	})
+	return _c
+}
+
+func (_c *MockDB_CreateOidcIdentity_Call) Return(_a0 error) *MockDB_CreateOidcIdentity_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_CreateOidcIdentity_Call) RunAndReturn(run func(context.Context, *db.OidcIdentity) error) *MockDB_CreateOidcIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOidcUser provides a mock function with given fields: ctx, username, identity
+func (_m *MockDB) CreateOidcUser(ctx context.Context, username string, identity *db.OidcIdentity) (*db.User, error) {
+	ret := _m.Called(ctx, username, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOidcUser")
+	}
+
+	var r0 *db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.OidcIdentity) (*db.User, error)); ok {
+		return rf(ctx, username, identity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.OidcIdentity) *db.User); ok {
+		r0 = rf(ctx, username, identity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *db.OidcIdentity) error); ok {
+		r1 = rf(ctx, username, identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_CreateOidcUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOidcUser'
+type MockDB_CreateOidcUser_Call struct {
+	*mock.Call
+}
+
+// CreateOidcUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - identity *db.OidcIdentity
+func (_e *MockDB_Expecter) CreateOidcUser(ctx interface{}, username interface{}, identity interface{}) *MockDB_CreateOidcUser_Call {
+	return &MockDB_CreateOidcUser_Call{Call: _e.mock.On("CreateOidcUser", ctx, username, identity)}
+}
+
+func (_c *MockDB_CreateOidcUser_Call) Run(run func(ctx context.Context, username string, identity *db.OidcIdentity)) *MockDB_CreateOidcUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*db.OidcIdentity))
+	})
+	return _c
+}
+
+func (_c *MockDB_CreateOidcUser_Call) Return(_a0 *db.User, _a1 error) *MockDB_CreateOidcUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_CreateOidcUser_Call) RunAndReturn(run func(context.Context, string, *db.OidcIdentity) (*db.User, error)) *MockDB_CreateOidcUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateShortLink provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) CreateShortLink(_a0 context.Context, _a1 *db.ShortLink) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateShortLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.ShortLink) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_CreateShortLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateShortLink'
+type MockDB_CreateShortLink_Call struct {
+	*mock.Call
+}
+
+// CreateShortLink is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *db.ShortLink
+func (_e *MockDB_Expecter) CreateShortLink(_a0 interface{}, _a1 interface{}) *MockDB_CreateShortLink_Call {
+	return &MockDB_CreateShortLink_Call{Call: _e.mock.On("CreateShortLink", _a0, _a1)}
+}
+
+func (_c *MockDB_CreateShortLink_Call) Run(run func(_a0 context.Context, _a1 *db.ShortLink)) *MockDB_CreateShortLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.ShortLink))
+	})
+	return _c
+}
+
+func (_c *MockDB_CreateShortLink_Call) Return(_a0 error) *MockDB_CreateShortLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_CreateShortLink_Call) RunAndReturn(run func(context.Context, *db.ShortLink) error) *MockDB_CreateShortLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStorageMigration provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) CreateStorageMigration(_a0 context.Context, _a1 *db.StorageMigration) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStorageMigration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.StorageMigration) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_CreateStorageMigration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStorageMigration'
+type MockDB_CreateStorageMigration_Call struct {
+	*mock.Call
+}
+
+// CreateStorageMigration is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *db.StorageMigration
+func (_e *MockDB_Expecter) CreateStorageMigration(_a0 interface{}, _a1 interface{}) *MockDB_CreateStorageMigration_Call {
+	return &MockDB_CreateStorageMigration_Call{Call: _e.mock.On("CreateStorageMigration", _a0, _a1)}
+}
+
+func (_c *MockDB_CreateStorageMigration_Call) Run(run func(_a0 context.Context, _a1 *db.StorageMigration)) *MockDB_CreateStorageMigration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.StorageMigration))
+	})
+	return _c
+}
+
+func (_c *MockDB_CreateStorageMigration_Call) Return(_a0 error) *MockDB_CreateStorageMigration_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_CreateStorageMigration_Call) RunAndReturn(run func(context.Context, *db.StorageMigration) error) *MockDB_CreateStorageMigration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockDB) CreateUser(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockDB_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+func (_e *MockDB_Expecter) CreateUser(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockDB_CreateUser_Call {
+	return &MockDB_CreateUser_Call{Call: _e.mock.On("CreateUser", _a0, _a1, _a2)}
+}
+
+func (_c *MockDB_CreateUser_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockDB_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_CreateUser_Call) Return(_a0 error) *MockDB_CreateUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_CreateUser_Call) RunAndReturn(run func(context.Context, string, string) error) *MockDB_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhook provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) CreateWebhook(_a0 context.Context, _a1 *db.Webhook) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.Webhook) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type MockDB_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *db.Webhook
+func (_e *MockDB_Expecter) CreateWebhook(_a0 interface{}, _a1 interface{}) *MockDB_CreateWebhook_Call {
+	return &MockDB_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", _a0, _a1)}
+}
+
+func (_c *MockDB_CreateWebhook_Call) Run(run func(_a0 context.Context, _a1 *db.Webhook)) *MockDB_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.Webhook))
+	})
+	return _c
+}
+
+func (_c *MockDB_CreateWebhook_Call) Return(_a0 error) *MockDB_CreateWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_CreateWebhook_Call) RunAndReturn(run func(context.Context, *db.Webhook) error) *MockDB_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhookDelivery provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) CreateWebhookDelivery(_a0 context.Context, _a1 *db.WebhookDelivery) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhookDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.WebhookDelivery) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_CreateWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhookDelivery'
+type MockDB_CreateWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// CreateWebhookDelivery is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *db.WebhookDelivery
+func (_e *MockDB_Expecter) CreateWebhookDelivery(_a0 interface{}, _a1 interface{}) *MockDB_CreateWebhookDelivery_Call {
+	return &MockDB_CreateWebhookDelivery_Call{Call: _e.mock.On("CreateWebhookDelivery", _a0, _a1)}
+}
+
+func (_c *MockDB_CreateWebhookDelivery_Call) Run(run func(_a0 context.Context, _a1 *db.WebhookDelivery)) *MockDB_CreateWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *MockDB_CreateWebhookDelivery_Call) Return(_a0 error) *MockDB_CreateWebhookDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_CreateWebhookDelivery_Call) RunAndReturn(run func(context.Context, *db.WebhookDelivery) error) *MockDB_CreateWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteApiToken provides a mock function with given fields: ctx, userId, tokenId
+func (_m *MockDB) DeleteApiToken(ctx context.Context, userId uint, tokenId uint) error {
+	ret := _m.Called(ctx, userId, tokenId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteApiToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userId, tokenId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_DeleteApiToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteApiToken'
+type MockDB_DeleteApiToken_Call struct {
+	*mock.Call
+}
+
+// DeleteApiToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - tokenId uint
+func (_e *MockDB_Expecter) DeleteApiToken(ctx interface{}, userId interface{}, tokenId interface{}) *MockDB_DeleteApiToken_Call {
+	return &MockDB_DeleteApiToken_Call{Call: _e.mock.On("DeleteApiToken", ctx, userId, tokenId)}
+}
+
+func (_c *MockDB_DeleteApiToken_Call) Run(run func(ctx context.Context, userId uint, tokenId uint)) *MockDB_DeleteApiToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_DeleteApiToken_Call) Return(_a0 error) *MockDB_DeleteApiToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_DeleteApiToken_Call) RunAndReturn(run func(context.Context, uint, uint) error) *MockDB_DeleteApiToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEvent provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) DeleteEvent(_a0 context.Context, _a1 uint64) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_DeleteEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEvent'
+type MockDB_DeleteEvent_Call struct {
+	*mock.Call
+}
+
+// DeleteEvent is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uint64
+func (_e *MockDB_Expecter) DeleteEvent(_a0 interface{}, _a1 interface{}) *MockDB_DeleteEvent_Call {
+	return &MockDB_DeleteEvent_Call{Call: _e.mock.On("DeleteEvent", _a0, _a1)}
+}
+
+func (_c *MockDB_DeleteEvent_Call) Run(run func(_a0 context.Context, _a1 uint64)) *MockDB_DeleteEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockDB_DeleteEvent_Call) Return(_a0 error) *MockDB_DeleteEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_DeleteEvent_Call) RunAndReturn(run func(context.Context, uint64) error) *MockDB_DeleteEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEventMember provides a mock function with given fields: ctx, eventId, memberId
+func (_m *MockDB) DeleteEventMember(ctx context.Context, eventId uint, memberId uint) error {
+	ret := _m.Called(ctx, eventId, memberId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEventMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, eventId, memberId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_DeleteEventMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEventMember'
+type MockDB_DeleteEventMember_Call struct {
+	*mock.Call
+}
+
+// DeleteEventMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - memberId uint
+func (_e *MockDB_Expecter) DeleteEventMember(ctx interface{}, eventId interface{}, memberId interface{}) *MockDB_DeleteEventMember_Call {
+	return &MockDB_DeleteEventMember_Call{Call: _e.mock.On("DeleteEventMember", ctx, eventId, memberId)}
+}
+
+func (_c *MockDB_DeleteEventMember_Call) Run(run func(ctx context.Context, eventId uint, memberId uint)) *MockDB_DeleteEventMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_DeleteEventMember_Call) Return(_a0 error) *MockDB_DeleteEventMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_DeleteEventMember_Call) RunAndReturn(run func(context.Context, uint, uint) error) *MockDB_DeleteEventMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEventTemplate provides a mock function with given fields: ctx, userId, templateId
+func (_m *MockDB) DeleteEventTemplate(ctx context.Context, userId uint, templateId uint) error {
+	ret := _m.Called(ctx, userId, templateId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEventTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userId, templateId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_DeleteEventTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEventTemplate'
+type MockDB_DeleteEventTemplate_Call struct {
+	*mock.Call
+}
+
+// DeleteEventTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - templateId uint
+func (_e *MockDB_Expecter) DeleteEventTemplate(ctx interface{}, userId interface{}, templateId interface{}) *MockDB_DeleteEventTemplate_Call {
+	return &MockDB_DeleteEventTemplate_Call{Call: _e.mock.On("DeleteEventTemplate", ctx, userId, templateId)}
+}
+
+func (_c *MockDB_DeleteEventTemplate_Call) Run(run func(ctx context.Context, userId uint, templateId uint)) *MockDB_DeleteEventTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_DeleteEventTemplate_Call) Return(_a0 error) *MockDB_DeleteEventTemplate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_DeleteEventTemplate_Call) RunAndReturn(run func(context.Context, uint, uint) error) *MockDB_DeleteEventTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFileInfo provides a mock function with given fields: ctx, eventId, id
+func (_m *MockDB) DeleteFileInfo(ctx context.Context, eventId uint, id string) ([]*db.ThumbnailInfo, error) {
+	ret := _m.Called(ctx, eventId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFileInfo")
+	}
+
+	var r0 []*db.ThumbnailInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) ([]*db.ThumbnailInfo, error)); ok {
+		return rf(ctx, eventId, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) []*db.ThumbnailInfo); ok {
+		r0 = rf(ctx, eventId, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.ThumbnailInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, eventId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_DeleteFileInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFileInfo'
+type MockDB_DeleteFileInfo_Call struct {
+	*mock.Call
+}
+
+// DeleteFileInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - id string
+func (_e *MockDB_Expecter) DeleteFileInfo(ctx interface{}, eventId interface{}, id interface{}) *MockDB_DeleteFileInfo_Call {
+	return &MockDB_DeleteFileInfo_Call{Call: _e.mock.On("DeleteFileInfo", ctx, eventId, id)}
+}
+
+func (_c *MockDB_DeleteFileInfo_Call) Run(run func(ctx context.Context, eventId uint, id string)) *MockDB_DeleteFileInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_DeleteFileInfo_Call) Return(_a0 []*db.ThumbnailInfo, _a1 error) *MockDB_DeleteFileInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_DeleteFileInfo_Call) RunAndReturn(run func(context.Context, uint, string) ([]*db.ThumbnailInfo, error)) *MockDB_DeleteFileInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteGoogleToken provides a mock function with given fields: ctx, userId
+func (_m *MockDB) DeleteGoogleToken(ctx context.Context, userId uint) error {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGoogleToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_DeleteGoogleToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGoogleToken'
+type MockDB_DeleteGoogleToken_Call struct {
+	*mock.Call
+}
+
+// DeleteGoogleToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+func (_e *MockDB_Expecter) DeleteGoogleToken(ctx interface{}, userId interface{}) *MockDB_DeleteGoogleToken_Call {
+	return &MockDB_DeleteGoogleToken_Call{Call: _e.mock.On("DeleteGoogleToken", ctx, userId)}
+}
+
+func (_c *MockDB_DeleteGoogleToken_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_DeleteGoogleToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_DeleteGoogleToken_Call) Return(_a0 error) *MockDB_DeleteGoogleToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_DeleteGoogleToken_Call) RunAndReturn(run func(context.Context, uint) error) *MockDB_DeleteGoogleToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteGuestToken provides a mock function with given fields: ctx, eventId, tokenId
+func (_m *MockDB) DeleteGuestToken(ctx context.Context, eventId uint, tokenId uint) error {
+	ret := _m.Called(ctx, eventId, tokenId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGuestToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, eventId, tokenId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_DeleteGuestToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGuestToken'
+type MockDB_DeleteGuestToken_Call struct {
+	*mock.Call
+}
+
+// DeleteGuestToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - tokenId uint
+func (_e *MockDB_Expecter) DeleteGuestToken(ctx interface{}, eventId interface{}, tokenId interface{}) *MockDB_DeleteGuestToken_Call {
+	return &MockDB_DeleteGuestToken_Call{Call: _e.mock.On("DeleteGuestToken", ctx, eventId, tokenId)}
+}
+
+func (_c *MockDB_DeleteGuestToken_Call) Run(run func(ctx context.Context, eventId uint, tokenId uint)) *MockDB_DeleteGuestToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_DeleteGuestToken_Call) Return(_a0 error) *MockDB_DeleteGuestToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_DeleteGuestToken_Call) RunAndReturn(run func(context.Context, uint, uint) error) *MockDB_DeleteGuestToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOidcIdentity provides a mock function with given fields: ctx, userId, identityId
+func (_m *MockDB) DeleteOidcIdentity(ctx context.Context, userId uint, identityId uint) error {
+	ret := _m.Called(ctx, userId, identityId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOidcIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userId, identityId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_DeleteOidcIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOidcIdentity'
+type MockDB_DeleteOidcIdentity_Call struct {
+	*mock.Call
+}
+
+// DeleteOidcIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - identityId uint
+func (_e *MockDB_Expecter) DeleteOidcIdentity(ctx interface{}, userId interface{}, identityId interface{}) *MockDB_DeleteOidcIdentity_Call {
+	return &MockDB_DeleteOidcIdentity_Call{Call: _e.mock.On("DeleteOidcIdentity", ctx, userId, identityId)}
+}
+
+func (_c *MockDB_DeleteOidcIdentity_Call) Run(run func(ctx context.Context, userId uint, identityId uint)) *MockDB_DeleteOidcIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_DeleteOidcIdentity_Call) Return(_a0 error) *MockDB_DeleteOidcIdentity_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_DeleteOidcIdentity_Call) RunAndReturn(run func(context.Context, uint, uint) error) *MockDB_DeleteOidcIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteShortLink provides a mock function with given fields: ctx, eventId, linkId
+func (_m *MockDB) DeleteShortLink(ctx context.Context, eventId uint, linkId uint) error {
+	ret := _m.Called(ctx, eventId, linkId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteShortLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, eventId, linkId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_DeleteShortLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteShortLink'
+type MockDB_DeleteShortLink_Call struct {
+	*mock.Call
+}
+
+// DeleteShortLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - linkId uint
+func (_e *MockDB_Expecter) DeleteShortLink(ctx interface{}, eventId interface{}, linkId interface{}) *MockDB_DeleteShortLink_Call {
+	return &MockDB_DeleteShortLink_Call{Call: _e.mock.On("DeleteShortLink", ctx, eventId, linkId)}
+}
+
+func (_c *MockDB_DeleteShortLink_Call) Run(run func(ctx context.Context, eventId uint, linkId uint)) *MockDB_DeleteShortLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_DeleteShortLink_Call) Return(_a0 error) *MockDB_DeleteShortLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_DeleteShortLink_Call) RunAndReturn(run func(context.Context, uint, uint) error) *MockDB_DeleteShortLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function with given fields: ctx, userId
+func (_m *MockDB) DeleteUser(ctx context.Context, userId uint) error {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockDB_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+func (_e *MockDB_Expecter) DeleteUser(ctx interface{}, userId interface{}) *MockDB_DeleteUser_Call {
+	return &MockDB_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, userId)}
+}
+
+func (_c *MockDB_DeleteUser_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_DeleteUser_Call) Return(_a0 error) *MockDB_DeleteUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_DeleteUser_Call) RunAndReturn(run func(context.Context, uint) error) *MockDB_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, userId, webhookId
+func (_m *MockDB) DeleteWebhook(ctx context.Context, userId uint, webhookId uint) error {
+	ret := _m.Called(ctx, userId, webhookId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userId, webhookId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type MockDB_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - webhookId uint
+func (_e *MockDB_Expecter) DeleteWebhook(ctx interface{}, userId interface{}, webhookId interface{}) *MockDB_DeleteWebhook_Call {
+	return &MockDB_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, userId, webhookId)}
+}
+
+func (_c *MockDB_DeleteWebhook_Call) Run(run func(ctx context.Context, userId uint, webhookId uint)) *MockDB_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_DeleteWebhook_Call) Return(_a0 error) *MockDB_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_DeleteWebhook_Call) RunAndReturn(run func(context.Context, uint, uint) error) *MockDB_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailPendingWebhookDeliveries provides a mock function with given fields: _a0
+func (_m *MockDB) FailPendingWebhookDeliveries(_a0 context.Context) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailPendingWebhookDeliveries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_FailPendingWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailPendingWebhookDeliveries'
+type MockDB_FailPendingWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// FailPendingWebhookDeliveries is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockDB_Expecter) FailPendingWebhookDeliveries(_a0 interface{}) *MockDB_FailPendingWebhookDeliveries_Call {
+	return &MockDB_FailPendingWebhookDeliveries_Call{Call: _e.mock.On("FailPendingWebhookDeliveries", _a0)}
+}
+
+func (_c *MockDB_FailPendingWebhookDeliveries_Call) Run(run func(_a0 context.Context)) *MockDB_FailPendingWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDB_FailPendingWebhookDeliveries_Call) Return(_a0 error) *MockDB_FailPendingWebhookDeliveries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_FailPendingWebhookDeliveries_Call) RunAndReturn(run func(context.Context) error) *MockDB_FailPendingWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailRunningStorageMigrations provides a mock function with given fields: _a0
+func (_m *MockDB) FailRunningStorageMigrations(_a0 context.Context) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailRunningStorageMigrations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_FailRunningStorageMigrations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailRunningStorageMigrations'
+type MockDB_FailRunningStorageMigrations_Call struct {
+	*mock.Call
+}
+
+// FailRunningStorageMigrations is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockDB_Expecter) FailRunningStorageMigrations(_a0 interface{}) *MockDB_FailRunningStorageMigrations_Call {
+	return &MockDB_FailRunningStorageMigrations_Call{Call: _e.mock.On("FailRunningStorageMigrations", _a0)}
+}
+
+func (_c *MockDB_FailRunningStorageMigrations_Call) Run(run func(_a0 context.Context)) *MockDB_FailRunningStorageMigrations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDB_FailRunningStorageMigrations_Call) Return(_a0 error) *MockDB_FailRunningStorageMigrations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_FailRunningStorageMigrations_Call) RunAndReturn(run func(context.Context) error) *MockDB_FailRunningStorageMigrations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUser provides a mock function with given fields: ctx, usernameOrEmail
+func (_m *MockDB) FindUser(ctx context.Context, usernameOrEmail string) (*db.User, error) {
+	ret := _m.Called(ctx, usernameOrEmail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUser")
+	}
+
+	var r0 *db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.User, error)); ok {
+		return rf(ctx, usernameOrEmail)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.User); ok {
+		r0 = rf(ctx, usernameOrEmail)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, usernameOrEmail)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_FindUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUser'
+type MockDB_FindUser_Call struct {
+	*mock.Call
+}
+
+// FindUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - usernameOrEmail string
+func (_e *MockDB_Expecter) FindUser(ctx interface{}, usernameOrEmail interface{}) *MockDB_FindUser_Call {
+	return &MockDB_FindUser_Call{Call: _e.mock.On("FindUser", ctx, usernameOrEmail)}
+}
+
+func (_c *MockDB_FindUser_Call) Run(run func(ctx context.Context, usernameOrEmail string)) *MockDB_FindUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_FindUser_Call) Return(_a0 *db.User, _a1 error) *MockDB_FindUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_FindUser_Call) RunAndReturn(run func(context.Context, string) (*db.User, error)) *MockDB_FindUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveEvent provides a mock function with given fields: _a0
+func (_m *MockDB) GetActiveEvent(_a0 context.Context) (*db.Event, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveEvent")
+	}
+
+	var r0 *db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*db.Event, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *db.Event); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetActiveEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveEvent'
+type MockDB_GetActiveEvent_Call struct {
+	*mock.Call
+}
+
+// GetActiveEvent is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockDB_Expecter) GetActiveEvent(_a0 interface{}) *MockDB_GetActiveEvent_Call {
+	return &MockDB_GetActiveEvent_Call{Call: _e.mock.On("GetActiveEvent", _a0)}
+}
+
+func (_c *MockDB_GetActiveEvent_Call) Run(run func(_a0 context.Context)) *MockDB_GetActiveEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetActiveEvent_Call) Return(_a0 *db.Event, _a1 error) *MockDB_GetActiveEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetActiveEvent_Call) RunAndReturn(run func(context.Context) (*db.Event, error)) *MockDB_GetActiveEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetApiToken provides a mock function with given fields: ctx, hash
+func (_m *MockDB) GetApiToken(ctx context.Context, hash string) (*db.ApiToken, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApiToken")
+	}
+
+	var r0 *db.ApiToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.ApiToken, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.ApiToken); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.ApiToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetApiToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApiToken'
+type MockDB_GetApiToken_Call struct {
+	*mock.Call
+}
+
+// GetApiToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+func (_e *MockDB_Expecter) GetApiToken(ctx interface{}, hash interface{}) *MockDB_GetApiToken_Call {
+	return &MockDB_GetApiToken_Call{Call: _e.mock.On("GetApiToken", ctx, hash)}
+}
+
+func (_c *MockDB_GetApiToken_Call) Run(run func(ctx context.Context, hash string)) *MockDB_GetApiToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetApiToken_Call) Return(_a0 *db.ApiToken, _a1 error) *MockDB_GetApiToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetApiToken_Call) RunAndReturn(run func(context.Context, string) (*db.ApiToken, error)) *MockDB_GetApiToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetApiTokens provides a mock function with given fields: ctx, userId
+func (_m *MockDB) GetApiTokens(ctx context.Context, userId uint) ([]*db.ApiToken, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApiTokens")
+	}
+
+	var r0 []*db.ApiToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*db.ApiToken, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*db.ApiToken); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.ApiToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetApiTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApiTokens'
+type MockDB_GetApiTokens_Call struct {
+	*mock.Call
+}
+
+// GetApiTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+func (_e *MockDB_Expecter) GetApiTokens(ctx interface{}, userId interface{}) *MockDB_GetApiTokens_Call {
+	return &MockDB_GetApiTokens_Call{Call: _e.mock.On("GetApiTokens", ctx, userId)}
+}
+
+func (_c *MockDB_GetApiTokens_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_GetApiTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetApiTokens_Call) Return(_a0 []*db.ApiToken, _a1 error) *MockDB_GetApiTokens_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetApiTokens_Call) RunAndReturn(run func(context.Context, uint) ([]*db.ApiToken, error)) *MockDB_GetApiTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuditLogs provides a mock function with given fields: ctx, eventId, limit
+func (_m *MockDB) GetAuditLogs(ctx context.Context, eventId *uint, limit int) ([]*db.AuditLog, error) {
+	ret := _m.Called(ctx, eventId, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuditLogs")
+	}
+
+	var r0 []*db.AuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uint, int) ([]*db.AuditLog, error)); ok {
+		return rf(ctx, eventId, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uint, int) []*db.AuditLog); ok {
+		r0 = rf(ctx, eventId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uint, int) error); ok {
+		r1 = rf(ctx, eventId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogs'
+type MockDB_GetAuditLogs_Call struct {
+	*mock.Call
+}
+
+// GetAuditLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId *uint
+//   - limit int
+func (_e *MockDB_Expecter) GetAuditLogs(ctx interface{}, eventId interface{}, limit interface{}) *MockDB_GetAuditLogs_Call {
+	return &MockDB_GetAuditLogs_Call{Call: _e.mock.On("GetAuditLogs", ctx, eventId, limit)}
+}
+
+func (_c *MockDB_GetAuditLogs_Call) Run(run func(ctx context.Context, eventId *uint, limit int)) *MockDB_GetAuditLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*uint), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetAuditLogs_Call) Return(_a0 []*db.AuditLog, _a1 error) *MockDB_GetAuditLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetAuditLogs_Call) RunAndReturn(run func(context.Context, *uint, int) ([]*db.AuditLog, error)) *MockDB_GetAuditLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEvent provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) GetEvent(_a0 context.Context, _a1 uint64) (*db.Event, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvent")
+	}
+
+	var r0 *db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*db.Event, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *db.Event); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEvent'
+type MockDB_GetEvent_Call struct {
+	*mock.Call
+}
+
+// GetEvent is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uint64
+func (_e *MockDB_Expecter) GetEvent(_a0 interface{}, _a1 interface{}) *MockDB_GetEvent_Call {
+	return &MockDB_GetEvent_Call{Call: _e.mock.On("GetEvent", _a0, _a1)}
+}
+
+func (_c *MockDB_GetEvent_Call) Run(run func(_a0 context.Context, _a1 uint64)) *MockDB_GetEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetEvent_Call) Return(_a0 *db.Event, _a1 error) *MockDB_GetEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetEvent_Call) RunAndReturn(run func(context.Context, uint64) (*db.Event, error)) *MockDB_GetEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventBySlug provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) GetEventBySlug(_a0 context.Context, _a1 string) (*db.Event, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventBySlug")
+	}
+
+	var r0 *db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.Event, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.Event); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEventBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventBySlug'
+type MockDB_GetEventBySlug_Call struct {
+	*mock.Call
+}
+
+// GetEventBySlug is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockDB_Expecter) GetEventBySlug(_a0 interface{}, _a1 interface{}) *MockDB_GetEventBySlug_Call {
+	return &MockDB_GetEventBySlug_Call{Call: _e.mock.On("GetEventBySlug", _a0, _a1)}
+}
+
+func (_c *MockDB_GetEventBySlug_Call) Run(run func(_a0 context.Context, _a1 string)) *MockDB_GetEventBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -486,34 +2028,2375 @@ func (_c *MockDB_GetEventBySlug_Call) Return(_a0 *db.Event, _a1 error) *MockDB_G
 	return _c
 }
 
-func (_c *MockDB_GetEventBySlug_Call) RunAndReturn(run func(context.Context, string) (*db.Event, error)) *MockDB_GetEventBySlug_Call {
+func (_c *MockDB_GetEventBySlug_Call) RunAndReturn(run func(context.Context, string) (*db.Event, error)) *MockDB_GetEventBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventInvites provides a mock function with given fields: ctx, userId
+func (_m *MockDB) GetEventInvites(ctx context.Context, userId uint) ([]*db.EventMember, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventInvites")
+	}
+
+	var r0 []*db.EventMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*db.EventMember, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*db.EventMember); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.EventMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEventInvites_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventInvites'
+type MockDB_GetEventInvites_Call struct {
+	*mock.Call
+}
+
+// GetEventInvites is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+func (_e *MockDB_Expecter) GetEventInvites(ctx interface{}, userId interface{}) *MockDB_GetEventInvites_Call {
+	return &MockDB_GetEventInvites_Call{Call: _e.mock.On("GetEventInvites", ctx, userId)}
+}
+
+func (_c *MockDB_GetEventInvites_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_GetEventInvites_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetEventInvites_Call) Return(_a0 []*db.EventMember, _a1 error) *MockDB_GetEventInvites_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetEventInvites_Call) RunAndReturn(run func(context.Context, uint) ([]*db.EventMember, error)) *MockDB_GetEventInvites_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventMembers provides a mock function with given fields: ctx, eventId
+func (_m *MockDB) GetEventMembers(ctx context.Context, eventId uint) ([]*db.EventMember, error) {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventMembers")
+	}
+
+	var r0 []*db.EventMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*db.EventMember, error)); ok {
+		return rf(ctx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*db.EventMember); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.EventMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEventMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventMembers'
+type MockDB_GetEventMembers_Call struct {
+	*mock.Call
+}
+
+// GetEventMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+func (_e *MockDB_Expecter) GetEventMembers(ctx interface{}, eventId interface{}) *MockDB_GetEventMembers_Call {
+	return &MockDB_GetEventMembers_Call{Call: _e.mock.On("GetEventMembers", ctx, eventId)}
+}
+
+func (_c *MockDB_GetEventMembers_Call) Run(run func(ctx context.Context, eventId uint)) *MockDB_GetEventMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetEventMembers_Call) Return(_a0 []*db.EventMember, _a1 error) *MockDB_GetEventMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetEventMembers_Call) RunAndReturn(run func(context.Context, uint) ([]*db.EventMember, error)) *MockDB_GetEventMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventPasswordHash provides a mock function with given fields: ctx, eventId
+func (_m *MockDB) GetEventPasswordHash(ctx context.Context, eventId uint64) (string, error) {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventPasswordHash")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (string, error)); ok {
+		return rf(ctx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) string); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEventPasswordHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventPasswordHash'
+type MockDB_GetEventPasswordHash_Call struct {
+	*mock.Call
+}
+
+// GetEventPasswordHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint64
+func (_e *MockDB_Expecter) GetEventPasswordHash(ctx interface{}, eventId interface{}) *MockDB_GetEventPasswordHash_Call {
+	return &MockDB_GetEventPasswordHash_Call{Call: _e.mock.On("GetEventPasswordHash", ctx, eventId)}
+}
+
+func (_c *MockDB_GetEventPasswordHash_Call) Run(run func(ctx context.Context, eventId uint64)) *MockDB_GetEventPasswordHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetEventPasswordHash_Call) Return(_a0 string, _a1 error) *MockDB_GetEventPasswordHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetEventPasswordHash_Call) RunAndReturn(run func(context.Context, uint64) (string, error)) *MockDB_GetEventPasswordHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventRole provides a mock function with given fields: ctx, userId, eventId
+func (_m *MockDB) GetEventRole(ctx context.Context, userId uint, eventId uint) (string, error) {
+	ret := _m.Called(ctx, userId, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventRole")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (string, error)); ok {
+		return rf(ctx, userId, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) string); ok {
+		r0 = rf(ctx, userId, eventId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userId, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEventRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventRole'
+type MockDB_GetEventRole_Call struct {
+	*mock.Call
+}
+
+// GetEventRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - eventId uint
+func (_e *MockDB_Expecter) GetEventRole(ctx interface{}, userId interface{}, eventId interface{}) *MockDB_GetEventRole_Call {
+	return &MockDB_GetEventRole_Call{Call: _e.mock.On("GetEventRole", ctx, userId, eventId)}
+}
+
+func (_c *MockDB_GetEventRole_Call) Run(run func(ctx context.Context, userId uint, eventId uint)) *MockDB_GetEventRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetEventRole_Call) Return(_a0 string, _a1 error) *MockDB_GetEventRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetEventRole_Call) RunAndReturn(run func(context.Context, uint, uint) (string, error)) *MockDB_GetEventRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventStats provides a mock function with given fields: ctx, eventId, top
+func (_m *MockDB) GetEventStats(ctx context.Context, eventId uint, top int) (*db.EventStats, error) {
+	ret := _m.Called(ctx, eventId, top)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventStats")
+	}
+
+	var r0 *db.EventStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) (*db.EventStats, error)); ok {
+		return rf(ctx, eventId, top)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) *db.EventStats); ok {
+		r0 = rf(ctx, eventId, top)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.EventStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int) error); ok {
+		r1 = rf(ctx, eventId, top)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEventStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventStats'
+type MockDB_GetEventStats_Call struct {
+	*mock.Call
+}
+
+// GetEventStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - top int
+func (_e *MockDB_Expecter) GetEventStats(ctx interface{}, eventId interface{}, top interface{}) *MockDB_GetEventStats_Call {
+	return &MockDB_GetEventStats_Call{Call: _e.mock.On("GetEventStats", ctx, eventId, top)}
+}
+
+func (_c *MockDB_GetEventStats_Call) Run(run func(ctx context.Context, eventId uint, top int)) *MockDB_GetEventStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetEventStats_Call) Return(_a0 *db.EventStats, _a1 error) *MockDB_GetEventStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetEventStats_Call) RunAndReturn(run func(context.Context, uint, int) (*db.EventStats, error)) *MockDB_GetEventStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventTemplate provides a mock function with given fields: ctx, userId, templateId
+func (_m *MockDB) GetEventTemplate(ctx context.Context, userId uint, templateId uint) (*db.EventTemplate, error) {
+	ret := _m.Called(ctx, userId, templateId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventTemplate")
+	}
+
+	var r0 *db.EventTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*db.EventTemplate, error)); ok {
+		return rf(ctx, userId, templateId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *db.EventTemplate); ok {
+		r0 = rf(ctx, userId, templateId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.EventTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userId, templateId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEventTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventTemplate'
+type MockDB_GetEventTemplate_Call struct {
+	*mock.Call
+}
+
+// GetEventTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - templateId uint
+func (_e *MockDB_Expecter) GetEventTemplate(ctx interface{}, userId interface{}, templateId interface{}) *MockDB_GetEventTemplate_Call {
+	return &MockDB_GetEventTemplate_Call{Call: _e.mock.On("GetEventTemplate", ctx, userId, templateId)}
+}
+
+func (_c *MockDB_GetEventTemplate_Call) Run(run func(ctx context.Context, userId uint, templateId uint)) *MockDB_GetEventTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetEventTemplate_Call) Return(_a0 *db.EventTemplate, _a1 error) *MockDB_GetEventTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetEventTemplate_Call) RunAndReturn(run func(context.Context, uint, uint) (*db.EventTemplate, error)) *MockDB_GetEventTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventTemplates provides a mock function with given fields: ctx, userId
+func (_m *MockDB) GetEventTemplates(ctx context.Context, userId uint) ([]*db.EventTemplate, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventTemplates")
+	}
+
+	var r0 []*db.EventTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*db.EventTemplate, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*db.EventTemplate); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.EventTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEventTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventTemplates'
+type MockDB_GetEventTemplates_Call struct {
+	*mock.Call
+}
+
+// GetEventTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+func (_e *MockDB_Expecter) GetEventTemplates(ctx interface{}, userId interface{}) *MockDB_GetEventTemplates_Call {
+	return &MockDB_GetEventTemplates_Call{Call: _e.mock.On("GetEventTemplates", ctx, userId)}
+}
+
+func (_c *MockDB_GetEventTemplates_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_GetEventTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetEventTemplates_Call) Return(_a0 []*db.EventTemplate, _a1 error) *MockDB_GetEventTemplates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetEventTemplates_Call) RunAndReturn(run func(context.Context, uint) ([]*db.EventTemplate, error)) *MockDB_GetEventTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventUsages provides a mock function with given fields: _a0
+func (_m *MockDB) GetEventUsages(_a0 context.Context) ([]*db.EventUsage, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventUsages")
+	}
+
+	var r0 []*db.EventUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*db.EventUsage, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*db.EventUsage); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.EventUsage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEventUsages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventUsages'
+type MockDB_GetEventUsages_Call struct {
+	*mock.Call
+}
+
+// GetEventUsages is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockDB_Expecter) GetEventUsages(_a0 interface{}) *MockDB_GetEventUsages_Call {
+	return &MockDB_GetEventUsages_Call{Call: _e.mock.On("GetEventUsages", _a0)}
+}
+
+func (_c *MockDB_GetEventUsages_Call) Run(run func(_a0 context.Context)) *MockDB_GetEventUsages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetEventUsages_Call) Return(_a0 []*db.EventUsage, _a1 error) *MockDB_GetEventUsages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetEventUsages_Call) RunAndReturn(run func(context.Context) ([]*db.EventUsage, error)) *MockDB_GetEventUsages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventWebhooks provides a mock function with given fields: ctx, userId, eventId
+func (_m *MockDB) GetEventWebhooks(ctx context.Context, userId uint, eventId uint) ([]*db.Webhook, error) {
+	ret := _m.Called(ctx, userId, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventWebhooks")
+	}
+
+	var r0 []*db.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) ([]*db.Webhook, error)); ok {
+		return rf(ctx, userId, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []*db.Webhook); ok {
+		r0 = rf(ctx, userId, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userId, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEventWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventWebhooks'
+type MockDB_GetEventWebhooks_Call struct {
+	*mock.Call
+}
+
+// GetEventWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - eventId uint
+func (_e *MockDB_Expecter) GetEventWebhooks(ctx interface{}, userId interface{}, eventId interface{}) *MockDB_GetEventWebhooks_Call {
+	return &MockDB_GetEventWebhooks_Call{Call: _e.mock.On("GetEventWebhooks", ctx, userId, eventId)}
+}
+
+func (_c *MockDB_GetEventWebhooks_Call) Run(run func(ctx context.Context, userId uint, eventId uint)) *MockDB_GetEventWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetEventWebhooks_Call) Return(_a0 []*db.Webhook, _a1 error) *MockDB_GetEventWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetEventWebhooks_Call) RunAndReturn(run func(context.Context, uint, uint) ([]*db.Webhook, error)) *MockDB_GetEventWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEvents provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) GetEvents(_a0 context.Context, _a1 uint) ([]*db.Event, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvents")
+	}
+
+	var r0 []*db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*db.Event, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*db.Event); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEvents'
+type MockDB_GetEvents_Call struct {
+	*mock.Call
+}
+
+// GetEvents is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uint
+func (_e *MockDB_Expecter) GetEvents(_a0 interface{}, _a1 interface{}) *MockDB_GetEvents_Call {
+	return &MockDB_GetEvents_Call{Call: _e.mock.On("GetEvents", _a0, _a1)}
+}
+
+func (_c *MockDB_GetEvents_Call) Run(run func(_a0 context.Context, _a1 uint)) *MockDB_GetEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetEvents_Call) Return(_a0 []*db.Event, _a1 error) *MockDB_GetEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetEvents_Call) RunAndReturn(run func(context.Context, uint) ([]*db.Event, error)) *MockDB_GetEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFileInfo provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) GetFileInfo(_a0 context.Context, _a1 string) (*db.FileInfo, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileInfo")
+	}
+
+	var r0 *db.FileInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.FileInfo, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.FileInfo); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.FileInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetFileInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileInfo'
+type MockDB_GetFileInfo_Call struct {
+	*mock.Call
+}
+
+// GetFileInfo is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockDB_Expecter) GetFileInfo(_a0 interface{}, _a1 interface{}) *MockDB_GetFileInfo_Call {
+	return &MockDB_GetFileInfo_Call{Call: _e.mock.On("GetFileInfo", _a0, _a1)}
+}
+
+func (_c *MockDB_GetFileInfo_Call) Run(run func(_a0 context.Context, _a1 string)) *MockDB_GetFileInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetFileInfo_Call) Return(_a0 *db.FileInfo, _a1 error) *MockDB_GetFileInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetFileInfo_Call) RunAndReturn(run func(context.Context, string) (*db.FileInfo, error)) *MockDB_GetFileInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFileInfos provides a mock function with given fields: ctx, eventId
+func (_m *MockDB) GetFileInfos(ctx context.Context, eventId uint) ([]*db.FileInfo, error) {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileInfos")
+	}
+
+	var r0 []*db.FileInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*db.FileInfo, error)); ok {
+		return rf(ctx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*db.FileInfo); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.FileInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetFileInfos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileInfos'
+type MockDB_GetFileInfos_Call struct {
+	*mock.Call
+}
+
+// GetFileInfos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+func (_e *MockDB_Expecter) GetFileInfos(ctx interface{}, eventId interface{}) *MockDB_GetFileInfos_Call {
+	return &MockDB_GetFileInfos_Call{Call: _e.mock.On("GetFileInfos", ctx, eventId)}
+}
+
+func (_c *MockDB_GetFileInfos_Call) Run(run func(ctx context.Context, eventId uint)) *MockDB_GetFileInfos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetFileInfos_Call) Return(_a0 []*db.FileInfo, _a1 error) *MockDB_GetFileInfos_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetFileInfos_Call) RunAndReturn(run func(context.Context, uint) ([]*db.FileInfo, error)) *MockDB_GetFileInfos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGoogleToken provides a mock function with given fields: ctx, uideId
+func (_m *MockDB) GetGoogleToken(ctx context.Context, uideId uint) ([]byte, error) {
+	ret := _m.Called(ctx, uideId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGoogleToken")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]byte, error)); ok {
+		return rf(ctx, uideId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []byte); ok {
+		r0 = rf(ctx, uideId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, uideId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetGoogleToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGoogleToken'
+type MockDB_GetGoogleToken_Call struct {
+	*mock.Call
+}
+
+// GetGoogleToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uideId uint
+func (_e *MockDB_Expecter) GetGoogleToken(ctx interface{}, uideId interface{}) *MockDB_GetGoogleToken_Call {
+	return &MockDB_GetGoogleToken_Call{Call: _e.mock.On("GetGoogleToken", ctx, uideId)}
+}
+
+func (_c *MockDB_GetGoogleToken_Call) Run(run func(ctx context.Context, uideId uint)) *MockDB_GetGoogleToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetGoogleToken_Call) Return(_a0 []byte, _a1 error) *MockDB_GetGoogleToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetGoogleToken_Call) RunAndReturn(run func(context.Context, uint) ([]byte, error)) *MockDB_GetGoogleToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGuestToken provides a mock function with given fields: ctx, eventId, tokenId
+func (_m *MockDB) GetGuestToken(ctx context.Context, eventId uint, tokenId uint) (*db.GuestToken, error) {
+	ret := _m.Called(ctx, eventId, tokenId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuestToken")
+	}
+
+	var r0 *db.GuestToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*db.GuestToken, error)); ok {
+		return rf(ctx, eventId, tokenId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *db.GuestToken); ok {
+		r0 = rf(ctx, eventId, tokenId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.GuestToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, eventId, tokenId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetGuestToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGuestToken'
+type MockDB_GetGuestToken_Call struct {
+	*mock.Call
+}
+
+// GetGuestToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - tokenId uint
+func (_e *MockDB_Expecter) GetGuestToken(ctx interface{}, eventId interface{}, tokenId interface{}) *MockDB_GetGuestToken_Call {
+	return &MockDB_GetGuestToken_Call{Call: _e.mock.On("GetGuestToken", ctx, eventId, tokenId)}
+}
+
+func (_c *MockDB_GetGuestToken_Call) Run(run func(ctx context.Context, eventId uint, tokenId uint)) *MockDB_GetGuestToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetGuestToken_Call) Return(_a0 *db.GuestToken, _a1 error) *MockDB_GetGuestToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetGuestToken_Call) RunAndReturn(run func(context.Context, uint, uint) (*db.GuestToken, error)) *MockDB_GetGuestToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGuestTokens provides a mock function with given fields: ctx, eventId
+func (_m *MockDB) GetGuestTokens(ctx context.Context, eventId uint) ([]*db.GuestToken, error) {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuestTokens")
+	}
+
+	var r0 []*db.GuestToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*db.GuestToken, error)); ok {
+		return rf(ctx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*db.GuestToken); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.GuestToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetGuestTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGuestTokens'
+type MockDB_GetGuestTokens_Call struct {
+	*mock.Call
+}
+
+// GetGuestTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+func (_e *MockDB_Expecter) GetGuestTokens(ctx interface{}, eventId interface{}) *MockDB_GetGuestTokens_Call {
+	return &MockDB_GetGuestTokens_Call{Call: _e.mock.On("GetGuestTokens", ctx, eventId)}
+}
+
+func (_c *MockDB_GetGuestTokens_Call) Run(run func(ctx context.Context, eventId uint)) *MockDB_GetGuestTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetGuestTokens_Call) Return(_a0 []*db.GuestToken, _a1 error) *MockDB_GetGuestTokens_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetGuestTokens_Call) RunAndReturn(run func(context.Context, uint) ([]*db.GuestToken, error)) *MockDB_GetGuestTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOidcIdentity provides a mock function with given fields: ctx, issuer, subject
+func (_m *MockDB) GetOidcIdentity(ctx context.Context, issuer string, subject string) (*db.OidcIdentity, error) {
+	ret := _m.Called(ctx, issuer, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOidcIdentity")
+	}
+
+	var r0 *db.OidcIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*db.OidcIdentity, error)); ok {
+		return rf(ctx, issuer, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *db.OidcIdentity); ok {
+		r0 = rf(ctx, issuer, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.OidcIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, issuer, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetOidcIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOidcIdentity'
+type MockDB_GetOidcIdentity_Call struct {
+	*mock.Call
+}
+
+// GetOidcIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - issuer string
+//   - subject string
+func (_e *MockDB_Expecter) GetOidcIdentity(ctx interface{}, issuer interface{}, subject interface{}) *MockDB_GetOidcIdentity_Call {
+	return &MockDB_GetOidcIdentity_Call{Call: _e.mock.On("GetOidcIdentity", ctx, issuer, subject)}
+}
+
+func (_c *MockDB_GetOidcIdentity_Call) Run(run func(ctx context.Context, issuer string, subject string)) *MockDB_GetOidcIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetOidcIdentity_Call) Return(_a0 *db.OidcIdentity, _a1 error) *MockDB_GetOidcIdentity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetOidcIdentity_Call) RunAndReturn(run func(context.Context, string, string) (*db.OidcIdentity, error)) *MockDB_GetOidcIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSetting provides a mock function with given fields: ctx, name
+func (_m *MockDB) GetSetting(ctx context.Context, name string) (string, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSetting")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSetting'
+type MockDB_GetSetting_Call struct {
+	*mock.Call
+}
+
+// GetSetting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockDB_Expecter) GetSetting(ctx interface{}, name interface{}) *MockDB_GetSetting_Call {
+	return &MockDB_GetSetting_Call{Call: _e.mock.On("GetSetting", ctx, name)}
+}
+
+func (_c *MockDB_GetSetting_Call) Run(run func(ctx context.Context, name string)) *MockDB_GetSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetSetting_Call) Return(_a0 string, _a1 error) *MockDB_GetSetting_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetSetting_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockDB_GetSetting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShortLink provides a mock function with given fields: ctx, code
+func (_m *MockDB) GetShortLink(ctx context.Context, code string) (*db.ShortLink, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShortLink")
+	}
+
+	var r0 *db.ShortLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.ShortLink, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.ShortLink); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.ShortLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetShortLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShortLink'
+type MockDB_GetShortLink_Call struct {
+	*mock.Call
+}
+
+// GetShortLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+func (_e *MockDB_Expecter) GetShortLink(ctx interface{}, code interface{}) *MockDB_GetShortLink_Call {
+	return &MockDB_GetShortLink_Call{Call: _e.mock.On("GetShortLink", ctx, code)}
+}
+
+func (_c *MockDB_GetShortLink_Call) Run(run func(ctx context.Context, code string)) *MockDB_GetShortLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetShortLink_Call) Return(_a0 *db.ShortLink, _a1 error) *MockDB_GetShortLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetShortLink_Call) RunAndReturn(run func(context.Context, string) (*db.ShortLink, error)) *MockDB_GetShortLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShortLinks provides a mock function with given fields: ctx, eventId
+func (_m *MockDB) GetShortLinks(ctx context.Context, eventId uint) ([]*db.ShortLink, error) {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShortLinks")
+	}
+
+	var r0 []*db.ShortLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*db.ShortLink, error)); ok {
+		return rf(ctx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*db.ShortLink); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.ShortLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetShortLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShortLinks'
+type MockDB_GetShortLinks_Call struct {
+	*mock.Call
+}
+
+// GetShortLinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+func (_e *MockDB_Expecter) GetShortLinks(ctx interface{}, eventId interface{}) *MockDB_GetShortLinks_Call {
+	return &MockDB_GetShortLinks_Call{Call: _e.mock.On("GetShortLinks", ctx, eventId)}
+}
+
+func (_c *MockDB_GetShortLinks_Call) Run(run func(ctx context.Context, eventId uint)) *MockDB_GetShortLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetShortLinks_Call) Return(_a0 []*db.ShortLink, _a1 error) *MockDB_GetShortLinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetShortLinks_Call) RunAndReturn(run func(context.Context, uint) ([]*db.ShortLink, error)) *MockDB_GetShortLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStorageMigration provides a mock function with given fields: ctx, eventId
+func (_m *MockDB) GetStorageMigration(ctx context.Context, eventId uint) (*db.StorageMigration, error) {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStorageMigration")
+	}
+
+	var r0 *db.StorageMigration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*db.StorageMigration, error)); ok {
+		return rf(ctx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *db.StorageMigration); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.StorageMigration)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetStorageMigration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStorageMigration'
+type MockDB_GetStorageMigration_Call struct {
+	*mock.Call
+}
+
+// GetStorageMigration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+func (_e *MockDB_Expecter) GetStorageMigration(ctx interface{}, eventId interface{}) *MockDB_GetStorageMigration_Call {
+	return &MockDB_GetStorageMigration_Call{Call: _e.mock.On("GetStorageMigration", ctx, eventId)}
+}
+
+func (_c *MockDB_GetStorageMigration_Call) Run(run func(ctx context.Context, eventId uint)) *MockDB_GetStorageMigration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetStorageMigration_Call) Return(_a0 *db.StorageMigration, _a1 error) *MockDB_GetStorageMigration_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetStorageMigration_Call) RunAndReturn(run func(context.Context, uint) (*db.StorageMigration, error)) *MockDB_GetStorageMigration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetThumbnailInfo provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) GetThumbnailInfo(_a0 context.Context, _a1 string) (*db.ThumbnailInfo, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetThumbnailInfo")
+	}
+
+	var r0 *db.ThumbnailInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.ThumbnailInfo, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.ThumbnailInfo); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.ThumbnailInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetThumbnailInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThumbnailInfo'
+type MockDB_GetThumbnailInfo_Call struct {
+	*mock.Call
+}
+
+// GetThumbnailInfo is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockDB_Expecter) GetThumbnailInfo(_a0 interface{}, _a1 interface{}) *MockDB_GetThumbnailInfo_Call {
+	return &MockDB_GetThumbnailInfo_Call{Call: _e.mock.On("GetThumbnailInfo", _a0, _a1)}
+}
+
+func (_c *MockDB_GetThumbnailInfo_Call) Run(run func(_a0 context.Context, _a1 string)) *MockDB_GetThumbnailInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetThumbnailInfo_Call) Return(_a0 *db.ThumbnailInfo, _a1 error) *MockDB_GetThumbnailInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetThumbnailInfo_Call) RunAndReturn(run func(context.Context, string) (*db.ThumbnailInfo, error)) *MockDB_GetThumbnailInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetThumbnails provides a mock function with given fields: ctx, eventId, limit, offset, includeHidden
+func (_m *MockDB) GetThumbnails(ctx context.Context, eventId uint, limit int, offset int, includeHidden bool) ([]*db.ThumbnailInfo, error) {
+	ret := _m.Called(ctx, eventId, limit, offset, includeHidden)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetThumbnails")
+	}
+
+	var r0 []*db.ThumbnailInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int, int, bool) ([]*db.ThumbnailInfo, error)); ok {
+		return rf(ctx, eventId, limit, offset, includeHidden)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int, int, bool) []*db.ThumbnailInfo); ok {
+		r0 = rf(ctx, eventId, limit, offset, includeHidden)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.ThumbnailInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int, int, bool) error); ok {
+		r1 = rf(ctx, eventId, limit, offset, includeHidden)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetThumbnails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThumbnails'
+type MockDB_GetThumbnails_Call struct {
+	*mock.Call
+}
+
+// GetThumbnails is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - limit int
+//   - offset int
+//   - includeHidden bool
+func (_e *MockDB_Expecter) GetThumbnails(ctx interface{}, eventId interface{}, limit interface{}, offset interface{}, includeHidden interface{}) *MockDB_GetThumbnails_Call {
+	return &MockDB_GetThumbnails_Call{Call: _e.mock.On("GetThumbnails", ctx, eventId, limit, offset, includeHidden)}
+}
+
+func (_c *MockDB_GetThumbnails_Call) Run(run func(ctx context.Context, eventId uint, limit int, offset int, includeHidden bool)) *MockDB_GetThumbnails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(int), args[3].(int), args[4].(bool))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetThumbnails_Call) Return(_a0 []*db.ThumbnailInfo, _a1 error) *MockDB_GetThumbnails_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetThumbnails_Call) RunAndReturn(run func(context.Context, uint, int, int, bool) ([]*db.ThumbnailInfo, error)) *MockDB_GetThumbnails_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrashedEvent provides a mock function with given fields: ctx, id
+func (_m *MockDB) GetTrashedEvent(ctx context.Context, id uint64) (*db.Event, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrashedEvent")
+	}
+
+	var r0 *db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*db.Event, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *db.Event); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetTrashedEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrashedEvent'
+type MockDB_GetTrashedEvent_Call struct {
+	*mock.Call
+}
+
+// GetTrashedEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+func (_e *MockDB_Expecter) GetTrashedEvent(ctx interface{}, id interface{}) *MockDB_GetTrashedEvent_Call {
+	return &MockDB_GetTrashedEvent_Call{Call: _e.mock.On("GetTrashedEvent", ctx, id)}
+}
+
+func (_c *MockDB_GetTrashedEvent_Call) Run(run func(ctx context.Context, id uint64)) *MockDB_GetTrashedEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetTrashedEvent_Call) Return(_a0 *db.Event, _a1 error) *MockDB_GetTrashedEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetTrashedEvent_Call) RunAndReturn(run func(context.Context, uint64) (*db.Event, error)) *MockDB_GetTrashedEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrashedEvents provides a mock function with given fields: ctx, userId
+func (_m *MockDB) GetTrashedEvents(ctx context.Context, userId uint) ([]*db.Event, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrashedEvents")
+	}
+
+	var r0 []*db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*db.Event, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*db.Event); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetTrashedEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrashedEvents'
+type MockDB_GetTrashedEvents_Call struct {
+	*mock.Call
+}
+
+// GetTrashedEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+func (_e *MockDB_Expecter) GetTrashedEvents(ctx interface{}, userId interface{}) *MockDB_GetTrashedEvents_Call {
+	return &MockDB_GetTrashedEvents_Call{Call: _e.mock.On("GetTrashedEvents", ctx, userId)}
+}
+
+func (_c *MockDB_GetTrashedEvents_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_GetTrashedEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetTrashedEvents_Call) Return(_a0 []*db.Event, _a1 error) *MockDB_GetTrashedEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetTrashedEvents_Call) RunAndReturn(run func(context.Context, uint) ([]*db.Event, error)) *MockDB_GetTrashedEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrashedEventsBefore provides a mock function with given fields: ctx, before
+func (_m *MockDB) GetTrashedEventsBefore(ctx context.Context, before time.Time) ([]*db.Event, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrashedEventsBefore")
+	}
+
+	var r0 []*db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*db.Event, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*db.Event); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetTrashedEventsBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrashedEventsBefore'
+type MockDB_GetTrashedEventsBefore_Call struct {
+	*mock.Call
+}
+
+// GetTrashedEventsBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockDB_Expecter) GetTrashedEventsBefore(ctx interface{}, before interface{}) *MockDB_GetTrashedEventsBefore_Call {
+	return &MockDB_GetTrashedEventsBefore_Call{Call: _e.mock.On("GetTrashedEventsBefore", ctx, before)}
+}
+
+func (_c *MockDB_GetTrashedEventsBefore_Call) Run(run func(ctx context.Context, before time.Time)) *MockDB_GetTrashedEventsBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetTrashedEventsBefore_Call) Return(_a0 []*db.Event, _a1 error) *MockDB_GetTrashedEventsBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetTrashedEventsBefore_Call) RunAndReturn(run func(context.Context, time.Time) ([]*db.Event, error)) *MockDB_GetTrashedEventsBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) GetUser(_a0 context.Context, _a1 string) (*db.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.User, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockDB_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockDB_Expecter) GetUser(_a0 interface{}, _a1 interface{}) *MockDB_GetUser_Call {
+	return &MockDB_GetUser_Call{Call: _e.mock.On("GetUser", _a0, _a1)}
+}
+
+func (_c *MockDB_GetUser_Call) Run(run func(_a0 context.Context, _a1 string)) *MockDB_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetUser_Call) Return(_a0 *db.User, _a1 error) *MockDB_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetUser_Call) RunAndReturn(run func(context.Context, string) (*db.User, error)) *MockDB_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByID provides a mock function with given fields: ctx, userId
+func (_m *MockDB) GetUserByID(ctx context.Context, userId uint) (*db.User, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*db.User, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *db.User); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type MockDB_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+func (_e *MockDB_Expecter) GetUserByID(ctx interface{}, userId interface{}) *MockDB_GetUserByID_Call {
+	return &MockDB_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, userId)}
+}
+
+func (_c *MockDB_GetUserByID_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetUserByID_Call) Return(_a0 *db.User, _a1 error) *MockDB_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetUserByID_Call) RunAndReturn(run func(context.Context, uint) (*db.User, error)) *MockDB_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsers provides a mock function with given fields: _a0
+func (_m *MockDB) GetUsers(_a0 context.Context) ([]*db.User, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 []*db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*db.User, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*db.User); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
+type MockDB_GetUsers_Call struct {
+	*mock.Call
+}
+
+// GetUsers is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockDB_Expecter) GetUsers(_a0 interface{}) *MockDB_GetUsers_Call {
+	return &MockDB_GetUsers_Call{Call: _e.mock.On("GetUsers", _a0)}
+}
+
+func (_c *MockDB_GetUsers_Call) Run(run func(_a0 context.Context)) *MockDB_GetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetUsers_Call) Return(_a0 []*db.User, _a1 error) *MockDB_GetUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetUsers_Call) RunAndReturn(run func(context.Context) ([]*db.User, error)) *MockDB_GetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookDeliveries provides a mock function with given fields: ctx, userId, webhookId, limit
+func (_m *MockDB) GetWebhookDeliveries(ctx context.Context, userId uint, webhookId uint, limit int) ([]*db.WebhookDelivery, error) {
+	ret := _m.Called(ctx, userId, webhookId, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDeliveries")
+	}
+
+	var r0 []*db.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, int) ([]*db.WebhookDelivery, error)); ok {
+		return rf(ctx, userId, webhookId, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, int) []*db.WebhookDelivery); ok {
+		r0 = rf(ctx, userId, webhookId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, int) error); ok {
+		r1 = rf(ctx, userId, webhookId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDeliveries'
+type MockDB_GetWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - webhookId uint
+//   - limit int
+func (_e *MockDB_Expecter) GetWebhookDeliveries(ctx interface{}, userId interface{}, webhookId interface{}, limit interface{}) *MockDB_GetWebhookDeliveries_Call {
+	return &MockDB_GetWebhookDeliveries_Call{Call: _e.mock.On("GetWebhookDeliveries", ctx, userId, webhookId, limit)}
+}
+
+func (_c *MockDB_GetWebhookDeliveries_Call) Run(run func(ctx context.Context, userId uint, webhookId uint, limit int)) *MockDB_GetWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetWebhookDeliveries_Call) Return(_a0 []*db.WebhookDelivery, _a1 error) *MockDB_GetWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetWebhookDeliveries_Call) RunAndReturn(run func(context.Context, uint, uint, int) ([]*db.WebhookDelivery, error)) *MockDB_GetWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhooks provides a mock function with given fields: ctx, userId
+func (_m *MockDB) GetWebhooks(ctx context.Context, userId uint) ([]*db.Webhook, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhooks")
+	}
+
+	var r0 []*db.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*db.Webhook, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*db.Webhook); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_GetWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhooks'
+type MockDB_GetWebhooks_Call struct {
+	*mock.Call
+}
+
+// GetWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+func (_e *MockDB_Expecter) GetWebhooks(ctx interface{}, userId interface{}) *MockDB_GetWebhooks_Call {
+	return &MockDB_GetWebhooks_Call{Call: _e.mock.On("GetWebhooks", ctx, userId)}
+}
+
+func (_c *MockDB_GetWebhooks_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_GetWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_GetWebhooks_Call) Return(_a0 []*db.Webhook, _a1 error) *MockDB_GetWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_GetWebhooks_Call) RunAndReturn(run func(context.Context, uint) ([]*db.Webhook, error)) *MockDB_GetWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LeaveEvent provides a mock function with given fields: ctx, userId, eventId
+func (_m *MockDB) LeaveEvent(ctx context.Context, userId uint, eventId uint) error {
+	ret := _m.Called(ctx, userId, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LeaveEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userId, eventId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_LeaveEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeaveEvent'
+type MockDB_LeaveEvent_Call struct {
+	*mock.Call
+}
+
+// LeaveEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - eventId uint
+func (_e *MockDB_Expecter) LeaveEvent(ctx interface{}, userId interface{}, eventId interface{}) *MockDB_LeaveEvent_Call {
+	return &MockDB_LeaveEvent_Call{Call: _e.mock.On("LeaveEvent", ctx, userId, eventId)}
+}
+
+func (_c *MockDB_LeaveEvent_Call) Run(run func(ctx context.Context, userId uint, eventId uint)) *MockDB_LeaveEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_LeaveEvent_Call) Return(_a0 error) *MockDB_LeaveEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_LeaveEvent_Call) RunAndReturn(run func(context.Context, uint, uint) error) *MockDB_LeaveEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeEvent provides a mock function with given fields: ctx, id
+func (_m *MockDB) PurgeEvent(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_PurgeEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeEvent'
+type MockDB_PurgeEvent_Call struct {
+	*mock.Call
+}
+
+// PurgeEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+func (_e *MockDB_Expecter) PurgeEvent(ctx interface{}, id interface{}) *MockDB_PurgeEvent_Call {
+	return &MockDB_PurgeEvent_Call{Call: _e.mock.On("PurgeEvent", ctx, id)}
+}
+
+func (_c *MockDB_PurgeEvent_Call) Run(run func(ctx context.Context, id uint64)) *MockDB_PurgeEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockDB_PurgeEvent_Call) Return(_a0 error) *MockDB_PurgeEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_PurgeEvent_Call) RunAndReturn(run func(context.Context, uint64) error) *MockDB_PurgeEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordLoginFailure provides a mock function with given fields: ctx, userId
+func (_m *MockDB) RecordLoginFailure(ctx context.Context, userId uint) (*time.Time, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordLoginFailure")
+	}
+
+	var r0 *time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*time.Time, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *time.Time); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_RecordLoginFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordLoginFailure'
+type MockDB_RecordLoginFailure_Call struct {
+	*mock.Call
+}
+
+// RecordLoginFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+func (_e *MockDB_Expecter) RecordLoginFailure(ctx interface{}, userId interface{}) *MockDB_RecordLoginFailure_Call {
+	return &MockDB_RecordLoginFailure_Call{Call: _e.mock.On("RecordLoginFailure", ctx, userId)}
+}
+
+func (_c *MockDB_RecordLoginFailure_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_RecordLoginFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_RecordLoginFailure_Call) Return(_a0 *time.Time, _a1 error) *MockDB_RecordLoginFailure_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_RecordLoginFailure_Call) RunAndReturn(run func(context.Context, uint) (*time.Time, error)) *MockDB_RecordLoginFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetLoginFailures provides a mock function with given fields: ctx, userId
+func (_m *MockDB) ResetLoginFailures(ctx context.Context, userId uint) error {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetLoginFailures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_ResetLoginFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetLoginFailures'
+type MockDB_ResetLoginFailures_Call struct {
+	*mock.Call
+}
+
+// ResetLoginFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+func (_e *MockDB_Expecter) ResetLoginFailures(ctx interface{}, userId interface{}) *MockDB_ResetLoginFailures_Call {
+	return &MockDB_ResetLoginFailures_Call{Call: _e.mock.On("ResetLoginFailures", ctx, userId)}
+}
+
+func (_c *MockDB_ResetLoginFailures_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_ResetLoginFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_ResetLoginFailures_Call) Return(_a0 error) *MockDB_ResetLoginFailures_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_ResetLoginFailures_Call) RunAndReturn(run func(context.Context, uint) error) *MockDB_ResetLoginFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetUserPassword provides a mock function with given fields: ctx, userId, password
+func (_m *MockDB) ResetUserPassword(ctx context.Context, userId uint, password string) error {
+	ret := _m.Called(ctx, userId, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetUserPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, userId, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_ResetUserPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetUserPassword'
+type MockDB_ResetUserPassword_Call struct {
+	*mock.Call
+}
+
+// ResetUserPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - password string
+func (_e *MockDB_Expecter) ResetUserPassword(ctx interface{}, userId interface{}, password interface{}) *MockDB_ResetUserPassword_Call {
+	return &MockDB_ResetUserPassword_Call{Call: _e.mock.On("ResetUserPassword", ctx, userId, password)}
+}
+
+func (_c *MockDB_ResetUserPassword_Call) Run(run func(ctx context.Context, userId uint, password string)) *MockDB_ResetUserPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_ResetUserPassword_Call) Return(_a0 error) *MockDB_ResetUserPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_ResetUserPassword_Call) RunAndReturn(run func(context.Context, uint, string) error) *MockDB_ResetUserPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreEvent provides a mock function with given fields: ctx, id
+func (_m *MockDB) RestoreEvent(ctx context.Context, id uint64) (*db.Event, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreEvent")
+	}
+
+	var r0 *db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*db.Event, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *db.Event); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_RestoreEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreEvent'
+type MockDB_RestoreEvent_Call struct {
+	*mock.Call
+}
+
+// RestoreEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+func (_e *MockDB_Expecter) RestoreEvent(ctx interface{}, id interface{}) *MockDB_RestoreEvent_Call {
+	return &MockDB_RestoreEvent_Call{Call: _e.mock.On("RestoreEvent", ctx, id)}
+}
+
+func (_c *MockDB_RestoreEvent_Call) Run(run func(ctx context.Context, id uint64)) *MockDB_RestoreEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockDB_RestoreEvent_Call) Return(_a0 *db.Event, _a1 error) *MockDB_RestoreEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_RestoreEvent_Call) RunAndReturn(run func(context.Context, uint64) (*db.Event, error)) *MockDB_RestoreEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUserSessions provides a mock function with given fields: ctx, userId
+func (_m *MockDB) RevokeUserSessions(ctx context.Context, userId uint) error {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_RevokeUserSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserSessions'
+type MockDB_RevokeUserSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeUserSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+func (_e *MockDB_Expecter) RevokeUserSessions(ctx interface{}, userId interface{}) *MockDB_RevokeUserSessions_Call {
+	return &MockDB_RevokeUserSessions_Call{Call: _e.mock.On("RevokeUserSessions", ctx, userId)}
+}
+
+func (_c *MockDB_RevokeUserSessions_Call) Run(run func(ctx context.Context, userId uint)) *MockDB_RevokeUserSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_RevokeUserSessions_Call) Return(_a0 error) *MockDB_RevokeUserSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_RevokeUserSessions_Call) RunAndReturn(run func(context.Context, uint) error) *MockDB_RevokeUserSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateGuestToken provides a mock function with given fields: ctx, eventId, tokenId
+func (_m *MockDB) RotateGuestToken(ctx context.Context, eventId uint, tokenId uint) (*db.GuestToken, error) {
+	ret := _m.Called(ctx, eventId, tokenId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateGuestToken")
+	}
+
+	var r0 *db.GuestToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*db.GuestToken, error)); ok {
+		return rf(ctx, eventId, tokenId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *db.GuestToken); ok {
+		r0 = rf(ctx, eventId, tokenId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.GuestToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, eventId, tokenId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_RotateGuestToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateGuestToken'
+type MockDB_RotateGuestToken_Call struct {
+	*mock.Call
+}
+
+// RotateGuestToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - tokenId uint
+func (_e *MockDB_Expecter) RotateGuestToken(ctx interface{}, eventId interface{}, tokenId interface{}) *MockDB_RotateGuestToken_Call {
+	return &MockDB_RotateGuestToken_Call{Call: _e.mock.On("RotateGuestToken", ctx, eventId, tokenId)}
+}
+
+func (_c *MockDB_RotateGuestToken_Call) Run(run func(ctx context.Context, eventId uint, tokenId uint)) *MockDB_RotateGuestToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockDB_RotateGuestToken_Call) Return(_a0 *db.GuestToken, _a1 error) *MockDB_RotateGuestToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_RotateGuestToken_Call) RunAndReturn(run func(context.Context, uint, uint) (*db.GuestToken, error)) *MockDB_RotateGuestToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunEventSchedules provides a mock function with given fields: ctx, now
+func (_m *MockDB) RunEventSchedules(ctx context.Context, now time.Time) ([]*db.Event, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunEventSchedules")
+	}
+
+	var r0 []*db.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*db.Event, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*db.Event); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_RunEventSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunEventSchedules'
+type MockDB_RunEventSchedules_Call struct {
+	*mock.Call
+}
+
+// RunEventSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockDB_Expecter) RunEventSchedules(ctx interface{}, now interface{}) *MockDB_RunEventSchedules_Call {
+	return &MockDB_RunEventSchedules_Call{Call: _e.mock.On("RunEventSchedules", ctx, now)}
+}
+
+func (_c *MockDB_RunEventSchedules_Call) Run(run func(ctx context.Context, now time.Time)) *MockDB_RunEventSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDB_RunEventSchedules_Call) Return(_a0 []*db.Event, _a1 error) *MockDB_RunEventSchedules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_RunEventSchedules_Call) RunAndReturn(run func(context.Context, time.Time) ([]*db.Event, error)) *MockDB_RunEventSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveEventBranding provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) SaveEventBranding(_a0 context.Context, _a1 *db.EventBranding) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveEventBranding")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.EventBranding) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_SaveEventBranding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveEventBranding'
+type MockDB_SaveEventBranding_Call struct {
+	*mock.Call
+}
+
+// SaveEventBranding is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *db.EventBranding
+func (_e *MockDB_Expecter) SaveEventBranding(_a0 interface{}, _a1 interface{}) *MockDB_SaveEventBranding_Call {
+	return &MockDB_SaveEventBranding_Call{Call: _e.mock.On("SaveEventBranding", _a0, _a1)}
+}
+
+func (_c *MockDB_SaveEventBranding_Call) Run(run func(_a0 context.Context, _a1 *db.EventBranding)) *MockDB_SaveEventBranding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.EventBranding))
+	})
+	return _c
+}
+
+func (_c *MockDB_SaveEventBranding_Call) Return(_a0 error) *MockDB_SaveEventBranding_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_SaveEventBranding_Call) RunAndReturn(run func(context.Context, *db.EventBranding) error) *MockDB_SaveEventBranding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetActiveEvent provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) SetActiveEvent(_a0 context.Context, _a1 uint64) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetActiveEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_SetActiveEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActiveEvent'
+type MockDB_SetActiveEvent_Call struct {
+	*mock.Call
+}
+
+// SetActiveEvent is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uint64
+func (_e *MockDB_Expecter) SetActiveEvent(_a0 interface{}, _a1 interface{}) *MockDB_SetActiveEvent_Call {
+	return &MockDB_SetActiveEvent_Call{Call: _e.mock.On("SetActiveEvent", _a0, _a1)}
+}
+
+func (_c *MockDB_SetActiveEvent_Call) Run(run func(_a0 context.Context, _a1 uint64)) *MockDB_SetActiveEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockDB_SetActiveEvent_Call) Return(_a0 error) *MockDB_SetActiveEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_SetActiveEvent_Call) RunAndReturn(run func(context.Context, uint64) error) *MockDB_SetActiveEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEvents provides a mock function with given fields: _a0, _a1
-func (_m *MockDB) GetEvents(_a0 context.Context, _a1 uint) ([]*db.Event, error) {
-	ret := _m.Called(_a0, _a1)
+// SetEventLive provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockDB) SetEventLive(_a0 context.Context, _a1 uint64, _a2 bool) (*db.Event, error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEvents")
+		panic("no return value specified for SetEventLive")
 	}
 
-	var r0 []*db.Event
+	var r0 *db.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*db.Event, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, bool) (*db.Event, error)); ok {
+		return rf(_a0, _a1, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint) []*db.Event); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, bool) *db.Event); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*db.Event)
+			r0 = ret.Get(0).(*db.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -521,296 +4404,558 @@ func (_m *MockDB) GetEvents(_a0 context.Context, _a1 uint) ([]*db.Event, error)
 	return r0, r1
 }
 
-// MockDB_GetEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEvents'
-type MockDB_GetEvents_Call struct {
+// MockDB_SetEventLive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventLive'
+type MockDB_SetEventLive_Call struct {
 	*mock.Call
 }
 
-// GetEvents is a helper method to define mock.On call
+// SetEventLive is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 uint
-func (_e *MockDB_Expecter) GetEvents(_a0 interface{}, _a1 interface{}) *MockDB_GetEvents_Call {
-	return &MockDB_GetEvents_Call{Call: _e.mock.On("GetEvents", _a0, _a1)}
+//   - _a1 uint64
+//   - _a2 bool
+func (_e *MockDB_Expecter) SetEventLive(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockDB_SetEventLive_Call {
+	return &MockDB_SetEventLive_Call{Call: _e.mock.On("SetEventLive", _a0, _a1, _a2)}
 }
 
-func (_c *MockDB_GetEvents_Call) Run(run func(_a0 context.Context, _a1 uint)) *MockDB_GetEvents_Call {
+func (_c *MockDB_SetEventLive_Call) Run(run func(_a0 context.Context, _a1 uint64, _a2 bool)) *MockDB_SetEventLive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint))
+		run(args[0].(context.Context), args[1].(uint64), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *MockDB_GetEvents_Call) Return(_a0 []*db.Event, _a1 error) *MockDB_GetEvents_Call {
+func (_c *MockDB_SetEventLive_Call) Return(_a0 *db.Event, _a1 error) *MockDB_SetEventLive_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDB_GetEvents_Call) RunAndReturn(run func(context.Context, uint) ([]*db.Event, error)) *MockDB_GetEvents_Call {
+func (_c *MockDB_SetEventLive_Call) RunAndReturn(run func(context.Context, uint64, bool) (*db.Event, error)) *MockDB_SetEventLive_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFileInfo provides a mock function with given fields: _a0, _a1
-func (_m *MockDB) GetFileInfo(_a0 context.Context, _a1 string) (*db.FileInfo, error) {
-	ret := _m.Called(_a0, _a1)
+// SetEventMemberRole provides a mock function with given fields: ctx, eventId, memberId, role
+func (_m *MockDB) SetEventMemberRole(ctx context.Context, eventId uint, memberId uint, role string) error {
+	ret := _m.Called(ctx, eventId, memberId, role)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFileInfo")
+		panic("no return value specified for SetEventMemberRole")
 	}
 
-	var r0 *db.FileInfo
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) error); ok {
+		r0 = rf(ctx, eventId, memberId, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_SetEventMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventMemberRole'
+type MockDB_SetEventMemberRole_Call struct {
+	*mock.Call
+}
+
+// SetEventMemberRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - memberId uint
+//   - role string
+func (_e *MockDB_Expecter) SetEventMemberRole(ctx interface{}, eventId interface{}, memberId interface{}, role interface{}) *MockDB_SetEventMemberRole_Call {
+	return &MockDB_SetEventMemberRole_Call{Call: _e.mock.On("SetEventMemberRole", ctx, eventId, memberId, role)}
+}
+
+func (_c *MockDB_SetEventMemberRole_Call) Run(run func(ctx context.Context, eventId uint, memberId uint, role string)) *MockDB_SetEventMemberRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_SetEventMemberRole_Call) Return(_a0 error) *MockDB_SetEventMemberRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_SetEventMemberRole_Call) RunAndReturn(run func(context.Context, uint, uint, string) error) *MockDB_SetEventMemberRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetEventSchedule provides a mock function with given fields: ctx, id, startsAt, endsAt, expiresAt
+func (_m *MockDB) SetEventSchedule(ctx context.Context, id uint64, startsAt *time.Time, endsAt *time.Time, expiresAt *time.Time) (*db.Event, error) {
+	ret := _m.Called(ctx, id, startsAt, endsAt, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEventSchedule")
+	}
+
+	var r0 *db.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.FileInfo, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *time.Time, *time.Time, *time.Time) (*db.Event, error)); ok {
+		return rf(ctx, id, startsAt, endsAt, expiresAt)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *db.FileInfo); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *time.Time, *time.Time, *time.Time) *db.Event); ok {
+		r0 = rf(ctx, id, startsAt, endsAt, expiresAt)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.FileInfo)
+			r0 = ret.Get(0).(*db.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, *time.Time, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, id, startsAt, endsAt, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDB_SetEventSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventSchedule'
+type MockDB_SetEventSchedule_Call struct {
+	*mock.Call
+}
+
+// SetEventSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+//   - startsAt *time.Time
+//   - endsAt *time.Time
+//   - expiresAt *time.Time
+func (_e *MockDB_Expecter) SetEventSchedule(ctx interface{}, id interface{}, startsAt interface{}, endsAt interface{}, expiresAt interface{}) *MockDB_SetEventSchedule_Call {
+	return &MockDB_SetEventSchedule_Call{Call: _e.mock.On("SetEventSchedule", ctx, id, startsAt, endsAt, expiresAt)}
+}
+
+func (_c *MockDB_SetEventSchedule_Call) Run(run func(ctx context.Context, id uint64, startsAt *time.Time, endsAt *time.Time, expiresAt *time.Time)) *MockDB_SetEventSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(*time.Time), args[3].(*time.Time), args[4].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDB_SetEventSchedule_Call) Return(_a0 *db.Event, _a1 error) *MockDB_SetEventSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDB_SetEventSchedule_Call) RunAndReturn(run func(context.Context, uint64, *time.Time, *time.Time, *time.Time) (*db.Event, error)) *MockDB_SetEventSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFileHidden provides a mock function with given fields: ctx, eventId, id, hidden
+func (_m *MockDB) SetFileHidden(ctx context.Context, eventId uint, id string, hidden bool) error {
+	ret := _m.Called(ctx, eventId, id, hidden)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFileHidden")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, bool) error); ok {
+		r0 = rf(ctx, eventId, id, hidden)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_SetFileHidden_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFileHidden'
+type MockDB_SetFileHidden_Call struct {
+	*mock.Call
+}
+
+// SetFileHidden is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - id string
+//   - hidden bool
+func (_e *MockDB_Expecter) SetFileHidden(ctx interface{}, eventId interface{}, id interface{}, hidden interface{}) *MockDB_SetFileHidden_Call {
+	return &MockDB_SetFileHidden_Call{Call: _e.mock.On("SetFileHidden", ctx, eventId, id, hidden)}
+}
+
+func (_c *MockDB_SetFileHidden_Call) Run(run func(ctx context.Context, eventId uint, id string, hidden bool)) *MockDB_SetFileHidden_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockDB_SetFileHidden_Call) Return(_a0 error) *MockDB_SetFileHidden_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_SetFileHidden_Call) RunAndReturn(run func(context.Context, uint, string, bool) error) *MockDB_SetFileHidden_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSetting provides a mock function with given fields: ctx, name, value
+func (_m *MockDB) SetSetting(ctx context.Context, name string, value string) error {
+	ret := _m.Called(ctx, name, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSetting")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_SetSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSetting'
+type MockDB_SetSetting_Call struct {
+	*mock.Call
+}
+
+// SetSetting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - value string
+func (_e *MockDB_Expecter) SetSetting(ctx interface{}, name interface{}, value interface{}) *MockDB_SetSetting_Call {
+	return &MockDB_SetSetting_Call{Call: _e.mock.On("SetSetting", ctx, name, value)}
+}
+
+func (_c *MockDB_SetSetting_Call) Run(run func(ctx context.Context, name string, value string)) *MockDB_SetSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDB_SetSetting_Call) Return(_a0 error) *MockDB_SetSetting_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_SetSetting_Call) RunAndReturn(run func(context.Context, string, string) error) *MockDB_SetSetting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserAdmin provides a mock function with given fields: ctx, userId, admin
+func (_m *MockDB) SetUserAdmin(ctx context.Context, userId uint, admin bool) error {
+	ret := _m.Called(ctx, userId, admin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserAdmin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, bool) error); ok {
+		r0 = rf(ctx, userId, admin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_SetUserAdmin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserAdmin'
+type MockDB_SetUserAdmin_Call struct {
+	*mock.Call
+}
+
+// SetUserAdmin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - admin bool
+func (_e *MockDB_Expecter) SetUserAdmin(ctx interface{}, userId interface{}, admin interface{}) *MockDB_SetUserAdmin_Call {
+	return &MockDB_SetUserAdmin_Call{Call: _e.mock.On("SetUserAdmin", ctx, userId, admin)}
+}
+
+func (_c *MockDB_SetUserAdmin_Call) Run(run func(ctx context.Context, userId uint, admin bool)) *MockDB_SetUserAdmin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockDB_SetUserAdmin_Call) Return(_a0 error) *MockDB_SetUserAdmin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_SetUserAdmin_Call) RunAndReturn(run func(context.Context, uint, bool) error) *MockDB_SetUserAdmin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserDisabled provides a mock function with given fields: ctx, userId, disabled
+func (_m *MockDB) SetUserDisabled(ctx context.Context, userId uint, disabled bool) error {
+	ret := _m.Called(ctx, userId, disabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserDisabled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, bool) error); ok {
+		r0 = rf(ctx, userId, disabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDB_SetUserDisabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserDisabled'
+type MockDB_SetUserDisabled_Call struct {
+	*mock.Call
+}
+
+// SetUserDisabled is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - disabled bool
+func (_e *MockDB_Expecter) SetUserDisabled(ctx interface{}, userId interface{}, disabled interface{}) *MockDB_SetUserDisabled_Call {
+	return &MockDB_SetUserDisabled_Call{Call: _e.mock.On("SetUserDisabled", ctx, userId, disabled)}
+}
+
+func (_c *MockDB_SetUserDisabled_Call) Run(run func(ctx context.Context, userId uint, disabled bool)) *MockDB_SetUserDisabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockDB_SetUserDisabled_Call) Return(_a0 error) *MockDB_SetUserDisabled_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDB_SetUserDisabled_Call) RunAndReturn(run func(context.Context, uint, bool) error) *MockDB_SetUserDisabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreGoogleToken provides a mock function with given fields: ctx, userId, token
+func (_m *MockDB) StoreGoogleToken(ctx context.Context, userId uint, token []byte) error {
+	ret := _m.Called(ctx, userId, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreGoogleToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []byte) error); ok {
+		r0 = rf(ctx, userId, token)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDB_GetFileInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileInfo'
-type MockDB_GetFileInfo_Call struct {
+// MockDB_StoreGoogleToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreGoogleToken'
+type MockDB_StoreGoogleToken_Call struct {
 	*mock.Call
 }
 
-// GetFileInfo is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 string
-func (_e *MockDB_Expecter) GetFileInfo(_a0 interface{}, _a1 interface{}) *MockDB_GetFileInfo_Call {
-	return &MockDB_GetFileInfo_Call{Call: _e.mock.On("GetFileInfo", _a0, _a1)}
+// StoreGoogleToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - token []byte
+func (_e *MockDB_Expecter) StoreGoogleToken(ctx interface{}, userId interface{}, token interface{}) *MockDB_StoreGoogleToken_Call {
+	return &MockDB_StoreGoogleToken_Call{Call: _e.mock.On("StoreGoogleToken", ctx, userId, token)}
 }
 
-func (_c *MockDB_GetFileInfo_Call) Run(run func(_a0 context.Context, _a1 string)) *MockDB_GetFileInfo_Call {
+func (_c *MockDB_StoreGoogleToken_Call) Run(run func(ctx context.Context, userId uint, token []byte)) *MockDB_StoreGoogleToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uint), args[2].([]byte))
 	})
 	return _c
 }
 
-func (_c *MockDB_GetFileInfo_Call) Return(_a0 *db.FileInfo, _a1 error) *MockDB_GetFileInfo_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDB_StoreGoogleToken_Call) Return(_a0 error) *MockDB_StoreGoogleToken_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_GetFileInfo_Call) RunAndReturn(run func(context.Context, string) (*db.FileInfo, error)) *MockDB_GetFileInfo_Call {
+func (_c *MockDB_StoreGoogleToken_Call) RunAndReturn(run func(context.Context, uint, []byte) error) *MockDB_StoreGoogleToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetGoogleToken provides a mock function with given fields: ctx, uideId
-func (_m *MockDB) GetGoogleToken(ctx context.Context, uideId uint) ([]byte, error) {
-	ret := _m.Called(ctx, uideId)
+// SwitchEventStorage provides a mock function with given fields: ctx, migration, target, fileIds, thumbnailIds, coverIds
+func (_m *MockDB) SwitchEventStorage(ctx context.Context, migration *db.StorageMigration, target *db.Event, fileIds map[string]string, thumbnailIds map[string]string, coverIds map[string]string) error {
+	ret := _m.Called(ctx, migration, target, fileIds, thumbnailIds, coverIds)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetGoogleToken")
+		panic("no return value specified for SwitchEventStorage")
 	}
 
-	var r0 []byte
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]byte, error)); ok {
-		return rf(ctx, uideId)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint) []byte); ok {
-		r0 = rf(ctx, uideId)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
-		r1 = rf(ctx, uideId)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.StorageMigration, *db.Event, map[string]string, map[string]string, map[string]string) error); ok {
+		r0 = rf(ctx, migration, target, fileIds, thumbnailIds, coverIds)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDB_GetGoogleToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGoogleToken'
-type MockDB_GetGoogleToken_Call struct {
+// MockDB_SwitchEventStorage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SwitchEventStorage'
+type MockDB_SwitchEventStorage_Call struct {
 	*mock.Call
 }
 
-// GetGoogleToken is a helper method to define mock.On call
+// SwitchEventStorage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uideId uint
-func (_e *MockDB_Expecter) GetGoogleToken(ctx interface{}, uideId interface{}) *MockDB_GetGoogleToken_Call {
-	return &MockDB_GetGoogleToken_Call{Call: _e.mock.On("GetGoogleToken", ctx, uideId)}
+//   - migration *db.StorageMigration
+//   - target *db.Event
+//   - fileIds map[string]string
+//   - thumbnailIds map[string]string
+//   - coverIds map[string]string
+func (_e *MockDB_Expecter) SwitchEventStorage(ctx interface{}, migration interface{}, target interface{}, fileIds interface{}, thumbnailIds interface{}, coverIds interface{}) *MockDB_SwitchEventStorage_Call {
+	return &MockDB_SwitchEventStorage_Call{Call: _e.mock.On("SwitchEventStorage", ctx, migration, target, fileIds, thumbnailIds, coverIds)}
 }
 
-func (_c *MockDB_GetGoogleToken_Call) Run(run func(ctx context.Context, uideId uint)) *MockDB_GetGoogleToken_Call {
+func (_c *MockDB_SwitchEventStorage_Call) Run(run func(ctx context.Context, migration *db.StorageMigration, target *db.Event, fileIds map[string]string, thumbnailIds map[string]string, coverIds map[string]string)) *MockDB_SwitchEventStorage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint))
+		run(args[0].(context.Context), args[1].(*db.StorageMigration), args[2].(*db.Event), args[3].(map[string]string), args[4].(map[string]string), args[5].(map[string]string))
 	})
 	return _c
 }
 
-func (_c *MockDB_GetGoogleToken_Call) Return(_a0 []byte, _a1 error) *MockDB_GetGoogleToken_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDB_SwitchEventStorage_Call) Return(_a0 error) *MockDB_SwitchEventStorage_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_GetGoogleToken_Call) RunAndReturn(run func(context.Context, uint) ([]byte, error)) *MockDB_GetGoogleToken_Call {
+func (_c *MockDB_SwitchEventStorage_Call) RunAndReturn(run func(context.Context, *db.StorageMigration, *db.Event, map[string]string, map[string]string, map[string]string) error) *MockDB_SwitchEventStorage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetThumbnailInfo provides a mock function with given fields: _a0, _a1
-func (_m *MockDB) GetThumbnailInfo(_a0 context.Context, _a1 string) (*db.ThumbnailInfo, error) {
-	ret := _m.Called(_a0, _a1)
+// TakePendingUpload provides a mock function with given fields: ctx, eventId, key
+func (_m *MockDB) TakePendingUpload(ctx context.Context, eventId uint, key string) error {
+	ret := _m.Called(ctx, eventId, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetThumbnailInfo")
-	}
-
-	var r0 *db.ThumbnailInfo
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.ThumbnailInfo, error)); ok {
-		return rf(_a0, _a1)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *db.ThumbnailInfo); ok {
-		r0 = rf(_a0, _a1)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.ThumbnailInfo)
-		}
+		panic("no return value specified for TakePendingUpload")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(_a0, _a1)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, eventId, key)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDB_GetThumbnailInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThumbnailInfo'
-type MockDB_GetThumbnailInfo_Call struct {
+// MockDB_TakePendingUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TakePendingUpload'
+type MockDB_TakePendingUpload_Call struct {
 	*mock.Call
 }
 
-// GetThumbnailInfo is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 string
-func (_e *MockDB_Expecter) GetThumbnailInfo(_a0 interface{}, _a1 interface{}) *MockDB_GetThumbnailInfo_Call {
-	return &MockDB_GetThumbnailInfo_Call{Call: _e.mock.On("GetThumbnailInfo", _a0, _a1)}
+// TakePendingUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint
+//   - key string
+func (_e *MockDB_Expecter) TakePendingUpload(ctx interface{}, eventId interface{}, key interface{}) *MockDB_TakePendingUpload_Call {
+	return &MockDB_TakePendingUpload_Call{Call: _e.mock.On("TakePendingUpload", ctx, eventId, key)}
 }
 
-func (_c *MockDB_GetThumbnailInfo_Call) Run(run func(_a0 context.Context, _a1 string)) *MockDB_GetThumbnailInfo_Call {
+func (_c *MockDB_TakePendingUpload_Call) Run(run func(ctx context.Context, eventId uint, key string)) *MockDB_TakePendingUpload_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uint), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockDB_GetThumbnailInfo_Call) Return(_a0 *db.ThumbnailInfo, _a1 error) *MockDB_GetThumbnailInfo_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDB_TakePendingUpload_Call) Return(_a0 error) *MockDB_TakePendingUpload_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_GetThumbnailInfo_Call) RunAndReturn(run func(context.Context, string) (*db.ThumbnailInfo, error)) *MockDB_GetThumbnailInfo_Call {
+func (_c *MockDB_TakePendingUpload_Call) RunAndReturn(run func(context.Context, uint, string) error) *MockDB_TakePendingUpload_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetThumbnails provides a mock function with given fields: ctx, eventId, limit, offset
-func (_m *MockDB) GetThumbnails(ctx context.Context, eventId uint, limit int, offset int) ([]*db.ThumbnailInfo, error) {
-	ret := _m.Called(ctx, eventId, limit, offset)
+// TransferEvent provides a mock function with given fields: ctx, eventId, userId
+func (_m *MockDB) TransferEvent(ctx context.Context, eventId uint64, userId uint) error {
+	ret := _m.Called(ctx, eventId, userId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetThumbnails")
-	}
-
-	var r0 []*db.ThumbnailInfo
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, int, int) ([]*db.ThumbnailInfo, error)); ok {
-		return rf(ctx, eventId, limit, offset)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint, int, int) []*db.ThumbnailInfo); ok {
-		r0 = rf(ctx, eventId, limit, offset)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*db.ThumbnailInfo)
-		}
+		panic("no return value specified for TransferEvent")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint, int, int) error); ok {
-		r1 = rf(ctx, eventId, limit, offset)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint) error); ok {
+		r0 = rf(ctx, eventId, userId)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDB_GetThumbnails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThumbnails'
-type MockDB_GetThumbnails_Call struct {
+// MockDB_TransferEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferEvent'
+type MockDB_TransferEvent_Call struct {
 	*mock.Call
 }
 
-// GetThumbnails is a helper method to define mock.On call
+// TransferEvent is a helper method to define mock.On call
 //   - ctx context.Context
-//   - eventId uint
-//   - limit int
-//   - offset int
-func (_e *MockDB_Expecter) GetThumbnails(ctx interface{}, eventId interface{}, limit interface{}, offset interface{}) *MockDB_GetThumbnails_Call {
-	return &MockDB_GetThumbnails_Call{Call: _e.mock.On("GetThumbnails", ctx, eventId, limit, offset)}
+//   - eventId uint64
+//   - userId uint
+func (_e *MockDB_Expecter) TransferEvent(ctx interface{}, eventId interface{}, userId interface{}) *MockDB_TransferEvent_Call {
+	return &MockDB_TransferEvent_Call{Call: _e.mock.On("TransferEvent", ctx, eventId, userId)}
 }
 
-func (_c *MockDB_GetThumbnails_Call) Run(run func(ctx context.Context, eventId uint, limit int, offset int)) *MockDB_GetThumbnails_Call {
+func (_c *MockDB_TransferEvent_Call) Run(run func(ctx context.Context, eventId uint64, userId uint)) *MockDB_TransferEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint), args[2].(int), args[3].(int))
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint))
 	})
 	return _c
 }
 
-func (_c *MockDB_GetThumbnails_Call) Return(_a0 []*db.ThumbnailInfo, _a1 error) *MockDB_GetThumbnails_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDB_TransferEvent_Call) Return(_a0 error) *MockDB_TransferEvent_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_GetThumbnails_Call) RunAndReturn(run func(context.Context, uint, int, int) ([]*db.ThumbnailInfo, error)) *MockDB_GetThumbnails_Call {
+func (_c *MockDB_TransferEvent_Call) RunAndReturn(run func(context.Context, uint64, uint) error) *MockDB_TransferEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUser provides a mock function with given fields: _a0, _a1
-func (_m *MockDB) GetUser(_a0 context.Context, _a1 string) (*db.User, error) {
-	ret := _m.Called(_a0, _a1)
+// UpdateEvent provides a mock function with given fields: ctx, id, update
+func (_m *MockDB) UpdateEvent(ctx context.Context, id uint64, update *db.EventUpdate) (*db.Event, error) {
+	ret := _m.Called(ctx, id, update)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUser")
+		panic("no return value specified for UpdateEvent")
 	}
 
-	var r0 *db.User
+	var r0 *db.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.User, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *db.EventUpdate) (*db.Event, error)); ok {
+		return rf(ctx, id, update)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *db.User); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *db.EventUpdate) *db.Event); ok {
+		r0 = rf(ctx, id, update)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.User)
+			r0 = ret.Get(0).(*db.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, *db.EventUpdate) error); ok {
+		r1 = rf(ctx, id, update)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -818,45 +4963,46 @@ func (_m *MockDB) GetUser(_a0 context.Context, _a1 string) (*db.User, error) {
 	return r0, r1
 }
 
-// MockDB_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
-type MockDB_GetUser_Call struct {
+// MockDB_UpdateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEvent'
+type MockDB_UpdateEvent_Call struct {
 	*mock.Call
 }
 
-// GetUser is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 string
-func (_e *MockDB_Expecter) GetUser(_a0 interface{}, _a1 interface{}) *MockDB_GetUser_Call {
-	return &MockDB_GetUser_Call{Call: _e.mock.On("GetUser", _a0, _a1)}
+// UpdateEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+//   - update *db.EventUpdate
+func (_e *MockDB_Expecter) UpdateEvent(ctx interface{}, id interface{}, update interface{}) *MockDB_UpdateEvent_Call {
+	return &MockDB_UpdateEvent_Call{Call: _e.mock.On("UpdateEvent", ctx, id, update)}
 }
 
-func (_c *MockDB_GetUser_Call) Run(run func(_a0 context.Context, _a1 string)) *MockDB_GetUser_Call {
+func (_c *MockDB_UpdateEvent_Call) Run(run func(ctx context.Context, id uint64, update *db.EventUpdate)) *MockDB_UpdateEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uint64), args[2].(*db.EventUpdate))
 	})
 	return _c
 }
 
-func (_c *MockDB_GetUser_Call) Return(_a0 *db.User, _a1 error) *MockDB_GetUser_Call {
+func (_c *MockDB_UpdateEvent_Call) Return(_a0 *db.Event, _a1 error) *MockDB_UpdateEvent_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDB_GetUser_Call) RunAndReturn(run func(context.Context, string) (*db.User, error)) *MockDB_GetUser_Call {
+func (_c *MockDB_UpdateEvent_Call) RunAndReturn(run func(context.Context, uint64, *db.EventUpdate) (*db.Event, error)) *MockDB_UpdateEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetActiveEvent provides a mock function with given fields: _a0, _a1
-func (_m *MockDB) SetActiveEvent(_a0 context.Context, _a1 uint64) error {
+// UpdateStorageMigration provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) UpdateStorageMigration(_a0 context.Context, _a1 *db.StorageMigration) error {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetActiveEvent")
+		panic("no return value specified for UpdateStorageMigration")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *db.StorageMigration) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
@@ -865,106 +5011,95 @@ func (_m *MockDB) SetActiveEvent(_a0 context.Context, _a1 uint64) error {
 	return r0
 }
 
-// MockDB_SetActiveEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActiveEvent'
-type MockDB_SetActiveEvent_Call struct {
+// MockDB_UpdateStorageMigration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStorageMigration'
+type MockDB_UpdateStorageMigration_Call struct {
 	*mock.Call
 }
 
-// SetActiveEvent is a helper method to define mock.On call
+// UpdateStorageMigration is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 uint64
-func (_e *MockDB_Expecter) SetActiveEvent(_a0 interface{}, _a1 interface{}) *MockDB_SetActiveEvent_Call {
-	return &MockDB_SetActiveEvent_Call{Call: _e.mock.On("SetActiveEvent", _a0, _a1)}
+//   - _a1 *db.StorageMigration
+func (_e *MockDB_Expecter) UpdateStorageMigration(_a0 interface{}, _a1 interface{}) *MockDB_UpdateStorageMigration_Call {
+	return &MockDB_UpdateStorageMigration_Call{Call: _e.mock.On("UpdateStorageMigration", _a0, _a1)}
 }
 
-func (_c *MockDB_SetActiveEvent_Call) Run(run func(_a0 context.Context, _a1 uint64)) *MockDB_SetActiveEvent_Call {
+func (_c *MockDB_UpdateStorageMigration_Call) Run(run func(_a0 context.Context, _a1 *db.StorageMigration)) *MockDB_UpdateStorageMigration_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64))
+		run(args[0].(context.Context), args[1].(*db.StorageMigration))
 	})
 	return _c
 }
 
-func (_c *MockDB_SetActiveEvent_Call) Return(_a0 error) *MockDB_SetActiveEvent_Call {
+func (_c *MockDB_UpdateStorageMigration_Call) Return(_a0 error) *MockDB_UpdateStorageMigration_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_SetActiveEvent_Call) RunAndReturn(run func(context.Context, uint64) error) *MockDB_SetActiveEvent_Call {
+func (_c *MockDB_UpdateStorageMigration_Call) RunAndReturn(run func(context.Context, *db.StorageMigration) error) *MockDB_UpdateStorageMigration_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetEventLive provides a mock function with given fields: _a0, _a1, _a2
-func (_m *MockDB) SetEventLive(_a0 context.Context, _a1 uint64, _a2 bool) (*db.Event, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// UpdateUser provides a mock function with given fields: ctx, userId, username, email
+func (_m *MockDB) UpdateUser(ctx context.Context, userId uint, username string, email string) error {
+	ret := _m.Called(ctx, userId, username, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetEventLive")
-	}
-
-	var r0 *db.Event
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, bool) (*db.Event, error)); ok {
-		return rf(_a0, _a1, _a2)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, bool) *db.Event); ok {
-		r0 = rf(_a0, _a1, _a2)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.Event)
-		}
+		panic("no return value specified for UpdateUser")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64, bool) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string) error); ok {
+		r0 = rf(ctx, userId, username, email)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDB_SetEventLive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventLive'
-type MockDB_SetEventLive_Call struct {
+// MockDB_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type MockDB_UpdateUser_Call struct {
 	*mock.Call
 }
 
-// SetEventLive is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 uint64
-//   - _a2 bool
-func (_e *MockDB_Expecter) SetEventLive(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockDB_SetEventLive_Call {
-	return &MockDB_SetEventLive_Call{Call: _e.mock.On("SetEventLive", _a0, _a1, _a2)}
+// UpdateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uint
+//   - username string
+//   - email string
+func (_e *MockDB_Expecter) UpdateUser(ctx interface{}, userId interface{}, username interface{}, email interface{}) *MockDB_UpdateUser_Call {
+	return &MockDB_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, userId, username, email)}
 }
 
-func (_c *MockDB_SetEventLive_Call) Run(run func(_a0 context.Context, _a1 uint64, _a2 bool)) *MockDB_SetEventLive_Call {
+func (_c *MockDB_UpdateUser_Call) Run(run func(ctx context.Context, userId uint, username string, email string)) *MockDB_UpdateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64), args[2].(bool))
+		run(args[0].(context.Context), args[1].(uint), args[2].(string), args[3].(string))
 	})
 	return _c
 }
 
-func (_c *MockDB_SetEventLive_Call) Return(_a0 *db.Event, _a1 error) *MockDB_SetEventLive_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDB_UpdateUser_Call) Return(_a0 error) *MockDB_UpdateUser_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_SetEventLive_Call) RunAndReturn(run func(context.Context, uint64, bool) (*db.Event, error)) *MockDB_SetEventLive_Call {
+func (_c *MockDB_UpdateUser_Call) RunAndReturn(run func(context.Context, uint, string, string) error) *MockDB_UpdateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StoreGoogleToken provides a mock function with given fields: ctx, userId, token
-func (_m *MockDB) StoreGoogleToken(ctx context.Context, userId uint, token []byte) error {
-	ret := _m.Called(ctx, userId, token)
+// UpdateWebhookDelivery provides a mock function with given fields: _a0, _a1
+func (_m *MockDB) UpdateWebhookDelivery(_a0 context.Context, _a1 *db.WebhookDelivery) error {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StoreGoogleToken")
+		panic("no return value specified for UpdateWebhookDelivery")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, []byte) error); ok {
-		r0 = rf(ctx, userId, token)
+	if rf, ok := ret.Get(0).(func(context.Context, *db.WebhookDelivery) error); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -972,39 +5107,38 @@ func (_m *MockDB) StoreGoogleToken(ctx context.Context, userId uint, token []byt
 	return r0
 }
 
-// MockDB_StoreGoogleToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreGoogleToken'
-type MockDB_StoreGoogleToken_Call struct {
+// MockDB_UpdateWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhookDelivery'
+type MockDB_UpdateWebhookDelivery_Call struct {
 	*mock.Call
 }
 
-// StoreGoogleToken is a helper method to define mock.On call
-//   - ctx context.Context
-//   - userId uint
-//   - token []byte
-func (_e *MockDB_Expecter) StoreGoogleToken(ctx interface{}, userId interface{}, token interface{}) *MockDB_StoreGoogleToken_Call {
-	return &MockDB_StoreGoogleToken_Call{Call: _e.mock.On("StoreGoogleToken", ctx, userId, token)}
+// UpdateWebhookDelivery is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *db.WebhookDelivery
+func (_e *MockDB_Expecter) UpdateWebhookDelivery(_a0 interface{}, _a1 interface{}) *MockDB_UpdateWebhookDelivery_Call {
+	return &MockDB_UpdateWebhookDelivery_Call{Call: _e.mock.On("UpdateWebhookDelivery", _a0, _a1)}
 }
 
-func (_c *MockDB_StoreGoogleToken_Call) Run(run func(ctx context.Context, userId uint, token []byte)) *MockDB_StoreGoogleToken_Call {
+func (_c *MockDB_UpdateWebhookDelivery_Call) Run(run func(_a0 context.Context, _a1 *db.WebhookDelivery)) *MockDB_UpdateWebhookDelivery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint), args[2].([]byte))
+		run(args[0].(context.Context), args[1].(*db.WebhookDelivery))
 	})
 	return _c
 }
 
-func (_c *MockDB_StoreGoogleToken_Call) Return(_a0 error) *MockDB_StoreGoogleToken_Call {
+func (_c *MockDB_UpdateWebhookDelivery_Call) Return(_a0 error) *MockDB_UpdateWebhookDelivery_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDB_StoreGoogleToken_Call) RunAndReturn(run func(context.Context, uint, []byte) error) *MockDB_StoreGoogleToken_Call {
+func (_c *MockDB_UpdateWebhookDelivery_Call) RunAndReturn(run func(context.Context, *db.WebhookDelivery) error) *MockDB_UpdateWebhookDelivery_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserAuthorizedForEvent provides a mock function with given fields: _a0, _a1, _a2
-func (_m *MockDB) UserAuthorizedForEvent(_a0 context.Context, _a1 uint, _a2 uint) (bool, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// UserAuthorizedForEvent provides a mock function with given fields: ctx, userId, eventId, role
+func (_m *MockDB) UserAuthorizedForEvent(ctx context.Context, userId uint, eventId uint, role string) (bool, error) {
+	ret := _m.Called(ctx, userId, eventId, role)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UserAuthorizedForEvent")
@@ -1012,17 +5146,17 @@ func (_m *MockDB) UserAuthorizedForEvent(_a0 context.Context, _a1 uint, _a2 uint
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (bool, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) (bool, error)); ok {
+		return rf(ctx, userId, eventId, role)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) bool); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) bool); ok {
+		r0 = rf(ctx, userId, eventId, role)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string) error); ok {
+		r1 = rf(ctx, userId, eventId, role)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1036,16 +5170,17 @@ type MockDB_UserAuthorizedForEvent_Call struct {
 }
 
 // UserAuthorizedForEvent is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 uint
-//   - _a2 uint
-func (_e *MockDB_Expecter) UserAuthorizedForEvent(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockDB_UserAuthorizedForEvent_Call {
-	return &MockDB_UserAuthorizedForEvent_Call{Call: _e.mock.On("UserAuthorizedForEvent", _a0, _a1, _a2)}
+//   - ctx context.Context
+//   - userId uint
+//   - eventId uint
+//   - role string
+func (_e *MockDB_Expecter) UserAuthorizedForEvent(ctx interface{}, userId interface{}, eventId interface{}, role interface{}) *MockDB_UserAuthorizedForEvent_Call {
+	return &MockDB_UserAuthorizedForEvent_Call{Call: _e.mock.On("UserAuthorizedForEvent", ctx, userId, eventId, role)}
 }
 
-func (_c *MockDB_UserAuthorizedForEvent_Call) Run(run func(_a0 context.Context, _a1 uint, _a2 uint)) *MockDB_UserAuthorizedForEvent_Call {
+func (_c *MockDB_UserAuthorizedForEvent_Call) Run(run func(ctx context.Context, userId uint, eventId uint, role string)) *MockDB_UserAuthorizedForEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint), args[3].(string))
 	})
 	return _c
 }
@@ -1055,7 +5190,7 @@ func (_c *MockDB_UserAuthorizedForEvent_Call) Return(_a0 bool, _a1 error) *MockD
 	return _c
 }
 
-func (_c *MockDB_UserAuthorizedForEvent_Call) RunAndReturn(run func(context.Context, uint, uint) (bool, error)) *MockDB_UserAuthorizedForEvent_Call {
+func (_c *MockDB_UserAuthorizedForEvent_Call) RunAndReturn(run func(context.Context, uint, uint, string) (bool, error)) *MockDB_UserAuthorizedForEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2,6 +2,9 @@
 package db
 
 import (
+	"slices"
+	"time"
+
 	"github.com/jj-style/eventpix/internal/data/storage"
 	gormcrypto "github.com/pkasila/gorm-crypto"
 	"gorm.io/gorm"
@@ -19,7 +22,37 @@ type Event struct {
 	UserID         uint
 	User           User
 	Active         bool
-	Password       *gormcrypto.EncryptedValue
+	// bcrypt hash of the password guests need to get into the event, if set
+	PasswordHash *string
+	// Deprecated: reversibly encrypted password from before they were hashed,
+	// moved into PasswordHash when the DB is opened
+	Password *gormcrypto.EncryptedValue
+	// set whilst the events media is being copied to a new storage
+	Migrating bool
+	// base64 encoded data key media is encrypted with before being stored, if set
+	EncryptionKey *gormcrypto.EncryptedValue
+	// layout of the keys media is stored under, storage.DefaultKeyTemplate if empty
+	KeyTemplate string
+	// other users helping run the event
+	Members []EventMember
+	// role of the user the event was got for, only set by GetEvents
+	Role string `gorm:"-"`
+	// when the event is set live and stops being live, if scheduled
+	StartsAt *time.Time
+	EndsAt   *time.Time
+	// when the events gallery is hidden from guests and the event archived, if scheduled
+	ExpiresAt *time.Time
+	// whether the scheduler has already acted on StartsAt and EndsAt, so it only does once
+	// and owners can still set the event live or not in between
+	Started bool `gorm:"default:false"`
+	Ended   bool `gorm:"default:false"`
+	// set when the event expires, its gallery is no longer shown to guests
+	Archived bool `gorm:"default:false"`
+	// slug the event had before it was put in the trash, the slug is freed up for other
+	// events whilst it's there
+	TrashedSlug string
+	// how the events gallery looks, if it's been branded
+	Branding *EventBranding
 
 	storage.Storage `gorm:"-"`
 	// All available storage options for the event
@@ -30,6 +63,22 @@ type Event struct {
 	FtpStorage         *FtpStorage
 }
 
+// StorageType is the name of the kind of storage the event uses
+func (e *Event) StorageType() string {
+	switch {
+	case e.FileSystemStorage != nil:
+		return "Filesystem"
+	case e.S3Storage != nil:
+		return "S3"
+	case e.FtpStorage != nil:
+		return "Ftp"
+	case e.GoogleDriveStorage != nil:
+		return "Google"
+	default:
+		return "None"
+	}
+}
+
 type FileInfo struct {
 	gorm.Model
 	ID      string
@@ -37,6 +86,25 @@ type FileInfo struct {
 	Event   Event
 	Name    string
 	Video   bool
+	// bytes in the original upload, 0 if not known
+	Size int64
+	// name of the guest link it was uploaded with, if it was
+	Uploader string
+	// random id of the guests browser it was uploaded from, telling guests apart without knowing who they are
+	GuestSession string
+	// times it's been opened in the gallery, and downloaded
+	Views     uint
+	Downloads uint
+	// hidden from guests in the gallery by a moderator
+	Hidden bool
+}
+
+// PendingUpload is a key handed out for a client to upload straight to the events storage,
+// which only it can be added to the event as
+type PendingUpload struct {
+	EventID   uint   `gorm:"primaryKey;autoIncrement:false"`
+	ObjectKey string `gorm:"primaryKey"`
+	ExpiresAt time.Time
 }
 
 type ThumbnailInfo struct {
@@ -51,11 +119,42 @@ type ThumbnailInfo struct {
 
 type User struct {
 	gorm.Model
-	Username         string
-	Password         string
-	Admin            bool
+	Username string
+	Password string
+	// where password reset links are sent, if the user has told us
+	Email string
+	Admin bool
+	// disabled users can't sign in or use their tokens
+	Disabled bool
+	// version of the users session tokens, bumped to log them out everywhere
+	TokenVersion uint
+	// failed logins in a row, which lock the account for a while once there are too many
+	FailedLogins uint
+	// when the account can be logged into again, after too many failed logins
+	LockedUntil      *time.Time
 	Events           []Event
 	GoogleDriveToken *GoogleDriveToken
+	OidcIdentities   []OidcIdentity
+}
+
+// Schedule works out what the events schedule says it should be at the time, and whether
+// that's something the scheduler hasn't done yet. Only the latest step due is taken, so an
+// event whose whole schedule has passed is archived without being set live first.
+func (e *Event) Schedule(now time.Time) (live bool, archived bool, due bool) {
+	switch {
+	case e.ExpiresAt != nil && !now.Before(*e.ExpiresAt):
+		return false, true, !e.Archived
+	case e.EndsAt != nil && !now.Before(*e.EndsAt):
+		return false, false, !e.Ended
+	case e.StartsAt != nil && !now.Before(*e.StartsAt):
+		return true, false, !e.Started
+	}
+	return e.Live, false, false
+}
+
+// Locked is whether the user is locked out after too many failed logins
+func (u *User) Locked() bool {
+	return u.LockedUntil != nil && time.Now().Before(*u.LockedUntil)
 }
 
 type FileSystemStorage struct {
@@ -73,6 +172,8 @@ type S3Storage struct {
 	Endpoint  string
 	EventID   uint
 	Insecure  bool
+	// clients upload and download media directly with the bucket
+	Presigned bool
 }
 
 type GoogleDriveStorage struct {
@@ -95,3 +196,270 @@ type FtpStorage struct {
 	Password  gormcrypto.EncryptedValue
 	EventID   uint
 }
+
+const (
+	MigrationRunning  = "running"
+	MigrationComplete = "complete"
+	MigrationFailed   = "failed"
+)
+
+// Progress of copying an events media from its current storage to a new one
+type StorageMigration struct {
+	gorm.Model
+	EventID   uint
+	Status    string
+	Total     int
+	Completed int
+	Error     string
+}
+
+// Token for a user to authenticate with the API, limited to a set of scopes
+type ApiToken struct {
+	gorm.Model
+	UserID uint
+	User   User
+	Name   string
+	// sha256 of the token, the token itself is only ever shown when it's created
+	Hash string `gorm:"uniqueIndex"`
+	// start of the token so the user can tell which is which
+	Prefix string
+	// comma separated scopes the token is allowed to use
+	Scopes string
+}
+
+// Endpoint to POST to when things happen to a user's events
+type Webhook struct {
+	gorm.Model
+	UserID uint
+	// only deliver for this event, or all the user's events if nil
+	EventID *uint
+	Url     string
+	// key deliveries are signed with, so the receiver can check they came from us
+	Secret gormcrypto.EncryptedValue
+	// comma separated subjects the webhook is subscribed to
+	Subjects string
+}
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// Record of sending something to a webhook, across all the attempts made
+type WebhookDelivery struct {
+	gorm.Model
+	WebhookID  uint
+	Subject    string
+	Payload    string
+	Status     string
+	Attempts   int
+	StatusCode int
+	Error      string
+}
+
+// Link letting guests into a password protected event without the password
+type GuestToken struct {
+	gorm.Model
+	EventID uint
+	Name    string
+	// auth.GuestView or auth.GuestUpload
+	Capability string
+	// never expires if nil
+	ExpiresAt *time.Time
+}
+
+// Account at an OpenID Connect provider the user signs in with
+type OidcIdentity struct {
+	gorm.Model
+	UserID uint
+	User   User
+	// name of the provider in config
+	Provider string
+	Issuer   string `gorm:"uniqueIndex:idx_oidc_identity"`
+	Subject  string `gorm:"uniqueIndex:idx_oidc_identity"`
+	Email    string
+}
+
+const (
+	// can do anything with the event, including deleting it and managing its members
+	RoleOwner = "owner"
+	// can change the events settings and moderate it
+	RoleManager = "manager"
+	// can only moderate the event
+	RoleModerator = "moderator"
+)
+
+// roles in order of what they can do, each can do everything the ones before it can
+var roles = []string{RoleModerator, RoleManager, RoleOwner}
+
+// RoleAtLeast is whether the role can do everything the wanted role can
+func RoleAtLeast(role, want string) bool {
+	have := slices.Index(roles, role)
+	return have >= 0 && have >= slices.Index(roles, want)
+}
+
+// ValidRole is whether the role is one users can be given on an event
+func ValidRole(role string) bool {
+	return slices.Contains(roles, role)
+}
+
+// User other than the events owner who helps run it
+type EventMember struct {
+	gorm.Model
+	EventID uint `gorm:"uniqueIndex:idx_event_member"`
+	Event   Event
+	UserID  uint `gorm:"uniqueIndex:idx_event_member"`
+	User    User
+	// RoleOwner, RoleManager or RoleModerator
+	Role string
+	// the user only gets the role once they accept the invite
+	Accepted bool
+}
+
+// Instance setting admins can change whilst the server is running
+type Setting struct {
+	Name  string `gorm:"primaryKey"`
+	Value string
+}
+
+// Media stored by an event, for admins
+type EventUsage struct {
+	Event *Event
+	// number of photos and videos
+	Files int64
+	// bytes of media, only counting media whose size is known
+	Bytes int64
+}
+
+// GuestVisit is a guests browser having opened the events gallery, once per browser
+type GuestVisit struct {
+	ID        uint   `gorm:"primaryKey"`
+	EventID   uint   `gorm:"uniqueIndex:idx_guest_visit"`
+	Session   string `gorm:"uniqueIndex:idx_guest_visit;size:64"`
+	CreatedAt time.Time
+}
+
+// EventStats is what guests have done in an event
+type EventStats struct {
+	Photos int64 `json:"photos"`
+	Videos int64 `json:"videos"`
+	// bytes of media, only counting media whose size is known
+	Bytes int64 `json:"bytes"`
+	// browsers which have opened the gallery, and uploaded to it
+	GuestSessions     int64 `json:"guestSessions"`
+	UploadingSessions int64 `json:"uploadingSessions"`
+	Views             int64 `json:"views"`
+	Downloads         int64 `json:"downloads"`
+	// uploads in each hour, or each day for events over more than a few days, oldest first
+	Uploads []UploadCount `json:"uploads"`
+	// busiest time for uploads, nil if there haven't been any
+	Peak *UploadCount `json:"peak"`
+	// most viewed and downloaded media
+	TopMedia []MediaStats `json:"topMedia"`
+	// guest links uploaded with the most, empty if no one has uploaded with one
+	TopUploaders []UploaderStats `json:"topUploaders"`
+}
+
+// UploadCount is how many uploads there were in the period from Time
+type UploadCount struct {
+	Time  time.Time `json:"time"`
+	Count int64     `json:"count"`
+}
+
+type MediaStats struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Video     bool   `json:"video"`
+	Views     uint   `json:"views"`
+	Downloads uint   `json:"downloads"`
+}
+
+type UploaderStats struct {
+	Name    string `json:"name"`
+	Uploads int64  `json:"uploads"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// Old slug of an event which still leads to it after its slug was changed
+type EventSlugRedirect struct {
+	Slug      string `gorm:"primaryKey"`
+	EventID   uint   `gorm:"index"`
+	CreatedAt time.Time
+}
+
+// Settings saved from an event which new events can be created with
+type EventTemplate struct {
+	gorm.Model
+	UserID uint `gorm:"index"`
+	Name   string
+	Cache  bool
+	// bcrypt hash of the password events are created with, if set
+	PasswordHash *string
+	// whether events get their own key to encrypt media with
+	Encrypt     bool
+	KeyTemplate string
+	// JSON of the storage events are created with, encrypted as it has credentials
+	Storage gormcrypto.EncryptedValue
+}
+
+// EventBranding is how an events gallery looks, empty fields keep the galleries usual look
+type EventBranding struct {
+	gorm.Model
+	EventID uint `gorm:"uniqueIndex"`
+	// colour of the galleries buttons and links, and its background, as #rrggbb
+	PrimaryColor    string
+	BackgroundColor string
+	// name of the font the galleries heading is in, one of branding.Fonts
+	Font string
+	// id of the cover image in the events storage, if it has one
+	CoverImage string
+	// Markdown shown to guests above the gallery
+	WelcomeText string
+	// text shown at the bottom of the gallery
+	Footer string
+}
+
+// ShortLink is a short link to an event, e.g. /e/Ab3x, which makes QR codes with fewer and bigger
+// squares that are easier to scan and print
+type ShortLink struct {
+	gorm.Model
+	EventID uint
+	Code    string `gorm:"uniqueIndex;size:16"`
+	Name    string
+	// lets guests in with the guest token, the link stops working when it's rotated or revoked
+	GuestTokenID *uint
+	// times the link has been followed
+	Scans uint
+}
+
+// EventUpdate is what to change about an event, only the fields set are changed
+type EventUpdate struct {
+	Name *string
+	Slug *string
+	// bcrypt hash of the new password, or empty to remove it
+	PasswordHash *string
+	Cache        *bool
+	// new credentials for the events S3 or FTP storage, whichever it has
+	AccessKey, SecretKey *string
+	Username, Password   *string
+}
+
+// AuditLog records who did what, entries are only ever added
+type AuditLog struct {
+	ID        uint      `gorm:"primarykey"`
+	CreatedAt time.Time `gorm:"index"`
+	// user who did it, 0 if it wasn't a logged in user
+	ActorID   uint
+	ActorName string
+	IP        string
+	// what was done, e.g. event.update
+	Action string
+	// what it was done to, e.g. user:4
+	Target string
+	// event it was done to, if any
+	EventID *uint `gorm:"index"`
+	// JSON of what changed, before and after
+	Before string
+	After  string
+}
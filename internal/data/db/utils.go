@@ -4,6 +4,8 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/jj-style/eventpix/internal/data/storage"
 	"github.com/spf13/afero"
@@ -25,6 +27,7 @@ func ExtractEventStorage(evt *Event, googleOauthConfig *oauth2.Config) error {
 			Bucket:    st.Bucket,
 			Endpoint:  st.Endpoint,
 			Insecure:  st.Insecure,
+			Presigned: st.Presigned,
 		})
 	} else if st := evt.GoogleDriveStorage; st != nil {
 		var token oauth2.Token
@@ -49,5 +52,31 @@ func ExtractEventStorage(evt *Event, googleOauthConfig *oauth2.Config) error {
 	} else {
 		return ErrNoStorage
 	}
+	if evt.EncryptionKey != nil {
+		key, err := base64.StdEncoding.DecodeString(evt.EncryptionKey.Raw.(string))
+		if err != nil {
+			return fmt.Errorf("decoding event encryption key: %v", err)
+		}
+		evt.Storage = storage.NewEncrypted(evt.Storage, key)
+	}
 	return nil
 }
+
+// Key to store the named media under in the events storage
+func MediaKey(evt *Event, name string) string {
+	tmpl := evt.KeyTemplate
+	if tmpl == "" {
+		tmpl = storage.DefaultKeyTemplate
+	}
+	return storage.Key(tmpl, evt.Slug, name, time.Now())
+}
+
+// Key to store the named thumbnail under in the events storage
+func ThumbnailKey(evt *Event, name string) string {
+	return storage.ThumbnailPrefix + MediaKey(evt, name)
+}
+
+// Key to store the events named cover image under in its storage
+func CoverKey(evt *Event, name string) string {
+	return storage.BrandingPrefix + MediaKey(evt, name)
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"io"
+
+	"github.com/jj-style/eventpix/internal/pkg/encrypt"
+)
+
+// encryptedStore encrypts data before storing it in the underlying
+// storage, and decrypts it transparently when it is retrieved.
+type encryptedStore struct {
+	Storage
+	key []byte
+}
+
+func (e *encryptedStore) Store(ctx context.Context, name string, data io.Reader) (string, error) {
+	r, err := encrypt.NewEncryptReader(e.key, data)
+	if err != nil {
+		return "", err
+	}
+	return e.Storage.Store(ctx, name, r)
+}
+
+func (e *encryptedStore) Get(ctx context.Context, id string) (io.ReadCloser, error) {
+	rc, err := e.Storage.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	dec, err := encrypt.NewDecryptReader(e.key, rc)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return dec, nil
+}
+
+func (e *encryptedStore) Delete(ctx context.Context, id string) error {
+	_, err := Delete(ctx, e.Storage, id)
+	return err
+}
+
+// NewEncrypted wraps the storage so everything stored in it is encrypted with the key
+func NewEncrypted(st Storage, key []byte) Storage {
+	return &encryptedStore{Storage: st, key: key}
+}
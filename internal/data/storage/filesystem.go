@@ -2,8 +2,11 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"io"
 	"io/fs"
+	"os"
+	"path"
 
 	"github.com/spf13/afero"
 )
@@ -34,12 +37,35 @@ func (f *filesystem) Get(_ context.Context, name string) (io.ReadCloser, error)
 }
 
 func (f *filesystem) Store(_ context.Context, name string, file io.Reader) (string, error) {
+	key, err := CleanKey(name)
+	if err != nil {
+		return "", err
+	}
 	buf, err := io.ReadAll(file)
 	if err != nil {
 		return "", err
 	}
-	if err := afero.WriteFile(f.fs, name, buf, fs.ModePerm); err != nil {
+	if err := f.fs.MkdirAll(path.Dir(key), fs.ModePerm); err != nil {
 		return "", err
 	}
-	return name, nil
+	// never overwrite an existing file
+	out, err := f.fs.OpenFile(key, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fs.ModePerm)
+	if err != nil {
+		return "", err
+	}
+	if _, err := out.Write(buf); err != nil {
+		out.Close()
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (f *filesystem) Delete(_ context.Context, name string) error {
+	if err := f.fs.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
 }
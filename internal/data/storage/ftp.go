@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"path"
 	"strings"
 	"time"
 
@@ -30,16 +31,60 @@ func (f *ftpStore) Get(ctx context.Context, name string) (io.ReadCloser, error)
 	return file, nil
 }
 
+func (f *ftpStore) Delete(ctx context.Context, name string) error {
+	conn, err := f.login()
+	if err != nil {
+		return err
+	}
+	defer conn.Logout()
+	if err := conn.Delete(name); err != nil && !strings.HasPrefix(err.Error(), fmt.Sprint(ftp.StatusFileUnavailable)) {
+		return err
+	}
+	return nil
+}
+
 func (f *ftpStore) Store(ctx context.Context, name string, file io.Reader) (string, error) {
+	key, err := CleanKey(name)
+	if err != nil {
+		return "", err
+	}
 	conn, err := f.login()
 	if err != nil {
 		return "", err
 	}
 	defer conn.Logout()
-	if err := conn.Stor(name, file); err != nil {
+	if err := f.makeDirs(conn, path.Dir(key)); err != nil {
 		return "", err
 	}
-	return name, nil
+	if err := conn.Stor(key, file); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// creates each directory in the path that doesn't already exist,
+// returning back to the stores directory afterwards
+func (f *ftpStore) makeDirs(conn *ftp.ServerConn, dir string) error {
+	if dir == "." {
+		return nil
+	}
+	// the stores directory can be relative, so go back to where it resolved to
+	start, err := conn.CurrentDir()
+	if err != nil {
+		return err
+	}
+	for _, part := range strings.Split(dir, "/") {
+		if err := conn.ChangeDir(part); err == nil {
+			continue
+		}
+		if err := conn.MakeDir(part); err != nil {
+			return fmt.Errorf("making directory %s: %v", dir, err)
+		}
+		if err := conn.ChangeDir(part); err != nil {
+			return err
+		}
+	}
+	return conn.ChangeDir(start)
 }
 
 func (f *ftpStore) login() (*ftp.ServerConn, error) {
@@ -2,10 +2,14 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"io"
+	"net/http"
+	"path"
 
 	"golang.org/x/oauth2"
 	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 )
 
@@ -23,8 +27,10 @@ func (g *googleDriveStore) Get(ctx context.Context, id string) (io.ReadCloser, e
 }
 
 func (g *googleDriveStore) Store(ctx context.Context, name string, data io.Reader) (string, error) {
+	// drive identifies files by ID rather than path, so there's no need
+	// to create the folders in the key, the name of the file is enough
 	f, err := g.drive.Files.Create(&drive.File{
-		Name:    name,
+		Name:    path.Base(name),
 		Parents: []string{g.folderId},
 	}).Media(data).Do()
 	if err != nil {
@@ -33,6 +39,15 @@ func (g *googleDriveStore) Store(ctx context.Context, name string, data io.Reade
 	return f.Id, err
 }
 
+func (g *googleDriveStore) Delete(ctx context.Context, id string) error {
+	err := g.drive.Files.Delete(id).Context(ctx).Do()
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) && gerr.Code == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
 func NewGoogleDriveStorage(config *oauth2.Config, token *oauth2.Token, folderId string) (Storage, error) {
 	client := config.Client(context.Background(), token)
 	srv, err := drive.NewService(context.Background(), option.WithHTTPClient(client))
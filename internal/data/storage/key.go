@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// layout of keys media is stored under when an event doesn't configure its own
+	DefaultKeyTemplate = "{event-slug}/{yyyy}/{mm}/{uuid}-{name}"
+	// put in front of the key of every thumbnail so they're kept apart from the media
+	ThumbnailPrefix = "thumbs/"
+	// put in front of the key of images events are branded with, such as their cover image
+	BrandingPrefix = "branding/"
+	// longest a sanitised name is allowed to be, leaving room for the rest of the key
+	maxNameLength = 128
+)
+
+var (
+	ErrInvalidKey = errors.New("invalid storage key")
+
+	placeholderRe     = regexp.MustCompile(`\{[^{}]*\}`)
+	unsafeNameCharsRe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	keyTemplateRe     = regexp.MustCompile(`^[A-Za-z0-9._/-]*$`)
+	placeholders      = []string{"{event-slug}", "{yyyy}", "{mm}", "{dd}", "{uuid}", "{name}"}
+)
+
+// ValidateKeyTemplate checks the template only uses known placeholders, and always
+// contains {uuid} so two files with the same name can never be stored under the same key
+func ValidateKeyTemplate(tmpl string) error {
+	for _, p := range placeholderRe.FindAllString(tmpl, -1) {
+		found := false
+		for _, known := range placeholders {
+			found = found || p == known
+		}
+		if !found {
+			return fmt.Errorf("unknown placeholder %s in key template, must be one of %s", p, strings.Join(placeholders, ", "))
+		}
+	}
+	if !strings.Contains(tmpl, "{uuid}") {
+		return errors.New("key template must contain {uuid}")
+	}
+	if !keyTemplateRe.MatchString(placeholderRe.ReplaceAllString(tmpl, "")) {
+		return fmt.Errorf("key template must only contain placeholders and characters matching %s", keyTemplateRe.String())
+	}
+	if _, err := CleanKey(Key(tmpl, "slug", "name", time.Now())); err != nil {
+		return fmt.Errorf("key template: %v", err)
+	}
+	return nil
+}
+
+// Key renders the template into a unique key to store the named file under
+func Key(tmpl string, slug string, name string, now time.Time) string {
+	now = now.UTC()
+	return strings.NewReplacer(
+		"{event-slug}", SanitiseName(slug),
+		"{yyyy}", fmt.Sprintf("%04d", now.Year()),
+		"{mm}", fmt.Sprintf("%02d", now.Month()),
+		"{dd}", fmt.Sprintf("%02d", now.Day()),
+		"{uuid}", uuid.NewString(),
+		"{name}", SanitiseName(name),
+	).Replace(tmpl)
+}
+
+// SanitiseName makes a name given by a user safe to use as part of a key, dropping
+// any directories and replacing anything but letters, numbers, dots, dashes and underscores
+func SanitiseName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = unsafeNameCharsRe.ReplaceAllString(name, "_")
+	name = strings.TrimLeft(name, ".")
+	if len(name) > maxNameLength {
+		ext := path.Ext(name)
+		if len(ext) > maxNameLength/2 {
+			ext = ""
+		}
+		name = name[:maxNameLength-len(ext)] + ext
+	}
+	if name == "" {
+		return "file"
+	}
+	return name
+}
+
+// CleanKey normalises the key, making sure it can't escape the storage's root
+func CleanKey(key string) (string, error) {
+	key = path.Clean(strings.ReplaceAll(key, `\`, "/"))
+	if key == "." || key == ".." || strings.HasPrefix(key, "/") || strings.HasPrefix(key, "../") {
+		return "", fmt.Errorf("%w: %s", ErrInvalidKey, key)
+	}
+	return key, nil
+}
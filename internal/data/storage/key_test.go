@@ -0,0 +1,89 @@
+package storage_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/data/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+
+	now := time.Date(2025, time.March, 7, 12, 0, 0, 0, time.UTC)
+	got := storage.Key(storage.DefaultKeyTemplate, "my-party", "IMG 0001.jpg", now)
+	is.Regexp(regexp.MustCompile(`^my-party/2025/03/[0-9a-f-]{36}-IMG_0001\.jpg$`), got)
+
+	// same name never gives the same key
+	is.NotEqual(got, storage.Key(storage.DefaultKeyTemplate, "my-party", "IMG 0001.jpg", now))
+
+	got = storage.Key("{yyyy}-{mm}-{dd}/{uuid}", "my-party", "IMG_0001.jpg", now)
+	is.Regexp(regexp.MustCompile(`^2025-03-07/[0-9a-f-]{36}$`), got)
+}
+
+func TestSanitiseName(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]string{
+		"IMG_0001.jpg":         "IMG_0001.jpg",
+		"my photo (1).jpg":     "my_photo_1_.jpg",
+		"../../etc/passwd":     "passwd",
+		`..\..\windows\system`: "system",
+		"/":                    "_",
+		"":                     "file",
+		"..":                   "file",
+		".hidden":              "hidden",
+		"café.png":             "caf_.png",
+	} {
+		require.Equal(t, want, storage.SanitiseName(name), name)
+	}
+}
+
+func TestValidateKeyTemplate(t *testing.T) {
+	t.Parallel()
+
+	for tmpl, valid := range map[string]bool{
+		storage.DefaultKeyTemplate:         true,
+		"{uuid}":                           true,
+		"photos/{event-slug}/{uuid}{name}": true,
+		"{event-slug}/{name}":              false,
+		"{uuid}-{unknown}":                 false,
+		"../{uuid}":                        false,
+		"/{uuid}":                          false,
+		"{uuid} {name}":                    false,
+	} {
+		err := storage.ValidateKeyTemplate(tmpl)
+		if valid {
+			require.NoError(t, err, tmpl)
+		} else {
+			require.Error(t, err, tmpl)
+		}
+	}
+}
+
+func TestCleanKey(t *testing.T) {
+	t.Parallel()
+
+	for key, want := range map[string]string{
+		"a/b/c.jpg":       "a/b/c.jpg",
+		"a//b/./c.jpg":    "a/b/c.jpg",
+		"a/../b.jpg":      "b.jpg",
+		"../b.jpg":        "",
+		"/etc/passwd":     "",
+		`..\windows.jpg`:  "",
+		"..":              "",
+		"":                "",
+		"thumbs/a/b.webp": "thumbs/a/b.webp",
+	} {
+		got, err := storage.CleanKey(key)
+		if want == "" {
+			require.ErrorIs(t, err, storage.ErrInvalidKey, key)
+		} else {
+			require.NoError(t, err, key)
+			require.Equal(t, want, got, key)
+		}
+	}
+}
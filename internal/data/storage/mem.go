@@ -33,6 +33,13 @@ func (m *memStore) Store(_ context.Context, name string, data io.Reader) (string
 	return name, nil
 }
 
+func (m *memStore) Delete(_ context.Context, name string) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.files, name)
+	return nil
+}
+
 func NewMemStore() Storage {
 	return &memStore{files: make(map[string][]byte)}
 }
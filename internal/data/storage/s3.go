@@ -2,9 +2,11 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"net/url"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
@@ -30,12 +32,57 @@ func (s *s3Store) Get(ctx context.Context, name string) (io.ReadCloser, error) {
 }
 
 func (s *s3Store) Store(ctx context.Context, name string, file io.Reader) (string, error) {
-	id := uuid.NewString()
-	_, err := s.s3.PutObject(ctx, s.bucket, id, file, -1, minio.PutObjectOptions{})
+	key, err := CleanKey(name)
 	if err != nil {
 		return "", err
 	}
-	return id, nil
+	_, err = s.s3.PutObject(ctx, s.bucket, key, file, -1, minio.PutObjectOptions{})
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, name string) error {
+	return s.s3.RemoveObject(ctx, s.bucket, name, minio.RemoveObjectOptions{})
+}
+
+// presignedS3Store is an s3Store which clients upload to and download from directly
+type presignedS3Store struct {
+	*s3Store
+}
+
+func (s *presignedS3Store) PresignPut(ctx context.Context, name string, expiry time.Duration) (string, string, error) {
+	key, err := CleanKey(name)
+	if err != nil {
+		return "", "", err
+	}
+	u, err := s.s3.PresignedPutObject(ctx, s.bucket, key, expiry)
+	if err != nil {
+		return "", "", err
+	}
+	return key, u.String(), nil
+}
+
+func (s *presignedS3Store) Size(ctx context.Context, id string) (int64, error) {
+	info, err := s.s3.StatObject(ctx, s.bucket, id, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return 0, ErrFileNotFound
+		}
+		return 0, err
+	}
+	return info.Size, nil
+}
+
+func (s *presignedS3Store) PresignGet(ctx context.Context, id string, filename string, expiry time.Duration) (string, error) {
+	params := url.Values{}
+	params.Set("response-content-disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	u, err := s.s3.PresignedGetObject(ctx, s.bucket, id, expiry, params)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
 }
 
 type S3Config struct {
@@ -45,15 +92,22 @@ type S3Config struct {
 	Bucket    string
 	Endpoint  string
 	Insecure  bool
+	// hand out presigned URLs so clients upload and download directly with the bucket
+	Presigned bool
 }
 
 func NewS3Store(cfg *S3Config) Storage {
 	minioClient, err := minio.New(cfg.Endpoint, &minio.Options{
 		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
 		Secure: !cfg.Insecure,
+		Region: cfg.Region,
 	})
 	if err != nil {
 		panic(err)
 	}
-	return &s3Store{s3: minioClient, bucket: cfg.Bucket}
+	store := &s3Store{s3: minioClient, bucket: cfg.Bucket}
+	if cfg.Presigned {
+		return &presignedS3Store{store}
+	}
+	return store
 }
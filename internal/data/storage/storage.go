@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 )
 
 var (
@@ -17,3 +18,30 @@ type Storage interface {
 	Store(context.Context, string, io.Reader) (string, error)
 	Get(context.Context, string) (io.ReadCloser, error)
 }
+
+// Presigner is implemented by storage which can hand out temporary URLs for clients to
+// upload and download data directly, without it having to go through the server
+type Presigner interface {
+	// PresignPut returns the ID the data will be stored as, and a URL to PUT it to
+	PresignPut(ctx context.Context, name string, expiry time.Duration) (string, string, error)
+	// PresignGet returns a URL to GET the data, downloaded as the given filename
+	PresignGet(ctx context.Context, id string, filename string, expiry time.Duration) (string, error)
+	// Size returns how many bytes are stored, to check what clients uploaded without trusting them.
+	// ErrFileNotFound if nothing was.
+	Size(ctx context.Context, id string) (int64, error)
+}
+
+// Deleter is implemented by storage which data can be removed from.
+// Deleting data which doesn't exist is not an error.
+type Deleter interface {
+	Delete(ctx context.Context, id string) error
+}
+
+// Delete removes the data from the storage if it supports it, returning false if it doesn't
+func Delete(ctx context.Context, st Storage, id string) (bool, error) {
+	d, ok := st.(Deleter)
+	if !ok {
+		return false, nil
+	}
+	return true, d.Delete(ctx, id)
+}
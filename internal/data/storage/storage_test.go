@@ -6,10 +6,19 @@ import (
 	"errors"
 	"io"
 	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"path"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/jj-style/eventpix/internal/data/storage"
+	"github.com/jj-style/eventpix/internal/pkg/encrypt"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/spf13/afero"
@@ -77,6 +86,13 @@ func TestStorage(t *testing.T) {
 			require.NoError(t, err)
 			gotData, _ := io.ReadAll(got)
 			require.Equal(t, []byte("data"), gotData)
+
+			// and delete it
+			deleted, err := storage.Delete(ctx, store, id)
+			require.NoError(t, err)
+			require.True(t, deleted)
+			_, err = store.Get(ctx, id)
+			require.ErrorIs(t, err, storage.ErrFileNotFound)
 		})
 
 		t.Run(name+" unhappy file not found", func(t *testing.T) {
@@ -99,6 +115,311 @@ func TestStorage(t *testing.T) {
 	}
 }
 
+func TestFilesystemKeys(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+	ctx := context.Background()
+
+	fs := afero.NewMemMapFs()
+	store := storage.NewFilesystem(fs, "/store")
+
+	id, err := store.Store(ctx, "party/2025/03/abc-photo.jpg", strings.NewReader("picture"))
+	is.NoError(err)
+	is.Equal("party/2025/03/abc-photo.jpg", id)
+	got, err := afero.ReadFile(fs, "/store/party/2025/03/abc-photo.jpg")
+	is.NoError(err)
+	is.Equal("picture", string(got))
+
+	// doesn't overwrite existing files
+	_, err = store.Store(ctx, "party/2025/03/abc-photo.jpg", strings.NewReader("other picture"))
+	is.Error(err)
+	got, err = afero.ReadFile(fs, "/store/party/2025/03/abc-photo.jpg")
+	is.NoError(err)
+	is.Equal("picture", string(got))
+
+	// can't escape the directory
+	_, err = store.Store(ctx, "../escaped.jpg", strings.NewReader("picture"))
+	is.ErrorIs(err, storage.ErrInvalidKey)
+	exists, err := afero.Exists(fs, "/escaped.jpg")
+	is.NoError(err)
+	is.False(exists)
+}
+
+func TestEncryptedStorage(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+	ctx := context.Background()
+
+	key, err := encrypt.NewKey()
+	is.NoError(err)
+	underlying := storage.NewMemStore()
+	store := storage.NewEncrypted(underlying, key)
+
+	id, err := store.Store(ctx, "file.txt", strings.NewReader("hello world"))
+	is.NoError(err)
+
+	// stored encrypted in the underlying storage
+	raw, err := underlying.Get(ctx, id)
+	is.NoError(err)
+	rawData, err := io.ReadAll(raw)
+	is.NoError(err)
+	is.NotContains(string(rawData), "hello world")
+
+	// read back decrypted
+	got, err := store.Get(ctx, id)
+	is.NoError(err)
+	defer got.Close()
+	data, err := io.ReadAll(got)
+	is.NoError(err)
+	is.Equal("hello world", string(data))
+
+	_, err = store.Get(ctx, "missing")
+	is.ErrorIs(err, storage.ErrFileNotFound)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	key, err := encrypt.NewKey()
+	require.NoError(t, err)
+	stores := map[string]storage.Storage{
+		"memory":     storage.NewMemStore(),
+		"filesystem": storage.NewFilesystem(afero.NewMemMapFs(), "/store"),
+		"encrypted":  storage.NewEncrypted(storage.NewMemStore(), key),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			is := require.New(t)
+
+			id, err := store.Store(ctx, "party/photo.jpg", strings.NewReader("picture"))
+			is.NoError(err)
+
+			deleted, err := storage.Delete(ctx, store, id)
+			is.NoError(err)
+			is.True(deleted)
+			_, err = store.Get(ctx, id)
+			is.ErrorIs(err, storage.ErrFileNotFound)
+
+			// already gone
+			_, err = storage.Delete(ctx, store, id)
+			is.NoError(err)
+		})
+	}
+}
+
+// a stand-in for s3 which accepts any request, as presigned
+// URLs are used by clients without the s3 client
+type fakeS3 struct {
+	objects map[string][]byte
+	sync.Mutex
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Disposition", r.URL.Query().Get("response-content-disposition"))
+		w.Write(data)
+	case http.MethodHead:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	}
+}
+
+func TestS3Presigned(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+	ctx := context.Background()
+
+	srv := httptest.NewServer(&fakeS3{objects: make(map[string][]byte)})
+	t.Cleanup(srv.Close)
+
+	cfg := &storage.S3Config{
+		Region:    "us-east-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+		Bucket:    "test",
+		Endpoint:  strings.TrimPrefix(srv.URL, "http://"),
+		Insecure:  true,
+	}
+	_, ok := storage.NewS3Store(cfg).(storage.Presigner)
+	is.False(ok, "only presigns when configured to")
+
+	cfg.Presigned = true
+	presigner, ok := storage.NewS3Store(cfg).(storage.Presigner)
+	is.True(ok)
+
+	id, putUrl, err := presigner.PresignPut(ctx, "file.jpg", time.Minute)
+	is.NoError(err)
+	is.NotEmpty(id)
+	is.Contains(putUrl, "/test/"+id)
+	req, err := http.NewRequest(http.MethodPut, putUrl, strings.NewReader("picture"))
+	is.NoError(err)
+	resp, err := http.DefaultClient.Do(req)
+	is.NoError(err)
+	resp.Body.Close()
+	is.Equal(http.StatusOK, resp.StatusCode)
+
+	size, err := presigner.Size(ctx, id)
+	is.NoError(err)
+	is.Equal(int64(len("picture")), size)
+	_, err = presigner.Size(ctx, "missing.jpg")
+	is.ErrorIs(err, storage.ErrFileNotFound)
+
+	getUrl, err := presigner.PresignGet(ctx, id, "file.jpg", time.Minute)
+	is.NoError(err)
+	resp, err = http.Get(getUrl)
+	is.NoError(err)
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	is.NoError(err)
+	is.Equal("picture", string(got))
+	is.Equal("attachment; filename=file.jpg", resp.Header.Get("Content-Disposition"))
+}
+
+// a stand-in for an ftp server, with just enough of it for the ftp store,
+// keeping track of each connections working directory
+type fakeFtp struct {
+	net.Listener
+	dirs  map[string]bool
+	files map[string][]byte
+	sync.Mutex
+}
+
+func newFakeFtp(t *testing.T, dirs ...string) *fakeFtp {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	f := &fakeFtp{Listener: l, dirs: map[string]bool{"/": true}, files: map[string][]byte{}}
+	for _, dir := range dirs {
+		f.dirs[dir] = true
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeFtp) serve(c net.Conn) {
+	defer c.Close()
+	conn := textproto.NewConn(c)
+	cwd := "/"
+	var data net.Listener
+	resolve := func(p string) string {
+		if path.IsAbs(p) {
+			return path.Clean(p)
+		}
+		return path.Join(cwd, p)
+	}
+	conn.PrintfLine("220 ready")
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+		f.Lock()
+		switch cmd {
+		case "USER":
+			conn.PrintfLine("331 password please")
+		case "PASS":
+			conn.PrintfLine("230 logged in")
+		case "TYPE":
+			conn.PrintfLine("200 ok")
+		case "PWD":
+			conn.PrintfLine(`257 "%s"`, cwd)
+		case "CWD":
+			if dir := resolve(arg); f.dirs[dir] {
+				cwd = dir
+				conn.PrintfLine("250 ok")
+			} else {
+				conn.PrintfLine("550 no such directory")
+			}
+		case "MKD":
+			f.dirs[resolve(arg)] = true
+			conn.PrintfLine("257 created")
+		case "EPSV":
+			if data, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+				conn.PrintfLine("425 can't open data connection")
+				break
+			}
+			conn.PrintfLine("229 passive (|||%d|)", data.Addr().(*net.TCPAddr).Port)
+		case "STOR":
+			conn.PrintfLine("150 ok")
+			dc, err := data.Accept()
+			if err != nil {
+				conn.PrintfLine("425 can't open data connection")
+				break
+			}
+			f.files[resolve(arg)], _ = io.ReadAll(dc)
+			dc.Close()
+			data.Close()
+			conn.PrintfLine("226 stored")
+		case "REIN":
+			conn.PrintfLine("220 ready")
+		case "QUIT":
+			conn.PrintfLine("221 bye")
+			f.Unlock()
+			return
+		default:
+			conn.PrintfLine("502 not implemented")
+		}
+		f.Unlock()
+	}
+}
+
+func TestFtpKeys(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+	ctx := context.Background()
+
+	server := newFakeFtp(t, "/home", "/home/uploads")
+	// relative to where the user logs in
+	store, err := storage.NewFtpStore(&storage.FtpConfig{
+		Address:   server.Addr().String(),
+		Username:  "user",
+		Password:  "123",
+		Directory: "home/uploads",
+	})
+	is.NoError(err)
+
+	for _, key := range []string{"party/2025/01/first.jpg", "party/2025/01/second.jpg", "top.jpg"} {
+		id, err := store.Store(ctx, key, strings.NewReader(key))
+		is.NoError(err)
+		is.Equal(key, id)
+	}
+
+	server.Lock()
+	defer server.Unlock()
+	is.Equal([]byte("party/2025/01/first.jpg"), server.files["/home/uploads/party/2025/01/first.jpg"])
+	is.Equal([]byte("party/2025/01/second.jpg"), server.files["/home/uploads/party/2025/01/second.jpg"])
+	is.Equal([]byte("top.jpg"), server.files["/home/uploads/top.jpg"])
+}
+
 type errReader struct{}
 
 func (x *errReader) Read(p []byte) (n int, err error) {
@@ -10,7 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
-	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,6 +23,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type StorageMigration_Status int32
+
+const (
+	StorageMigration_UNSPECIFIED StorageMigration_Status = 0
+	StorageMigration_RUNNING     StorageMigration_Status = 1
+	StorageMigration_COMPLETE    StorageMigration_Status = 2
+	StorageMigration_FAILED      StorageMigration_Status = 3
+)
+
+// Enum value maps for StorageMigration_Status.
+var (
+	StorageMigration_Status_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "RUNNING",
+		2: "COMPLETE",
+		3: "FAILED",
+	}
+	StorageMigration_Status_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"RUNNING":     1,
+		"COMPLETE":    2,
+		"FAILED":      3,
+	}
+)
+
+func (x StorageMigration_Status) Enum() *StorageMigration_Status {
+	p := new(StorageMigration_Status)
+	*p = x
+	return p
+}
+
+func (x StorageMigration_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StorageMigration_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_picture_v1_picture_proto_enumTypes[0].Descriptor()
+}
+
+func (StorageMigration_Status) Type() protoreflect.EnumType {
+	return &file_picture_v1_picture_proto_enumTypes[0]
+}
+
+func (x StorageMigration_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StorageMigration_Status.Descriptor instead.
+func (StorageMigration_Status) EnumDescriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{42, 0}
+}
+
 // Message representing an event
 type Event struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -45,10 +97,32 @@ type Event struct {
 	Storage isEvent_Storage `protobuf_oneof:"storage"`
 	// Whether the event is active
 	Active bool `protobuf:"varint,8,opt,name=active,proto3" json:"active,omitempty"`
-	// Password of the event
-	Password *wrapperspb.StringValue `protobuf:"bytes,9,opt,name=password,proto3" json:"password,omitempty"`
+	// Whether guests need the events password, or a guest link, to get into the event
+	PasswordProtected bool `protobuf:"varint,15,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
 	// Whether media is cached for the event
-	Cache         bool `protobuf:"varint,11,opt,name=cache,proto3" json:"cache,omitempty"`
+	Cache bool `protobuf:"varint,11,opt,name=cache,proto3" json:"cache,omitempty"`
+	// Whether media is encrypted before being put in the events storage
+	Encrypted bool `protobuf:"varint,12,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	// Whether media is uploaded and downloaded directly with the events storage
+	Presigned bool `protobuf:"varint,13,opt,name=presigned,proto3" json:"presigned,omitempty"`
+	// Layout of the keys media is stored under in the events storage
+	KeyTemplate string `protobuf:"bytes,14,opt,name=key_template,json=keyTemplate,proto3" json:"key_template,omitempty"`
+	// Role of the user on the event (owner, manager or moderator), only set when listing their events
+	Role string `protobuf:"bytes,16,opt,name=role,proto3" json:"role,omitempty"`
+	// When the event is set live, if scheduled
+	StartsAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	// When the event stops being live, if scheduled
+	EndsAt *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	// When the events gallery is hidden from guests and the event archived, if scheduled
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Whether the event has been archived, its gallery is only visible to its owner and members
+	Archived bool `protobuf:"varint,20,opt,name=archived,proto3" json:"archived,omitempty"`
+	// Slug of the event
+	Slug string `protobuf:"bytes,21,opt,name=slug,proto3" json:"slug,omitempty"`
+	// When the event was put in the trash, if it's there
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// How the events gallery looks
+	Branding      *Branding `protobuf:"bytes,23,opt,name=branding,proto3" json:"branding,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -161,11 +235,11 @@ func (x *Event) GetActive() bool {
 	return false
 }
 
-func (x *Event) GetPassword() *wrapperspb.StringValue {
+func (x *Event) GetPasswordProtected() bool {
 	if x != nil {
-		return x.Password
+		return x.PasswordProtected
 	}
-	return nil
+	return false
 }
 
 func (x *Event) GetCache() bool {
@@ -175,6 +249,83 @@ func (x *Event) GetCache() bool {
 	return false
 }
 
+func (x *Event) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *Event) GetPresigned() bool {
+	if x != nil {
+		return x.Presigned
+	}
+	return false
+}
+
+func (x *Event) GetKeyTemplate() string {
+	if x != nil {
+		return x.KeyTemplate
+	}
+	return ""
+}
+
+func (x *Event) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Event) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Event) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Event) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Event) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Event) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Event) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Event) GetBranding() *Branding {
+	if x != nil {
+		return x.Branding
+	}
+	return nil
+}
+
 type isEvent_Storage interface {
 	isEvent_Storage()
 }
@@ -203,6 +354,97 @@ func (*Event_GoogleDrive) isEvent_Storage() {}
 
 func (*Event_Ftp) isEvent_Storage() {}
 
+// How an events gallery looks, empty fields keep the galleries usual look
+type Branding struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Colour of the galleries buttons and links, as #rrggbb
+	PrimaryColor string `protobuf:"bytes,1,opt,name=primary_color,json=primaryColor,proto3" json:"primary_color,omitempty"`
+	// Colour of the galleries background, as #rrggbb
+	BackgroundColor string `protobuf:"bytes,2,opt,name=background_color,json=backgroundColor,proto3" json:"background_color,omitempty"`
+	// Font the galleries heading is in, one of the fonts eventpix comes with
+	Font string `protobuf:"bytes,3,opt,name=font,proto3" json:"font,omitempty"`
+	// Whether the event has a cover image, which is uploaded through the web UI
+	CoverImage bool `protobuf:"varint,4,opt,name=cover_image,json=coverImage,proto3" json:"cover_image,omitempty"`
+	// Markdown shown to guests above the gallery
+	WelcomeText string `protobuf:"bytes,5,opt,name=welcome_text,json=welcomeText,proto3" json:"welcome_text,omitempty"`
+	// Text shown at the bottom of the gallery
+	Footer        string `protobuf:"bytes,6,opt,name=footer,proto3" json:"footer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Branding) Reset() {
+	*x = Branding{}
+	mi := &file_picture_v1_picture_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Branding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Branding) ProtoMessage() {}
+
+func (x *Branding) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Branding.ProtoReflect.Descriptor instead.
+func (*Branding) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Branding) GetPrimaryColor() string {
+	if x != nil {
+		return x.PrimaryColor
+	}
+	return ""
+}
+
+func (x *Branding) GetBackgroundColor() string {
+	if x != nil {
+		return x.BackgroundColor
+	}
+	return ""
+}
+
+func (x *Branding) GetFont() string {
+	if x != nil {
+		return x.Font
+	}
+	return ""
+}
+
+func (x *Branding) GetCoverImage() bool {
+	if x != nil {
+		return x.CoverImage
+	}
+	return false
+}
+
+func (x *Branding) GetWelcomeText() string {
+	if x != nil {
+		return x.WelcomeText
+	}
+	return ""
+}
+
+func (x *Branding) GetFooter() string {
+	if x != nil {
+		return x.Footer
+	}
+	return ""
+}
+
 // Wrapper around a list of FileInfo
 type FileInfosValue struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -213,7 +455,7 @@ type FileInfosValue struct {
 
 func (x *FileInfosValue) Reset() {
 	*x = FileInfosValue{}
-	mi := &file_picture_v1_picture_proto_msgTypes[1]
+	mi := &file_picture_v1_picture_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -225,7 +467,7 @@ func (x *FileInfosValue) String() string {
 func (*FileInfosValue) ProtoMessage() {}
 
 func (x *FileInfosValue) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[1]
+	mi := &file_picture_v1_picture_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -238,7 +480,7 @@ func (x *FileInfosValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileInfosValue.ProtoReflect.Descriptor instead.
 func (*FileInfosValue) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{1}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *FileInfosValue) GetValue() []*FileInfo {
@@ -258,14 +500,16 @@ type FileInfo struct {
 	// Whether the file is a video or not
 	Video bool `protobuf:"varint,3,opt,name=video,proto3" json:"video,omitempty"`
 	// ID of the event the file belongs to
-	EventId       uint64 `protobuf:"varint,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventId uint64 `protobuf:"varint,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Whether a moderator has hidden the file from the gallery
+	Hidden        bool `protobuf:"varint,5,opt,name=hidden,proto3" json:"hidden,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FileInfo) Reset() {
 	*x = FileInfo{}
-	mi := &file_picture_v1_picture_proto_msgTypes[2]
+	mi := &file_picture_v1_picture_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -277,7 +521,7 @@ func (x *FileInfo) String() string {
 func (*FileInfo) ProtoMessage() {}
 
 func (x *FileInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[2]
+	mi := &file_picture_v1_picture_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -290,7 +534,7 @@ func (x *FileInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
 func (*FileInfo) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{2}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *FileInfo) GetId() string {
@@ -321,6 +565,13 @@ func (x *FileInfo) GetEventId() uint64 {
 	return 0
 }
 
+func (x *FileInfo) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
 // Create an event where photos will be taken and associated with
 type CreateEventRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -342,14 +593,33 @@ type CreateEventRequest struct {
 	// Password of the event
 	Password string `protobuf:"bytes,7,opt,name=password,proto3" json:"password,omitempty"`
 	// Whether to cache media in the event
-	Cache         bool `protobuf:"varint,9,opt,name=cache,proto3" json:"cache,omitempty"`
+	Cache bool `protobuf:"varint,9,opt,name=cache,proto3" json:"cache,omitempty"`
+	// Whether to encrypt media before putting it in the events storage
+	Encrypt bool `protobuf:"varint,10,opt,name=encrypt,proto3" json:"encrypt,omitempty"`
+	// Layout of the keys media is stored under in the events storage,
+	// made up of {event-slug}, {yyyy}, {mm}, {dd}, {uuid} and {name}
+	KeyTemplate string `protobuf:"bytes,11,opt,name=key_template,json=keyTemplate,proto3" json:"key_template,omitempty"`
+	// When to set the event live, optional
+	StartsAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	// When the event stops being live, optional
+	EndsAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	// When to hide the events gallery from guests and archive it, optional
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Settings to start from, any given above are used instead of them. The media
+	// of a duplicated event isn't copied, only its storage, password and cache settings
+	//
+	// Types that are valid to be assigned to From:
+	//
+	//	*CreateEventRequest_TemplateId
+	//	*CreateEventRequest_DuplicateEventId
+	From          isCreateEventRequest_From `protobuf_oneof:"from"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateEventRequest) Reset() {
 	*x = CreateEventRequest{}
-	mi := &file_picture_v1_picture_proto_msgTypes[3]
+	mi := &file_picture_v1_picture_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -361,7 +631,7 @@ func (x *CreateEventRequest) String() string {
 func (*CreateEventRequest) ProtoMessage() {}
 
 func (x *CreateEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[3]
+	mi := &file_picture_v1_picture_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,7 +644,7 @@ func (x *CreateEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateEventRequest.ProtoReflect.Descriptor instead.
 func (*CreateEventRequest) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{3}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateEventRequest) GetName() string {
@@ -455,6 +725,66 @@ func (x *CreateEventRequest) GetCache() bool {
 	return false
 }
 
+func (x *CreateEventRequest) GetEncrypt() bool {
+	if x != nil {
+		return x.Encrypt
+	}
+	return false
+}
+
+func (x *CreateEventRequest) GetKeyTemplate() string {
+	if x != nil {
+		return x.KeyTemplate
+	}
+	return ""
+}
+
+func (x *CreateEventRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *CreateEventRequest) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *CreateEventRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateEventRequest) GetFrom() isCreateEventRequest_From {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *CreateEventRequest) GetTemplateId() uint64 {
+	if x != nil {
+		if x, ok := x.From.(*CreateEventRequest_TemplateId); ok {
+			return x.TemplateId
+		}
+	}
+	return 0
+}
+
+func (x *CreateEventRequest) GetDuplicateEventId() uint64 {
+	if x != nil {
+		if x, ok := x.From.(*CreateEventRequest_DuplicateEventId); ok {
+			return x.DuplicateEventId
+		}
+	}
+	return 0
+}
+
 type isCreateEventRequest_Storage interface {
 	isCreateEventRequest_Storage()
 }
@@ -483,6 +813,24 @@ func (*CreateEventRequest_GoogleDrive) isCreateEventRequest_Storage() {}
 
 func (*CreateEventRequest_Ftp) isCreateEventRequest_Storage() {}
 
+type isCreateEventRequest_From interface {
+	isCreateEventRequest_From()
+}
+
+type CreateEventRequest_TemplateId struct {
+	// One of the users templates
+	TemplateId uint64 `protobuf:"varint,15,opt,name=template_id,json=templateId,proto3,oneof"`
+}
+
+type CreateEventRequest_DuplicateEventId struct {
+	// Another event the user owns
+	DuplicateEventId uint64 `protobuf:"varint,16,opt,name=duplicate_event_id,json=duplicateEventId,proto3,oneof"`
+}
+
+func (*CreateEventRequest_TemplateId) isCreateEventRequest_From() {}
+
+func (*CreateEventRequest_DuplicateEventId) isCreateEventRequest_From() {}
+
 // Response from successfully creating an event
 type CreateEventResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -494,7 +842,7 @@ type CreateEventResponse struct {
 
 func (x *CreateEventResponse) Reset() {
 	*x = CreateEventResponse{}
-	mi := &file_picture_v1_picture_proto_msgTypes[4]
+	mi := &file_picture_v1_picture_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -506,7 +854,7 @@ func (x *CreateEventResponse) String() string {
 func (*CreateEventResponse) ProtoMessage() {}
 
 func (x *CreateEventResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[4]
+	mi := &file_picture_v1_picture_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,7 +867,7 @@ func (x *CreateEventResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateEventResponse.ProtoReflect.Descriptor instead.
 func (*CreateEventResponse) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{4}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreateEventResponse) GetId() uint64 {
@@ -538,7 +886,7 @@ type GetEventsRequest struct {
 
 func (x *GetEventsRequest) Reset() {
 	*x = GetEventsRequest{}
-	mi := &file_picture_v1_picture_proto_msgTypes[5]
+	mi := &file_picture_v1_picture_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -550,7 +898,7 @@ func (x *GetEventsRequest) String() string {
 func (*GetEventsRequest) ProtoMessage() {}
 
 func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[5]
+	mi := &file_picture_v1_picture_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -563,7 +911,7 @@ func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEventsRequest.ProtoReflect.Descriptor instead.
 func (*GetEventsRequest) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{5}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{6}
 }
 
 // Message containings events queries
@@ -577,7 +925,7 @@ type GetEventsResponse struct {
 
 func (x *GetEventsResponse) Reset() {
 	*x = GetEventsResponse{}
-	mi := &file_picture_v1_picture_proto_msgTypes[6]
+	mi := &file_picture_v1_picture_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -589,7 +937,7 @@ func (x *GetEventsResponse) String() string {
 func (*GetEventsResponse) ProtoMessage() {}
 
 func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[6]
+	mi := &file_picture_v1_picture_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -602,7 +950,7 @@ func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEventsResponse.ProtoReflect.Descriptor instead.
 func (*GetEventsResponse) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{6}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetEventsResponse) GetEvents() []*Event {
@@ -628,7 +976,7 @@ type GetEventRequest struct {
 
 func (x *GetEventRequest) Reset() {
 	*x = GetEventRequest{}
-	mi := &file_picture_v1_picture_proto_msgTypes[7]
+	mi := &file_picture_v1_picture_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -640,7 +988,7 @@ func (x *GetEventRequest) String() string {
 func (*GetEventRequest) ProtoMessage() {}
 
 func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[7]
+	mi := &file_picture_v1_picture_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -653,7 +1001,7 @@ func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
 func (*GetEventRequest) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{7}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetEventRequest) GetValue() isGetEventRequest_Value {
@@ -706,7 +1054,7 @@ type GetActiveEventRequest struct {
 
 func (x *GetActiveEventRequest) Reset() {
 	*x = GetActiveEventRequest{}
-	mi := &file_picture_v1_picture_proto_msgTypes[8]
+	mi := &file_picture_v1_picture_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -718,7 +1066,7 @@ func (x *GetActiveEventRequest) String() string {
 func (*GetActiveEventRequest) ProtoMessage() {}
 
 func (x *GetActiveEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[8]
+	mi := &file_picture_v1_picture_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -731,7 +1079,7 @@ func (x *GetActiveEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetActiveEventRequest.ProtoReflect.Descriptor instead.
 func (*GetActiveEventRequest) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{8}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{9}
 }
 
 // Request to set the active event
@@ -745,7 +1093,7 @@ type SetActiveEventRequest struct {
 
 func (x *SetActiveEventRequest) Reset() {
 	*x = SetActiveEventRequest{}
-	mi := &file_picture_v1_picture_proto_msgTypes[9]
+	mi := &file_picture_v1_picture_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -757,7 +1105,7 @@ func (x *SetActiveEventRequest) String() string {
 func (*SetActiveEventRequest) ProtoMessage() {}
 
 func (x *SetActiveEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[9]
+	mi := &file_picture_v1_picture_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -770,7 +1118,7 @@ func (x *SetActiveEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetActiveEventRequest.ProtoReflect.Descriptor instead.
 func (*SetActiveEventRequest) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{9}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SetActiveEventRequest) GetId() uint64 {
@@ -789,7 +1137,7 @@ type GetEventResponse struct {
 
 func (x *GetEventResponse) Reset() {
 	*x = GetEventResponse{}
-	mi := &file_picture_v1_picture_proto_msgTypes[10]
+	mi := &file_picture_v1_picture_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -801,7 +1149,7 @@ func (x *GetEventResponse) String() string {
 func (*GetEventResponse) ProtoMessage() {}
 
 func (x *GetEventResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[10]
+	mi := &file_picture_v1_picture_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -814,7 +1162,7 @@ func (x *GetEventResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEventResponse.ProtoReflect.Descriptor instead.
 func (*GetEventResponse) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{10}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetEventResponse) GetEvent() *Event {
@@ -834,7 +1182,7 @@ type SetEventLiveRequest struct {
 
 func (x *SetEventLiveRequest) Reset() {
 	*x = SetEventLiveRequest{}
-	mi := &file_picture_v1_picture_proto_msgTypes[11]
+	mi := &file_picture_v1_picture_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -846,7 +1194,7 @@ func (x *SetEventLiveRequest) String() string {
 func (*SetEventLiveRequest) ProtoMessage() {}
 
 func (x *SetEventLiveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[11]
+	mi := &file_picture_v1_picture_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -859,7 +1207,7 @@ func (x *SetEventLiveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetEventLiveRequest.ProtoReflect.Descriptor instead.
 func (*SetEventLiveRequest) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{11}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SetEventLiveRequest) GetId() uint64 {
@@ -886,7 +1234,7 @@ type SetEventLiveResponse struct {
 
 func (x *SetEventLiveResponse) Reset() {
 	*x = SetEventLiveResponse{}
-	mi := &file_picture_v1_picture_proto_msgTypes[12]
+	mi := &file_picture_v1_picture_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -898,7 +1246,7 @@ func (x *SetEventLiveResponse) String() string {
 func (*SetEventLiveResponse) ProtoMessage() {}
 
 func (x *SetEventLiveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[12]
+	mi := &file_picture_v1_picture_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -911,7 +1259,7 @@ func (x *SetEventLiveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetEventLiveResponse.ProtoReflect.Descriptor instead.
 func (*SetEventLiveResponse) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{12}
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SetEventLiveResponse) GetEvent() *Event {
@@ -921,28 +1269,1350 @@ func (x *SetEventLiveResponse) GetEvent() *Event {
 	return nil
 }
 
-type DeleteEventRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+// Schedules when the event is live and when it's archived. Unset times are unscheduled
+type SetEventScheduleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When to set the event live
+	StartsAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	// When the event stops being live
+	EndsAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	// When to hide the events gallery from guests and archive it
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEventScheduleRequest) Reset() {
+	*x = SetEventScheduleRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEventScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEventScheduleRequest) ProtoMessage() {}
+
+func (x *SetEventScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEventScheduleRequest.ProtoReflect.Descriptor instead.
+func (*SetEventScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetEventScheduleRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetEventScheduleRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *SetEventScheduleRequest) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *SetEventScheduleRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type SetEventScheduleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The updated event
+	Event         *Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEventScheduleResponse) Reset() {
+	*x = SetEventScheduleResponse{}
+	mi := &file_picture_v1_picture_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEventScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEventScheduleResponse) ProtoMessage() {}
+
+func (x *SetEventScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEventScheduleResponse.ProtoReflect.Descriptor instead.
+func (*SetEventScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetEventScheduleResponse) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// Changes an events settings after it's been created, only the fields set are changed
+type UpdateEventRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// New name of the event
+	Name *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// New slug of the event, the old slug keeps redirecting to the event
+	Slug *string `protobuf:"bytes,3,opt,name=slug,proto3,oneof" json:"slug,omitempty"`
+	// New password guests need to get into the event, or empty to no longer need one
+	Password *string `protobuf:"bytes,4,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	// Whether to cache media in the event
+	Cache *bool `protobuf:"varint,5,opt,name=cache,proto3,oneof" json:"cache,omitempty"`
+	// New credentials for the events storage, which must be the same type of storage.
+	// To move the event to another storage migrate it instead
+	//
+	// Types that are valid to be assigned to Credentials:
+	//
+	//	*UpdateEventRequest_S3Credentials
+	//	*UpdateEventRequest_FtpCredentials
+	Credentials   isUpdateEventRequest_Credentials `protobuf_oneof:"credentials"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEventRequest) Reset() {
+	*x = UpdateEventRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEventRequest) ProtoMessage() {}
+
+func (x *UpdateEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEventRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEventRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateEventRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateEventRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetSlug() string {
+	if x != nil && x.Slug != nil {
+		return *x.Slug
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetCache() bool {
+	if x != nil && x.Cache != nil {
+		return *x.Cache
+	}
+	return false
+}
+
+func (x *UpdateEventRequest) GetCredentials() isUpdateEventRequest_Credentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetS3Credentials() *S3Credentials {
+	if x != nil {
+		if x, ok := x.Credentials.(*UpdateEventRequest_S3Credentials); ok {
+			return x.S3Credentials
+		}
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetFtpCredentials() *FtpCredentials {
+	if x != nil {
+		if x, ok := x.Credentials.(*UpdateEventRequest_FtpCredentials); ok {
+			return x.FtpCredentials
+		}
+	}
+	return nil
+}
+
+type isUpdateEventRequest_Credentials interface {
+	isUpdateEventRequest_Credentials()
+}
+
+type UpdateEventRequest_S3Credentials struct {
+	S3Credentials *S3Credentials `protobuf:"bytes,6,opt,name=s3_credentials,json=s3Credentials,proto3,oneof"`
+}
+
+type UpdateEventRequest_FtpCredentials struct {
+	FtpCredentials *FtpCredentials `protobuf:"bytes,7,opt,name=ftp_credentials,json=ftpCredentials,proto3,oneof"`
+}
+
+func (*UpdateEventRequest_S3Credentials) isUpdateEventRequest_Credentials() {}
+
+func (*UpdateEventRequest_FtpCredentials) isUpdateEventRequest_Credentials() {}
+
+type UpdateEventResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The updated event
+	Event         *Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEventResponse) Reset() {
+	*x = UpdateEventResponse{}
+	mi := &file_picture_v1_picture_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEventResponse) ProtoMessage() {}
+
+func (x *UpdateEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEventResponse.ProtoReflect.Descriptor instead.
+func (*UpdateEventResponse) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateEventResponse) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// Replaces how the events gallery looks
+type SetEventBrandingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The events new branding, its cover image is kept unless removed
+	Branding *Branding `protobuf:"bytes,2,opt,name=branding,proto3" json:"branding,omitempty"`
+	// Whether to remove the events cover image
+	RemoveCoverImage bool `protobuf:"varint,3,opt,name=remove_cover_image,json=removeCoverImage,proto3" json:"remove_cover_image,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetEventBrandingRequest) Reset() {
+	*x = SetEventBrandingRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEventBrandingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEventBrandingRequest) ProtoMessage() {}
+
+func (x *SetEventBrandingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEventBrandingRequest.ProtoReflect.Descriptor instead.
+func (*SetEventBrandingRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetEventBrandingRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetEventBrandingRequest) GetBranding() *Branding {
+	if x != nil {
+		return x.Branding
+	}
+	return nil
+}
+
+func (x *SetEventBrandingRequest) GetRemoveCoverImage() bool {
+	if x != nil {
+		return x.RemoveCoverImage
+	}
+	return false
+}
+
+type SetEventBrandingResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The updated event
+	Event         *Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEventBrandingResponse) Reset() {
+	*x = SetEventBrandingResponse{}
+	mi := &file_picture_v1_picture_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEventBrandingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEventBrandingResponse) ProtoMessage() {}
+
+func (x *SetEventBrandingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEventBrandingResponse.ProtoReflect.Descriptor instead.
+func (*SetEventBrandingResponse) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetEventBrandingResponse) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type DeleteEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEventRequest) Reset() {
+	*x = DeleteEventRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEventRequest) ProtoMessage() {}
+
+func (x *DeleteEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEventRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEventRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteEventRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Message to get the events in the trash the user owns
+type GetTrashedEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrashedEventsRequest) Reset() {
+	*x = GetTrashedEventsRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrashedEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrashedEventsRequest) ProtoMessage() {}
+
+func (x *GetTrashedEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrashedEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetTrashedEventsRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{21}
+}
+
+// Message to take an event back out of the trash
+type RestoreEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreEventRequest) Reset() {
+	*x = RestoreEventRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreEventRequest) ProtoMessage() {}
+
+func (x *RestoreEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreEventRequest.ProtoReflect.Descriptor instead.
+func (*RestoreEventRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RestoreEventRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RestoreEventResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The restored event
+	Event         *Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreEventResponse) Reset() {
+	*x = RestoreEventResponse{}
+	mi := &file_picture_v1_picture_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreEventResponse) ProtoMessage() {}
+
+func (x *RestoreEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreEventResponse.ProtoReflect.Descriptor instead.
+func (*RestoreEventResponse) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RestoreEventResponse) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// Settings saved from an event which new events can be created with
+type EventTemplate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifier of the template
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the template
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Whether media is cached for events
+	Cache bool `protobuf:"varint,3,opt,name=cache,proto3" json:"cache,omitempty"`
+	// Whether events are created with the password of the event it was saved from
+	PasswordProtected bool `protobuf:"varint,4,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	// Whether media is encrypted before being put in events storage
+	Encrypted bool `protobuf:"varint,5,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	// Layout of the keys media is stored under in events storage
+	KeyTemplate string `protobuf:"bytes,6,opt,name=key_template,json=keyTemplate,proto3" json:"key_template,omitempty"`
+	// Kind of storage events are created with, e.g. S3
+	StorageType   string `protobuf:"bytes,7,opt,name=storage_type,json=storageType,proto3" json:"storage_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventTemplate) Reset() {
+	*x = EventTemplate{}
+	mi := &file_picture_v1_picture_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventTemplate) ProtoMessage() {}
+
+func (x *EventTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventTemplate.ProtoReflect.Descriptor instead.
+func (*EventTemplate) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EventTemplate) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EventTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EventTemplate) GetCache() bool {
+	if x != nil {
+		return x.Cache
+	}
+	return false
+}
+
+func (x *EventTemplate) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+func (x *EventTemplate) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *EventTemplate) GetKeyTemplate() string {
+	if x != nil {
+		return x.KeyTemplate
+	}
+	return ""
+}
+
+func (x *EventTemplate) GetStorageType() string {
+	if x != nil {
+		return x.StorageType
+	}
+	return ""
+}
+
+// Message to save an events settings as a template
+type SaveEventTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event to save the settings of
+	EventId uint64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Name of the template
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveEventTemplateRequest) Reset() {
+	*x = SaveEventTemplateRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveEventTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveEventTemplateRequest) ProtoMessage() {}
+
+func (x *SaveEventTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveEventTemplateRequest.ProtoReflect.Descriptor instead.
+func (*SaveEventTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SaveEventTemplateRequest) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SaveEventTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Message to get the users templates
+type GetEventTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventTemplatesRequest) Reset() {
+	*x = GetEventTemplatesRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventTemplatesRequest) ProtoMessage() {}
+
+func (x *GetEventTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*GetEventTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{26}
+}
+
+type GetEventTemplatesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The users templates
+	Templates     []*EventTemplate `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventTemplatesResponse) Reset() {
+	*x = GetEventTemplatesResponse{}
+	mi := &file_picture_v1_picture_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventTemplatesResponse) ProtoMessage() {}
+
+func (x *GetEventTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*GetEventTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetEventTemplatesResponse) GetTemplates() []*EventTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// Message to delete one of the users templates
+type DeleteEventTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEventTemplateRequest) Reset() {
+	*x = DeleteEventTemplateRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEventTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEventTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteEventTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEventTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEventTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteEventTemplateRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Message to upload a file to an event
+type UploadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event the file is a part of
+	EventId uint64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// The file to upload
+	File          *File `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadRequest) Reset() {
+	*x = UploadRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadRequest) ProtoMessage() {}
+
+func (x *UploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
+func (*UploadRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UploadRequest) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *UploadRequest) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+// File to upload
+type File struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the file
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The files data in bytes
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *File) Reset() {
+	*x = File{}
+	mi := &file_picture_v1_picture_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *File) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*File) ProtoMessage() {}
+
+func (x *File) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use File.ProtoReflect.Descriptor instead.
+func (*File) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *File) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *File) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadResponse) Reset() {
+	*x = UploadResponse{}
+	mi := &file_picture_v1_picture_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadResponse) ProtoMessage() {}
+
+func (x *UploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadResponse.ProtoReflect.Descriptor instead.
+func (*UploadResponse) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{31}
+}
+
+// Request for a URL to upload a file straight to the events storage
+type PresignUploadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event the file is a part of
+	EventId uint64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// The name of the file
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Content type of the file
+	ContentType   string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PresignUploadRequest) Reset() {
+	*x = PresignUploadRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresignUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignUploadRequest) ProtoMessage() {}
+
+func (x *PresignUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignUploadRequest.ProtoReflect.Descriptor instead.
+func (*PresignUploadRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PresignUploadRequest) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *PresignUploadRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PresignUploadRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type PresignUploadResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID the file will be stored as
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// URL to PUT the files data to
+	Url           string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PresignUploadResponse) Reset() {
+	*x = PresignUploadResponse{}
+	mi := &file_picture_v1_picture_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresignUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignUploadResponse) ProtoMessage() {}
+
+func (x *PresignUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignUploadResponse.ProtoReflect.Descriptor instead.
+func (*PresignUploadResponse) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PresignUploadResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PresignUploadResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Request to add a file uploaded straight to the events storage to the event
+type CompleteUploadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event the file is a part of
+	EventId uint64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// ID the file was stored as
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// The name of the file
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Content type of the file
+	ContentType string `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Size of the file in bytes, as reported by the uploader. Ignored, the size in the storage is used
+	Size          int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteUploadRequest) Reset() {
+	*x = CompleteUploadRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteUploadRequest) ProtoMessage() {}
+
+func (x *CompleteUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteUploadRequest.ProtoReflect.Descriptor instead.
+func (*CompleteUploadRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CompleteUploadRequest) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *CompleteUploadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CompleteUploadRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CompleteUploadRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *CompleteUploadRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type GetThumbnailsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event to query thumbnails for
+	EventId uint64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Limit the number of results
+	Limit int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Offset to search from
+	Offset int64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Include thumbnails of media hidden from the gallery
+	IncludeHidden bool `protobuf:"varint,4,opt,name=include_hidden,json=includeHidden,proto3" json:"include_hidden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThumbnailsRequest) Reset() {
+	*x = GetThumbnailsRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThumbnailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThumbnailsRequest) ProtoMessage() {}
+
+func (x *GetThumbnailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThumbnailsRequest.ProtoReflect.Descriptor instead.
+func (*GetThumbnailsRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetThumbnailsRequest) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *GetThumbnailsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetThumbnailsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetThumbnailsRequest) GetIncludeHidden() bool {
+	if x != nil {
+		return x.IncludeHidden
+	}
+	return false
+}
+
+type GetThumbnailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Thumbnails    []*Thumbnail           `protobuf:"bytes,1,rep,name=thumbnails,proto3" json:"thumbnails,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThumbnailsResponse) Reset() {
+	*x = GetThumbnailsResponse{}
+	mi := &file_picture_v1_picture_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThumbnailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThumbnailsResponse) ProtoMessage() {}
+
+func (x *GetThumbnailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThumbnailsResponse.ProtoReflect.Descriptor instead.
+func (*GetThumbnailsResponse) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetThumbnailsResponse) GetThumbnails() []*Thumbnail {
+	if x != nil {
+		return x.Thumbnails
+	}
+	return nil
+}
+
+type Thumbnail struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the thumbnail
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the thumbnail file
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// File information the thumbnail is for.
+	FileInfo *FileInfo `protobuf:"bytes,3,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	// ID of the event the thumbnail belongs to
+	EventId       uint64 `protobuf:"varint,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteEventRequest) Reset() {
-	*x = DeleteEventRequest{}
-	mi := &file_picture_v1_picture_proto_msgTypes[13]
+func (x *Thumbnail) Reset() {
+	*x = Thumbnail{}
+	mi := &file_picture_v1_picture_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteEventRequest) String() string {
+func (x *Thumbnail) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteEventRequest) ProtoMessage() {}
+func (*Thumbnail) ProtoMessage() {}
 
-func (x *DeleteEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[13]
+func (x *Thumbnail) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -953,44 +2623,67 @@ func (x *DeleteEventRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteEventRequest.ProtoReflect.Descriptor instead.
-func (*DeleteEventRequest) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use Thumbnail.ProtoReflect.Descriptor instead.
+func (*Thumbnail) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *DeleteEventRequest) GetId() uint64 {
+func (x *Thumbnail) GetId() string {
 	if x != nil {
 		return x.Id
 	}
+	return ""
+}
+
+func (x *Thumbnail) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Thumbnail) GetFileInfo() *FileInfo {
+	if x != nil {
+		return x.FileInfo
+	}
+	return nil
+}
+
+func (x *Thumbnail) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
 	return 0
 }
 
-// Message to upload a file to an event
-type UploadRequest struct {
+// Request to hide media from, or show it again in, the events gallery
+type HideMediaRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Event the file is a part of
+	// Event the media is a part of
 	EventId uint64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	// The file to upload
-	File          *File `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	// ID of the file to hide
+	FileId string `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// Whether to hide the media, or show it again
+	Hidden        bool `protobuf:"varint,3,opt,name=hidden,proto3" json:"hidden,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadRequest) Reset() {
-	*x = UploadRequest{}
-	mi := &file_picture_v1_picture_proto_msgTypes[14]
+func (x *HideMediaRequest) Reset() {
+	*x = HideMediaRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadRequest) String() string {
+func (x *HideMediaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadRequest) ProtoMessage() {}
+func (*HideMediaRequest) ProtoMessage() {}
 
-func (x *UploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[14]
+func (x *HideMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1001,51 +2694,58 @@ func (x *UploadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
-func (*UploadRequest) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use HideMediaRequest.ProtoReflect.Descriptor instead.
+func (*HideMediaRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *UploadRequest) GetEventId() uint64 {
+func (x *HideMediaRequest) GetEventId() uint64 {
 	if x != nil {
 		return x.EventId
 	}
 	return 0
 }
 
-func (x *UploadRequest) GetFile() *File {
+func (x *HideMediaRequest) GetFileId() string {
 	if x != nil {
-		return x.File
+		return x.FileId
 	}
-	return nil
+	return ""
 }
 
-// File to upload
-type File struct {
+func (x *HideMediaRequest) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+// Request to permanently delete media from an event and its storage
+type DeleteMediaRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The name of the file
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// The files data in bytes
-	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Event the media is a part of
+	EventId uint64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// ID of the file to delete
+	FileId        string `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *File) Reset() {
-	*x = File{}
-	mi := &file_picture_v1_picture_proto_msgTypes[15]
+func (x *DeleteMediaRequest) Reset() {
+	*x = DeleteMediaRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *File) String() string {
+func (x *DeleteMediaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*File) ProtoMessage() {}
+func (*DeleteMediaRequest) ProtoMessage() {}
 
-func (x *File) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[15]
+func (x *DeleteMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1056,46 +2756,58 @@ func (x *File) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use File.ProtoReflect.Descriptor instead.
-func (*File) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use DeleteMediaRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMediaRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *File) GetName() string {
+func (x *DeleteMediaRequest) GetEventId() uint64 {
 	if x != nil {
-		return x.Name
+		return x.EventId
 	}
-	return ""
+	return 0
 }
 
-func (x *File) GetData() []byte {
+func (x *DeleteMediaRequest) GetFileId() string {
 	if x != nil {
-		return x.Data
+		return x.FileId
 	}
-	return nil
+	return ""
 }
 
-type UploadResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// Request to move all of an events media to a new storage
+type MigrateEventStorageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event to migrate
+	EventId uint64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Storage to migrate the media to
+	//
+	// Types that are valid to be assigned to Storage:
+	//
+	//	*MigrateEventStorageRequest_Filesystem
+	//	*MigrateEventStorageRequest_S3
+	//	*MigrateEventStorageRequest_GoogleDrive
+	//	*MigrateEventStorageRequest_Ftp
+	Storage       isMigrateEventStorageRequest_Storage `protobuf_oneof:"storage"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadResponse) Reset() {
-	*x = UploadResponse{}
-	mi := &file_picture_v1_picture_proto_msgTypes[16]
+func (x *MigrateEventStorageRequest) Reset() {
+	*x = MigrateEventStorageRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadResponse) String() string {
+func (x *MigrateEventStorageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadResponse) ProtoMessage() {}
+func (*MigrateEventStorageRequest) ProtoMessage() {}
 
-func (x *UploadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[16]
+func (x *MigrateEventStorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1106,96 +2818,112 @@ func (x *UploadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadResponse.ProtoReflect.Descriptor instead.
-func (*UploadResponse) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{16}
-}
-
-type GetThumbnailsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Event to query thumbnails for
-	EventId uint64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	// Limit the number of results
-	Limit int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	// Offset to search from
-	Offset        int64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Deprecated: Use MigrateEventStorageRequest.ProtoReflect.Descriptor instead.
+func (*MigrateEventStorageRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *GetThumbnailsRequest) Reset() {
-	*x = GetThumbnailsRequest{}
-	mi := &file_picture_v1_picture_proto_msgTypes[17]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *MigrateEventStorageRequest) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
 }
 
-func (x *GetThumbnailsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *MigrateEventStorageRequest) GetStorage() isMigrateEventStorageRequest_Storage {
+	if x != nil {
+		return x.Storage
+	}
+	return nil
 }
 
-func (*GetThumbnailsRequest) ProtoMessage() {}
-
-func (x *GetThumbnailsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[17]
+func (x *MigrateEventStorageRequest) GetFilesystem() *Filesystem {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		if x, ok := x.Storage.(*MigrateEventStorageRequest_Filesystem); ok {
+			return x.Filesystem
 		}
-		return ms
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetThumbnailsRequest.ProtoReflect.Descriptor instead.
-func (*GetThumbnailsRequest) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{17}
+	return nil
 }
 
-func (x *GetThumbnailsRequest) GetEventId() uint64 {
+func (x *MigrateEventStorageRequest) GetS3() *S3 {
 	if x != nil {
-		return x.EventId
+		if x, ok := x.Storage.(*MigrateEventStorageRequest_S3); ok {
+			return x.S3
+		}
 	}
-	return 0
+	return nil
 }
 
-func (x *GetThumbnailsRequest) GetLimit() int64 {
+func (x *MigrateEventStorageRequest) GetGoogleDrive() *GoogleDrive {
 	if x != nil {
-		return x.Limit
+		if x, ok := x.Storage.(*MigrateEventStorageRequest_GoogleDrive); ok {
+			return x.GoogleDrive
+		}
 	}
-	return 0
+	return nil
 }
 
-func (x *GetThumbnailsRequest) GetOffset() int64 {
+func (x *MigrateEventStorageRequest) GetFtp() *Ftp {
 	if x != nil {
-		return x.Offset
+		if x, ok := x.Storage.(*MigrateEventStorageRequest_Ftp); ok {
+			return x.Ftp
+		}
 	}
-	return 0
+	return nil
 }
 
-type GetThumbnailsResponse struct {
+type isMigrateEventStorageRequest_Storage interface {
+	isMigrateEventStorageRequest_Storage()
+}
+
+type MigrateEventStorageRequest_Filesystem struct {
+	Filesystem *Filesystem `protobuf:"bytes,2,opt,name=filesystem,proto3,oneof"`
+}
+
+type MigrateEventStorageRequest_S3 struct {
+	S3 *S3 `protobuf:"bytes,3,opt,name=s3,proto3,oneof"`
+}
+
+type MigrateEventStorageRequest_GoogleDrive struct {
+	GoogleDrive *GoogleDrive `protobuf:"bytes,4,opt,name=googleDrive,proto3,oneof"`
+}
+
+type MigrateEventStorageRequest_Ftp struct {
+	Ftp *Ftp `protobuf:"bytes,5,opt,name=ftp,proto3,oneof"`
+}
+
+func (*MigrateEventStorageRequest_Filesystem) isMigrateEventStorageRequest_Storage() {}
+
+func (*MigrateEventStorageRequest_S3) isMigrateEventStorageRequest_Storage() {}
+
+func (*MigrateEventStorageRequest_GoogleDrive) isMigrateEventStorageRequest_Storage() {}
+
+func (*MigrateEventStorageRequest_Ftp) isMigrateEventStorageRequest_Storage() {}
+
+// Request to get the latest storage migration of an event
+type GetStorageMigrationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Thumbnails    []*Thumbnail           `protobuf:"bytes,1,rep,name=thumbnails,proto3" json:"thumbnails,omitempty"`
+	EventId       uint64                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetThumbnailsResponse) Reset() {
-	*x = GetThumbnailsResponse{}
-	mi := &file_picture_v1_picture_proto_msgTypes[18]
+func (x *GetStorageMigrationRequest) Reset() {
+	*x = GetStorageMigrationRequest{}
+	mi := &file_picture_v1_picture_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetThumbnailsResponse) String() string {
+func (x *GetStorageMigrationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetThumbnailsResponse) ProtoMessage() {}
+func (*GetStorageMigrationRequest) ProtoMessage() {}
 
-func (x *GetThumbnailsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[18]
+func (x *GetStorageMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1206,47 +2934,52 @@ func (x *GetThumbnailsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetThumbnailsResponse.ProtoReflect.Descriptor instead.
-func (*GetThumbnailsResponse) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use GetStorageMigrationRequest.ProtoReflect.Descriptor instead.
+func (*GetStorageMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *GetThumbnailsResponse) GetThumbnails() []*Thumbnail {
+func (x *GetStorageMigrationRequest) GetEventId() uint64 {
 	if x != nil {
-		return x.Thumbnails
+		return x.EventId
 	}
-	return nil
+	return 0
 }
 
-type Thumbnail struct {
+// Progress of migrating an events media between storages
+type StorageMigration struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// ID of the thumbnail
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Name of the thumbnail file
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	// File information the thumbnail is for.
-	FileInfo *FileInfo `protobuf:"bytes,3,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
-	// ID of the event the thumbnail belongs to
-	EventId       uint64 `protobuf:"varint,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// ID of the migration
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ID of the event being migrated
+	EventId uint64 `protobuf:"varint,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Current status of the migration
+	Status StorageMigration_Status `protobuf:"varint,3,opt,name=status,proto3,enum=picture.v1.StorageMigration_Status" json:"status,omitempty"`
+	// Number of files and thumbnails to copy
+	Total int64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// Number of files and thumbnails copied so far
+	Completed int64 `protobuf:"varint,5,opt,name=completed,proto3" json:"completed,omitempty"`
+	// Reason the migration failed
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Thumbnail) Reset() {
-	*x = Thumbnail{}
-	mi := &file_picture_v1_picture_proto_msgTypes[19]
+func (x *StorageMigration) Reset() {
+	*x = StorageMigration{}
+	mi := &file_picture_v1_picture_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Thumbnail) String() string {
+func (x *StorageMigration) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Thumbnail) ProtoMessage() {}
+func (*StorageMigration) ProtoMessage() {}
 
-func (x *Thumbnail) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_picture_proto_msgTypes[19]
+func (x *StorageMigration) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_picture_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1257,45 +2990,59 @@ func (x *Thumbnail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Thumbnail.ProtoReflect.Descriptor instead.
-func (*Thumbnail) Descriptor() ([]byte, []int) {
-	return file_picture_v1_picture_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use StorageMigration.ProtoReflect.Descriptor instead.
+func (*StorageMigration) Descriptor() ([]byte, []int) {
+	return file_picture_v1_picture_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *Thumbnail) GetId() string {
+func (x *StorageMigration) GetId() uint64 {
 	if x != nil {
 		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *Thumbnail) GetName() string {
+func (x *StorageMigration) GetEventId() uint64 {
 	if x != nil {
-		return x.Name
+		return x.EventId
 	}
-	return ""
+	return 0
 }
 
-func (x *Thumbnail) GetFileInfo() *FileInfo {
+func (x *StorageMigration) GetStatus() StorageMigration_Status {
 	if x != nil {
-		return x.FileInfo
+		return x.Status
 	}
-	return nil
+	return StorageMigration_UNSPECIFIED
 }
 
-func (x *Thumbnail) GetEventId() uint64 {
+func (x *StorageMigration) GetTotal() int64 {
 	if x != nil {
-		return x.EventId
+		return x.Total
+	}
+	return 0
+}
+
+func (x *StorageMigration) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
 	}
 	return 0
 }
 
+func (x *StorageMigration) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_picture_v1_picture_proto protoreflect.FileDescriptor
 
 const file_picture_v1_picture_proto_rawDesc = "" +
 	"\n" +
 	"\x18picture/v1/picture.proto\x12\n" +
-	"picture.v1\x1a\x18picture/v1/storage.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xab\x03\n" +
+	"picture.v1\x1a\x18picture/v1/storage.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe9\x06\n" +
 	"\x05Event\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -1309,17 +3056,40 @@ const file_picture_v1_picture_proto_rawDesc = "" +
 	"\vgoogleDrive\x18\a \x01(\v2\x17.picture.v1.GoogleDriveH\x00R\vgoogleDrive\x12#\n" +
 	"\x03ftp\x18\n" +
 	" \x01(\v2\x0f.picture.v1.FtpH\x00R\x03ftp\x12\x16\n" +
-	"\x06active\x18\b \x01(\bR\x06active\x128\n" +
-	"\bpassword\x18\t \x01(\v2\x1c.google.protobuf.StringValueR\bpassword\x12\x14\n" +
-	"\x05cache\x18\v \x01(\bR\x05cacheB\t\n" +
-	"\astorage\"<\n" +
+	"\x06active\x18\b \x01(\bR\x06active\x12-\n" +
+	"\x12password_protected\x18\x0f \x01(\bR\x11passwordProtected\x12\x14\n" +
+	"\x05cache\x18\v \x01(\bR\x05cache\x12\x1c\n" +
+	"\tencrypted\x18\f \x01(\bR\tencrypted\x12\x1c\n" +
+	"\tpresigned\x18\r \x01(\bR\tpresigned\x12!\n" +
+	"\fkey_template\x18\x0e \x01(\tR\vkeyTemplate\x12\x12\n" +
+	"\x04role\x18\x10 \x01(\tR\x04role\x127\n" +
+	"\tstarts_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1a\n" +
+	"\barchived\x18\x14 \x01(\bR\barchived\x12\x12\n" +
+	"\x04slug\x18\x15 \x01(\tR\x04slug\x129\n" +
+	"\n" +
+	"deleted_at\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x120\n" +
+	"\bbranding\x18\x17 \x01(\v2\x14.picture.v1.BrandingR\bbrandingB\t\n" +
+	"\astorageJ\x04\b\t\x10\n" +
+	"R\bpassword\"\xca\x01\n" +
+	"\bBranding\x12#\n" +
+	"\rprimary_color\x18\x01 \x01(\tR\fprimaryColor\x12)\n" +
+	"\x10background_color\x18\x02 \x01(\tR\x0fbackgroundColor\x12\x12\n" +
+	"\x04font\x18\x03 \x01(\tR\x04font\x12\x1f\n" +
+	"\vcover_image\x18\x04 \x01(\bR\n" +
+	"coverImage\x12!\n" +
+	"\fwelcome_text\x18\x05 \x01(\tR\vwelcomeText\x12\x16\n" +
+	"\x06footer\x18\x06 \x01(\tR\x06footer\"<\n" +
 	"\x0eFileInfosValue\x12*\n" +
-	"\x05value\x18\x01 \x03(\v2\x14.picture.v1.FileInfoR\x05value\"_\n" +
+	"\x05value\x18\x01 \x03(\v2\x14.picture.v1.FileInfoR\x05value\"w\n" +
 	"\bFileInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
 	"\x05video\x18\x03 \x01(\bR\x05video\x12\x19\n" +
-	"\bevent_id\x18\x04 \x01(\x04R\aeventId\"\xcb\x02\n" +
+	"\bevent_id\x18\x04 \x01(\x04R\aeventId\x12\x16\n" +
+	"\x06hidden\x18\x05 \x01(\bR\x06hidden\"\x8c\x05\n" +
 	"\x12CreateEventRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x12\n" +
@@ -1331,8 +3101,19 @@ const file_picture_v1_picture_proto_rawDesc = "" +
 	"\vgoogleDrive\x18\x06 \x01(\v2\x17.picture.v1.GoogleDriveH\x00R\vgoogleDrive\x12#\n" +
 	"\x03ftp\x18\b \x01(\v2\x0f.picture.v1.FtpH\x00R\x03ftp\x12\x1a\n" +
 	"\bpassword\x18\a \x01(\tR\bpassword\x12\x14\n" +
-	"\x05cache\x18\t \x01(\bR\x05cacheB\t\n" +
-	"\astorage\"%\n" +
+	"\x05cache\x18\t \x01(\bR\x05cache\x12\x18\n" +
+	"\aencrypt\x18\n" +
+	" \x01(\bR\aencrypt\x12!\n" +
+	"\fkey_template\x18\v \x01(\tR\vkeyTemplate\x127\n" +
+	"\tstarts_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12!\n" +
+	"\vtemplate_id\x18\x0f \x01(\x04H\x01R\n" +
+	"templateId\x12.\n" +
+	"\x12duplicate_event_id\x18\x10 \x01(\x04H\x01R\x10duplicateEventIdB\t\n" +
+	"\astorageB\x06\n" +
+	"\x04from\"%\n" +
 	"\x13CreateEventResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\"\x12\n" +
 	"\x10GetEventsRequest\">\n" +
@@ -1351,8 +3132,58 @@ const file_picture_v1_picture_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
 	"\x04live\x18\x02 \x01(\bR\x04live\"?\n" +
 	"\x14SetEventLiveResponse\x12'\n" +
+	"\x05event\x18\x01 \x01(\v2\x11.picture.v1.EventR\x05event\"\xd2\x01\n" +
+	"\x17SetEventScheduleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x127\n" +
+	"\tstarts_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"C\n" +
+	"\x18SetEventScheduleResponse\x12'\n" +
+	"\x05event\x18\x01 \x01(\v2\x11.picture.v1.EventR\x05event\"\xd5\x02\n" +
+	"\x12UpdateEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x01R\x04name\x88\x01\x01\x12\x17\n" +
+	"\x04slug\x18\x03 \x01(\tH\x02R\x04slug\x88\x01\x01\x12\x1f\n" +
+	"\bpassword\x18\x04 \x01(\tH\x03R\bpassword\x88\x01\x01\x12\x19\n" +
+	"\x05cache\x18\x05 \x01(\bH\x04R\x05cache\x88\x01\x01\x12B\n" +
+	"\x0es3_credentials\x18\x06 \x01(\v2\x19.picture.v1.S3CredentialsH\x00R\rs3Credentials\x12E\n" +
+	"\x0fftp_credentials\x18\a \x01(\v2\x1a.picture.v1.FtpCredentialsH\x00R\x0eftpCredentialsB\r\n" +
+	"\vcredentialsB\a\n" +
+	"\x05_nameB\a\n" +
+	"\x05_slugB\v\n" +
+	"\t_passwordB\b\n" +
+	"\x06_cache\">\n" +
+	"\x13UpdateEventResponse\x12'\n" +
+	"\x05event\x18\x01 \x01(\v2\x11.picture.v1.EventR\x05event\"\x89\x01\n" +
+	"\x17SetEventBrandingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x120\n" +
+	"\bbranding\x18\x02 \x01(\v2\x14.picture.v1.BrandingR\bbranding\x12,\n" +
+	"\x12remove_cover_image\x18\x03 \x01(\bR\x10removeCoverImage\"C\n" +
+	"\x18SetEventBrandingResponse\x12'\n" +
 	"\x05event\x18\x01 \x01(\v2\x11.picture.v1.EventR\x05event\"$\n" +
 	"\x12DeleteEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\x19\n" +
+	"\x17GetTrashedEventsRequest\"%\n" +
+	"\x13RestoreEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"?\n" +
+	"\x14RestoreEventResponse\x12'\n" +
+	"\x05event\x18\x01 \x01(\v2\x11.picture.v1.EventR\x05event\"\xdc\x01\n" +
+	"\rEventTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05cache\x18\x03 \x01(\bR\x05cache\x12-\n" +
+	"\x12password_protected\x18\x04 \x01(\bR\x11passwordProtected\x12\x1c\n" +
+	"\tencrypted\x18\x05 \x01(\bR\tencrypted\x12!\n" +
+	"\fkey_template\x18\x06 \x01(\tR\vkeyTemplate\x12!\n" +
+	"\fstorage_type\x18\a \x01(\tR\vstorageType\"I\n" +
+	"\x18SaveEventTemplateRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x1a\n" +
+	"\x18GetEventTemplatesRequest\"T\n" +
+	"\x19GetEventTemplatesResponse\x127\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x19.picture.v1.EventTemplateR\ttemplates\",\n" +
+	"\x1aDeleteEventTemplateRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\"P\n" +
 	"\rUploadRequest\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12$\n" +
@@ -1360,11 +3191,25 @@ const file_picture_v1_picture_proto_rawDesc = "" +
 	"\x04File\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04data\x18\x02 \x01(\fR\x04data\"\x10\n" +
-	"\x0eUploadResponse\"_\n" +
+	"\x0eUploadResponse\"h\n" +
+	"\x14PresignUploadRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\"9\n" +
+	"\x15PresignUploadResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"\x8d\x01\n" +
+	"\x15CompleteUploadRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\"\x86\x01\n" +
 	"\x14GetThumbnailsRequest\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x03R\x06offset\"N\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12%\n" +
+	"\x0einclude_hidden\x18\x04 \x01(\bR\rincludeHidden\"N\n" +
 	"\x15GetThumbnailsResponse\x125\n" +
 	"\n" +
 	"thumbnails\x18\x01 \x03(\v2\x15.picture.v1.ThumbnailR\n" +
@@ -1373,17 +3218,62 @@ const file_picture_v1_picture_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x121\n" +
 	"\tfile_info\x18\x03 \x01(\v2\x14.picture.v1.FileInfoR\bfileInfo\x12\x19\n" +
-	"\bevent_id\x18\x04 \x01(\x04R\aeventId2\xc2\x05\n" +
+	"\bevent_id\x18\x04 \x01(\x04R\aeventId\"^\n" +
+	"\x10HideMediaRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x16\n" +
+	"\x06hidden\x18\x03 \x01(\bR\x06hidden\"H\n" +
+	"\x12DeleteMediaRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\"\x80\x02\n" +
+	"\x1aMigrateEventStorageRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x128\n" +
+	"\n" +
+	"filesystem\x18\x02 \x01(\v2\x16.picture.v1.FilesystemH\x00R\n" +
+	"filesystem\x12 \n" +
+	"\x02s3\x18\x03 \x01(\v2\x0e.picture.v1.S3H\x00R\x02s3\x12;\n" +
+	"\vgoogleDrive\x18\x04 \x01(\v2\x17.picture.v1.GoogleDriveH\x00R\vgoogleDrive\x12#\n" +
+	"\x03ftp\x18\x05 \x01(\v2\x0f.picture.v1.FtpH\x00R\x03ftpB\t\n" +
+	"\astorage\"7\n" +
+	"\x1aGetStorageMigrationRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\"\x86\x02\n" +
+	"\x10StorageMigration\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\x04R\aeventId\x12;\n" +
+	"\x06status\x18\x03 \x01(\x0e2#.picture.v1.StorageMigration.StatusR\x06status\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x12\x1c\n" +
+	"\tcompleted\x18\x05 \x01(\x03R\tcompleted\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"@\n" +
+	"\x06Status\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\v\n" +
+	"\aRUNNING\x10\x01\x12\f\n" +
+	"\bCOMPLETE\x10\x02\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x032\xf5\x0e\n" +
 	"\x0ePictureService\x12N\n" +
 	"\vCreateEvent\x12\x1e.picture.v1.CreateEventRequest\x1a\x1f.picture.v1.CreateEventResponse\x12Q\n" +
-	"\fSetEventLive\x12\x1f.picture.v1.SetEventLiveRequest\x1a .picture.v1.SetEventLiveResponse\x12H\n" +
+	"\fSetEventLive\x12\x1f.picture.v1.SetEventLiveRequest\x1a .picture.v1.SetEventLiveResponse\x12]\n" +
+	"\x10SetEventSchedule\x12#.picture.v1.SetEventScheduleRequest\x1a$.picture.v1.SetEventScheduleResponse\x12N\n" +
+	"\vUpdateEvent\x12\x1e.picture.v1.UpdateEventRequest\x1a\x1f.picture.v1.UpdateEventResponse\x12]\n" +
+	"\x10SetEventBranding\x12#.picture.v1.SetEventBrandingRequest\x1a$.picture.v1.SetEventBrandingResponse\x12H\n" +
 	"\tGetEvents\x12\x1c.picture.v1.GetEventsRequest\x1a\x1d.picture.v1.GetEventsResponse\x12E\n" +
 	"\bGetEvent\x12\x1b.picture.v1.GetEventRequest\x1a\x1c.picture.v1.GetEventResponse\x12Q\n" +
 	"\x0eGetActiveEvent\x12!.picture.v1.GetActiveEventRequest\x1a\x1c.picture.v1.GetEventResponse\x12K\n" +
 	"\x0eSetActiveEvent\x12!.picture.v1.SetActiveEventRequest\x1a\x16.google.protobuf.Empty\x12E\n" +
-	"\vDeleteEvent\x12\x1e.picture.v1.DeleteEventRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\vDeleteEvent\x12\x1e.picture.v1.DeleteEventRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\x10GetTrashedEvents\x12#.picture.v1.GetTrashedEventsRequest\x1a\x1d.picture.v1.GetEventsResponse\x12Q\n" +
+	"\fRestoreEvent\x12\x1f.picture.v1.RestoreEventRequest\x1a .picture.v1.RestoreEventResponse\x12T\n" +
+	"\x11SaveEventTemplate\x12$.picture.v1.SaveEventTemplateRequest\x1a\x19.picture.v1.EventTemplate\x12`\n" +
+	"\x11GetEventTemplates\x12$.picture.v1.GetEventTemplatesRequest\x1a%.picture.v1.GetEventTemplatesResponse\x12U\n" +
+	"\x13DeleteEventTemplate\x12&.picture.v1.DeleteEventTemplateRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
 	"\x06Upload\x12\x19.picture.v1.UploadRequest\x1a\x1a.picture.v1.UploadResponse\x12T\n" +
-	"\rGetThumbnails\x12 .picture.v1.GetThumbnailsRequest\x1a!.picture.v1.GetThumbnailsResponseB\xa7\x01\n" +
+	"\rPresignUpload\x12 .picture.v1.PresignUploadRequest\x1a!.picture.v1.PresignUploadResponse\x12O\n" +
+	"\x0eCompleteUpload\x12!.picture.v1.CompleteUploadRequest\x1a\x1a.picture.v1.UploadResponse\x12T\n" +
+	"\rGetThumbnails\x12 .picture.v1.GetThumbnailsRequest\x1a!.picture.v1.GetThumbnailsResponse\x12A\n" +
+	"\tHideMedia\x12\x1c.picture.v1.HideMediaRequest\x1a\x16.google.protobuf.Empty\x12E\n" +
+	"\vDeleteMedia\x12\x1e.picture.v1.DeleteMediaRequest\x1a\x16.google.protobuf.Empty\x12[\n" +
+	"\x13MigrateEventStorage\x12&.picture.v1.MigrateEventStorageRequest\x1a\x1c.picture.v1.StorageMigration\x12[\n" +
+	"\x13GetStorageMigration\x12&.picture.v1.GetStorageMigrationRequest\x1a\x1c.picture.v1.StorageMigrationB\xa7\x01\n" +
 	"\x0ecom.picture.v1B\fPictureProtoP\x01Z>github.com/jj-style/eventpix/internal/gen/picture/v1;picturev1\xa2\x02\x03PXX\xaa\x02\n" +
 	"Picture.V1\xca\x02\n" +
 	"Picture\\V1\xe2\x02\x16Picture\\V1\\GPBMetadata\xea\x02\vPicture::V1b\x06proto3"
@@ -1400,76 +3290,154 @@ func file_picture_v1_picture_proto_rawDescGZIP() []byte {
 	return file_picture_v1_picture_proto_rawDescData
 }
 
-var file_picture_v1_picture_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_picture_v1_picture_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_picture_v1_picture_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_picture_v1_picture_proto_goTypes = []any{
-	(*Event)(nil),                  // 0: picture.v1.Event
-	(*FileInfosValue)(nil),         // 1: picture.v1.FileInfosValue
-	(*FileInfo)(nil),               // 2: picture.v1.FileInfo
-	(*CreateEventRequest)(nil),     // 3: picture.v1.CreateEventRequest
-	(*CreateEventResponse)(nil),    // 4: picture.v1.CreateEventResponse
-	(*GetEventsRequest)(nil),       // 5: picture.v1.GetEventsRequest
-	(*GetEventsResponse)(nil),      // 6: picture.v1.GetEventsResponse
-	(*GetEventRequest)(nil),        // 7: picture.v1.GetEventRequest
-	(*GetActiveEventRequest)(nil),  // 8: picture.v1.GetActiveEventRequest
-	(*SetActiveEventRequest)(nil),  // 9: picture.v1.SetActiveEventRequest
-	(*GetEventResponse)(nil),       // 10: picture.v1.GetEventResponse
-	(*SetEventLiveRequest)(nil),    // 11: picture.v1.SetEventLiveRequest
-	(*SetEventLiveResponse)(nil),   // 12: picture.v1.SetEventLiveResponse
-	(*DeleteEventRequest)(nil),     // 13: picture.v1.DeleteEventRequest
-	(*UploadRequest)(nil),          // 14: picture.v1.UploadRequest
-	(*File)(nil),                   // 15: picture.v1.File
-	(*UploadResponse)(nil),         // 16: picture.v1.UploadResponse
-	(*GetThumbnailsRequest)(nil),   // 17: picture.v1.GetThumbnailsRequest
-	(*GetThumbnailsResponse)(nil),  // 18: picture.v1.GetThumbnailsResponse
-	(*Thumbnail)(nil),              // 19: picture.v1.Thumbnail
-	(*Filesystem)(nil),             // 20: picture.v1.Filesystem
-	(*S3)(nil),                     // 21: picture.v1.S3
-	(*GoogleDrive)(nil),            // 22: picture.v1.GoogleDrive
-	(*Ftp)(nil),                    // 23: picture.v1.Ftp
-	(*wrapperspb.StringValue)(nil), // 24: google.protobuf.StringValue
-	(*emptypb.Empty)(nil),          // 25: google.protobuf.Empty
+	(StorageMigration_Status)(0),       // 0: picture.v1.StorageMigration.Status
+	(*Event)(nil),                      // 1: picture.v1.Event
+	(*Branding)(nil),                   // 2: picture.v1.Branding
+	(*FileInfosValue)(nil),             // 3: picture.v1.FileInfosValue
+	(*FileInfo)(nil),                   // 4: picture.v1.FileInfo
+	(*CreateEventRequest)(nil),         // 5: picture.v1.CreateEventRequest
+	(*CreateEventResponse)(nil),        // 6: picture.v1.CreateEventResponse
+	(*GetEventsRequest)(nil),           // 7: picture.v1.GetEventsRequest
+	(*GetEventsResponse)(nil),          // 8: picture.v1.GetEventsResponse
+	(*GetEventRequest)(nil),            // 9: picture.v1.GetEventRequest
+	(*GetActiveEventRequest)(nil),      // 10: picture.v1.GetActiveEventRequest
+	(*SetActiveEventRequest)(nil),      // 11: picture.v1.SetActiveEventRequest
+	(*GetEventResponse)(nil),           // 12: picture.v1.GetEventResponse
+	(*SetEventLiveRequest)(nil),        // 13: picture.v1.SetEventLiveRequest
+	(*SetEventLiveResponse)(nil),       // 14: picture.v1.SetEventLiveResponse
+	(*SetEventScheduleRequest)(nil),    // 15: picture.v1.SetEventScheduleRequest
+	(*SetEventScheduleResponse)(nil),   // 16: picture.v1.SetEventScheduleResponse
+	(*UpdateEventRequest)(nil),         // 17: picture.v1.UpdateEventRequest
+	(*UpdateEventResponse)(nil),        // 18: picture.v1.UpdateEventResponse
+	(*SetEventBrandingRequest)(nil),    // 19: picture.v1.SetEventBrandingRequest
+	(*SetEventBrandingResponse)(nil),   // 20: picture.v1.SetEventBrandingResponse
+	(*DeleteEventRequest)(nil),         // 21: picture.v1.DeleteEventRequest
+	(*GetTrashedEventsRequest)(nil),    // 22: picture.v1.GetTrashedEventsRequest
+	(*RestoreEventRequest)(nil),        // 23: picture.v1.RestoreEventRequest
+	(*RestoreEventResponse)(nil),       // 24: picture.v1.RestoreEventResponse
+	(*EventTemplate)(nil),              // 25: picture.v1.EventTemplate
+	(*SaveEventTemplateRequest)(nil),   // 26: picture.v1.SaveEventTemplateRequest
+	(*GetEventTemplatesRequest)(nil),   // 27: picture.v1.GetEventTemplatesRequest
+	(*GetEventTemplatesResponse)(nil),  // 28: picture.v1.GetEventTemplatesResponse
+	(*DeleteEventTemplateRequest)(nil), // 29: picture.v1.DeleteEventTemplateRequest
+	(*UploadRequest)(nil),              // 30: picture.v1.UploadRequest
+	(*File)(nil),                       // 31: picture.v1.File
+	(*UploadResponse)(nil),             // 32: picture.v1.UploadResponse
+	(*PresignUploadRequest)(nil),       // 33: picture.v1.PresignUploadRequest
+	(*PresignUploadResponse)(nil),      // 34: picture.v1.PresignUploadResponse
+	(*CompleteUploadRequest)(nil),      // 35: picture.v1.CompleteUploadRequest
+	(*GetThumbnailsRequest)(nil),       // 36: picture.v1.GetThumbnailsRequest
+	(*GetThumbnailsResponse)(nil),      // 37: picture.v1.GetThumbnailsResponse
+	(*Thumbnail)(nil),                  // 38: picture.v1.Thumbnail
+	(*HideMediaRequest)(nil),           // 39: picture.v1.HideMediaRequest
+	(*DeleteMediaRequest)(nil),         // 40: picture.v1.DeleteMediaRequest
+	(*MigrateEventStorageRequest)(nil), // 41: picture.v1.MigrateEventStorageRequest
+	(*GetStorageMigrationRequest)(nil), // 42: picture.v1.GetStorageMigrationRequest
+	(*StorageMigration)(nil),           // 43: picture.v1.StorageMigration
+	(*Filesystem)(nil),                 // 44: picture.v1.Filesystem
+	(*S3)(nil),                         // 45: picture.v1.S3
+	(*GoogleDrive)(nil),                // 46: picture.v1.GoogleDrive
+	(*Ftp)(nil),                        // 47: picture.v1.Ftp
+	(*timestamppb.Timestamp)(nil),      // 48: google.protobuf.Timestamp
+	(*S3Credentials)(nil),              // 49: picture.v1.S3Credentials
+	(*FtpCredentials)(nil),             // 50: picture.v1.FtpCredentials
+	(*emptypb.Empty)(nil),              // 51: google.protobuf.Empty
 }
 var file_picture_v1_picture_proto_depIdxs = []int32{
-	1,  // 0: picture.v1.Event.file_infos:type_name -> picture.v1.FileInfosValue
-	20, // 1: picture.v1.Event.filesystem:type_name -> picture.v1.Filesystem
-	21, // 2: picture.v1.Event.s3:type_name -> picture.v1.S3
-	22, // 3: picture.v1.Event.googleDrive:type_name -> picture.v1.GoogleDrive
-	23, // 4: picture.v1.Event.ftp:type_name -> picture.v1.Ftp
-	24, // 5: picture.v1.Event.password:type_name -> google.protobuf.StringValue
-	2,  // 6: picture.v1.FileInfosValue.value:type_name -> picture.v1.FileInfo
-	20, // 7: picture.v1.CreateEventRequest.filesystem:type_name -> picture.v1.Filesystem
-	21, // 8: picture.v1.CreateEventRequest.s3:type_name -> picture.v1.S3
-	22, // 9: picture.v1.CreateEventRequest.googleDrive:type_name -> picture.v1.GoogleDrive
-	23, // 10: picture.v1.CreateEventRequest.ftp:type_name -> picture.v1.Ftp
-	0,  // 11: picture.v1.GetEventsResponse.events:type_name -> picture.v1.Event
-	0,  // 12: picture.v1.GetEventResponse.event:type_name -> picture.v1.Event
-	0,  // 13: picture.v1.SetEventLiveResponse.event:type_name -> picture.v1.Event
-	15, // 14: picture.v1.UploadRequest.file:type_name -> picture.v1.File
-	19, // 15: picture.v1.GetThumbnailsResponse.thumbnails:type_name -> picture.v1.Thumbnail
-	2,  // 16: picture.v1.Thumbnail.file_info:type_name -> picture.v1.FileInfo
-	3,  // 17: picture.v1.PictureService.CreateEvent:input_type -> picture.v1.CreateEventRequest
-	11, // 18: picture.v1.PictureService.SetEventLive:input_type -> picture.v1.SetEventLiveRequest
-	5,  // 19: picture.v1.PictureService.GetEvents:input_type -> picture.v1.GetEventsRequest
-	7,  // 20: picture.v1.PictureService.GetEvent:input_type -> picture.v1.GetEventRequest
-	8,  // 21: picture.v1.PictureService.GetActiveEvent:input_type -> picture.v1.GetActiveEventRequest
-	9,  // 22: picture.v1.PictureService.SetActiveEvent:input_type -> picture.v1.SetActiveEventRequest
-	13, // 23: picture.v1.PictureService.DeleteEvent:input_type -> picture.v1.DeleteEventRequest
-	14, // 24: picture.v1.PictureService.Upload:input_type -> picture.v1.UploadRequest
-	17, // 25: picture.v1.PictureService.GetThumbnails:input_type -> picture.v1.GetThumbnailsRequest
-	4,  // 26: picture.v1.PictureService.CreateEvent:output_type -> picture.v1.CreateEventResponse
-	12, // 27: picture.v1.PictureService.SetEventLive:output_type -> picture.v1.SetEventLiveResponse
-	6,  // 28: picture.v1.PictureService.GetEvents:output_type -> picture.v1.GetEventsResponse
-	10, // 29: picture.v1.PictureService.GetEvent:output_type -> picture.v1.GetEventResponse
-	10, // 30: picture.v1.PictureService.GetActiveEvent:output_type -> picture.v1.GetEventResponse
-	25, // 31: picture.v1.PictureService.SetActiveEvent:output_type -> google.protobuf.Empty
-	25, // 32: picture.v1.PictureService.DeleteEvent:output_type -> google.protobuf.Empty
-	16, // 33: picture.v1.PictureService.Upload:output_type -> picture.v1.UploadResponse
-	18, // 34: picture.v1.PictureService.GetThumbnails:output_type -> picture.v1.GetThumbnailsResponse
-	26, // [26:35] is the sub-list for method output_type
-	17, // [17:26] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	3,  // 0: picture.v1.Event.file_infos:type_name -> picture.v1.FileInfosValue
+	44, // 1: picture.v1.Event.filesystem:type_name -> picture.v1.Filesystem
+	45, // 2: picture.v1.Event.s3:type_name -> picture.v1.S3
+	46, // 3: picture.v1.Event.googleDrive:type_name -> picture.v1.GoogleDrive
+	47, // 4: picture.v1.Event.ftp:type_name -> picture.v1.Ftp
+	48, // 5: picture.v1.Event.starts_at:type_name -> google.protobuf.Timestamp
+	48, // 6: picture.v1.Event.ends_at:type_name -> google.protobuf.Timestamp
+	48, // 7: picture.v1.Event.expires_at:type_name -> google.protobuf.Timestamp
+	48, // 8: picture.v1.Event.deleted_at:type_name -> google.protobuf.Timestamp
+	2,  // 9: picture.v1.Event.branding:type_name -> picture.v1.Branding
+	4,  // 10: picture.v1.FileInfosValue.value:type_name -> picture.v1.FileInfo
+	44, // 11: picture.v1.CreateEventRequest.filesystem:type_name -> picture.v1.Filesystem
+	45, // 12: picture.v1.CreateEventRequest.s3:type_name -> picture.v1.S3
+	46, // 13: picture.v1.CreateEventRequest.googleDrive:type_name -> picture.v1.GoogleDrive
+	47, // 14: picture.v1.CreateEventRequest.ftp:type_name -> picture.v1.Ftp
+	48, // 15: picture.v1.CreateEventRequest.starts_at:type_name -> google.protobuf.Timestamp
+	48, // 16: picture.v1.CreateEventRequest.ends_at:type_name -> google.protobuf.Timestamp
+	48, // 17: picture.v1.CreateEventRequest.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 18: picture.v1.GetEventsResponse.events:type_name -> picture.v1.Event
+	1,  // 19: picture.v1.GetEventResponse.event:type_name -> picture.v1.Event
+	1,  // 20: picture.v1.SetEventLiveResponse.event:type_name -> picture.v1.Event
+	48, // 21: picture.v1.SetEventScheduleRequest.starts_at:type_name -> google.protobuf.Timestamp
+	48, // 22: picture.v1.SetEventScheduleRequest.ends_at:type_name -> google.protobuf.Timestamp
+	48, // 23: picture.v1.SetEventScheduleRequest.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 24: picture.v1.SetEventScheduleResponse.event:type_name -> picture.v1.Event
+	49, // 25: picture.v1.UpdateEventRequest.s3_credentials:type_name -> picture.v1.S3Credentials
+	50, // 26: picture.v1.UpdateEventRequest.ftp_credentials:type_name -> picture.v1.FtpCredentials
+	1,  // 27: picture.v1.UpdateEventResponse.event:type_name -> picture.v1.Event
+	2,  // 28: picture.v1.SetEventBrandingRequest.branding:type_name -> picture.v1.Branding
+	1,  // 29: picture.v1.SetEventBrandingResponse.event:type_name -> picture.v1.Event
+	1,  // 30: picture.v1.RestoreEventResponse.event:type_name -> picture.v1.Event
+	25, // 31: picture.v1.GetEventTemplatesResponse.templates:type_name -> picture.v1.EventTemplate
+	31, // 32: picture.v1.UploadRequest.file:type_name -> picture.v1.File
+	38, // 33: picture.v1.GetThumbnailsResponse.thumbnails:type_name -> picture.v1.Thumbnail
+	4,  // 34: picture.v1.Thumbnail.file_info:type_name -> picture.v1.FileInfo
+	44, // 35: picture.v1.MigrateEventStorageRequest.filesystem:type_name -> picture.v1.Filesystem
+	45, // 36: picture.v1.MigrateEventStorageRequest.s3:type_name -> picture.v1.S3
+	46, // 37: picture.v1.MigrateEventStorageRequest.googleDrive:type_name -> picture.v1.GoogleDrive
+	47, // 38: picture.v1.MigrateEventStorageRequest.ftp:type_name -> picture.v1.Ftp
+	0,  // 39: picture.v1.StorageMigration.status:type_name -> picture.v1.StorageMigration.Status
+	5,  // 40: picture.v1.PictureService.CreateEvent:input_type -> picture.v1.CreateEventRequest
+	13, // 41: picture.v1.PictureService.SetEventLive:input_type -> picture.v1.SetEventLiveRequest
+	15, // 42: picture.v1.PictureService.SetEventSchedule:input_type -> picture.v1.SetEventScheduleRequest
+	17, // 43: picture.v1.PictureService.UpdateEvent:input_type -> picture.v1.UpdateEventRequest
+	19, // 44: picture.v1.PictureService.SetEventBranding:input_type -> picture.v1.SetEventBrandingRequest
+	7,  // 45: picture.v1.PictureService.GetEvents:input_type -> picture.v1.GetEventsRequest
+	9,  // 46: picture.v1.PictureService.GetEvent:input_type -> picture.v1.GetEventRequest
+	10, // 47: picture.v1.PictureService.GetActiveEvent:input_type -> picture.v1.GetActiveEventRequest
+	11, // 48: picture.v1.PictureService.SetActiveEvent:input_type -> picture.v1.SetActiveEventRequest
+	21, // 49: picture.v1.PictureService.DeleteEvent:input_type -> picture.v1.DeleteEventRequest
+	22, // 50: picture.v1.PictureService.GetTrashedEvents:input_type -> picture.v1.GetTrashedEventsRequest
+	23, // 51: picture.v1.PictureService.RestoreEvent:input_type -> picture.v1.RestoreEventRequest
+	26, // 52: picture.v1.PictureService.SaveEventTemplate:input_type -> picture.v1.SaveEventTemplateRequest
+	27, // 53: picture.v1.PictureService.GetEventTemplates:input_type -> picture.v1.GetEventTemplatesRequest
+	29, // 54: picture.v1.PictureService.DeleteEventTemplate:input_type -> picture.v1.DeleteEventTemplateRequest
+	30, // 55: picture.v1.PictureService.Upload:input_type -> picture.v1.UploadRequest
+	33, // 56: picture.v1.PictureService.PresignUpload:input_type -> picture.v1.PresignUploadRequest
+	35, // 57: picture.v1.PictureService.CompleteUpload:input_type -> picture.v1.CompleteUploadRequest
+	36, // 58: picture.v1.PictureService.GetThumbnails:input_type -> picture.v1.GetThumbnailsRequest
+	39, // 59: picture.v1.PictureService.HideMedia:input_type -> picture.v1.HideMediaRequest
+	40, // 60: picture.v1.PictureService.DeleteMedia:input_type -> picture.v1.DeleteMediaRequest
+	41, // 61: picture.v1.PictureService.MigrateEventStorage:input_type -> picture.v1.MigrateEventStorageRequest
+	42, // 62: picture.v1.PictureService.GetStorageMigration:input_type -> picture.v1.GetStorageMigrationRequest
+	6,  // 63: picture.v1.PictureService.CreateEvent:output_type -> picture.v1.CreateEventResponse
+	14, // 64: picture.v1.PictureService.SetEventLive:output_type -> picture.v1.SetEventLiveResponse
+	16, // 65: picture.v1.PictureService.SetEventSchedule:output_type -> picture.v1.SetEventScheduleResponse
+	18, // 66: picture.v1.PictureService.UpdateEvent:output_type -> picture.v1.UpdateEventResponse
+	20, // 67: picture.v1.PictureService.SetEventBranding:output_type -> picture.v1.SetEventBrandingResponse
+	8,  // 68: picture.v1.PictureService.GetEvents:output_type -> picture.v1.GetEventsResponse
+	12, // 69: picture.v1.PictureService.GetEvent:output_type -> picture.v1.GetEventResponse
+	12, // 70: picture.v1.PictureService.GetActiveEvent:output_type -> picture.v1.GetEventResponse
+	51, // 71: picture.v1.PictureService.SetActiveEvent:output_type -> google.protobuf.Empty
+	51, // 72: picture.v1.PictureService.DeleteEvent:output_type -> google.protobuf.Empty
+	8,  // 73: picture.v1.PictureService.GetTrashedEvents:output_type -> picture.v1.GetEventsResponse
+	24, // 74: picture.v1.PictureService.RestoreEvent:output_type -> picture.v1.RestoreEventResponse
+	25, // 75: picture.v1.PictureService.SaveEventTemplate:output_type -> picture.v1.EventTemplate
+	28, // 76: picture.v1.PictureService.GetEventTemplates:output_type -> picture.v1.GetEventTemplatesResponse
+	51, // 77: picture.v1.PictureService.DeleteEventTemplate:output_type -> google.protobuf.Empty
+	32, // 78: picture.v1.PictureService.Upload:output_type -> picture.v1.UploadResponse
+	34, // 79: picture.v1.PictureService.PresignUpload:output_type -> picture.v1.PresignUploadResponse
+	32, // 80: picture.v1.PictureService.CompleteUpload:output_type -> picture.v1.UploadResponse
+	37, // 81: picture.v1.PictureService.GetThumbnails:output_type -> picture.v1.GetThumbnailsResponse
+	51, // 82: picture.v1.PictureService.HideMedia:output_type -> google.protobuf.Empty
+	51, // 83: picture.v1.PictureService.DeleteMedia:output_type -> google.protobuf.Empty
+	43, // 84: picture.v1.PictureService.MigrateEventStorage:output_type -> picture.v1.StorageMigration
+	43, // 85: picture.v1.PictureService.GetStorageMigration:output_type -> picture.v1.StorageMigration
+	63, // [63:86] is the sub-list for method output_type
+	40, // [40:63] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_picture_v1_picture_proto_init() }
@@ -1484,28 +3452,41 @@ func file_picture_v1_picture_proto_init() {
 		(*Event_GoogleDrive)(nil),
 		(*Event_Ftp)(nil),
 	}
-	file_picture_v1_picture_proto_msgTypes[3].OneofWrappers = []any{
+	file_picture_v1_picture_proto_msgTypes[4].OneofWrappers = []any{
 		(*CreateEventRequest_Filesystem)(nil),
 		(*CreateEventRequest_S3)(nil),
 		(*CreateEventRequest_GoogleDrive)(nil),
 		(*CreateEventRequest_Ftp)(nil),
+		(*CreateEventRequest_TemplateId)(nil),
+		(*CreateEventRequest_DuplicateEventId)(nil),
 	}
-	file_picture_v1_picture_proto_msgTypes[7].OneofWrappers = []any{
+	file_picture_v1_picture_proto_msgTypes[8].OneofWrappers = []any{
 		(*GetEventRequest_Id)(nil),
 		(*GetEventRequest_Slug)(nil),
 	}
+	file_picture_v1_picture_proto_msgTypes[16].OneofWrappers = []any{
+		(*UpdateEventRequest_S3Credentials)(nil),
+		(*UpdateEventRequest_FtpCredentials)(nil),
+	}
+	file_picture_v1_picture_proto_msgTypes[40].OneofWrappers = []any{
+		(*MigrateEventStorageRequest_Filesystem)(nil),
+		(*MigrateEventStorageRequest_S3)(nil),
+		(*MigrateEventStorageRequest_GoogleDrive)(nil),
+		(*MigrateEventStorageRequest_Ftp)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_picture_v1_picture_proto_rawDesc), len(file_picture_v1_picture_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   20,
+			NumEnums:      1,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_picture_v1_picture_proto_goTypes,
 		DependencyIndexes: file_picture_v1_picture_proto_depIdxs,
+		EnumInfos:         file_picture_v1_picture_proto_enumTypes,
 		MessageInfos:      file_picture_v1_picture_proto_msgTypes,
 	}.Build()
 	File_picture_v1_picture_proto = out.File
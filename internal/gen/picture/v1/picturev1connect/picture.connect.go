@@ -0,0 +1,746 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: picture/v1/picture.proto
+
+package picturev1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// PictureServiceName is the fully-qualified name of the PictureService service.
+	PictureServiceName = "picture.v1.PictureService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// PictureServiceCreateEventProcedure is the fully-qualified name of the PictureService's
+	// CreateEvent RPC.
+	PictureServiceCreateEventProcedure = "/picture.v1.PictureService/CreateEvent"
+	// PictureServiceSetEventLiveProcedure is the fully-qualified name of the PictureService's
+	// SetEventLive RPC.
+	PictureServiceSetEventLiveProcedure = "/picture.v1.PictureService/SetEventLive"
+	// PictureServiceSetEventScheduleProcedure is the fully-qualified name of the PictureService's
+	// SetEventSchedule RPC.
+	PictureServiceSetEventScheduleProcedure = "/picture.v1.PictureService/SetEventSchedule"
+	// PictureServiceUpdateEventProcedure is the fully-qualified name of the PictureService's
+	// UpdateEvent RPC.
+	PictureServiceUpdateEventProcedure = "/picture.v1.PictureService/UpdateEvent"
+	// PictureServiceSetEventBrandingProcedure is the fully-qualified name of the PictureService's
+	// SetEventBranding RPC.
+	PictureServiceSetEventBrandingProcedure = "/picture.v1.PictureService/SetEventBranding"
+	// PictureServiceGetEventsProcedure is the fully-qualified name of the PictureService's GetEvents
+	// RPC.
+	PictureServiceGetEventsProcedure = "/picture.v1.PictureService/GetEvents"
+	// PictureServiceGetEventProcedure is the fully-qualified name of the PictureService's GetEvent RPC.
+	PictureServiceGetEventProcedure = "/picture.v1.PictureService/GetEvent"
+	// PictureServiceGetActiveEventProcedure is the fully-qualified name of the PictureService's
+	// GetActiveEvent RPC.
+	PictureServiceGetActiveEventProcedure = "/picture.v1.PictureService/GetActiveEvent"
+	// PictureServiceSetActiveEventProcedure is the fully-qualified name of the PictureService's
+	// SetActiveEvent RPC.
+	PictureServiceSetActiveEventProcedure = "/picture.v1.PictureService/SetActiveEvent"
+	// PictureServiceDeleteEventProcedure is the fully-qualified name of the PictureService's
+	// DeleteEvent RPC.
+	PictureServiceDeleteEventProcedure = "/picture.v1.PictureService/DeleteEvent"
+	// PictureServiceGetTrashedEventsProcedure is the fully-qualified name of the PictureService's
+	// GetTrashedEvents RPC.
+	PictureServiceGetTrashedEventsProcedure = "/picture.v1.PictureService/GetTrashedEvents"
+	// PictureServiceRestoreEventProcedure is the fully-qualified name of the PictureService's
+	// RestoreEvent RPC.
+	PictureServiceRestoreEventProcedure = "/picture.v1.PictureService/RestoreEvent"
+	// PictureServiceSaveEventTemplateProcedure is the fully-qualified name of the PictureService's
+	// SaveEventTemplate RPC.
+	PictureServiceSaveEventTemplateProcedure = "/picture.v1.PictureService/SaveEventTemplate"
+	// PictureServiceGetEventTemplatesProcedure is the fully-qualified name of the PictureService's
+	// GetEventTemplates RPC.
+	PictureServiceGetEventTemplatesProcedure = "/picture.v1.PictureService/GetEventTemplates"
+	// PictureServiceDeleteEventTemplateProcedure is the fully-qualified name of the PictureService's
+	// DeleteEventTemplate RPC.
+	PictureServiceDeleteEventTemplateProcedure = "/picture.v1.PictureService/DeleteEventTemplate"
+	// PictureServiceUploadProcedure is the fully-qualified name of the PictureService's Upload RPC.
+	PictureServiceUploadProcedure = "/picture.v1.PictureService/Upload"
+	// PictureServicePresignUploadProcedure is the fully-qualified name of the PictureService's
+	// PresignUpload RPC.
+	PictureServicePresignUploadProcedure = "/picture.v1.PictureService/PresignUpload"
+	// PictureServiceCompleteUploadProcedure is the fully-qualified name of the PictureService's
+	// CompleteUpload RPC.
+	PictureServiceCompleteUploadProcedure = "/picture.v1.PictureService/CompleteUpload"
+	// PictureServiceGetThumbnailsProcedure is the fully-qualified name of the PictureService's
+	// GetThumbnails RPC.
+	PictureServiceGetThumbnailsProcedure = "/picture.v1.PictureService/GetThumbnails"
+	// PictureServiceHideMediaProcedure is the fully-qualified name of the PictureService's HideMedia
+	// RPC.
+	PictureServiceHideMediaProcedure = "/picture.v1.PictureService/HideMedia"
+	// PictureServiceDeleteMediaProcedure is the fully-qualified name of the PictureService's
+	// DeleteMedia RPC.
+	PictureServiceDeleteMediaProcedure = "/picture.v1.PictureService/DeleteMedia"
+	// PictureServiceMigrateEventStorageProcedure is the fully-qualified name of the PictureService's
+	// MigrateEventStorage RPC.
+	PictureServiceMigrateEventStorageProcedure = "/picture.v1.PictureService/MigrateEventStorage"
+	// PictureServiceGetStorageMigrationProcedure is the fully-qualified name of the PictureService's
+	// GetStorageMigration RPC.
+	PictureServiceGetStorageMigrationProcedure = "/picture.v1.PictureService/GetStorageMigration"
+)
+
+// PictureServiceClient is a client for the picture.v1.PictureService service.
+type PictureServiceClient interface {
+	CreateEvent(context.Context, *connect.Request[v1.CreateEventRequest]) (*connect.Response[v1.CreateEventResponse], error)
+	SetEventLive(context.Context, *connect.Request[v1.SetEventLiveRequest]) (*connect.Response[v1.SetEventLiveResponse], error)
+	SetEventSchedule(context.Context, *connect.Request[v1.SetEventScheduleRequest]) (*connect.Response[v1.SetEventScheduleResponse], error)
+	UpdateEvent(context.Context, *connect.Request[v1.UpdateEventRequest]) (*connect.Response[v1.UpdateEventResponse], error)
+	SetEventBranding(context.Context, *connect.Request[v1.SetEventBrandingRequest]) (*connect.Response[v1.SetEventBrandingResponse], error)
+	GetEvents(context.Context, *connect.Request[v1.GetEventsRequest]) (*connect.Response[v1.GetEventsResponse], error)
+	GetEvent(context.Context, *connect.Request[v1.GetEventRequest]) (*connect.Response[v1.GetEventResponse], error)
+	GetActiveEvent(context.Context, *connect.Request[v1.GetActiveEventRequest]) (*connect.Response[v1.GetEventResponse], error)
+	SetActiveEvent(context.Context, *connect.Request[v1.SetActiveEventRequest]) (*connect.Response[emptypb.Empty], error)
+	DeleteEvent(context.Context, *connect.Request[v1.DeleteEventRequest]) (*connect.Response[emptypb.Empty], error)
+	GetTrashedEvents(context.Context, *connect.Request[v1.GetTrashedEventsRequest]) (*connect.Response[v1.GetEventsResponse], error)
+	RestoreEvent(context.Context, *connect.Request[v1.RestoreEventRequest]) (*connect.Response[v1.RestoreEventResponse], error)
+	SaveEventTemplate(context.Context, *connect.Request[v1.SaveEventTemplateRequest]) (*connect.Response[v1.EventTemplate], error)
+	GetEventTemplates(context.Context, *connect.Request[v1.GetEventTemplatesRequest]) (*connect.Response[v1.GetEventTemplatesResponse], error)
+	DeleteEventTemplate(context.Context, *connect.Request[v1.DeleteEventTemplateRequest]) (*connect.Response[emptypb.Empty], error)
+	Upload(context.Context, *connect.Request[v1.UploadRequest]) (*connect.Response[v1.UploadResponse], error)
+	PresignUpload(context.Context, *connect.Request[v1.PresignUploadRequest]) (*connect.Response[v1.PresignUploadResponse], error)
+	CompleteUpload(context.Context, *connect.Request[v1.CompleteUploadRequest]) (*connect.Response[v1.UploadResponse], error)
+	GetThumbnails(context.Context, *connect.Request[v1.GetThumbnailsRequest]) (*connect.Response[v1.GetThumbnailsResponse], error)
+	HideMedia(context.Context, *connect.Request[v1.HideMediaRequest]) (*connect.Response[emptypb.Empty], error)
+	DeleteMedia(context.Context, *connect.Request[v1.DeleteMediaRequest]) (*connect.Response[emptypb.Empty], error)
+	MigrateEventStorage(context.Context, *connect.Request[v1.MigrateEventStorageRequest]) (*connect.Response[v1.StorageMigration], error)
+	GetStorageMigration(context.Context, *connect.Request[v1.GetStorageMigrationRequest]) (*connect.Response[v1.StorageMigration], error)
+}
+
+// NewPictureServiceClient constructs a client for the picture.v1.PictureService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewPictureServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) PictureServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	pictureServiceMethods := v1.File_picture_v1_picture_proto.Services().ByName("PictureService").Methods()
+	return &pictureServiceClient{
+		createEvent: connect.NewClient[v1.CreateEventRequest, v1.CreateEventResponse](
+			httpClient,
+			baseURL+PictureServiceCreateEventProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("CreateEvent")),
+			connect.WithClientOptions(opts...),
+		),
+		setEventLive: connect.NewClient[v1.SetEventLiveRequest, v1.SetEventLiveResponse](
+			httpClient,
+			baseURL+PictureServiceSetEventLiveProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("SetEventLive")),
+			connect.WithClientOptions(opts...),
+		),
+		setEventSchedule: connect.NewClient[v1.SetEventScheduleRequest, v1.SetEventScheduleResponse](
+			httpClient,
+			baseURL+PictureServiceSetEventScheduleProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("SetEventSchedule")),
+			connect.WithClientOptions(opts...),
+		),
+		updateEvent: connect.NewClient[v1.UpdateEventRequest, v1.UpdateEventResponse](
+			httpClient,
+			baseURL+PictureServiceUpdateEventProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("UpdateEvent")),
+			connect.WithClientOptions(opts...),
+		),
+		setEventBranding: connect.NewClient[v1.SetEventBrandingRequest, v1.SetEventBrandingResponse](
+			httpClient,
+			baseURL+PictureServiceSetEventBrandingProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("SetEventBranding")),
+			connect.WithClientOptions(opts...),
+		),
+		getEvents: connect.NewClient[v1.GetEventsRequest, v1.GetEventsResponse](
+			httpClient,
+			baseURL+PictureServiceGetEventsProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("GetEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		getEvent: connect.NewClient[v1.GetEventRequest, v1.GetEventResponse](
+			httpClient,
+			baseURL+PictureServiceGetEventProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("GetEvent")),
+			connect.WithClientOptions(opts...),
+		),
+		getActiveEvent: connect.NewClient[v1.GetActiveEventRequest, v1.GetEventResponse](
+			httpClient,
+			baseURL+PictureServiceGetActiveEventProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("GetActiveEvent")),
+			connect.WithClientOptions(opts...),
+		),
+		setActiveEvent: connect.NewClient[v1.SetActiveEventRequest, emptypb.Empty](
+			httpClient,
+			baseURL+PictureServiceSetActiveEventProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("SetActiveEvent")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteEvent: connect.NewClient[v1.DeleteEventRequest, emptypb.Empty](
+			httpClient,
+			baseURL+PictureServiceDeleteEventProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("DeleteEvent")),
+			connect.WithClientOptions(opts...),
+		),
+		getTrashedEvents: connect.NewClient[v1.GetTrashedEventsRequest, v1.GetEventsResponse](
+			httpClient,
+			baseURL+PictureServiceGetTrashedEventsProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("GetTrashedEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		restoreEvent: connect.NewClient[v1.RestoreEventRequest, v1.RestoreEventResponse](
+			httpClient,
+			baseURL+PictureServiceRestoreEventProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("RestoreEvent")),
+			connect.WithClientOptions(opts...),
+		),
+		saveEventTemplate: connect.NewClient[v1.SaveEventTemplateRequest, v1.EventTemplate](
+			httpClient,
+			baseURL+PictureServiceSaveEventTemplateProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("SaveEventTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		getEventTemplates: connect.NewClient[v1.GetEventTemplatesRequest, v1.GetEventTemplatesResponse](
+			httpClient,
+			baseURL+PictureServiceGetEventTemplatesProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("GetEventTemplates")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteEventTemplate: connect.NewClient[v1.DeleteEventTemplateRequest, emptypb.Empty](
+			httpClient,
+			baseURL+PictureServiceDeleteEventTemplateProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("DeleteEventTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		upload: connect.NewClient[v1.UploadRequest, v1.UploadResponse](
+			httpClient,
+			baseURL+PictureServiceUploadProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("Upload")),
+			connect.WithClientOptions(opts...),
+		),
+		presignUpload: connect.NewClient[v1.PresignUploadRequest, v1.PresignUploadResponse](
+			httpClient,
+			baseURL+PictureServicePresignUploadProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("PresignUpload")),
+			connect.WithClientOptions(opts...),
+		),
+		completeUpload: connect.NewClient[v1.CompleteUploadRequest, v1.UploadResponse](
+			httpClient,
+			baseURL+PictureServiceCompleteUploadProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("CompleteUpload")),
+			connect.WithClientOptions(opts...),
+		),
+		getThumbnails: connect.NewClient[v1.GetThumbnailsRequest, v1.GetThumbnailsResponse](
+			httpClient,
+			baseURL+PictureServiceGetThumbnailsProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("GetThumbnails")),
+			connect.WithClientOptions(opts...),
+		),
+		hideMedia: connect.NewClient[v1.HideMediaRequest, emptypb.Empty](
+			httpClient,
+			baseURL+PictureServiceHideMediaProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("HideMedia")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteMedia: connect.NewClient[v1.DeleteMediaRequest, emptypb.Empty](
+			httpClient,
+			baseURL+PictureServiceDeleteMediaProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("DeleteMedia")),
+			connect.WithClientOptions(opts...),
+		),
+		migrateEventStorage: connect.NewClient[v1.MigrateEventStorageRequest, v1.StorageMigration](
+			httpClient,
+			baseURL+PictureServiceMigrateEventStorageProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("MigrateEventStorage")),
+			connect.WithClientOptions(opts...),
+		),
+		getStorageMigration: connect.NewClient[v1.GetStorageMigrationRequest, v1.StorageMigration](
+			httpClient,
+			baseURL+PictureServiceGetStorageMigrationProcedure,
+			connect.WithSchema(pictureServiceMethods.ByName("GetStorageMigration")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// pictureServiceClient implements PictureServiceClient.
+type pictureServiceClient struct {
+	createEvent         *connect.Client[v1.CreateEventRequest, v1.CreateEventResponse]
+	setEventLive        *connect.Client[v1.SetEventLiveRequest, v1.SetEventLiveResponse]
+	setEventSchedule    *connect.Client[v1.SetEventScheduleRequest, v1.SetEventScheduleResponse]
+	updateEvent         *connect.Client[v1.UpdateEventRequest, v1.UpdateEventResponse]
+	setEventBranding    *connect.Client[v1.SetEventBrandingRequest, v1.SetEventBrandingResponse]
+	getEvents           *connect.Client[v1.GetEventsRequest, v1.GetEventsResponse]
+	getEvent            *connect.Client[v1.GetEventRequest, v1.GetEventResponse]
+	getActiveEvent      *connect.Client[v1.GetActiveEventRequest, v1.GetEventResponse]
+	setActiveEvent      *connect.Client[v1.SetActiveEventRequest, emptypb.Empty]
+	deleteEvent         *connect.Client[v1.DeleteEventRequest, emptypb.Empty]
+	getTrashedEvents    *connect.Client[v1.GetTrashedEventsRequest, v1.GetEventsResponse]
+	restoreEvent        *connect.Client[v1.RestoreEventRequest, v1.RestoreEventResponse]
+	saveEventTemplate   *connect.Client[v1.SaveEventTemplateRequest, v1.EventTemplate]
+	getEventTemplates   *connect.Client[v1.GetEventTemplatesRequest, v1.GetEventTemplatesResponse]
+	deleteEventTemplate *connect.Client[v1.DeleteEventTemplateRequest, emptypb.Empty]
+	upload              *connect.Client[v1.UploadRequest, v1.UploadResponse]
+	presignUpload       *connect.Client[v1.PresignUploadRequest, v1.PresignUploadResponse]
+	completeUpload      *connect.Client[v1.CompleteUploadRequest, v1.UploadResponse]
+	getThumbnails       *connect.Client[v1.GetThumbnailsRequest, v1.GetThumbnailsResponse]
+	hideMedia           *connect.Client[v1.HideMediaRequest, emptypb.Empty]
+	deleteMedia         *connect.Client[v1.DeleteMediaRequest, emptypb.Empty]
+	migrateEventStorage *connect.Client[v1.MigrateEventStorageRequest, v1.StorageMigration]
+	getStorageMigration *connect.Client[v1.GetStorageMigrationRequest, v1.StorageMigration]
+}
+
+// CreateEvent calls picture.v1.PictureService.CreateEvent.
+func (c *pictureServiceClient) CreateEvent(ctx context.Context, req *connect.Request[v1.CreateEventRequest]) (*connect.Response[v1.CreateEventResponse], error) {
+	return c.createEvent.CallUnary(ctx, req)
+}
+
+// SetEventLive calls picture.v1.PictureService.SetEventLive.
+func (c *pictureServiceClient) SetEventLive(ctx context.Context, req *connect.Request[v1.SetEventLiveRequest]) (*connect.Response[v1.SetEventLiveResponse], error) {
+	return c.setEventLive.CallUnary(ctx, req)
+}
+
+// SetEventSchedule calls picture.v1.PictureService.SetEventSchedule.
+func (c *pictureServiceClient) SetEventSchedule(ctx context.Context, req *connect.Request[v1.SetEventScheduleRequest]) (*connect.Response[v1.SetEventScheduleResponse], error) {
+	return c.setEventSchedule.CallUnary(ctx, req)
+}
+
+// UpdateEvent calls picture.v1.PictureService.UpdateEvent.
+func (c *pictureServiceClient) UpdateEvent(ctx context.Context, req *connect.Request[v1.UpdateEventRequest]) (*connect.Response[v1.UpdateEventResponse], error) {
+	return c.updateEvent.CallUnary(ctx, req)
+}
+
+// SetEventBranding calls picture.v1.PictureService.SetEventBranding.
+func (c *pictureServiceClient) SetEventBranding(ctx context.Context, req *connect.Request[v1.SetEventBrandingRequest]) (*connect.Response[v1.SetEventBrandingResponse], error) {
+	return c.setEventBranding.CallUnary(ctx, req)
+}
+
+// GetEvents calls picture.v1.PictureService.GetEvents.
+func (c *pictureServiceClient) GetEvents(ctx context.Context, req *connect.Request[v1.GetEventsRequest]) (*connect.Response[v1.GetEventsResponse], error) {
+	return c.getEvents.CallUnary(ctx, req)
+}
+
+// GetEvent calls picture.v1.PictureService.GetEvent.
+func (c *pictureServiceClient) GetEvent(ctx context.Context, req *connect.Request[v1.GetEventRequest]) (*connect.Response[v1.GetEventResponse], error) {
+	return c.getEvent.CallUnary(ctx, req)
+}
+
+// GetActiveEvent calls picture.v1.PictureService.GetActiveEvent.
+func (c *pictureServiceClient) GetActiveEvent(ctx context.Context, req *connect.Request[v1.GetActiveEventRequest]) (*connect.Response[v1.GetEventResponse], error) {
+	return c.getActiveEvent.CallUnary(ctx, req)
+}
+
+// SetActiveEvent calls picture.v1.PictureService.SetActiveEvent.
+func (c *pictureServiceClient) SetActiveEvent(ctx context.Context, req *connect.Request[v1.SetActiveEventRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.setActiveEvent.CallUnary(ctx, req)
+}
+
+// DeleteEvent calls picture.v1.PictureService.DeleteEvent.
+func (c *pictureServiceClient) DeleteEvent(ctx context.Context, req *connect.Request[v1.DeleteEventRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteEvent.CallUnary(ctx, req)
+}
+
+// GetTrashedEvents calls picture.v1.PictureService.GetTrashedEvents.
+func (c *pictureServiceClient) GetTrashedEvents(ctx context.Context, req *connect.Request[v1.GetTrashedEventsRequest]) (*connect.Response[v1.GetEventsResponse], error) {
+	return c.getTrashedEvents.CallUnary(ctx, req)
+}
+
+// RestoreEvent calls picture.v1.PictureService.RestoreEvent.
+func (c *pictureServiceClient) RestoreEvent(ctx context.Context, req *connect.Request[v1.RestoreEventRequest]) (*connect.Response[v1.RestoreEventResponse], error) {
+	return c.restoreEvent.CallUnary(ctx, req)
+}
+
+// SaveEventTemplate calls picture.v1.PictureService.SaveEventTemplate.
+func (c *pictureServiceClient) SaveEventTemplate(ctx context.Context, req *connect.Request[v1.SaveEventTemplateRequest]) (*connect.Response[v1.EventTemplate], error) {
+	return c.saveEventTemplate.CallUnary(ctx, req)
+}
+
+// GetEventTemplates calls picture.v1.PictureService.GetEventTemplates.
+func (c *pictureServiceClient) GetEventTemplates(ctx context.Context, req *connect.Request[v1.GetEventTemplatesRequest]) (*connect.Response[v1.GetEventTemplatesResponse], error) {
+	return c.getEventTemplates.CallUnary(ctx, req)
+}
+
+// DeleteEventTemplate calls picture.v1.PictureService.DeleteEventTemplate.
+func (c *pictureServiceClient) DeleteEventTemplate(ctx context.Context, req *connect.Request[v1.DeleteEventTemplateRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteEventTemplate.CallUnary(ctx, req)
+}
+
+// Upload calls picture.v1.PictureService.Upload.
+func (c *pictureServiceClient) Upload(ctx context.Context, req *connect.Request[v1.UploadRequest]) (*connect.Response[v1.UploadResponse], error) {
+	return c.upload.CallUnary(ctx, req)
+}
+
+// PresignUpload calls picture.v1.PictureService.PresignUpload.
+func (c *pictureServiceClient) PresignUpload(ctx context.Context, req *connect.Request[v1.PresignUploadRequest]) (*connect.Response[v1.PresignUploadResponse], error) {
+	return c.presignUpload.CallUnary(ctx, req)
+}
+
+// CompleteUpload calls picture.v1.PictureService.CompleteUpload.
+func (c *pictureServiceClient) CompleteUpload(ctx context.Context, req *connect.Request[v1.CompleteUploadRequest]) (*connect.Response[v1.UploadResponse], error) {
+	return c.completeUpload.CallUnary(ctx, req)
+}
+
+// GetThumbnails calls picture.v1.PictureService.GetThumbnails.
+func (c *pictureServiceClient) GetThumbnails(ctx context.Context, req *connect.Request[v1.GetThumbnailsRequest]) (*connect.Response[v1.GetThumbnailsResponse], error) {
+	return c.getThumbnails.CallUnary(ctx, req)
+}
+
+// HideMedia calls picture.v1.PictureService.HideMedia.
+func (c *pictureServiceClient) HideMedia(ctx context.Context, req *connect.Request[v1.HideMediaRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.hideMedia.CallUnary(ctx, req)
+}
+
+// DeleteMedia calls picture.v1.PictureService.DeleteMedia.
+func (c *pictureServiceClient) DeleteMedia(ctx context.Context, req *connect.Request[v1.DeleteMediaRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteMedia.CallUnary(ctx, req)
+}
+
+// MigrateEventStorage calls picture.v1.PictureService.MigrateEventStorage.
+func (c *pictureServiceClient) MigrateEventStorage(ctx context.Context, req *connect.Request[v1.MigrateEventStorageRequest]) (*connect.Response[v1.StorageMigration], error) {
+	return c.migrateEventStorage.CallUnary(ctx, req)
+}
+
+// GetStorageMigration calls picture.v1.PictureService.GetStorageMigration.
+func (c *pictureServiceClient) GetStorageMigration(ctx context.Context, req *connect.Request[v1.GetStorageMigrationRequest]) (*connect.Response[v1.StorageMigration], error) {
+	return c.getStorageMigration.CallUnary(ctx, req)
+}
+
+// PictureServiceHandler is an implementation of the picture.v1.PictureService service.
+type PictureServiceHandler interface {
+	CreateEvent(context.Context, *connect.Request[v1.CreateEventRequest]) (*connect.Response[v1.CreateEventResponse], error)
+	SetEventLive(context.Context, *connect.Request[v1.SetEventLiveRequest]) (*connect.Response[v1.SetEventLiveResponse], error)
+	SetEventSchedule(context.Context, *connect.Request[v1.SetEventScheduleRequest]) (*connect.Response[v1.SetEventScheduleResponse], error)
+	UpdateEvent(context.Context, *connect.Request[v1.UpdateEventRequest]) (*connect.Response[v1.UpdateEventResponse], error)
+	SetEventBranding(context.Context, *connect.Request[v1.SetEventBrandingRequest]) (*connect.Response[v1.SetEventBrandingResponse], error)
+	GetEvents(context.Context, *connect.Request[v1.GetEventsRequest]) (*connect.Response[v1.GetEventsResponse], error)
+	GetEvent(context.Context, *connect.Request[v1.GetEventRequest]) (*connect.Response[v1.GetEventResponse], error)
+	GetActiveEvent(context.Context, *connect.Request[v1.GetActiveEventRequest]) (*connect.Response[v1.GetEventResponse], error)
+	SetActiveEvent(context.Context, *connect.Request[v1.SetActiveEventRequest]) (*connect.Response[emptypb.Empty], error)
+	DeleteEvent(context.Context, *connect.Request[v1.DeleteEventRequest]) (*connect.Response[emptypb.Empty], error)
+	GetTrashedEvents(context.Context, *connect.Request[v1.GetTrashedEventsRequest]) (*connect.Response[v1.GetEventsResponse], error)
+	RestoreEvent(context.Context, *connect.Request[v1.RestoreEventRequest]) (*connect.Response[v1.RestoreEventResponse], error)
+	SaveEventTemplate(context.Context, *connect.Request[v1.SaveEventTemplateRequest]) (*connect.Response[v1.EventTemplate], error)
+	GetEventTemplates(context.Context, *connect.Request[v1.GetEventTemplatesRequest]) (*connect.Response[v1.GetEventTemplatesResponse], error)
+	DeleteEventTemplate(context.Context, *connect.Request[v1.DeleteEventTemplateRequest]) (*connect.Response[emptypb.Empty], error)
+	Upload(context.Context, *connect.Request[v1.UploadRequest]) (*connect.Response[v1.UploadResponse], error)
+	PresignUpload(context.Context, *connect.Request[v1.PresignUploadRequest]) (*connect.Response[v1.PresignUploadResponse], error)
+	CompleteUpload(context.Context, *connect.Request[v1.CompleteUploadRequest]) (*connect.Response[v1.UploadResponse], error)
+	GetThumbnails(context.Context, *connect.Request[v1.GetThumbnailsRequest]) (*connect.Response[v1.GetThumbnailsResponse], error)
+	HideMedia(context.Context, *connect.Request[v1.HideMediaRequest]) (*connect.Response[emptypb.Empty], error)
+	DeleteMedia(context.Context, *connect.Request[v1.DeleteMediaRequest]) (*connect.Response[emptypb.Empty], error)
+	MigrateEventStorage(context.Context, *connect.Request[v1.MigrateEventStorageRequest]) (*connect.Response[v1.StorageMigration], error)
+	GetStorageMigration(context.Context, *connect.Request[v1.GetStorageMigrationRequest]) (*connect.Response[v1.StorageMigration], error)
+}
+
+// NewPictureServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewPictureServiceHandler(svc PictureServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	pictureServiceMethods := v1.File_picture_v1_picture_proto.Services().ByName("PictureService").Methods()
+	pictureServiceCreateEventHandler := connect.NewUnaryHandler(
+		PictureServiceCreateEventProcedure,
+		svc.CreateEvent,
+		connect.WithSchema(pictureServiceMethods.ByName("CreateEvent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceSetEventLiveHandler := connect.NewUnaryHandler(
+		PictureServiceSetEventLiveProcedure,
+		svc.SetEventLive,
+		connect.WithSchema(pictureServiceMethods.ByName("SetEventLive")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceSetEventScheduleHandler := connect.NewUnaryHandler(
+		PictureServiceSetEventScheduleProcedure,
+		svc.SetEventSchedule,
+		connect.WithSchema(pictureServiceMethods.ByName("SetEventSchedule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceUpdateEventHandler := connect.NewUnaryHandler(
+		PictureServiceUpdateEventProcedure,
+		svc.UpdateEvent,
+		connect.WithSchema(pictureServiceMethods.ByName("UpdateEvent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceSetEventBrandingHandler := connect.NewUnaryHandler(
+		PictureServiceSetEventBrandingProcedure,
+		svc.SetEventBranding,
+		connect.WithSchema(pictureServiceMethods.ByName("SetEventBranding")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceGetEventsHandler := connect.NewUnaryHandler(
+		PictureServiceGetEventsProcedure,
+		svc.GetEvents,
+		connect.WithSchema(pictureServiceMethods.ByName("GetEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceGetEventHandler := connect.NewUnaryHandler(
+		PictureServiceGetEventProcedure,
+		svc.GetEvent,
+		connect.WithSchema(pictureServiceMethods.ByName("GetEvent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceGetActiveEventHandler := connect.NewUnaryHandler(
+		PictureServiceGetActiveEventProcedure,
+		svc.GetActiveEvent,
+		connect.WithSchema(pictureServiceMethods.ByName("GetActiveEvent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceSetActiveEventHandler := connect.NewUnaryHandler(
+		PictureServiceSetActiveEventProcedure,
+		svc.SetActiveEvent,
+		connect.WithSchema(pictureServiceMethods.ByName("SetActiveEvent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceDeleteEventHandler := connect.NewUnaryHandler(
+		PictureServiceDeleteEventProcedure,
+		svc.DeleteEvent,
+		connect.WithSchema(pictureServiceMethods.ByName("DeleteEvent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceGetTrashedEventsHandler := connect.NewUnaryHandler(
+		PictureServiceGetTrashedEventsProcedure,
+		svc.GetTrashedEvents,
+		connect.WithSchema(pictureServiceMethods.ByName("GetTrashedEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceRestoreEventHandler := connect.NewUnaryHandler(
+		PictureServiceRestoreEventProcedure,
+		svc.RestoreEvent,
+		connect.WithSchema(pictureServiceMethods.ByName("RestoreEvent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceSaveEventTemplateHandler := connect.NewUnaryHandler(
+		PictureServiceSaveEventTemplateProcedure,
+		svc.SaveEventTemplate,
+		connect.WithSchema(pictureServiceMethods.ByName("SaveEventTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceGetEventTemplatesHandler := connect.NewUnaryHandler(
+		PictureServiceGetEventTemplatesProcedure,
+		svc.GetEventTemplates,
+		connect.WithSchema(pictureServiceMethods.ByName("GetEventTemplates")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceDeleteEventTemplateHandler := connect.NewUnaryHandler(
+		PictureServiceDeleteEventTemplateProcedure,
+		svc.DeleteEventTemplate,
+		connect.WithSchema(pictureServiceMethods.ByName("DeleteEventTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceUploadHandler := connect.NewUnaryHandler(
+		PictureServiceUploadProcedure,
+		svc.Upload,
+		connect.WithSchema(pictureServiceMethods.ByName("Upload")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServicePresignUploadHandler := connect.NewUnaryHandler(
+		PictureServicePresignUploadProcedure,
+		svc.PresignUpload,
+		connect.WithSchema(pictureServiceMethods.ByName("PresignUpload")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceCompleteUploadHandler := connect.NewUnaryHandler(
+		PictureServiceCompleteUploadProcedure,
+		svc.CompleteUpload,
+		connect.WithSchema(pictureServiceMethods.ByName("CompleteUpload")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceGetThumbnailsHandler := connect.NewUnaryHandler(
+		PictureServiceGetThumbnailsProcedure,
+		svc.GetThumbnails,
+		connect.WithSchema(pictureServiceMethods.ByName("GetThumbnails")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceHideMediaHandler := connect.NewUnaryHandler(
+		PictureServiceHideMediaProcedure,
+		svc.HideMedia,
+		connect.WithSchema(pictureServiceMethods.ByName("HideMedia")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceDeleteMediaHandler := connect.NewUnaryHandler(
+		PictureServiceDeleteMediaProcedure,
+		svc.DeleteMedia,
+		connect.WithSchema(pictureServiceMethods.ByName("DeleteMedia")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceMigrateEventStorageHandler := connect.NewUnaryHandler(
+		PictureServiceMigrateEventStorageProcedure,
+		svc.MigrateEventStorage,
+		connect.WithSchema(pictureServiceMethods.ByName("MigrateEventStorage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	pictureServiceGetStorageMigrationHandler := connect.NewUnaryHandler(
+		PictureServiceGetStorageMigrationProcedure,
+		svc.GetStorageMigration,
+		connect.WithSchema(pictureServiceMethods.ByName("GetStorageMigration")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/picture.v1.PictureService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case PictureServiceCreateEventProcedure:
+			pictureServiceCreateEventHandler.ServeHTTP(w, r)
+		case PictureServiceSetEventLiveProcedure:
+			pictureServiceSetEventLiveHandler.ServeHTTP(w, r)
+		case PictureServiceSetEventScheduleProcedure:
+			pictureServiceSetEventScheduleHandler.ServeHTTP(w, r)
+		case PictureServiceUpdateEventProcedure:
+			pictureServiceUpdateEventHandler.ServeHTTP(w, r)
+		case PictureServiceSetEventBrandingProcedure:
+			pictureServiceSetEventBrandingHandler.ServeHTTP(w, r)
+		case PictureServiceGetEventsProcedure:
+			pictureServiceGetEventsHandler.ServeHTTP(w, r)
+		case PictureServiceGetEventProcedure:
+			pictureServiceGetEventHandler.ServeHTTP(w, r)
+		case PictureServiceGetActiveEventProcedure:
+			pictureServiceGetActiveEventHandler.ServeHTTP(w, r)
+		case PictureServiceSetActiveEventProcedure:
+			pictureServiceSetActiveEventHandler.ServeHTTP(w, r)
+		case PictureServiceDeleteEventProcedure:
+			pictureServiceDeleteEventHandler.ServeHTTP(w, r)
+		case PictureServiceGetTrashedEventsProcedure:
+			pictureServiceGetTrashedEventsHandler.ServeHTTP(w, r)
+		case PictureServiceRestoreEventProcedure:
+			pictureServiceRestoreEventHandler.ServeHTTP(w, r)
+		case PictureServiceSaveEventTemplateProcedure:
+			pictureServiceSaveEventTemplateHandler.ServeHTTP(w, r)
+		case PictureServiceGetEventTemplatesProcedure:
+			pictureServiceGetEventTemplatesHandler.ServeHTTP(w, r)
+		case PictureServiceDeleteEventTemplateProcedure:
+			pictureServiceDeleteEventTemplateHandler.ServeHTTP(w, r)
+		case PictureServiceUploadProcedure:
+			pictureServiceUploadHandler.ServeHTTP(w, r)
+		case PictureServicePresignUploadProcedure:
+			pictureServicePresignUploadHandler.ServeHTTP(w, r)
+		case PictureServiceCompleteUploadProcedure:
+			pictureServiceCompleteUploadHandler.ServeHTTP(w, r)
+		case PictureServiceGetThumbnailsProcedure:
+			pictureServiceGetThumbnailsHandler.ServeHTTP(w, r)
+		case PictureServiceHideMediaProcedure:
+			pictureServiceHideMediaHandler.ServeHTTP(w, r)
+		case PictureServiceDeleteMediaProcedure:
+			pictureServiceDeleteMediaHandler.ServeHTTP(w, r)
+		case PictureServiceMigrateEventStorageProcedure:
+			pictureServiceMigrateEventStorageHandler.ServeHTTP(w, r)
+		case PictureServiceGetStorageMigrationProcedure:
+			pictureServiceGetStorageMigrationHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedPictureServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedPictureServiceHandler struct{}
+
+func (UnimplementedPictureServiceHandler) CreateEvent(context.Context, *connect.Request[v1.CreateEventRequest]) (*connect.Response[v1.CreateEventResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.CreateEvent is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) SetEventLive(context.Context, *connect.Request[v1.SetEventLiveRequest]) (*connect.Response[v1.SetEventLiveResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.SetEventLive is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) SetEventSchedule(context.Context, *connect.Request[v1.SetEventScheduleRequest]) (*connect.Response[v1.SetEventScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.SetEventSchedule is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) UpdateEvent(context.Context, *connect.Request[v1.UpdateEventRequest]) (*connect.Response[v1.UpdateEventResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.UpdateEvent is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) SetEventBranding(context.Context, *connect.Request[v1.SetEventBrandingRequest]) (*connect.Response[v1.SetEventBrandingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.SetEventBranding is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) GetEvents(context.Context, *connect.Request[v1.GetEventsRequest]) (*connect.Response[v1.GetEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.GetEvents is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) GetEvent(context.Context, *connect.Request[v1.GetEventRequest]) (*connect.Response[v1.GetEventResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.GetEvent is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) GetActiveEvent(context.Context, *connect.Request[v1.GetActiveEventRequest]) (*connect.Response[v1.GetEventResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.GetActiveEvent is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) SetActiveEvent(context.Context, *connect.Request[v1.SetActiveEventRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.SetActiveEvent is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) DeleteEvent(context.Context, *connect.Request[v1.DeleteEventRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.DeleteEvent is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) GetTrashedEvents(context.Context, *connect.Request[v1.GetTrashedEventsRequest]) (*connect.Response[v1.GetEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.GetTrashedEvents is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) RestoreEvent(context.Context, *connect.Request[v1.RestoreEventRequest]) (*connect.Response[v1.RestoreEventResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.RestoreEvent is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) SaveEventTemplate(context.Context, *connect.Request[v1.SaveEventTemplateRequest]) (*connect.Response[v1.EventTemplate], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.SaveEventTemplate is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) GetEventTemplates(context.Context, *connect.Request[v1.GetEventTemplatesRequest]) (*connect.Response[v1.GetEventTemplatesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.GetEventTemplates is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) DeleteEventTemplate(context.Context, *connect.Request[v1.DeleteEventTemplateRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.DeleteEventTemplate is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) Upload(context.Context, *connect.Request[v1.UploadRequest]) (*connect.Response[v1.UploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.Upload is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) PresignUpload(context.Context, *connect.Request[v1.PresignUploadRequest]) (*connect.Response[v1.PresignUploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.PresignUpload is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) CompleteUpload(context.Context, *connect.Request[v1.CompleteUploadRequest]) (*connect.Response[v1.UploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.CompleteUpload is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) GetThumbnails(context.Context, *connect.Request[v1.GetThumbnailsRequest]) (*connect.Response[v1.GetThumbnailsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.GetThumbnails is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) HideMedia(context.Context, *connect.Request[v1.HideMediaRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.HideMedia is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) DeleteMedia(context.Context, *connect.Request[v1.DeleteMediaRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.DeleteMedia is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) MigrateEventStorage(context.Context, *connect.Request[v1.MigrateEventStorageRequest]) (*connect.Response[v1.StorageMigration], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.MigrateEventStorage is not implemented"))
+}
+
+func (UnimplementedPictureServiceHandler) GetStorageMigration(context.Context, *connect.Request[v1.GetStorageMigrationRequest]) (*connect.Response[v1.StorageMigration], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("picture.v1.PictureService.GetStorageMigration is not implemented"))
+}
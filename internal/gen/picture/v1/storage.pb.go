@@ -73,6 +73,7 @@ type S3 struct {
 	Region        string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
 	Endpoint      string                 `protobuf:"bytes,5,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
 	Insecure      bool                   `protobuf:"varint,6,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	Presigned     bool                   `protobuf:"varint,7,opt,name=presigned,proto3" json:"presigned,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -149,6 +150,66 @@ func (x *S3) GetInsecure() bool {
 	return false
 }
 
+func (x *S3) GetPresigned() bool {
+	if x != nil {
+		return x.Presigned
+	}
+	return false
+}
+
+// New keys for an events existing S3 bucket
+type S3Credentials struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessKey     string                 `protobuf:"bytes,1,opt,name=access_key,json=accessKey,proto3" json:"access_key,omitempty"`
+	SecretKey     string                 `protobuf:"bytes,2,opt,name=secret_key,json=secretKey,proto3" json:"secret_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *S3Credentials) Reset() {
+	*x = S3Credentials{}
+	mi := &file_picture_v1_storage_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S3Credentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S3Credentials) ProtoMessage() {}
+
+func (x *S3Credentials) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_storage_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S3Credentials.ProtoReflect.Descriptor instead.
+func (*S3Credentials) Descriptor() ([]byte, []int) {
+	return file_picture_v1_storage_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *S3Credentials) GetAccessKey() string {
+	if x != nil {
+		return x.AccessKey
+	}
+	return ""
+}
+
+func (x *S3Credentials) GetSecretKey() string {
+	if x != nil {
+		return x.SecretKey
+	}
+	return ""
+}
+
 type GoogleDrive struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FolderId      string                 `protobuf:"bytes,1,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
@@ -158,7 +219,7 @@ type GoogleDrive struct {
 
 func (x *GoogleDrive) Reset() {
 	*x = GoogleDrive{}
-	mi := &file_picture_v1_storage_proto_msgTypes[2]
+	mi := &file_picture_v1_storage_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -170,7 +231,7 @@ func (x *GoogleDrive) String() string {
 func (*GoogleDrive) ProtoMessage() {}
 
 func (x *GoogleDrive) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_storage_proto_msgTypes[2]
+	mi := &file_picture_v1_storage_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -183,7 +244,7 @@ func (x *GoogleDrive) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoogleDrive.ProtoReflect.Descriptor instead.
 func (*GoogleDrive) Descriptor() ([]byte, []int) {
-	return file_picture_v1_storage_proto_rawDescGZIP(), []int{2}
+	return file_picture_v1_storage_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GoogleDrive) GetFolderId() string {
@@ -205,7 +266,7 @@ type Ftp struct {
 
 func (x *Ftp) Reset() {
 	*x = Ftp{}
-	mi := &file_picture_v1_storage_proto_msgTypes[3]
+	mi := &file_picture_v1_storage_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -217,7 +278,7 @@ func (x *Ftp) String() string {
 func (*Ftp) ProtoMessage() {}
 
 func (x *Ftp) ProtoReflect() protoreflect.Message {
-	mi := &file_picture_v1_storage_proto_msgTypes[3]
+	mi := &file_picture_v1_storage_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -230,7 +291,7 @@ func (x *Ftp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Ftp.ProtoReflect.Descriptor instead.
 func (*Ftp) Descriptor() ([]byte, []int) {
-	return file_picture_v1_storage_proto_rawDescGZIP(), []int{3}
+	return file_picture_v1_storage_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Ftp) GetAddress() string {
@@ -261,6 +322,59 @@ func (x *Ftp) GetDirectory() string {
 	return ""
 }
 
+// New login for an events existing FTP server
+type FtpCredentials struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FtpCredentials) Reset() {
+	*x = FtpCredentials{}
+	mi := &file_picture_v1_storage_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FtpCredentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FtpCredentials) ProtoMessage() {}
+
+func (x *FtpCredentials) ProtoReflect() protoreflect.Message {
+	mi := &file_picture_v1_storage_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FtpCredentials.ProtoReflect.Descriptor instead.
+func (*FtpCredentials) Descriptor() ([]byte, []int) {
+	return file_picture_v1_storage_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FtpCredentials) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *FtpCredentials) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
 var File_picture_v1_storage_proto protoreflect.FileDescriptor
 
 const file_picture_v1_storage_proto_rawDesc = "" +
@@ -269,7 +383,7 @@ const file_picture_v1_storage_proto_rawDesc = "" +
 	"picture.v1\"*\n" +
 	"\n" +
 	"Filesystem\x12\x1c\n" +
-	"\tdirectory\x18\x01 \x01(\tR\tdirectory\"\xaa\x01\n" +
+	"\tdirectory\x18\x01 \x01(\tR\tdirectory\"\xc8\x01\n" +
 	"\x02S3\x12\x16\n" +
 	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x1d\n" +
 	"\n" +
@@ -278,14 +392,23 @@ const file_picture_v1_storage_proto_rawDesc = "" +
 	"secret_key\x18\x03 \x01(\tR\tsecretKey\x12\x16\n" +
 	"\x06region\x18\x04 \x01(\tR\x06region\x12\x1a\n" +
 	"\bendpoint\x18\x05 \x01(\tR\bendpoint\x12\x1a\n" +
-	"\binsecure\x18\x06 \x01(\bR\binsecure\"*\n" +
+	"\binsecure\x18\x06 \x01(\bR\binsecure\x12\x1c\n" +
+	"\tpresigned\x18\a \x01(\bR\tpresigned\"M\n" +
+	"\rS3Credentials\x12\x1d\n" +
+	"\n" +
+	"access_key\x18\x01 \x01(\tR\taccessKey\x12\x1d\n" +
+	"\n" +
+	"secret_key\x18\x02 \x01(\tR\tsecretKey\"*\n" +
 	"\vGoogleDrive\x12\x1b\n" +
 	"\tfolder_id\x18\x01 \x01(\tR\bfolderId\"u\n" +
 	"\x03Ftp\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1c\n" +
-	"\tdirectory\x18\x04 \x01(\tR\tdirectoryB\xa7\x01\n" +
+	"\tdirectory\x18\x04 \x01(\tR\tdirectory\"H\n" +
+	"\x0eFtpCredentials\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpasswordB\xa7\x01\n" +
 	"\x0ecom.picture.v1B\fStorageProtoP\x01Z>github.com/jj-style/eventpix/internal/gen/picture/v1;picturev1\xa2\x02\x03PXX\xaa\x02\n" +
 	"Picture.V1\xca\x02\n" +
 	"Picture\\V1\xe2\x02\x16Picture\\V1\\GPBMetadata\xea\x02\vPicture::V1b\x06proto3"
@@ -302,12 +425,14 @@ func file_picture_v1_storage_proto_rawDescGZIP() []byte {
 	return file_picture_v1_storage_proto_rawDescData
 }
 
-var file_picture_v1_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_picture_v1_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_picture_v1_storage_proto_goTypes = []any{
-	(*Filesystem)(nil),  // 0: picture.v1.Filesystem
-	(*S3)(nil),          // 1: picture.v1.S3
-	(*GoogleDrive)(nil), // 2: picture.v1.GoogleDrive
-	(*Ftp)(nil),         // 3: picture.v1.Ftp
+	(*Filesystem)(nil),     // 0: picture.v1.Filesystem
+	(*S3)(nil),             // 1: picture.v1.S3
+	(*S3Credentials)(nil),  // 2: picture.v1.S3Credentials
+	(*GoogleDrive)(nil),    // 3: picture.v1.GoogleDrive
+	(*Ftp)(nil),            // 4: picture.v1.Ftp
+	(*FtpCredentials)(nil), // 5: picture.v1.FtpCredentials
 }
 var file_picture_v1_storage_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -328,7 +453,7 @@ func file_picture_v1_storage_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_picture_v1_storage_proto_rawDesc), len(file_picture_v1_storage_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,100 @@
+// Package branding has what events galleries can be styled with, the fonts
+// they can be shown in and the Markdown guests are welcomed with.
+package branding
+
+import (
+	"bytes"
+	"html/template"
+	"net/url"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// Font is one of the fonts galleries can be shown in
+type Font struct {
+	Name string
+	// CSS font-family of the font, with fallbacks for whilst it loads
+	Family template.CSS
+	// family to load from Google Fonts, empty if it's already on the guests device
+	google string
+}
+
+// Href is the stylesheet to load the font from, empty if there isn't one
+func (f Font) Href() string {
+	if f.google == "" {
+		return ""
+	}
+	return "https://fonts.googleapis.com/css2?" + url.Values{"family": {f.google}, "display": {"swap"}}.Encode()
+}
+
+// what galleries are shown with if their event doesn't choose otherwise
+const (
+	DefaultFont            = "Delius Swash Caps"
+	DefaultPrimaryColor    = "#ff4f79"
+	DefaultBackgroundColor = "#f0ffff"
+)
+
+// Fonts galleries can be shown in
+var Fonts = []Font{
+	{Name: DefaultFont, Family: `"Delius Swash Caps", serif`, google: "Delius Swash Caps"},
+	{Name: "Dancing Script", Family: `"Dancing Script", cursive`, google: "Dancing Script"},
+	{Name: "Great Vibes", Family: `"Great Vibes", cursive`, google: "Great Vibes"},
+	{Name: "Playfair Display", Family: `"Playfair Display", serif`, google: "Playfair Display"},
+	{Name: "Lora", Family: `"Lora", serif`, google: "Lora"},
+	{Name: "Montserrat", Family: `"Montserrat", sans-serif`, google: "Montserrat"},
+	{Name: "Raleway", Family: `"Raleway", sans-serif`, google: "Raleway"},
+	{Name: "System", Family: `system-ui, -apple-system, "Segoe UI", Roboto, sans-serif`},
+}
+
+// LookupFont gets the named font, the default font if the name is empty
+func LookupFont(name string) (Font, bool) {
+	if name == "" {
+		name = DefaultFont
+	}
+	for _, f := range Fonts {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Font{}, false
+}
+
+// Style is what a gallery is shown with, its events branding with the defaults for anything it doesn't set
+type Style struct {
+	PrimaryColor    string
+	BackgroundColor string
+	Font            Font
+}
+
+// NewStyle gets the style of a gallery branded with the colours and font
+func NewStyle(primaryColor, backgroundColor, font string) Style {
+	style := Style{PrimaryColor: primaryColor, BackgroundColor: backgroundColor}
+	if style.PrimaryColor == "" {
+		style.PrimaryColor = DefaultPrimaryColor
+	}
+	if style.BackgroundColor == "" {
+		style.BackgroundColor = DefaultBackgroundColor
+	}
+	var ok bool
+	if style.Font, ok = LookupFont(font); !ok {
+		style.Font, _ = LookupFont(DefaultFont)
+	}
+	return style
+}
+
+var (
+	md = goldmark.New(goldmark.WithExtensions(extension.Linkify, extension.Strikethrough))
+	// goldmark already leaves out raw HTML, this is in case anything else gets through
+	policy = bluemonday.UGCPolicy().AddTargetBlankToFullyQualifiedLinks(true)
+)
+
+// Markdown renders the Markdown as HTML which is safe to show guests
+func Markdown(src string) template.HTML {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(src), &buf); err != nil {
+		return template.HTML(template.HTMLEscapeString(src))
+	}
+	return template.HTML(policy.SanitizeBytes(buf.Bytes()))
+}
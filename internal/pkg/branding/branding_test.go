@@ -0,0 +1,84 @@
+package branding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		src      string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "formatting",
+			src:      "# Welcome\n\nThanks for coming to **our wedding**, see [the venue](https://example.com)",
+			contains: []string{"<h1>Welcome</h1>", "<strong>our wedding</strong>", `href="https://example.com"`},
+		},
+		{
+			name:     "raw html is left out",
+			src:      "hello <script>alert(1)</script><img src=x onerror=alert(1)>",
+			contains: []string{"hello"},
+			excludes: []string{"<script", "onerror"},
+		},
+		{
+			name:     "script links are left out",
+			src:      "[click me](javascript:alert(1))",
+			contains: []string{"click me"},
+			excludes: []string{"javascript:"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := require.New(t)
+			got := string(Markdown(tt.src))
+			for _, want := range tt.contains {
+				is.Contains(got, want)
+			}
+			for _, exclude := range tt.excludes {
+				is.NotContains(got, exclude)
+			}
+		})
+	}
+}
+
+func TestLookupFont(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+
+	font, ok := LookupFont("")
+	is.True(ok)
+	is.Equal(DefaultFont, font.Name)
+	is.Equal("https://fonts.googleapis.com/css2?display=swap&family=Delius+Swash+Caps", font.Href())
+
+	font, ok = LookupFont("System")
+	is.True(ok)
+	is.Empty(font.Href())
+
+	_, ok = LookupFont("Comic Sans")
+	is.False(ok)
+}
+
+func TestNewStyle(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+
+	style := NewStyle("", "", "")
+	is.Equal(DefaultPrimaryColor, style.PrimaryColor)
+	is.Equal(DefaultBackgroundColor, style.BackgroundColor)
+	is.Equal(DefaultFont, style.Font.Name)
+
+	style = NewStyle("#112233", "#ffffff", "Lora")
+	is.Equal("#112233", style.PrimaryColor)
+	is.Equal("#ffffff", style.BackgroundColor)
+	is.Equal("Lora", style.Font.Name)
+
+	// fonts which are no longer available fall back to the default
+	is.Equal(DefaultFont, NewStyle("", "", "Comic Sans").Font.Name)
+}
@@ -0,0 +1,191 @@
+// Streaming authenticated encryption of media.
+//
+// Data is split into chunks which are each sealed with AES-256-GCM, so large files
+// (e.g. videos) can be encrypted and decrypted without holding them in memory.
+// Each chunks nonce is made of a random per-stream prefix, the chunk counter and a flag
+// marking the final chunk, so chunks can't be reordered, dropped or the stream truncated.
+//
+//	header: magic (4 bytes) | nonce prefix (7 bytes)
+//	chunk:  ciphertext of up to ChunkSize bytes of plaintext | GCM tag (16 bytes)
+package encrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// size of the data key used to encrypt media
+	KeySize = 32
+	// amount of plaintext sealed in each chunk
+	ChunkSize = 64 * 1024
+
+	prefixSize = 7
+)
+
+var (
+	magic = []byte("EPX\x01")
+
+	ErrInvalidHeader = errors.New("invalid encryption header")
+	ErrCorrupt       = errors.New("encrypted data is corrupt, truncated or the key is incorrect")
+)
+
+// NewKey generates a random data key
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// NewEncryptReader returns a reader of the encrypted contents of r
+func NewEncryptReader(key []byte, r io.Reader) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, prefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+	return &encryptReader{
+		stream: stream{aead: aead, prefix: prefix},
+		src:    r,
+		buf:    make([]byte, 0, ChunkSize+1),
+		out:    append(append([]byte{}, magic...), prefix...),
+	}, nil
+}
+
+// NewDecryptReader returns a reader of the decrypted contents of r, which must
+// have been encrypted by a reader from NewEncryptReader with the same key.
+// An error is returned from Read if the data has been tampered with.
+func NewDecryptReader(key []byte, r io.ReadCloser) (io.ReadCloser, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, len(magic)+prefixSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, ErrInvalidHeader
+	}
+	if string(header[:len(magic)]) != string(magic) {
+		return nil, ErrInvalidHeader
+	}
+	return &decryptReader{
+		stream: stream{aead: aead, prefix: header[len(magic):]},
+		src:    r,
+		buf:    make([]byte, 0, ChunkSize+aead.Overhead()+1),
+	}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("invalid key size %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+type stream struct {
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+}
+
+func (s *stream) nonce(last bool) []byte {
+	nonce := make([]byte, s.aead.NonceSize())
+	copy(nonce, s.prefix)
+	binary.BigEndian.PutUint32(nonce[prefixSize:], s.counter)
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+	s.counter++
+	return nonce
+}
+
+// reads the next chunk of n bytes from src, keeping one byte of lookahead in buf
+// to know whether the chunk is the last in the stream.
+func nextChunk(src io.Reader, buf []byte, n int) ([]byte, []byte, bool, error) {
+	got, err := io.ReadFull(src, buf[len(buf):n+1])
+	buf = buf[:len(buf)+got]
+	switch {
+	case err == nil:
+		// a byte past the chunk was read, so there is more to come
+		return buf[:n], buf[n:], false, nil
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return buf, buf[:0], true, nil
+	default:
+		return nil, nil, false, err
+	}
+}
+
+type encryptReader struct {
+	stream
+	src  io.Reader
+	buf  []byte
+	out  []byte
+	done bool
+}
+
+func (e *encryptReader) Read(p []byte) (int, error) {
+	for len(e.out) == 0 {
+		if e.done {
+			return 0, io.EOF
+		}
+		chunk, rest, last, err := nextChunk(e.src, e.buf, ChunkSize)
+		if err != nil {
+			return 0, err
+		}
+		e.out = e.aead.Seal(e.out[:0], e.nonce(last), chunk, nil)
+		e.buf = append(e.buf[:0], rest...)
+		e.done = last
+	}
+	n := copy(p, e.out)
+	e.out = e.out[n:]
+	return n, nil
+}
+
+type decryptReader struct {
+	stream
+	src  io.ReadCloser
+	buf  []byte
+	out  []byte
+	done bool
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		chunk, rest, last, err := nextChunk(d.src, d.buf, ChunkSize+d.aead.Overhead())
+		if err != nil {
+			return 0, err
+		}
+		if len(chunk) < d.aead.Overhead() {
+			return 0, ErrCorrupt
+		}
+		d.out, err = d.aead.Open(d.out[:0], d.nonce(last), chunk, nil)
+		if err != nil {
+			return 0, ErrCorrupt
+		}
+		d.buf = append(d.buf[:0], rest...)
+		d.done = last
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+func (d *decryptReader) Close() error {
+	return d.src.Close()
+}
@@ -0,0 +1,101 @@
+package encrypt_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/jj-style/eventpix/internal/pkg/encrypt"
+	"github.com/stretchr/testify/require"
+)
+
+func encrypted(t *testing.T, key, data []byte) []byte {
+	t.Helper()
+	r, err := encrypt.NewEncryptReader(key, bytes.NewReader(data))
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return got
+}
+
+func decrypted(key, data []byte) ([]byte, error) {
+	r, err := encrypt.NewDecryptReader(key, io.NopCloser(bytes.NewReader(data)))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	key, err := encrypt.NewKey()
+	require.NoError(t, err)
+
+	for name, size := range map[string]int{
+		"empty":           0,
+		"small":           10,
+		"exactly a chunk": encrypt.ChunkSize,
+		"chunk and a bit": encrypt.ChunkSize + 1,
+		"many chunks":     encrypt.ChunkSize*3 + 123,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			is := require.New(t)
+
+			data := make([]byte, size)
+			_, _ = rand.Read(data)
+
+			ciphertext := encrypted(t, key, data)
+			if size > 0 {
+				is.NotContains(string(ciphertext), string(data))
+			}
+
+			got, err := decrypted(key, ciphertext)
+			is.NoError(err)
+			is.Equal(data, got)
+		})
+	}
+}
+
+func TestDecryptUnhappy(t *testing.T) {
+	t.Parallel()
+
+	key, err := encrypt.NewKey()
+	require.NoError(t, err)
+	data := make([]byte, encrypt.ChunkSize*2+10)
+	_, _ = rand.Read(data)
+	ciphertext := encrypted(t, key, data)
+
+	t.Run("wrong key", func(t *testing.T) {
+		t.Parallel()
+		other, err := encrypt.NewKey()
+		require.NoError(t, err)
+		_, err = decrypted(other, ciphertext)
+		require.ErrorIs(t, err, encrypt.ErrCorrupt)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		t.Parallel()
+		tampered := bytes.Clone(ciphertext)
+		tampered[len(tampered)/2] ^= 0xff
+		_, err := decrypted(key, tampered)
+		require.ErrorIs(t, err, encrypt.ErrCorrupt)
+	})
+
+	t.Run("truncated at chunk boundary", func(t *testing.T) {
+		t.Parallel()
+		// header + first two full chunks, dropping the final chunk
+		truncated := ciphertext[:11+2*(encrypt.ChunkSize+16)]
+		_, err := decrypted(key, truncated)
+		require.ErrorIs(t, err, encrypt.ErrCorrupt)
+	})
+
+	t.Run("not encrypted", func(t *testing.T) {
+		t.Parallel()
+		_, err := decrypted(key, []byte("plain old data"))
+		require.ErrorIs(t, err, encrypt.ErrInvalidHeader)
+	})
+}
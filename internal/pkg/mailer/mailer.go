@@ -0,0 +1,91 @@
+// sends emails to users, such as password reset links
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/config"
+	"go.uber.org/zap"
+)
+
+// Mailer sends plain text emails
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// NewMailer creates the mailer in config, defaulting to one which
+// logs emails instead of sending them if nothing is specified
+func NewMailer(cfg *config.Config, logger *zap.Logger) (Mailer, error) {
+	mail := cfg.Mail
+	if mail == nil {
+		mail = &config.Mail{Mode: "log"}
+	}
+	switch mail.Mode {
+	case "", "log":
+		return &logMailer{log: logger.Sugar()}, nil
+	case "smtp":
+		if mail.Host == "" || mail.From == "" {
+			return nil, fmt.Errorf("smtp mailer needs a host and from address")
+		}
+		port := mail.Port
+		if port == 0 {
+			port = 25
+		}
+		return &smtpMailer{
+			addr:     net.JoinHostPort(mail.Host, strconv.Itoa(port)),
+			host:     mail.Host,
+			username: mail.Username,
+			password: mail.Password,
+			from:     mail.From,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown mail mode %s", mail.Mode)
+	}
+}
+
+// logMailer logs emails rather than sending them, for instances without a mail server
+type logMailer struct {
+	log *zap.SugaredLogger
+}
+
+func (l *logMailer) Send(_ context.Context, to, subject, body string) error {
+	l.log.Infow("email not sent, mail isn't configured", "to", to, "subject", subject, "body", body)
+	return nil
+}
+
+type smtpMailer struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+func (s *smtpMailer) Send(_ context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return fmt.Errorf("invalid email header")
+	}
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+	return smtp.SendMail(s.addr, auth, s.from, []string{to}, message(s.from, to, subject, body))
+}
+
+func message(from, to, subject, body string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,103 @@
+package mailer_test
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/pkg/mailer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeSmtp accepts a single email and sends what it received down the channel
+func fakeSmtp(t *testing.T) (string, int, <-chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+
+		reply("220 localhost")
+		var data strings.Builder
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					received <- data.String()
+					reply("250 OK")
+					continue
+				}
+				data.WriteString(line)
+				continue
+			}
+			switch cmd := strings.ToUpper(strings.Fields(line)[0]); cmd {
+			case "DATA":
+				inData = true
+				reply("354 go ahead")
+			case "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, p, received
+}
+
+func TestSmtpMailer(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+
+	host, port, received := fakeSmtp(t)
+	m, err := mailer.NewMailer(&config.Config{Mail: &config.Mail{
+		Mode: "smtp",
+		Host: host,
+		Port: port,
+		From: "eventpix@example.com",
+	}}, zap.NewNop())
+	is.NoError(err)
+
+	is.NoError(m.Send(t.Context(), "bob@example.com", "Reset your password", "click the link\nthanks"))
+	got := <-received
+	is.Contains(got, "From: eventpix@example.com\r\n")
+	is.Contains(got, "To: bob@example.com\r\n")
+	is.Contains(got, "Subject: Reset your password\r\n")
+	is.Contains(got, "click the link\r\nthanks")
+
+	is.Error(m.Send(t.Context(), "bob@example.com\r\nBcc: eve@example.com", "hi", "body"))
+}
+
+func TestNewMailer(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+
+	m, err := mailer.NewMailer(&config.Config{}, zap.NewNop())
+	is.NoError(err)
+	is.NoError(m.Send(t.Context(), "bob@example.com", "hi", "body"))
+
+	_, err = mailer.NewMailer(&config.Config{Mail: &config.Mail{Mode: "smtp"}}, zap.NewNop())
+	is.Error(err)
+	_, err = mailer.NewMailer(&config.Config{Mail: &config.Mail{Mode: "pigeon"}}, zap.NewNop())
+	is.Error(err)
+}
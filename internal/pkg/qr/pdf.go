@@ -0,0 +1,156 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"io"
+	"slices"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Layouts codes can be printed in
+const (
+	// a single code filling an A4 page
+	LayoutPoster = "poster"
+	// a single code on an A6 card to stand on tables
+	LayoutCard = "card"
+	// A4 pages of cards to cut out
+	LayoutSheet = "sheet"
+)
+
+// CardsPerSheet is how many cards a sheet can be split into
+var CardsPerSheet = []int{2, 4, 6, 8}
+
+// DefaultInstructions are printed under the code if none are given
+const DefaultInstructions = "Scan with your phone's camera to see and share photos"
+
+// pixels wide the code is drawn at, enough to stay sharp on a printed A4 poster
+const printSize = 1024
+
+// points in a millimetre, which the pages are measured in
+const ptPerMm = 72 / 25.4
+
+// Print is how a code is laid out on the page
+type Print struct {
+	Layout string
+	// cards on each sheet, only for LayoutSheet
+	Cards int
+	// printed above the code, usually the events name
+	Title string
+	// printed under the code
+	Instructions string
+}
+
+// PDF writes the code laid out to print
+func (p *Print) PDF(w io.Writer, code *Code) error {
+	size, cols, rows := "A4", 1, 1
+	switch p.Layout {
+	case LayoutPoster:
+	case LayoutCard:
+		size = "A6"
+	case LayoutSheet:
+		if !slices.Contains(CardsPerSheet, p.Cards) {
+			return fmt.Errorf("sheets can have %v cards", CardsPerSheet)
+		}
+		cols, rows = 2, p.Cards/2
+		if p.Cards == 2 {
+			cols, rows = 1, 2
+		}
+	default:
+		return fmt.Errorf("unknown layout %q", p.Layout)
+	}
+
+	img, err := code.PNG(printSize)
+	if err != nil {
+		return fmt.Errorf("drawing code: %w", err)
+	}
+
+	pdf := fpdf.New("P", "mm", size, "")
+	pdf.SetTitle(p.Title, true)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetMargins(0, 0, 0)
+	pdf.RegisterImageOptionsReader("code", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(img))
+	pdf.AddPage()
+
+	pageW, pageH := pdf.GetPageSize()
+	cellW, cellH := pageW/float64(cols), pageH/float64(rows)
+	for row := range rows {
+		for col := range cols {
+			p.card(pdf, code, float64(col)*cellW, float64(row)*cellH, cellW, cellH)
+		}
+	}
+	if cols*rows > 1 {
+		cutLines(pdf, cols, rows, cellW, cellH)
+	}
+
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+	return pdf.Output(w)
+}
+
+// card draws the title, code and instructions in the box
+func (p *Print) card(pdf *fpdf.Fpdf, code *Code, x, y, w, h float64) {
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	margin := min(w, h) * 0.06
+	textW := w - 2*margin
+
+	setFill(pdf, code.Background)
+	pdf.Rect(x, y, w, h, "F")
+	setText(pdf, code.Foreground)
+
+	// the title shrinks until it fits in two lines
+	title := tr(p.Title)
+	titleSize := h * 0.065 * ptPerMm
+	pdf.SetFont("Helvetica", "B", titleSize)
+	for titleSize > 8 && len(pdf.SplitLines([]byte(title), textW)) > 2 {
+		titleSize--
+		pdf.SetFontSize(titleSize)
+	}
+	pdf.SetXY(x+margin, y+margin)
+	pdf.MultiCell(textW, titleSize/ptPerMm*1.2, title, "", "C", false)
+
+	qrSize := min(textW, h*0.55)
+	qrY := y + h*0.22
+	pdf.ImageOptions("code", x+(w-qrSize)/2, qrY, qrSize, qrSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	instructions := p.Instructions
+	if instructions == "" {
+		instructions = DefaultInstructions
+	}
+	textSize := h * 0.03 * ptPerMm
+	pdf.SetFont("Helvetica", "", textSize)
+	pdf.SetXY(x+margin, qrY+qrSize+margin/2)
+	pdf.MultiCell(textW, textSize/ptPerMm*1.3, tr(instructions), "", "C", false)
+}
+
+// cutLines draws dashed lines between the cards on a sheet
+func cutLines(pdf *fpdf.Fpdf, cols, rows int, cellW, cellH float64) {
+	pageW, pageH := pdf.GetPageSize()
+	pdf.SetDrawColor(160, 160, 160)
+	pdf.SetLineWidth(0.2)
+	pdf.SetDashPattern([]float64{2, 2}, 0)
+	for col := 1; col < cols; col++ {
+		pdf.Line(float64(col)*cellW, 0, float64(col)*cellW, pageH)
+	}
+	for row := 1; row < rows; row++ {
+		pdf.Line(0, float64(row)*cellH, pageW, float64(row)*cellH)
+	}
+}
+
+func setFill(pdf *fpdf.Fpdf, c color.Color) {
+	r, g, b := rgb(c)
+	pdf.SetFillColor(r, g, b)
+}
+
+func setText(pdf *fpdf.Fpdf, c color.Color) {
+	r, g, b := rgb(c)
+	pdf.SetTextColor(r, g, b)
+}
+
+func rgb(c color.Color) (int, int, int) {
+	r, g, b, _ := c.RGBA()
+	return int(r >> 8), int(g >> 8), int(b >> 8)
+}
@@ -0,0 +1,141 @@
+// Package qr draws QR codes for events, with an optional logo in the middle,
+// and lays them out as PDFs to print as posters and table cards.
+package qr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/image/draw"
+)
+
+// Code is a QR code for a link, in the colours it's printed in
+type Code struct {
+	URL        string
+	Foreground color.Color
+	Background color.Color
+	// shown in the middle of the code, which is then made with the highest
+	// error correction so it still scans with part of it covered up
+	Logo image.Image
+}
+
+// qr encodes the URL, with the highest error correction if part of it is covered by a logo
+func (c *Code) qr() (*qrcode.QRCode, error) {
+	level := qrcode.Medium
+	if c.Logo != nil {
+		level = qrcode.Highest
+	}
+	q, err := qrcode.New(c.URL, level)
+	if err != nil {
+		return nil, err
+	}
+	q.ForegroundColor, q.BackgroundColor = c.Foreground, c.Background
+	return q, nil
+}
+
+// Image draws the code as a square image size pixels wide
+func (c *Code) Image(size int) (image.Image, error) {
+	q, err := c.qr()
+	if err != nil {
+		return nil, err
+	}
+	code := q.Image(size)
+	if c.Logo == nil {
+		return code, nil
+	}
+
+	img := image.NewRGBA(code.Bounds())
+	draw.Draw(img, img.Bounds(), code, code.Bounds().Min, draw.Src)
+
+	// the logo covers at most a fifth of the width of the code, well within what
+	// the highest error correction can recover, with a border so it stands out
+	box := size / 5
+	border := box / 10
+	centre := img.Bounds().Min.Add(image.Pt(img.Bounds().Dx()/2, img.Bounds().Dy()/2))
+	boxRect := image.Rect(centre.X-box/2-border, centre.Y-box/2-border, centre.X+box/2+border, centre.Y+box/2+border)
+	draw.Draw(img, boxRect, image.NewUniform(c.Background), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(img, fit(c.Logo.Bounds(), centre, box), c.Logo, c.Logo.Bounds(), draw.Over, nil)
+	return img, nil
+}
+
+// PNG draws the code as a PNG size pixels wide
+func (c *Code) PNG(size int) ([]byte, error) {
+	img, err := c.Image(size)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG draws the code as an SVG, which printers can scale to any size
+func (c *Code) SVG() ([]byte, error) {
+	q, err := c.qr()
+	if err != nil {
+		return nil, err
+	}
+	bitmap := q.Bitmap()
+	n := len(bitmap)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, n, n)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="%s"/>`, n, n, hex(c.Background))
+	// a run of dark squares along a row is drawn in one go
+	fmt.Fprintf(&buf, `<path fill="%s" d="`, hex(c.Foreground))
+	for y, row := range bitmap {
+		for x := 0; x < n; x++ {
+			if !row[x] {
+				continue
+			}
+			start := x
+			for x < n && row[x] {
+				x++
+			}
+			fmt.Fprintf(&buf, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+	buf.WriteString(`"/>`)
+
+	if c.Logo != nil {
+		var logo bytes.Buffer
+		if err := png.Encode(&logo, c.Logo); err != nil {
+			return nil, err
+		}
+		// the same size and border as the logo in Image
+		box := float64(n) / 5
+		border := box / 10
+		centre := float64(n) / 2
+		fmt.Fprintf(&buf, `<rect x="%g" y="%g" width="%g" height="%g" fill="%s"/>`,
+			centre-box/2-border, centre-box/2-border, box+2*border, box+2*border, hex(c.Background))
+		fmt.Fprintf(&buf, `<image x="%g" y="%g" width="%g" height="%g" href="data:image/png;base64,%s"/>`,
+			centre-box/2, centre-box/2, box, box, base64.StdEncoding.EncodeToString(logo.Bytes()))
+	}
+	buf.WriteString("</svg>")
+	return buf.Bytes(), nil
+}
+
+// hex formats the colour as #rrggbb
+func hex(c color.Color) string {
+	r, g, b := rgb(c)
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// fit gets the biggest rectangle with the same aspect ratio as the image which fits
+// in a square box wide, centred on the point
+func fit(src image.Rectangle, centre image.Point, box int) image.Rectangle {
+	w, h := box, box
+	if src.Dx() > src.Dy() {
+		h = box * src.Dy() / src.Dx()
+	} else {
+		w = box * src.Dx() / src.Dy()
+	}
+	return image.Rect(centre.X-w/2, centre.Y-h/2, centre.X-w/2+w, centre.Y-h/2+h)
+}
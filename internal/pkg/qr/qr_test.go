@@ -0,0 +1,129 @@
+package qr
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode(t *testing.T) {
+	t.Parallel()
+
+	red := color.RGBA{R: 255, A: 255}
+	logo := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	draw.Draw(logo, logo.Bounds(), image.NewUniform(red), image.Point{}, draw.Src)
+
+	t.Run("plain", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		code := &Code{URL: "https://example.com/event/wedding", Foreground: color.Black, Background: color.White}
+
+		img, err := code.Image(256)
+		is.NoError(err)
+		is.Equal(256, img.Bounds().Dx())
+		// the quiet zone around the code is the background
+		is.Equal(color.RGBAModel.Convert(color.White), color.RGBAModel.Convert(img.At(1, 1)))
+	})
+
+	t.Run("with logo", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		background := color.RGBA{R: 240, G: 255, B: 255, A: 255}
+		code := &Code{URL: "https://example.com/event/wedding", Foreground: color.Black, Background: background, Logo: logo}
+
+		img, err := code.Image(500)
+		is.NoError(err)
+		centre := image.Pt(img.Bounds().Dx()/2, img.Bounds().Dy()/2)
+		is.Equal(red, color.RGBAModel.Convert(img.At(centre.X, centre.Y)))
+		// the logo keeps its shape, so there's a border of background above and below it
+		is.Equal(background, color.RGBAModel.Convert(img.At(centre.X, centre.Y-40)))
+		// and it doesn't cover the rest of the code
+		is.NotEqual(red, color.RGBAModel.Convert(img.At(centre.X-60, centre.Y)))
+	})
+
+	t.Run("png", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		code := &Code{URL: "https://example.com", Foreground: color.Black, Background: color.White, Logo: logo}
+
+		data, err := code.PNG(128)
+		is.NoError(err)
+		img, format, err := image.Decode(bytes.NewReader(data))
+		is.NoError(err)
+		is.Equal("png", format)
+		is.Equal(128, img.Bounds().Dx())
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		code := &Code{URL: "https://example.com", Foreground: color.RGBA{R: 0x11, G: 0x22, B: 0x33, A: 255}, Background: color.White}
+
+		svg, err := code.SVG()
+		is.NoError(err)
+		var doc struct {
+			XMLName xml.Name `xml:"svg"`
+			ViewBox string   `xml:"viewBox,attr"`
+			Rects   []struct {
+				Fill string `xml:"fill,attr"`
+			} `xml:"rect"`
+			Path struct {
+				Fill string `xml:"fill,attr"`
+				D    string `xml:"d,attr"`
+			} `xml:"path"`
+			Images []struct{} `xml:"image"`
+		}
+		is.NoError(xml.Unmarshal(svg, &doc))
+		is.Equal("#ffffff", doc.Rects[0].Fill)
+		is.Equal("#112233", doc.Path.Fill)
+		is.NotEmpty(doc.Path.D)
+		is.Empty(doc.Images)
+
+		code.Logo = logo
+		svg, err = code.SVG()
+		is.NoError(err)
+		doc.Rects, doc.Images = nil, nil
+		is.NoError(xml.Unmarshal(svg, &doc))
+		is.Len(doc.Images, 1)
+		is.Len(doc.Rects, 2)
+	})
+}
+
+func TestPrint(t *testing.T) {
+	t.Parallel()
+
+	code := &Code{URL: "https://example.com/event/wedding", Foreground: color.Black, Background: color.RGBA{R: 240, G: 255, B: 255, A: 255}}
+
+	tests := []struct {
+		name    string
+		print   Print
+		wantErr bool
+	}{
+		{name: "poster", print: Print{Layout: LayoutPoster, Title: "Jo & Sam's Wedding"}},
+		{name: "card", print: Print{Layout: LayoutCard, Title: "Jo & Sam's Wedding", Instructions: "Share your photos with us!"}},
+		{name: "sheet", print: Print{Layout: LayoutSheet, Cards: 8, Title: "A very long event name which needs to be made smaller to fit on the card"}},
+		{name: "sheet of two", print: Print{Layout: LayoutSheet, Cards: 2, Title: "Café launch 🎉"}},
+		{name: "sheet of too many", print: Print{Layout: LayoutSheet, Cards: 100, Title: "party"}, wantErr: true},
+		{name: "unknown layout", print: Print{Layout: "billboard", Title: "party"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := require.New(t)
+
+			var buf bytes.Buffer
+			err := tt.print.PDF(&buf, code)
+			if tt.wantErr {
+				is.Error(err)
+				return
+			}
+			is.NoError(err)
+			is.True(bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strings"
+)
+
+// ApiTokenPrefix starts every API token, telling them apart from session tokens
+const ApiTokenPrefix = "epx_"
+
+// Scopes an API token can be granted
+const (
+	ScopeReadEvents   = "events:read"
+	ScopeManageEvents = "events:manage"
+	ScopeUpload       = "upload"
+	ScopeModerate     = "moderate"
+)
+
+type Scope struct {
+	Name        string
+	Description string
+}
+
+// Scopes lists every scope with a description of what it allows
+var Scopes = []Scope{
+	{ScopeReadEvents, "Read events and their media"},
+	{ScopeManageEvents, "Create, update and delete events"},
+	{ScopeUpload, "Upload media to events"},
+	{ScopeModerate, "Moderate media guests upload to events"},
+}
+
+// NewApiToken generates a random API token, returning it with the hash to store
+func NewApiToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := ApiTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, HashApiToken(token), nil
+}
+
+// HashApiToken hashes the token to look it up by. Tokens are long and random
+// so a fast hash is enough, unlike passwords.
+func HashApiToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsApiToken is whether the token is an API token, rather than a session token
+func IsApiToken(token string) bool {
+	return strings.HasPrefix(token, ApiTokenPrefix)
+}
+
+// ValidScope is whether the scope is one a token can be granted
+func ValidScope(scope string) bool {
+	return slices.ContainsFunc(Scopes, func(s Scope) bool { return s.Name == scope })
+}
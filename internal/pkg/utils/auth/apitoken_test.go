@@ -0,0 +1,35 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiToken(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+
+	token, hash, err := auth.NewApiToken()
+	is.NoError(err)
+	is.True(auth.IsApiToken(token))
+	is.Equal(auth.HashApiToken(token), hash)
+	is.NotContains(hash, token)
+
+	other, otherHash, err := auth.NewApiToken()
+	is.NoError(err)
+	is.NotEqual(token, other)
+	is.NotEqual(hash, otherHash)
+
+	session, err := auth.CreateToken("secret", "user", 0)
+	is.NoError(err)
+	is.False(auth.IsApiToken(session))
+}
+
+func TestValidScope(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, auth.ValidScope(auth.ScopeUpload))
+	require.False(t, auth.ValidScope("admin"))
+}
@@ -0,0 +1,46 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCsrfToken(t *testing.T) {
+	t.Parallel()
+
+	secret := "secret key"
+
+	t.Run("happy", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		token, err := auth.CreateCsrfToken(secret)
+		is.NoError(err)
+		is.True(auth.VerifyCsrfToken(secret, token))
+
+		other, err := auth.CreateCsrfToken(secret)
+		is.NoError(err)
+		is.NotEqual(token, other)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		token, err := auth.CreateCsrfToken(secret)
+		is.NoError(err)
+		is.False(auth.VerifyCsrfToken("other secret", token))
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		is.False(auth.VerifyCsrfToken(secret, ""))
+		is.False(auth.VerifyCsrfToken(secret, "nonce"))
+		is.False(auth.VerifyCsrfToken(secret, ".signature"))
+		is.False(auth.VerifyCsrfToken(secret, "nonce.signature"))
+	})
+}
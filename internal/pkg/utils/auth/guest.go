@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// What a guest token allows a guest to do in an event
+const (
+	GuestView   = "view"
+	GuestUpload = "upload"
+)
+
+// GuestSessionCookieName is the cookie with a random id telling guests browsers apart, for the events analytics
+const GuestSessionCookieName = "GuestSession"
+
+// GuestCookieName is the cookie a guest token for the event is kept in after the first visit
+func GuestCookieName(eventId uint64) string {
+	return fmt.Sprintf("GuestToken-%d", eventId)
+}
+
+type GuestClaims struct {
+	EventID    uint64 `json:"evt"`
+	Capability string `json:"cap"`
+	// fingerprint of the events password hash, for guests who logged in with the password
+	Password string `json:"pwd,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// PasswordLogin is whether the guest logged in with the events password, rather than a guest link
+func (g *GuestClaims) PasswordLogin() bool {
+	return g.Password != ""
+}
+
+// MatchesPassword is whether the guest logged in with the events current password
+func (g *GuestClaims) MatchesPassword(passwordHash string) bool {
+	return passwordHash != "" && subtle.ConstantTimeCompare([]byte(g.Password), []byte(passwordFingerprint(passwordHash))) == 1
+}
+
+// TokenID is the ID of the guest token stored in the DB, used to revoke it
+func (g *GuestClaims) TokenID() (uint, error) {
+	id, err := strconv.ParseUint(g.ID, 10, 64)
+	return uint(id), err
+}
+
+// CreateGuestToken signs a token letting guests into the event, expiring at expiresAt if not nil
+func CreateGuestToken(secretKey string, eventId uint64, tokenId uint, capability string, expiresAt *time.Time) (string, error) {
+	claims := GuestClaims{
+		EventID:    eventId,
+		Capability: capability,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: "guest",
+			ID:      strconv.FormatUint(uint64(tokenId), 10),
+		},
+	}
+	if expiresAt != nil {
+		claims.ExpiresAt = jwt.NewNumericDate(*expiresAt)
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(guestKey(secretKey))
+}
+
+// CreateEventLoginToken signs a token for a guest who logged into the event with its password.
+// It's tied to the password so changing it logs everyone out.
+func CreateEventLoginToken(secretKey string, eventId uint64, passwordHash string, expiresAt time.Time) (string, error) {
+	claims := GuestClaims{
+		EventID:    eventId,
+		Capability: GuestUpload,
+		Password:   passwordFingerprint(passwordHash),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "guest",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(guestKey(secretKey))
+}
+
+// VerifyGuestToken checks the guest token was signed by us and hasn't expired.
+// It could still have been revoked, which is up to the caller to check.
+func VerifyGuestToken(secretKey, tokenString string) (*GuestClaims, error) {
+	var claims GuestClaims
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return guestKey(secretKey), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid || claims.Subject != "guest" {
+		return nil, errors.New("invalid guest token")
+	}
+	return &claims, nil
+}
+
+// GuestCan is whether a guest with the capability can do what they want to
+func GuestCan(capability, want string) bool {
+	return capability == want || capability == GuestUpload && want == GuestView
+}
+
+// tokens can be read by anyone, so only carry a digest of the hash
+func passwordFingerprint(passwordHash string) string {
+	sum := sha256.Sum256([]byte(passwordHash))
+	return hex.EncodeToString(sum[:16])
+}
+
+// guest tokens are signed with their own key so they can never pass as a user's session token
+func guestKey(secretKey string) []byte {
+	return []byte(secretKey + ":guest")
+}
@@ -0,0 +1,89 @@
+package auth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestToken(t *testing.T) {
+	t.Parallel()
+
+	secret := "secret key"
+
+	t.Run("happy", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		token, err := auth.CreateGuestToken(secret, 1, 2, auth.GuestUpload, nil)
+		is.NoError(err)
+
+		claims, err := auth.VerifyGuestToken(secret, token)
+		is.NoError(err)
+		is.Equal(uint64(1), claims.EventID)
+		is.Equal(auth.GuestUpload, claims.Capability)
+		id, err := claims.TokenID()
+		is.NoError(err)
+		is.Equal(uint(2), id)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+
+		expired := time.Now().Add(-time.Minute)
+		token, err := auth.CreateGuestToken(secret, 1, 2, auth.GuestView, &expired)
+		require.NoError(t, err)
+
+		_, err = auth.VerifyGuestToken(secret, token)
+		require.Error(t, err)
+	})
+
+	t.Run("not interchangeable with session tokens", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		guest, err := auth.CreateGuestToken(secret, 1, 2, auth.GuestView, nil)
+		is.NoError(err)
+		_, err = auth.VerifyToken(secret, guest)
+		is.Error(err)
+
+		session, err := auth.CreateToken(secret, "guest", 0)
+		is.NoError(err)
+		_, err = auth.VerifyGuestToken(secret, session)
+		is.Error(err)
+	})
+}
+
+func TestGuestCan(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, auth.GuestCan(auth.GuestUpload, auth.GuestView))
+	require.True(t, auth.GuestCan(auth.GuestView, auth.GuestView))
+	require.False(t, auth.GuestCan(auth.GuestView, auth.GuestUpload))
+	require.False(t, auth.GuestCan("", auth.GuestView))
+}
+
+func TestEventLoginToken(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+
+	token, err := auth.CreateEventLoginToken("secret key", 1, "hash", time.Now().Add(time.Hour))
+	is.NoError(err)
+	is.NotContains(token, "hash")
+
+	claims, err := auth.VerifyGuestToken("secret key", token)
+	is.NoError(err)
+	is.True(claims.PasswordLogin())
+	is.Equal(auth.GuestUpload, claims.Capability)
+	is.True(claims.MatchesPassword("hash"))
+	is.False(claims.MatchesPassword("new hash"))
+	is.False(claims.MatchesPassword(""))
+
+	link, err := auth.CreateGuestToken("secret key", 1, 2, auth.GuestView, nil)
+	is.NoError(err)
+	claims, err = auth.VerifyGuestToken("secret key", link)
+	is.NoError(err)
+	is.False(claims.PasswordLogin())
+}
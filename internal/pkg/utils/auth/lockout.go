@@ -0,0 +1,24 @@
+package auth
+
+import "time"
+
+const (
+	// failed logins in a row allowed before the account is locked
+	LockoutThreshold = 5
+	// how long the account is first locked for, doubled with every failure after
+	lockoutBase = time.Minute
+	lockoutMax  = time.Hour
+)
+
+// LockoutDuration is how long an account is locked for after the failed logins in a row, none
+// until the threshold is reached and then backing off exponentially up to an hour
+func LockoutDuration(failures uint) time.Duration {
+	if failures < LockoutThreshold {
+		return 0
+	}
+	shift := failures - LockoutThreshold
+	if shift >= 6 {
+		return lockoutMax
+	}
+	return min(lockoutBase<<shift, lockoutMax)
+}
@@ -0,0 +1,22 @@
+package auth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+
+	is.Zero(auth.LockoutDuration(0))
+	is.Zero(auth.LockoutDuration(auth.LockoutThreshold - 1))
+	is.Equal(time.Minute, auth.LockoutDuration(auth.LockoutThreshold))
+	is.Equal(2*time.Minute, auth.LockoutDuration(auth.LockoutThreshold+1))
+	is.Equal(32*time.Minute, auth.LockoutDuration(auth.LockoutThreshold+5))
+	is.Equal(time.Hour, auth.LockoutDuration(auth.LockoutThreshold+6))
+	is.Equal(time.Hour, auth.LockoutDuration(1000))
+}
@@ -3,14 +3,12 @@ package auth
 import (
 	"errors"
 	"fmt"
-	"time"
+	"slices"
+	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
-const CookieName = "AuthToken"
-
 func EncryptPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
 	return string(bytes), err
@@ -21,37 +19,36 @@ func ComparePassword(password, hash string) bool {
 	return err == nil
 }
 
-func CreateToken(secretKey, username string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256,
-		jwt.MapClaims{
-			"sub": username,
-			"exp": time.Now().Add(time.Hour * 24).Unix(),
-		})
+// MinPasswordLength is the shortest password users can have
+const MinPasswordLength = 8
 
-	tokenString, err := token.SignedString([]byte(secretKey))
-	if err != nil {
-		return "", err
-	}
+// bcrypt ignores anything past 72 bytes, so longer passwords would silently be truncated
+const maxPasswordLength = 72
 
-	return tokenString, nil
+// ErrWeakPassword is returned when a password isn't strong enough to be used
+var ErrWeakPassword = errors.New("password is too weak")
+
+// a handful of the most common passwords, which would be guessed first
+var commonPasswords = []string{
+	"password", "password1", "password123", "passw0rd", "12345678", "123456789", "1234567890",
+	"qwerty123", "qwertyuiop", "iloveyou", "sunshine", "princess", "football", "baseball",
+	"welcome1", "letmein1", "admin123", "abc12345", "11111111", "00000000", "trustno1",
+	"eventpix", "changeme",
 }
 
-func VerifyToken(secretKey, tokenString string) (jwt.MapClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(secretKey), nil
-	})
-	if err != nil {
-		return nil, err
-	}
-	if claims, ok := token.Claims.(jwt.MapClaims); ok {
-		if !token.Valid {
-			return nil, errors.New("invalid token")
-		}
-		return claims, nil
-	} else {
-		return nil, errors.New("failed to parse jwt claims map")
+// CheckPasswordStrength checks the password is strong enough for the user to sign in with
+func CheckPasswordStrength(username, password string) error {
+	switch {
+	case len(password) < MinPasswordLength:
+		return fmt.Errorf("%w: must be at least %d characters", ErrWeakPassword, MinPasswordLength)
+	case len(password) > maxPasswordLength:
+		return fmt.Errorf("%w: must be at most %d bytes", ErrWeakPassword, maxPasswordLength)
+	case username != "" && strings.EqualFold(password, username):
+		return fmt.Errorf("%w: must not be the same as the username", ErrWeakPassword)
+	case slices.Contains(commonPasswords, strings.ToLower(password)):
+		return fmt.Errorf("%w: too common", ErrWeakPassword)
+	case strings.Count(password, password[:1]) == len(password):
+		return fmt.Errorf("%w: must not repeat the same character", ErrWeakPassword)
 	}
+	return nil
 }
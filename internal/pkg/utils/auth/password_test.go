@@ -1,6 +1,7 @@
 package auth_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
@@ -18,32 +19,32 @@ func TestEncryptAndHashComparePassword(t *testing.T) {
 	require.False(t, auth.ComparePassword("wrong password", got))
 }
 
-func TestToken(t *testing.T) {
+func TestCheckPasswordStrength(t *testing.T) {
 	t.Parallel()
 
-	secret := "secret key"
-
-	t.Run("happy", func(t *testing.T) {
-		t.Parallel()
-
-		got, err := auth.CreateToken(secret, "username")
-		require.NoError(t, err)
-
-		claims, err := auth.VerifyToken(secret, got)
-		require.NoError(t, err)
-		subject, err := claims.GetSubject()
-		require.NoError(t, err)
-		require.Equal(t, "username", subject)
-	})
-
-	t.Run("unhappy", func(t *testing.T) {
-		t.Parallel()
-
-		got, err := auth.CreateToken(secret, "username")
-		require.NoError(t, err)
-
-		claims, err := auth.VerifyToken("wrong secret", got)
-		require.Error(t, err)
-		require.Nil(t, claims)
-	})
+	tests := []struct {
+		name     string
+		username string
+		password string
+		weak     bool
+	}{
+		{name: "strong", username: "bob", password: "correct horse battery"},
+		{name: "too short", username: "bob", password: "x7#kQ", weak: true},
+		{name: "too long", username: "bob", password: strings.Repeat("ab", 40), weak: true},
+		{name: "same as username", username: "bobthebuilder", password: "BobTheBuilder", weak: true},
+		{name: "common", username: "bob", password: "Password123", weak: true},
+		{name: "repeated", username: "bob", password: "zzzzzzzzzz", weak: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := auth.CheckPasswordStrength(tt.username, tt.password)
+			if tt.weak {
+				require.ErrorIs(t, err, auth.ErrWeakPassword)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
 }
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// PasswordResetExpiry is how long a password reset link can be used for
+const PasswordResetExpiry = time.Hour
+
+type PasswordResetClaims struct {
+	// fingerprint of the users password hash when the reset was asked for
+	Password string `json:"pwd"`
+	jwt.RegisteredClaims
+}
+
+// UserID is the user whose password can be reset
+func (p *PasswordResetClaims) UserID() (uint, error) {
+	id, err := strconv.ParseUint(p.Subject, 10, 64)
+	return uint(id), err
+}
+
+// MatchesPassword is whether the users password hasn't changed since the reset was asked for,
+// so a reset link can only be used once
+func (p *PasswordResetClaims) MatchesPassword(passwordHash string) bool {
+	return subtle.ConstantTimeCompare([]byte(p.Password), []byte(passwordFingerprint(passwordHash))) == 1
+}
+
+// CreatePasswordResetToken signs a token letting whoever holds it set a new password for the user
+func CreatePasswordResetToken(secretKey string, userId uint, passwordHash string) (string, error) {
+	claims := PasswordResetClaims{
+		Password: passwordFingerprint(passwordHash),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(userId), 10),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(PasswordResetExpiry)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(resetKey(secretKey))
+}
+
+// VerifyPasswordResetToken checks the reset token was signed by us and hasn't expired.
+// Whether it has already been used is up to the caller to check with MatchesPassword.
+func VerifyPasswordResetToken(secretKey, tokenString string) (*PasswordResetClaims, error) {
+	var claims PasswordResetClaims
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return resetKey(secretKey), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid password reset token")
+	}
+	return &claims, nil
+}
+
+// reset tokens are signed with their own key so they can never pass as any other token
+func resetKey(secretKey string) []byte {
+	return []byte(secretKey + ":reset")
+}
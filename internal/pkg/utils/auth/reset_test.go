@@ -0,0 +1,51 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordResetToken(t *testing.T) {
+	t.Parallel()
+
+	secret := "secret key"
+
+	t.Run("happy", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		token, err := auth.CreatePasswordResetToken(secret, 3, "hash")
+		is.NoError(err)
+
+		claims, err := auth.VerifyPasswordResetToken(secret, token)
+		is.NoError(err)
+		id, err := claims.UserID()
+		is.NoError(err)
+		is.Equal(uint(3), id)
+		is.True(claims.MatchesPassword("hash"))
+		// once the password changes the token is spent
+		is.False(claims.MatchesPassword("new hash"))
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := auth.CreatePasswordResetToken(secret, 3, "hash")
+		require.NoError(t, err)
+
+		_, err = auth.VerifyPasswordResetToken("other key", token)
+		require.Error(t, err)
+	})
+
+	t.Run("not a reset token", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := auth.CreateGuestToken(secret, 1, 2, auth.GuestUpload, nil)
+		require.NoError(t, err)
+
+		_, err = auth.VerifyPasswordResetToken(secret, token)
+		require.Error(t, err)
+	})
+}
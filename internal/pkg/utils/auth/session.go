@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jj-style/eventpix/internal/config"
+)
+
+const CookieName = "AuthToken"
+
+const (
+	// how long a session lasts without being used, if not configured
+	DefaultSessionTtl = 24 * time.Hour
+	// how long a session can be kept alive for after logging in, if not configured
+	DefaultSessionMaxAge = 30 * 24 * time.Hour
+)
+
+type SessionClaims struct {
+	// version of the users tokens the session was issued for, bumped to log the user out everywhere
+	Version uint `json:"ver"`
+	// when the user logged in, sessions aren't refreshed past the max age from then
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func CreateToken(secretKey, username string, version uint) (string, error) {
+	return CreateTokenWithExpiry(secretKey, username, version, DefaultSessionTtl)
+}
+
+// CreateTokenWithExpiry creates a token for the user which is valid for the given duration
+func CreateTokenWithExpiry(secretKey, username string, version uint, expiry time.Duration) (string, error) {
+	now := time.Now()
+	return signSession(secretKey, username, version, now, now.Add(expiry))
+}
+
+func VerifyToken(secretKey, tokenString string) (*SessionClaims, error) {
+	var claims SessionClaims
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secretKey), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return &claims, nil
+}
+
+func signSession(secretKey, username string, version uint, authTime, expiresAt time.Time) (string, error) {
+	claims := SessionClaims{
+		Version:  version,
+		AuthTime: jwt.NewNumericDate(authTime),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   username,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secretKey))
+}
+
+// Sessions issues users session tokens and keeps them in the session cookie.
+// Sessions slide: using one in its second half issues a fresh token, up to the max age after logging in.
+type Sessions struct {
+	secretKey string
+	ttl       time.Duration
+	maxAge    time.Duration
+	// only send the cookie over HTTPS, when the server is served over it
+	secure bool
+}
+
+func NewSessions(cfg *config.Config) *Sessions {
+	s := &Sessions{
+		secretKey: cfg.Server.SecretKey,
+		ttl:       cfg.Server.SessionTtl,
+		maxAge:    cfg.Server.SessionMaxAge,
+		secure:    strings.HasPrefix(cfg.Server.ServerUrl, "https://"),
+	}
+	if s.ttl <= 0 {
+		s.ttl = DefaultSessionTtl
+	}
+	if s.maxAge <= 0 {
+		s.maxAge = DefaultSessionMaxAge
+	}
+	return s
+}
+
+// Create logs the user in, creating a session token at the users current token version
+func (s *Sessions) Create(username string, version uint) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(min(s.ttl, s.maxAge))
+	token, err := signSession(s.secretKey, username, version, now, expiresAt)
+	return token, expiresAt, err
+}
+
+func (s *Sessions) Verify(token string) (*SessionClaims, error) {
+	return VerifyToken(s.secretKey, token)
+}
+
+// Refresh reissues the session if it's past halfway through its life and the user logged in
+// less than the max age ago. Returns false if the session doesn't need refreshing, or can't be.
+func (s *Sessions) Refresh(claims *SessionClaims) (string, time.Time, bool) {
+	now := time.Now()
+	if claims.ExpiresAt == nil || claims.ExpiresAt.Sub(now) > s.ttl/2 {
+		return "", time.Time{}, false
+	}
+	authTime := claims.IssuedAt
+	if claims.AuthTime != nil {
+		authTime = claims.AuthTime
+	}
+	if authTime == nil {
+		return "", time.Time{}, false
+	}
+	expiresAt := now.Add(s.ttl)
+	if deadline := authTime.Add(s.maxAge); deadline.Before(expiresAt) {
+		expiresAt = deadline
+	}
+	if !expiresAt.After(claims.ExpiresAt.Time) {
+		return "", time.Time{}, false
+	}
+	token, err := signSession(s.secretKey, claims.Subject, claims.Version, authTime.Time, expiresAt)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return token, expiresAt, true
+}
+
+// Secure is whether cookies are only sent over HTTPS
+func (s *Sessions) Secure() bool {
+	return s.secure
+}
+
+// SetCookie keeps the session token in the session cookie until it expires
+func (s *Sessions) SetCookie(w http.ResponseWriter, token string, expiresAt time.Time) {
+	http.SetCookie(w, s.cookie(token, int(time.Until(expiresAt).Seconds())))
+}
+
+// ClearCookie removes the session cookie from the browser
+func (s *Sessions) ClearCookie(w http.ResponseWriter) {
+	http.SetCookie(w, s.cookie("", -1))
+}
+
+func (s *Sessions) cookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     CookieName,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   s.secure,
+		HttpOnly: true,
+		// lax so the cookie is still sent when coming back from oauth redirects
+		SameSite: http.SameSiteLaxMode,
+	}
+}
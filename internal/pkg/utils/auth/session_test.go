@@ -0,0 +1,141 @@
+package auth_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToken(t *testing.T) {
+	t.Parallel()
+
+	secret := "secret key"
+
+	t.Run("happy", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := auth.CreateToken(secret, "username", 3)
+		require.NoError(t, err)
+
+		claims, err := auth.VerifyToken(secret, got)
+		require.NoError(t, err)
+		subject, err := claims.GetSubject()
+		require.NoError(t, err)
+		require.Equal(t, "username", subject)
+		require.Equal(t, uint(3), claims.Version)
+	})
+
+	t.Run("unhappy", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := auth.CreateToken(secret, "username", 0)
+		require.NoError(t, err)
+
+		claims, err := auth.VerifyToken("wrong secret", got)
+		require.Error(t, err)
+		require.Nil(t, claims)
+	})
+}
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+
+	newSessions := func(serverUrl string) *auth.Sessions {
+		return auth.NewSessions(&config.Config{Server: &config.Server{
+			SecretKey:     "secret key",
+			ServerUrl:     serverUrl,
+			SessionTtl:    time.Hour,
+			SessionMaxAge: 3 * time.Hour,
+		}})
+	}
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		sessions := newSessions("http://localhost:8080")
+
+		token, expiresAt, err := sessions.Create("username", 2)
+		is.NoError(err)
+		is.WithinDuration(time.Now().Add(time.Hour), expiresAt, time.Minute)
+
+		claims, err := sessions.Verify(token)
+		is.NoError(err)
+		is.Equal("username", claims.Subject)
+		is.Equal(uint(2), claims.Version)
+
+		// fresh sessions don't need refreshing
+		_, _, ok := sessions.Refresh(claims)
+		is.False(ok)
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		sessions := newSessions("http://localhost:8080")
+
+		claims := &auth.SessionClaims{
+			Version:  2,
+			AuthTime: jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "username",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(10 * time.Minute)),
+			},
+		}
+		token, expiresAt, ok := sessions.Refresh(claims)
+		is.True(ok)
+		is.WithinDuration(time.Now().Add(time.Hour), expiresAt, time.Minute)
+
+		refreshed, err := sessions.Verify(token)
+		is.NoError(err)
+		is.Equal("username", refreshed.Subject)
+		is.Equal(uint(2), refreshed.Version)
+		is.Equal(claims.AuthTime.Unix(), refreshed.AuthTime.Unix())
+	})
+
+	t.Run("refresh capped at max age", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		sessions := newSessions("http://localhost:8080")
+
+		authTime := time.Now().Add(-150 * time.Minute)
+		claims := &auth.SessionClaims{
+			AuthTime: jwt.NewNumericDate(authTime),
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "username",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(10 * time.Minute)),
+			},
+		}
+		_, expiresAt, ok := sessions.Refresh(claims)
+		is.True(ok)
+		is.WithinDuration(authTime.Add(3*time.Hour), expiresAt, time.Second)
+
+		// past the max age it can't be refreshed any more
+		claims.AuthTime = jwt.NewNumericDate(time.Now().Add(-3 * time.Hour))
+		_, _, ok = sessions.Refresh(claims)
+		is.False(ok)
+	})
+
+	t.Run("cookie flags", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		w := httptest.NewRecorder()
+		newSessions("https://eventpix.example.com").SetCookie(w, "token", time.Now().Add(time.Hour))
+		cookie := w.Result().Cookies()[0]
+		is.Equal(auth.CookieName, cookie.Name)
+		is.True(cookie.Secure)
+		is.True(cookie.HttpOnly)
+		is.Contains(w.Header().Get("Set-Cookie"), "SameSite=Lax")
+
+		w = httptest.NewRecorder()
+		newSessions("http://localhost:8080").ClearCookie(w)
+		cookie = w.Result().Cookies()[0]
+		is.False(cookie.Secure)
+		is.Negative(cookie.MaxAge)
+	})
+}
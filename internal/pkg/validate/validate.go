@@ -4,13 +4,26 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/data/storage"
+	"github.com/jj-style/eventpix/internal/pkg/branding"
 )
 
+// longest welcome text and footer galleries can have
+const (
+	maxWelcomeText = 5000
+	maxFooter      = 500
+)
+
+var colorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
 type Validator interface {
 	ValidateEvent(evt *db.Event) error
 	ValidateSlug(slug string) error
+	ValidateSchedule(startsAt, endsAt, expiresAt *time.Time) error
+	ValidateBranding(b *db.EventBranding) error
 }
 
 type validator struct {
@@ -24,6 +37,47 @@ func (v *validator) ValidateEvent(evt *db.Event) error {
 	if err := v.ValidateSlug(evt.Slug); err != nil {
 		return err
 	}
+	if evt.KeyTemplate != "" {
+		if err := storage.ValidateKeyTemplate(evt.KeyTemplate); err != nil {
+			return err
+		}
+	}
+	return v.ValidateSchedule(evt.StartsAt, evt.EndsAt, evt.ExpiresAt)
+}
+
+// ValidateSchedule checks an events schedule is in order, any of the times can be left out
+func (v *validator) ValidateSchedule(startsAt, endsAt, expiresAt *time.Time) error {
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return errors.New("event must end after it starts")
+	}
+	if expiresAt != nil {
+		if endsAt != nil && expiresAt.Before(*endsAt) {
+			return errors.New("event can't expire before it ends")
+		}
+		if startsAt != nil && !expiresAt.After(*startsAt) {
+			return errors.New("event must expire after it starts")
+		}
+	}
+	return nil
+}
+
+// ValidateBranding checks the galleries colours and font are ones it can be shown with,
+// empty fields are left as the default
+func (v *validator) ValidateBranding(b *db.EventBranding) error {
+	for name, color := range map[string]string{"primary": b.PrimaryColor, "background": b.BackgroundColor} {
+		if color != "" && !colorRe.MatchString(color) {
+			return fmt.Errorf("%s colour must be like #rrggbb", name)
+		}
+	}
+	if _, ok := branding.LookupFont(b.Font); !ok {
+		return fmt.Errorf("unknown font %q", b.Font)
+	}
+	if len(b.WelcomeText) > maxWelcomeText {
+		return fmt.Errorf("welcome text can't be longer than %d characters", maxWelcomeText)
+	}
+	if len(b.Footer) > maxFooter {
+		return fmt.Errorf("footer can't be longer than %d characters", maxFooter)
+	}
 	return nil
 }
 
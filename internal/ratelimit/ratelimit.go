@@ -0,0 +1,113 @@
+// counts requests so they can be limited
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/redis/rueidis"
+)
+
+// Limiter counts requests against keys in fixed windows of time
+type Limiter interface {
+	// Allow counts a request against the key, returning whether it's within the limit
+	// and, if it isn't, how long until the window resets
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error)
+}
+
+// NewLimiter counts requests in redis when it's used as the cache, so they're
+// shared by every server, and in memory otherwise
+func NewLimiter(cfg *config.Cache) (Limiter, error) {
+	if cfg == nil || cfg.Mode != "redis" {
+		return NewMemoryLimiter(), nil
+	}
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: strings.Split(cfg.Addr, ","),
+		Username:    cfg.Username,
+		Password:    cfg.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewRedisLimiter(client), nil
+}
+
+type window struct {
+	count   int
+	resetAt time.Time
+}
+
+type memoryLimiter struct {
+	windows   map[string]*window
+	lastSweep time.Time
+	now       func() time.Time
+	sync.Mutex
+}
+
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{windows: make(map[string]*window), now: time.Now}
+}
+
+func (m *memoryLimiter) Allow(_ context.Context, key string, limit int, period time.Duration) (bool, time.Duration, error) {
+	m.Lock()
+	defer m.Unlock()
+	now := m.now()
+	m.sweep(now)
+
+	w, ok := m.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &window{resetAt: now.Add(period)}
+		m.windows[key] = w
+	}
+	w.count++
+	if w.count > limit {
+		return false, w.resetAt.Sub(now), nil
+	}
+	return true, 0, nil
+}
+
+// forget windows which have ended every so often, so keys which are never used again don't pile up
+func (m *memoryLimiter) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < time.Minute {
+		return
+	}
+	m.lastSweep = now
+	for key, w := range m.windows {
+		if !now.Before(w.resetAt) {
+			delete(m.windows, key)
+		}
+	}
+}
+
+// counts the request and starts the window if it's the first, atomically so the count
+// can't be left without an expiry
+var allowScript = rueidis.NewLuaScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return {count, redis.call("PTTL", KEYS[1])}
+`)
+
+type redisLimiter struct {
+	client rueidis.Client
+}
+
+func NewRedisLimiter(client rueidis.Client) Limiter {
+	return &redisLimiter{client: client}
+}
+
+func (r *redisLimiter) Allow(ctx context.Context, key string, limit int, period time.Duration) (bool, time.Duration, error) {
+	res, err := allowScript.Exec(ctx, r.client, []string{key}, []string{strconv.FormatInt(period.Milliseconds(), 10)}).AsIntSlice()
+	if err != nil {
+		return false, 0, err
+	}
+	if res[0] > int64(limit) {
+		return false, time.Duration(res[1]) * time.Millisecond, nil
+	}
+	return true, 0, nil
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimiter(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+
+	now := time.Now()
+	limiter := NewMemoryLimiter().(*memoryLimiter)
+	limiter.now = func() time.Time { return now }
+
+	for range 3 {
+		ok, _, err := limiter.Allow(t.Context(), "a", 3, time.Minute)
+		is.NoError(err)
+		is.True(ok)
+	}
+	ok, retryAfter, err := limiter.Allow(t.Context(), "a", 3, time.Minute)
+	is.NoError(err)
+	is.False(ok)
+	is.Equal(time.Minute, retryAfter)
+
+	// other keys are counted separately
+	ok, _, err = limiter.Allow(t.Context(), "b", 3, time.Minute)
+	is.NoError(err)
+	is.True(ok)
+
+	// until the window resets
+	now = now.Add(30 * time.Second)
+	_, retryAfter, _ = limiter.Allow(t.Context(), "a", 3, time.Minute)
+	is.Equal(30*time.Second, retryAfter)
+	now = now.Add(30 * time.Second)
+	ok, _, err = limiter.Allow(t.Context(), "a", 3, time.Minute)
+	is.NoError(err)
+	is.True(ok)
+
+	// ended windows are forgotten
+	now = now.Add(2 * time.Minute)
+	_, _, _ = limiter.Allow(t.Context(), "c", 3, time.Minute)
+	is.NotContains(limiter.windows, "a")
+	is.NotContains(limiter.windows, "b")
+}
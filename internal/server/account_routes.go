@@ -0,0 +1,142 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+)
+
+func updateAccount(d db.DB, accounts *service.Accounts, sessions *auth.Sessions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		if err := accounts.UpdateProfile(c, user, c.PostForm("username"), c.PostForm("email")); err != nil {
+			abortWithAccountError(c, err)
+			return
+		}
+		// sessions are for the username, so a renamed user needs a new one
+		renderAccount(c, d, sessions, user.ID, "Saved.")
+	}
+}
+
+func changePassword(d db.DB, accounts *service.Accounts, sessions *auth.Sessions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		if err := accounts.ChangePassword(c, user, c.PostForm("current"), c.PostForm("password")); err != nil {
+			abortWithAccountError(c, err)
+			return
+		}
+		// changing the password logs the user out everywhere, including here
+		renderAccount(c, d, sessions, user.ID, "Password changed, you've been logged out on every other device.")
+	}
+}
+
+func deleteAccount(accounts *service.Accounts, sessions *auth.Sessions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h := c.MustGet(middleware.HtmxKey).(*htmx.Handler)
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		// the checkbox is only sent when checked
+		deleteMedia := c.PostForm("deleteMedia") != ""
+		if err := accounts.DeleteAccount(c, user, c.PostForm("password"), deleteMedia); err != nil {
+			abortWithAccountError(c, err)
+			return
+		}
+		sessions.ClearCookie(c.Writer)
+		c.Status(http.StatusOK)
+		h.Redirect("/")
+	}
+}
+
+func getForgotPassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.HTML(http.StatusOK, "forgotPassword", gin.H{
+			"title": "Forgot Password",
+			"nav": gin.H{
+				"dark": true,
+				"items": []gin.H{
+					{
+						"name": "Home",
+						"href": "/",
+					},
+				},
+			},
+		})
+	}
+}
+
+func postForgotPassword(accounts *service.Accounts) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := accounts.RequestPasswordReset(c, c.PostForm("user")); err != nil {
+			AbortWithError(c, http.StatusInternalServerError, errors.New("couldn't send the reset link, try again later"))
+			return
+		}
+		c.HTML(http.StatusOK, "passwordResetSent", nil)
+	}
+}
+
+func getResetPassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.HTML(http.StatusOK, "resetPassword", gin.H{
+			"title": "Reset Password",
+			"token": c.Query("token"),
+			"nav": gin.H{
+				"dark": true,
+				"items": []gin.H{
+					{
+						"name": "Home",
+						"href": "/",
+					},
+				},
+			},
+		})
+	}
+}
+
+func postResetPassword(accounts *service.Accounts) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h := c.MustGet(middleware.HtmxKey).(*htmx.Handler)
+		if _, err := accounts.ResetPassword(c, c.PostForm("token"), c.PostForm("password")); err != nil {
+			abortWithAccountError(c, err)
+			return
+		}
+		c.Status(http.StatusOK)
+		h.Redirect("/login")
+	}
+}
+
+// renderAccount renders the account section of the profile with a fresh session for the user,
+// whose username or session version may have just changed
+func renderAccount(c *gin.Context, d db.DB, sessions *auth.Sessions, userId uint, saved string) {
+	user, err := d.GetUserByID(c, userId)
+	if err != nil {
+		AbortWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	token, expiresAt, err := sessions.Create(user.Username, user.TokenVersion)
+	if err != nil {
+		AbortWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	sessions.SetCookie(c.Writer, token, expiresAt)
+	c.HTML(http.StatusOK, "account", gin.H{"user": user, "saved": saved})
+}
+
+func abortWithAccountError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrWrongPassword):
+		AbortWithError(c, http.StatusForbidden, err)
+	case errors.Is(err, service.ErrInvalidResetToken):
+		AbortWithError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrInvalidProfile), errors.Is(err, auth.ErrWeakPassword):
+		AbortWithError(c, http.StatusUnprocessableEntity, err)
+	case errors.Is(err, db.ErrUserExists):
+		AbortWithError(c, http.StatusConflict, err)
+	default:
+		AbortWithError(c, http.StatusInternalServerError, err)
+	}
+}
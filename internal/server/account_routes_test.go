@@ -0,0 +1,142 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	mockmailer "github.com/jj-style/eventpix/internal/pkg/mailer/mocks"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/service"
+	mockservice "github.com/jj-style/eventpix/internal/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestAccountRoutes(t *testing.T) {
+	t.Parallel()
+
+	hash, err := auth.EncryptPassword("hunter2hunter2")
+	require.NoError(t, err)
+	user := &db.User{Model: gorm.Model{ID: 1}, Username: "bob", Password: hash, TokenVersion: 2}
+
+	cfg := &config.Config{Server: &config.Server{SecretKey: "secret", ServerUrl: "https://pix.example.com"}}
+	sessions := auth.NewSessions(cfg)
+
+	postForm := func(router *gin.Engine, path string, form url.Values) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("rename", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, user, 0, "")
+		accounts := service.NewAccounts(cfg, mdb, mockservice.NewMockEventpixService(t), mockmailer.NewMockMailer(t), zap.NewNop(), service.NewAuditor(mdb, zap.NewNop()))
+		router.POST("/profile/account", updateAccount(mdb, accounts, sessions))
+
+		mdb.EXPECT().UpdateUser(mock.Anything, uint(1), "robert", "").Return(nil)
+		mdb.EXPECT().GetUserByID(mock.Anything, uint(1)).Return(&db.User{Model: gorm.Model{ID: 1}, Username: "robert", TokenVersion: 2}, nil)
+
+		w := postForm(router, "/profile/account", url.Values{"username": {"robert"}})
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), `value="robert"`)
+		// logged in as the new username
+		is.Contains(w.Header().Get("Set-Cookie"), auth.CookieName+"=")
+	})
+
+	t.Run("rename taken", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, user, 0, "")
+		accounts := service.NewAccounts(cfg, mdb, mockservice.NewMockEventpixService(t), mockmailer.NewMockMailer(t), zap.NewNop(), service.NewAuditor(mdb, zap.NewNop()))
+		router.POST("/profile/account", updateAccount(mdb, accounts, sessions))
+
+		mdb.EXPECT().UpdateUser(mock.Anything, uint(1), "alice", "").Return(db.ErrUserExists)
+
+		w := postForm(router, "/profile/account", url.Values{"username": {"alice"}})
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("change password", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, user, 0, "")
+		accounts := service.NewAccounts(cfg, mdb, mockservice.NewMockEventpixService(t), mockmailer.NewMockMailer(t), zap.NewNop(), service.NewAuditor(mdb, zap.NewNop()))
+		router.POST("/profile/password", changePassword(mdb, accounts, sessions))
+
+		w := postForm(router, "/profile/password", url.Values{"current": {"wrong password"}, "password": {"correct horse battery"}})
+		is.Equal(http.StatusForbidden, w.Code)
+
+		mdb.EXPECT().ResetUserPassword(mock.Anything, uint(1), "short").Return(auth.ErrWeakPassword).Once()
+		w = postForm(router, "/profile/password", url.Values{"current": {"hunter2hunter2"}, "password": {"short"}})
+		is.Equal(http.StatusUnprocessableEntity, w.Code)
+
+		mdb.EXPECT().ResetUserPassword(mock.Anything, uint(1), "correct horse battery").Return(nil).Once()
+		mdb.EXPECT().GetUserByID(mock.Anything, uint(1)).Return(&db.User{Model: gorm.Model{ID: 1}, Username: "bob", TokenVersion: 3}, nil)
+		w = postForm(router, "/profile/password", url.Values{"current": {"hunter2hunter2"}, "password": {"correct horse battery"}})
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), "Password changed")
+		is.Contains(w.Header().Get("Set-Cookie"), auth.CookieName+"=")
+	})
+
+	t.Run("delete account", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, user, 0, "")
+		accounts := service.NewAccounts(cfg, mdb, mockservice.NewMockEventpixService(t), mockmailer.NewMockMailer(t), zap.NewNop(), service.NewAuditor(mdb, zap.NewNop()))
+		router.POST("/profile/delete", deleteAccount(accounts, sessions))
+
+		w := postForm(router, "/profile/delete", url.Values{"password": {"wrong password"}})
+		is.Equal(http.StatusForbidden, w.Code)
+
+		mdb.EXPECT().GetEvents(mock.Anything, uint(1)).Return([]*db.Event{}, nil)
+		mdb.EXPECT().GetTrashedEvents(mock.Anything, uint(1)).Return([]*db.Event{}, nil)
+		mdb.EXPECT().DeleteUser(mock.Anything, uint(1)).Return(nil)
+		w = postForm(router, "/profile/delete", url.Values{"password": {"hunter2hunter2"}})
+		is.Equal(http.StatusOK, w.Code)
+		is.Equal("/", w.Header().Get("HX-Redirect"))
+	})
+
+	t.Run("forgot password", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, user, 0, "")
+		accounts := service.NewAccounts(cfg, mdb, mockservice.NewMockEventpixService(t), mockmailer.NewMockMailer(t), zap.NewNop(), service.NewAuditor(mdb, zap.NewNop()))
+		router.POST("/forgot-password", postForgotPassword(accounts))
+
+		mdb.EXPECT().FindUser(mock.Anything, "nobody").Return(nil, gorm.ErrRecordNotFound)
+
+		w := postForm(router, "/forgot-password", url.Values{"user": {"nobody"}})
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), "on its way")
+	})
+
+	t.Run("reset password", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, user, 0, "")
+		accounts := service.NewAccounts(cfg, mdb, mockservice.NewMockEventpixService(t), mockmailer.NewMockMailer(t), zap.NewNop(), service.NewAuditor(mdb, zap.NewNop()))
+		router.POST("/reset-password", postResetPassword(accounts))
+
+		w := postForm(router, "/reset-password", url.Values{"token": {"not a token"}, "password": {"correct horse battery"}})
+		is.Equal(http.StatusBadRequest, w.Code)
+
+		token, err := auth.CreatePasswordResetToken("secret", 1, hash)
+		is.NoError(err)
+		mdb.EXPECT().GetUserByID(mock.Anything, uint(1)).Return(user, nil)
+		mdb.EXPECT().ResetUserPassword(mock.Anything, uint(1), "correct horse battery").Return(nil)
+		w = postForm(router, "/reset-password", url.Values{"token": {token}, "password": {"correct horse battery"}})
+		is.Equal(http.StatusOK, w.Code)
+		is.Equal("/login", w.Header().Get("HX-Redirect"))
+	})
+}
@@ -0,0 +1,220 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+func getAdmin(d db.DB, settings *service.Settings) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		data, err := adminData(c, d, settings)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		data["title"] = "Admin"
+		data["user"] = c.MustGet(gin.AuthUserKey).(*db.User)
+		data["nav"] = gin.H{
+			"dark": true,
+			"items": []gin.H{
+				{
+					"name": "Events",
+					"href": "/events",
+				},
+				{
+					"name":         "Profile",
+					"href":         "/profile",
+					"userRequired": true,
+				},
+				{
+					"name":          "Admin",
+					"href":          "/admin",
+					"active":        true,
+					"adminRequired": true,
+				},
+				{
+					"name":         "Logout",
+					"href":         "/auth/logout",
+					"userRequired": true,
+				},
+			},
+		}
+		c.HTML(http.StatusOK, "admin", data)
+	}
+}
+
+func setUserDisabled(d db.DB, audit *service.Auditor, disabled bool) gin.HandlerFunc {
+	action := service.AuditUserEnable
+	if disabled {
+		action = service.AuditUserDisable
+	}
+	return func(c *gin.Context) {
+		userId, ok := adminTargetUser(c)
+		if !ok {
+			return
+		}
+		if err := d.SetUserDisabled(c, userId, disabled); err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		audit.Record(c, service.Audit{Action: action, Target: service.UserTarget(userId), After: map[string]any{"disabled": disabled}})
+		renderAdminUsers(c, d)
+	}
+}
+
+func deleteUser(d db.DB, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId, ok := adminTargetUser(c)
+		if !ok {
+			return
+		}
+		if err := d.DeleteUser(c, userId); err != nil {
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				AbortWithError(c, http.StatusNotFound, errors.New("user not found"))
+			case errors.Is(err, db.ErrUserOwnsEvents):
+				AbortWithError(c, http.StatusConflict, err)
+			default:
+				AbortWithError(c, http.StatusInternalServerError, err)
+			}
+			return
+		}
+		audit.Record(c, service.Audit{Action: service.AuditUserDelete, Target: service.UserTarget(userId)})
+		renderAdminUsers(c, d)
+	}
+}
+
+func resetUserPassword(d db.DB, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		password := c.PostForm("password")
+		if password == "" {
+			AbortWithError(c, http.StatusBadRequest, errors.New("password is required"))
+			return
+		}
+		if err := d.ResetUserPassword(c, uint(userId), password); err != nil {
+			if errors.Is(err, auth.ErrWeakPassword) {
+				AbortWithError(c, http.StatusUnprocessableEntity, err)
+				return
+			}
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		audit.Record(c, service.Audit{Action: service.AuditUserPassword, Target: service.UserTarget(uint(userId))})
+		renderAdminUsers(c, d)
+	}
+}
+
+func transferEvent(d db.DB, settings *service.Settings, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId, err := strconv.ParseUint(c.Param("eventId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		userId, err := strconv.ParseUint(c.PostForm("userId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := d.TransferEvent(c, eventId, uint(userId)); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				AbortWithError(c, http.StatusNotFound, errors.New("event or user not found"))
+				return
+			}
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		audit.Record(c, service.Audit{Action: service.AuditEventTransfer, EventID: lo.ToPtr(uint(eventId)), After: map[string]any{"userId": userId}})
+		data, err := adminData(c, d, settings)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.HTML(http.StatusOK, "adminEvents", data)
+	}
+}
+
+func setSignups(settings *service.Settings, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if settings.SingleEventMode() {
+			AbortWithError(c, http.StatusBadRequest, errors.New("signups can't be enabled in single event mode"))
+			return
+		}
+		// the checkbox is only sent when checked
+		disabled := c.PostForm("signups") == ""
+		if err := settings.SetDisableSignups(c, disabled); err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		audit.Record(c, service.Audit{Action: service.AuditSignups, After: map[string]any{"disableSignups": disabled}})
+		c.HTML(http.StatusOK, "adminSettings", gin.H{"settings": settings})
+	}
+}
+
+// the user an admin is acting on, who can't be themselves so admins can't lock themselves out
+func adminTargetUser(c *gin.Context) (uint, bool) {
+	user := c.MustGet(gin.AuthUserKey).(*db.User)
+	userId, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+	if err != nil {
+		AbortWithError(c, http.StatusBadRequest, err)
+		return 0, false
+	}
+	if uint(userId) == user.ID {
+		AbortWithError(c, http.StatusBadRequest, errors.New("can't do that to yourself"))
+		return 0, false
+	}
+	return uint(userId), true
+}
+
+func renderAdminUsers(c *gin.Context, d db.DB) {
+	users, err := d.GetUsers(c)
+	if err != nil {
+		AbortWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.HTML(http.StatusOK, "adminUsers", gin.H{"user": c.MustGet(gin.AuthUserKey).(*db.User), "users": users})
+}
+
+type adminEvent struct {
+	*db.EventUsage
+	StorageType string
+}
+
+// everything needed to render the admin console
+func adminData(c *gin.Context, d db.DB, settings *service.Settings) (gin.H, error) {
+	users, err := d.GetUsers(c)
+	if err != nil {
+		return nil, err
+	}
+	usages, err := d.GetEventUsages(c)
+	if err != nil {
+		return nil, err
+	}
+	var files, bytes int64
+	events := lo.Map(usages, func(u *db.EventUsage, _ int) adminEvent {
+		files += u.Files
+		bytes += u.Bytes
+		return adminEvent{EventUsage: u, StorageType: u.Event.StorageType()}
+	})
+	return gin.H{
+		"user":       c.MustGet(gin.AuthUserKey).(*db.User),
+		"users":      users,
+		"events":     events,
+		"totalFiles": files,
+		"totalBytes": bytes,
+		"settings":   settings,
+	}, nil
+}
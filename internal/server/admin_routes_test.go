@@ -0,0 +1,164 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestAdminRoutes(t *testing.T) {
+	t.Parallel()
+
+	adminUser := &db.User{Model: gorm.Model{ID: 1}, Username: "admin", Admin: true}
+	lockedUntil := time.Now().Add(time.Hour)
+	users := []*db.User{adminUser, {Model: gorm.Model{ID: 2}, Username: "bob", LockedUntil: &lockedUntil}}
+
+	postForm := func(router *gin.Engine, path string, form url.Values) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("admins only", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, &db.User{Model: gorm.Model{ID: 2}, Username: "bob"}, 0, "")
+		router.GET("/admin", middleware.AdminRequired(), getAdmin(mdb, nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin", nil)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("console", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, adminUser, 0, "")
+		mdb.EXPECT().GetSetting(mock.Anything, "disableSignups").Return("", gorm.ErrRecordNotFound)
+		settings, err := service.NewSettings(&config.Config{Server: &config.Server{}}, mdb)
+		is.NoError(err)
+		admin := router.Group("/admin", middleware.AdminRequired())
+		admin.GET("", getAdmin(mdb, settings))
+
+		mdb.EXPECT().GetUsers(mock.Anything).Return(users, nil)
+		mdb.EXPECT().GetEventUsages(mock.Anything).Return([]*db.EventUsage{{
+			Event: &db.Event{Model: gorm.Model{ID: 3}, Name: "party", UserID: 2, S3Storage: &db.S3Storage{}},
+			Files: 2,
+			Bytes: 3 * 1000 * 1000,
+		}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin", nil)
+		router.ServeHTTP(w, req)
+
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), "party")
+		is.Contains(w.Body.String(), "<td>S3</td>")
+		is.Contains(w.Body.String(), "3.0 MB")
+		is.Contains(w.Body.String(), `<option value="2" selected>bob</option>`)
+		is.Contains(w.Body.String(), "Locked")
+	})
+
+	t.Run("disable user", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, adminUser, 0, "")
+		admin := router.Group("/admin", middleware.AdminRequired())
+		admin.POST("/users/:userId/disable", setUserDisabled(mdb, service.NewAuditor(mdb, zap.NewNop()), true))
+
+		mdb.EXPECT().SetUserDisabled(mock.Anything, uint(2), true).Return(nil)
+		mdb.EXPECT().GetUsers(mock.Anything).Return(users, nil)
+
+		w := postForm(router, "/admin/users/2/disable", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("can't disable yourself", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, adminUser, 0, "")
+		admin := router.Group("/admin", middleware.AdminRequired())
+		admin.POST("/users/:userId/disable", setUserDisabled(mdb, service.NewAuditor(mdb, zap.NewNop()), true))
+
+		w := postForm(router, "/admin/users/1/disable", nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("delete user with events", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, adminUser, 0, "")
+		admin := router.Group("/admin", middleware.AdminRequired())
+		admin.DELETE("/users/:userId", deleteUser(mdb, service.NewAuditor(mdb, zap.NewNop())))
+
+		mdb.EXPECT().DeleteUser(mock.Anything, uint(2)).Return(db.ErrUserOwnsEvents)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/admin/users/2", nil)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("reset password", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, adminUser, 0, "")
+		admin := router.Group("/admin", middleware.AdminRequired())
+		admin.POST("/users/:userId/password", resetUserPassword(mdb, service.NewAuditor(mdb, zap.NewNop())))
+
+		mdb.EXPECT().ResetUserPassword(mock.Anything, uint(2), "new password").Return(nil)
+		mdb.EXPECT().GetUsers(mock.Anything).Return(users, nil)
+
+		w := postForm(router, "/admin/users/2/password", url.Values{"password": {"new password"}})
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("transfer event", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, adminUser, 0, "")
+		mdb.EXPECT().GetSetting(mock.Anything, "disableSignups").Return("", gorm.ErrRecordNotFound)
+		settings, err := service.NewSettings(&config.Config{Server: &config.Server{}}, mdb)
+		require.NoError(t, err)
+		admin := router.Group("/admin", middleware.AdminRequired())
+		admin.POST("/events/:eventId/transfer", transferEvent(mdb, settings, service.NewAuditor(mdb, zap.NewNop())))
+
+		mdb.EXPECT().TransferEvent(mock.Anything, uint64(3), uint(9)).Return(gorm.ErrRecordNotFound)
+
+		w := postForm(router, "/admin/events/3/transfer", url.Values{"userId": {"9"}})
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("toggle signups", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, adminUser, 0, "")
+		mdb.EXPECT().GetSetting(mock.Anything, "disableSignups").Return("", gorm.ErrRecordNotFound)
+		settings, err := service.NewSettings(&config.Config{Server: &config.Server{}}, mdb)
+		is.NoError(err)
+		admin := router.Group("/admin", middleware.AdminRequired())
+		admin.POST("/settings/signups", setSignups(settings, service.NewAuditor(mdb, zap.NewNop())))
+
+		mdb.EXPECT().SetSetting(mock.Anything, "disableSignups", "true").Return(nil)
+
+		w := postForm(router, "/admin/settings/signups", nil)
+		is.Equal(http.StatusOK, w.Code)
+		is.False(settings.SignupsEnabled())
+
+		mdb.EXPECT().SetSetting(mock.Anything, "disableSignups", "false").Return(nil)
+
+		w = postForm(router, "/admin/settings/signups", url.Values{"signups": {"on"}})
+		is.Equal(http.StatusOK, w.Code)
+		is.True(settings.SignupsEnabled())
+	})
+}
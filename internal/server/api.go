@@ -0,0 +1,296 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime"
+	"net"
+	"net/http"
+	"path/filepath"
+
+	"connectrpc.com/connect"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/gen/picture/v1/picturev1connect"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+// pictureServer serves the PictureService over Connect, gRPC and gRPC-web.
+// Requests must be authenticated (see middleware.ConnectAuth) and users
+// can only act on the events they own or are members of, as far as their role allows.
+type pictureServer struct {
+	db       db.DB
+	svc      service.EventpixService
+	migrator *service.StorageMigrator
+}
+
+var _ picturev1connect.PictureServiceHandler = (*pictureServer)(nil)
+
+// scope an API token needs to call each procedure
+var procedureScopes = map[string]string{
+	picturev1connect.PictureServiceCreateEventProcedure:         auth.ScopeManageEvents,
+	picturev1connect.PictureServiceSetEventLiveProcedure:        auth.ScopeManageEvents,
+	picturev1connect.PictureServiceSetEventScheduleProcedure:    auth.ScopeManageEvents,
+	picturev1connect.PictureServiceUpdateEventProcedure:         auth.ScopeManageEvents,
+	picturev1connect.PictureServiceSetEventBrandingProcedure:    auth.ScopeManageEvents,
+	picturev1connect.PictureServiceGetEventsProcedure:           auth.ScopeReadEvents,
+	picturev1connect.PictureServiceGetEventProcedure:            auth.ScopeReadEvents,
+	picturev1connect.PictureServiceGetActiveEventProcedure:      auth.ScopeReadEvents,
+	picturev1connect.PictureServiceSetActiveEventProcedure:      auth.ScopeManageEvents,
+	picturev1connect.PictureServiceDeleteEventProcedure:         auth.ScopeManageEvents,
+	picturev1connect.PictureServiceGetTrashedEventsProcedure:    auth.ScopeReadEvents,
+	picturev1connect.PictureServiceRestoreEventProcedure:        auth.ScopeManageEvents,
+	picturev1connect.PictureServiceSaveEventTemplateProcedure:   auth.ScopeManageEvents,
+	picturev1connect.PictureServiceGetEventTemplatesProcedure:   auth.ScopeReadEvents,
+	picturev1connect.PictureServiceDeleteEventTemplateProcedure: auth.ScopeManageEvents,
+	picturev1connect.PictureServiceUploadProcedure:              auth.ScopeUpload,
+	picturev1connect.PictureServicePresignUploadProcedure:       auth.ScopeUpload,
+	picturev1connect.PictureServiceCompleteUploadProcedure:      auth.ScopeUpload,
+	picturev1connect.PictureServiceGetThumbnailsProcedure:       auth.ScopeReadEvents,
+	picturev1connect.PictureServiceHideMediaProcedure:           auth.ScopeModerate,
+	picturev1connect.PictureServiceDeleteMediaProcedure:         auth.ScopeModerate,
+	picturev1connect.PictureServiceMigrateEventStorageProcedure: auth.ScopeManageEvents,
+	picturev1connect.PictureServiceGetStorageMigrationProcedure: auth.ScopeReadEvents,
+}
+
+func newPictureServer(d db.DB, svc service.EventpixService, migrator *service.StorageMigrator) *pictureServer {
+	return &pictureServer{db: d, svc: svc, migrator: migrator}
+}
+
+func (p *pictureServer) CreateEvent(ctx context.Context, req *connect.Request[picturev1.CreateEventRequest]) (*connect.Response[picturev1.CreateEventResponse], error) {
+	user := middleware.UserFromContext(ctx)
+	if req.Msg.GetGoogleDrive() != nil && user.GoogleDriveToken == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("google drive integration not setup for user"))
+	}
+	resp, err := p.svc.CreateEvent(withActor(ctx, req), user.ID, req.Msg)
+	switch {
+	case errors.Is(err, service.ErrNotEventOwner):
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, service.ErrEncryptedPresigned):
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return response(resp, err)
+}
+
+func (p *pictureServer) SetEventLive(ctx context.Context, req *connect.Request[picturev1.SetEventLiveRequest]) (*connect.Response[picturev1.SetEventLiveResponse], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetId(), db.RoleManager); err != nil {
+		return nil, err
+	}
+	return response(p.svc.SetEventLive(withActor(ctx, req), req.Msg))
+}
+
+func (p *pictureServer) SetEventSchedule(ctx context.Context, req *connect.Request[picturev1.SetEventScheduleRequest]) (*connect.Response[picturev1.SetEventScheduleResponse], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetId(), db.RoleManager); err != nil {
+		return nil, err
+	}
+	return response(p.svc.SetEventSchedule(withActor(ctx, req), req.Msg))
+}
+
+func (p *pictureServer) UpdateEvent(ctx context.Context, req *connect.Request[picturev1.UpdateEventRequest]) (*connect.Response[picturev1.UpdateEventResponse], error) {
+	// only owners can see the storage, so only they can change its credentials
+	role := db.RoleManager
+	if req.Msg.GetCredentials() != nil {
+		role = db.RoleOwner
+	}
+	if err := p.authorizeEvent(ctx, req.Msg.GetId(), role); err != nil {
+		return nil, err
+	}
+	resp, err := p.svc.UpdateEvent(withActor(ctx, req), req.Msg)
+	if errors.Is(err, db.ErrSlugTaken) {
+		return nil, connect.NewError(connect.CodeAlreadyExists, err)
+	}
+	return response(resp, err)
+}
+
+func (p *pictureServer) SetEventBranding(ctx context.Context, req *connect.Request[picturev1.SetEventBrandingRequest]) (*connect.Response[picturev1.SetEventBrandingResponse], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetId(), db.RoleManager); err != nil {
+		return nil, err
+	}
+	return response(p.svc.SetEventBranding(withActor(ctx, req), req.Msg))
+}
+
+func (p *pictureServer) GetEvents(ctx context.Context, req *connect.Request[picturev1.GetEventsRequest]) (*connect.Response[picturev1.GetEventsResponse], error) {
+	return response(p.svc.GetEvents(ctx, req.Msg, middleware.UserFromContext(ctx).ID))
+}
+
+func (p *pictureServer) GetEvent(ctx context.Context, req *connect.Request[picturev1.GetEventRequest]) (*connect.Response[picturev1.GetEventResponse], error) {
+	resp, err := p.svc.GetEvent(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	// may be got by slug, so only know which event it is after getting it
+	if err := p.authorizeEvent(ctx, resp.GetEvent().GetId(), db.RoleModerator); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (p *pictureServer) GetActiveEvent(ctx context.Context, req *connect.Request[picturev1.GetActiveEventRequest]) (*connect.Response[picturev1.GetEventResponse], error) {
+	resp, err := p.svc.GetActiveEvent(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.authorizeEvent(ctx, resp.GetEvent().GetId(), db.RoleModerator); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (p *pictureServer) SetActiveEvent(ctx context.Context, req *connect.Request[picturev1.SetActiveEventRequest]) (*connect.Response[emptypb.Empty], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetId(), db.RoleManager); err != nil {
+		return nil, err
+	}
+	return response(p.svc.SetActiveEvent(withActor(ctx, req), req.Msg))
+}
+
+func (p *pictureServer) DeleteEvent(ctx context.Context, req *connect.Request[picturev1.DeleteEventRequest]) (*connect.Response[emptypb.Empty], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetId(), db.RoleOwner); err != nil {
+		return nil, err
+	}
+	return response(p.svc.DeleteEvent(withActor(ctx, req), req.Msg))
+}
+
+func (p *pictureServer) GetTrashedEvents(ctx context.Context, req *connect.Request[picturev1.GetTrashedEventsRequest]) (*connect.Response[picturev1.GetEventsResponse], error) {
+	return response(p.svc.GetTrashedEvents(ctx, req.Msg, middleware.UserFromContext(ctx).ID))
+}
+
+func (p *pictureServer) RestoreEvent(ctx context.Context, req *connect.Request[picturev1.RestoreEventRequest]) (*connect.Response[picturev1.RestoreEventResponse], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetId(), db.RoleOwner); err != nil {
+		return nil, err
+	}
+	return response(p.svc.RestoreEvent(withActor(ctx, req), req.Msg))
+}
+
+func (p *pictureServer) SaveEventTemplate(ctx context.Context, req *connect.Request[picturev1.SaveEventTemplateRequest]) (*connect.Response[picturev1.EventTemplate], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetEventId(), db.RoleOwner); err != nil {
+		return nil, err
+	}
+	return response(p.svc.SaveEventTemplate(withActor(ctx, req), middleware.UserFromContext(ctx).ID, req.Msg))
+}
+
+func (p *pictureServer) GetEventTemplates(ctx context.Context, req *connect.Request[picturev1.GetEventTemplatesRequest]) (*connect.Response[picturev1.GetEventTemplatesResponse], error) {
+	return response(p.svc.GetEventTemplates(ctx, middleware.UserFromContext(ctx).ID, req.Msg))
+}
+
+func (p *pictureServer) DeleteEventTemplate(ctx context.Context, req *connect.Request[picturev1.DeleteEventTemplateRequest]) (*connect.Response[emptypb.Empty], error) {
+	resp, err := p.svc.DeleteEventTemplate(withActor(ctx, req), middleware.UserFromContext(ctx).ID, req.Msg)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	return response(resp, err)
+}
+
+func (p *pictureServer) Upload(ctx context.Context, req *connect.Request[picturev1.UploadRequest]) (*connect.Response[picturev1.UploadResponse], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetEventId(), db.RoleModerator); err != nil {
+		return nil, err
+	}
+	file := req.Msg.GetFile()
+	// no content-type sent with the file, so go off its extension, falling back to sniffing the data
+	contentType := mime.TypeByExtension(filepath.Ext(file.GetName()))
+	if contentType == "" {
+		contentType = http.DetectContentType(file.GetData())
+	}
+	if err := p.svc.Upload(withActor(ctx, req), req.Msg.GetEventId(), file.GetName(), bytes.NewReader(file.GetData()), contentType); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&picturev1.UploadResponse{}), nil
+}
+
+func (p *pictureServer) PresignUpload(ctx context.Context, req *connect.Request[picturev1.PresignUploadRequest]) (*connect.Response[picturev1.PresignUploadResponse], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetEventId(), db.RoleModerator); err != nil {
+		return nil, err
+	}
+	return response(p.svc.PresignUpload(withActor(ctx, req), req.Msg))
+}
+
+func (p *pictureServer) CompleteUpload(ctx context.Context, req *connect.Request[picturev1.CompleteUploadRequest]) (*connect.Response[picturev1.UploadResponse], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetEventId(), db.RoleModerator); err != nil {
+		return nil, err
+	}
+	resp, err := p.svc.CompleteUpload(withActor(ctx, req), req.Msg)
+	if errors.Is(err, service.ErrUploadNotPresigned) {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	return response(resp, err)
+}
+
+func (p *pictureServer) GetThumbnails(ctx context.Context, req *connect.Request[picturev1.GetThumbnailsRequest]) (*connect.Response[picturev1.GetThumbnailsResponse], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetEventId(), db.RoleModerator); err != nil {
+		return nil, err
+	}
+	return response(p.svc.GetThumbnails(ctx, req.Msg))
+}
+
+func (p *pictureServer) HideMedia(ctx context.Context, req *connect.Request[picturev1.HideMediaRequest]) (*connect.Response[emptypb.Empty], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetEventId(), db.RoleModerator); err != nil {
+		return nil, err
+	}
+	resp, err := p.svc.HideMedia(withActor(ctx, req), req.Msg)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	return response(resp, err)
+}
+
+func (p *pictureServer) DeleteMedia(ctx context.Context, req *connect.Request[picturev1.DeleteMediaRequest]) (*connect.Response[emptypb.Empty], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetEventId(), db.RoleModerator); err != nil {
+		return nil, err
+	}
+	resp, err := p.svc.DeleteMedia(withActor(ctx, req), req.Msg)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	return response(resp, err)
+}
+
+func (p *pictureServer) MigrateEventStorage(ctx context.Context, req *connect.Request[picturev1.MigrateEventStorageRequest]) (*connect.Response[picturev1.StorageMigration], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetEventId(), db.RoleOwner); err != nil {
+		return nil, err
+	}
+	return response(p.migrator.Start(withActor(ctx, req), req.Msg))
+}
+
+func (p *pictureServer) GetStorageMigration(ctx context.Context, req *connect.Request[picturev1.GetStorageMigrationRequest]) (*connect.Response[picturev1.StorageMigration], error) {
+	if err := p.authorizeEvent(ctx, req.Msg.GetEventId(), db.RoleOwner); err != nil {
+		return nil, err
+	}
+	return response(p.migrator.GetStorageMigration(ctx, req.Msg))
+}
+
+// checks the authenticated user has at least the role on the event
+func (p *pictureServer) authorizeEvent(ctx context.Context, eventId uint64, role string) error {
+	user := middleware.UserFromContext(ctx)
+	ok, err := p.db.UserAuthorizedForEvent(ctx, user.ID, uint(eventId), role)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if !ok {
+		return connect.NewError(connect.CodePermissionDenied, errors.New("user not authorized for event"))
+	}
+	return nil
+}
+
+// sets who's calling in the context, for the audit log
+func withActor(ctx context.Context, req connect.AnyRequest) context.Context {
+	user := middleware.UserFromContext(ctx)
+	ip, _, err := net.SplitHostPort(req.Peer().Addr)
+	if err != nil {
+		ip = req.Peer().Addr
+	}
+	return service.WithActor(ctx, service.Actor{UserID: user.ID, Username: user.Username, IP: ip})
+}
+
+// wraps the result of a service call into a Connect response
+func response[T any](msg *T, err error) (*connect.Response[T], error) {
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(msg), nil
+}
@@ -0,0 +1,286 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/gen/picture/v1/picturev1connect"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	mockService "github.com/jj-style/eventpix/internal/service/mocks"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func TestPictureServiceApi(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	mdb := mockdb.NewMockDB(t)
+	msvc := mockService.NewMockEventpixService(t)
+	router := gin.New()
+	path, handler := picturev1connect.NewPictureServiceHandler(
+		newPictureServer(mdb, msvc, nil),
+		connect.WithInterceptors(middleware.ConnectAuth(auth.NewSessions(&config.Config{Server: &config.Server{SecretKey: "secret"}}), mdb, procedureScopes)),
+	)
+	router.Any(path+"*procedure", gin.WrapH(handler))
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	token, err := auth.CreateToken("secret", "user", 0)
+	require.NoError(t, err)
+	mdb.EXPECT().
+		GetUser(mock.Anything, "user").
+		Return(&db.User{Model: gorm.Model{ID: 1}, Username: "user"}, nil).
+		Maybe()
+
+	// client for each protocol connect supports
+	clients := map[string]picturev1connect.PictureServiceClient{
+		"connect":  picturev1connect.NewPictureServiceClient(http.DefaultClient, srv.URL),
+		"grpc-web": picturev1connect.NewPictureServiceClient(http.DefaultClient, srv.URL, connect.WithGRPCWeb()),
+	}
+
+	for name, client := range clients {
+		t.Run(name+" unauthenticated", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := client.GetEvents(ctx, connect.NewRequest(&picturev1.GetEventsRequest{}))
+			require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+		})
+
+		t.Run(name+" invalid token", func(t *testing.T) {
+			t.Parallel()
+
+			req := connect.NewRequest(&picturev1.GetEventsRequest{})
+			req.Header().Set("Authorization", "Bearer not-a-token")
+			_, err := client.GetEvents(ctx, req)
+			require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+		})
+	}
+
+	t.Run("logged out everywhere", func(t *testing.T) {
+		t.Parallel()
+
+		mdb.EXPECT().
+			GetUser(mock.Anything, "revoked").
+			Return(&db.User{Model: gorm.Model{ID: 2}, Username: "revoked", TokenVersion: 1}, nil)
+		revoked, err := auth.CreateToken("secret", "revoked", 0)
+		require.NoError(t, err)
+
+		req := connect.NewRequest(&picturev1.GetEventsRequest{})
+		req.Header().Set("Authorization", "Bearer "+revoked)
+		_, err = clients["connect"].GetEvents(ctx, req)
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+
+	t.Run("happy get events", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		msvc.EXPECT().
+			GetEvents(mock.Anything, mock.Anything, uint(1)).
+			Return(&picturev1.GetEventsResponse{Events: []*picturev1.Event{{Id: 1, Name: "party"}}}, nil)
+
+		req := connect.NewRequest(&picturev1.GetEventsRequest{})
+		req.Header().Set("Authorization", "Bearer "+token)
+		got, err := clients["connect"].GetEvents(ctx, req)
+		is.NoError(err)
+		is.Len(got.Msg.GetEvents(), 1)
+		is.Equal("party", got.Msg.GetEvents()[0].GetName())
+	})
+
+	t.Run("happy set event live", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb.EXPECT().
+			UserAuthorizedForEvent(mock.Anything, uint(1), uint(2), db.RoleManager).
+			Return(true, nil)
+		msvc.EXPECT().
+			SetEventLive(mock.Anything, mock.Anything).
+			Return(&picturev1.SetEventLiveResponse{Event: &picturev1.Event{Id: 2, Live: true}}, nil)
+
+		req := connect.NewRequest(&picturev1.SetEventLiveRequest{Id: 2, Live: true})
+		req.Header().Set("Authorization", "Bearer "+token)
+		got, err := clients["grpc-web"].SetEventLive(ctx, req)
+		is.NoError(err)
+		is.True(got.Msg.GetEvent().GetLive())
+	})
+
+	t.Run("happy set event schedule", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		startsAt := timestamppb.New(time.Now().Add(time.Hour))
+		mdb.EXPECT().
+			UserAuthorizedForEvent(mock.Anything, uint(1), uint(4), db.RoleManager).
+			Return(true, nil)
+		msvc.EXPECT().
+			SetEventSchedule(mock.Anything, mock.MatchedBy(func(req *picturev1.SetEventScheduleRequest) bool {
+				return req.GetId() == 4 && req.GetStartsAt().AsTime().Equal(startsAt.AsTime())
+			})).
+			Return(&picturev1.SetEventScheduleResponse{Event: &picturev1.Event{Id: 4, StartsAt: startsAt}}, nil)
+
+		req := connect.NewRequest(&picturev1.SetEventScheduleRequest{Id: 4, StartsAt: startsAt})
+		req.Header().Set("Authorization", "Bearer "+token)
+		got, err := clients["connect"].SetEventSchedule(ctx, req)
+		is.NoError(err)
+		is.True(got.Msg.GetEvent().GetStartsAt().AsTime().Equal(startsAt.AsTime()))
+	})
+
+	t.Run("update event slug taken", func(t *testing.T) {
+		t.Parallel()
+
+		mdb.EXPECT().
+			UserAuthorizedForEvent(mock.Anything, uint(1), uint(5), db.RoleManager).
+			Return(true, nil)
+		msvc.EXPECT().
+			UpdateEvent(mock.Anything, mock.MatchedBy(func(req *picturev1.UpdateEventRequest) bool { return req.GetSlug() == "taken" })).
+			Return(nil, db.ErrSlugTaken)
+
+		req := connect.NewRequest(&picturev1.UpdateEventRequest{Id: 5, Slug: lo.ToPtr("taken")})
+		req.Header().Set("Authorization", "Bearer "+token)
+		_, err := clients["connect"].UpdateEvent(ctx, req)
+		require.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+	})
+
+	t.Run("update event credentials needs owner", func(t *testing.T) {
+		t.Parallel()
+
+		mdb.EXPECT().
+			UserAuthorizedForEvent(mock.Anything, uint(1), uint(6), db.RoleOwner).
+			Return(false, nil)
+
+		req := connect.NewRequest(&picturev1.UpdateEventRequest{
+			Id:          6,
+			Credentials: &picturev1.UpdateEventRequest_S3Credentials{S3Credentials: &picturev1.S3Credentials{AccessKey: "key", SecretKey: "secret"}},
+		})
+		req.Header().Set("Authorization", "Bearer "+token)
+		_, err := clients["connect"].UpdateEvent(ctx, req)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("save template needs owner", func(t *testing.T) {
+		t.Parallel()
+
+		mdb.EXPECT().
+			UserAuthorizedForEvent(mock.Anything, uint(1), uint(7), db.RoleOwner).
+			Return(false, nil)
+
+		req := connect.NewRequest(&picturev1.SaveEventTemplateRequest{EventId: 7, Name: "weddings"})
+		req.Header().Set("Authorization", "Bearer "+token)
+		_, err := clients["connect"].SaveEventTemplate(ctx, req)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("set branding needs manager", func(t *testing.T) {
+		t.Parallel()
+
+		mdb.EXPECT().
+			UserAuthorizedForEvent(mock.Anything, uint(1), uint(8), db.RoleManager).
+			Return(false, nil)
+
+		req := connect.NewRequest(&picturev1.SetEventBrandingRequest{Id: 8, Branding: &picturev1.Branding{Font: "Lora"}})
+		req.Header().Set("Authorization", "Bearer "+token)
+		_, err := clients["connect"].SetEventBranding(ctx, req)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("happy hide media", func(t *testing.T) {
+		t.Parallel()
+
+		mdb.EXPECT().
+			UserAuthorizedForEvent(mock.Anything, uint(1), uint(9), db.RoleModerator).
+			Return(true, nil)
+		msvc.EXPECT().
+			HideMedia(mock.Anything, mock.MatchedBy(func(req *picturev1.HideMediaRequest) bool { return req.GetFileId() == "cake.jpg" && req.GetHidden() })).
+			Return(&emptypb.Empty{}, nil)
+
+		req := connect.NewRequest(&picturev1.HideMediaRequest{EventId: 9, FileId: "cake.jpg", Hidden: true})
+		req.Header().Set("Authorization", "Bearer "+token)
+		_, err := clients["connect"].HideMedia(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete media not in event", func(t *testing.T) {
+		t.Parallel()
+
+		mdb.EXPECT().
+			UserAuthorizedForEvent(mock.Anything, uint(1), uint(10), db.RoleModerator).
+			Return(true, nil)
+		msvc.EXPECT().
+			DeleteMedia(mock.Anything, mock.MatchedBy(func(req *picturev1.DeleteMediaRequest) bool { return req.GetEventId() == 10 })).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		req := connect.NewRequest(&picturev1.DeleteMediaRequest{EventId: 10, FileId: "other.jpg"})
+		req.Header().Set("Authorization", "Bearer "+token)
+		_, err := clients["connect"].DeleteMedia(ctx, req)
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("unauthorized for event", func(t *testing.T) {
+		t.Parallel()
+
+		mdb.EXPECT().
+			UserAuthorizedForEvent(mock.Anything, uint(1), uint(3), db.RoleOwner).
+			Return(false, nil)
+
+		req := connect.NewRequest(&picturev1.DeleteEventRequest{Id: 3})
+		req.Header().Set("Authorization", "Bearer "+token)
+		_, err := clients["connect"].DeleteEvent(ctx, req)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("api token", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		apiToken, hash, err := auth.NewApiToken()
+		is.NoError(err)
+		mdb.EXPECT().
+			GetApiToken(mock.Anything, hash).
+			Return(&db.ApiToken{User: db.User{Model: gorm.Model{ID: 4}}, Scopes: auth.ScopeReadEvents}, nil)
+		msvc.EXPECT().
+			GetEvents(mock.Anything, mock.Anything, uint(4)).
+			Return(&picturev1.GetEventsResponse{}, nil)
+
+		req := connect.NewRequest(&picturev1.GetEventsRequest{})
+		req.Header().Set("Authorization", "Bearer "+apiToken)
+		_, err = clients["connect"].GetEvents(ctx, req)
+		is.NoError(err)
+
+		// token can read but not manage events
+		del := connect.NewRequest(&picturev1.DeleteEventRequest{Id: 5})
+		del.Header().Set("Authorization", "Bearer "+apiToken)
+		_, err = clients["connect"].DeleteEvent(ctx, del)
+		is.Equal(connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("api token without moderate scope", func(t *testing.T) {
+		t.Parallel()
+
+		apiToken, hash, err := auth.NewApiToken()
+		require.NoError(t, err)
+		mdb.EXPECT().
+			GetApiToken(mock.Anything, hash).
+			Return(&db.ApiToken{User: db.User{Model: gorm.Model{ID: 4}}, Scopes: auth.ScopeManageEvents}, nil)
+
+		req := connect.NewRequest(&picturev1.DeleteMediaRequest{EventId: 5, FileId: "cake.jpg"})
+		req.Header().Set("Authorization", "Bearer "+apiToken)
+		_, err = clients["connect"].DeleteMedia(ctx, req)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
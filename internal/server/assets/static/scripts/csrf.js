@@ -0,0 +1,6 @@
+// CSRF token the server set in the csrf_token cookie, sent back in the X-CSRF-Token header
+// on requests which change anything. htmx requests send it from hx-headers on the body.
+function csrfToken() {
+    const cookie = document.cookie.split('; ').find((c) => c.startsWith('csrf_token='));
+    return cookie ? decodeURIComponent(cookie.substring('csrf_token='.length)) : '';
+}
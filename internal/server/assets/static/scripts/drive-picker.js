@@ -0,0 +1,14 @@
+// fills in the google drive folder ID input once a folder is picked
+var driveElement = null;
+window.addEventListener("load", function () {
+  document.body.addEventListener('drivePicker', function (e) {
+      if (driveElement !== null) driveElement.removeEventListener("picker:picked", console.log)
+      console.log("drive picker event");
+      driveElement = document.querySelector("drive-picker");
+      driveElement.addEventListener("picker:picked", function(result) {
+        console.log(result);
+        let folderIdInput = document.getElementById('googleDriveFolderId');
+        folderIdInput.value = result.detail.docs[0].id;
+      });
+  })
+});
@@ -0,0 +1,44 @@
+// uploads files straight to the events storage with presigned URLs,
+// instead of sending them through the server
+async function presignedUpload(eventId, file) {
+  let resp = await fetch("/upload/presign", {
+    method: "POST",
+    headers: { "Content-Type": "application/json", "X-CSRF-Token": csrfToken() },
+    body: JSON.stringify({ eventId: eventId, name: file.name, contentType: file.type }),
+  });
+  if (!resp.ok) throw new Error(`preparing upload of ${file.name} failed`);
+  let presigned = await resp.json();
+
+  resp = await fetch(presigned.url, { method: "PUT", body: file });
+  if (!resp.ok) throw new Error(`uploading ${file.name} failed`);
+
+  resp = await fetch("/upload/complete", {
+    method: "POST",
+    headers: { "Content-Type": "application/json", "X-CSRF-Token": csrfToken() },
+    body: JSON.stringify({ eventId: eventId, id: presigned.id, name: file.name, contentType: file.type, size: file.size }),
+  });
+  if (!resp.ok) throw new Error(`completing upload of ${file.name} failed`);
+}
+
+window.addEventListener("load", function () {
+  let form = document.querySelector("form[data-presigned]");
+  if (form === null) return;
+  form.addEventListener("submit", async function (e) {
+    e.preventDefault();
+    let eventId = form.elements["eventId"].value;
+    let error = document.getElementById("uploadFormError");
+    let buttons = form.querySelectorAll("button");
+    error.textContent = "";
+    buttons.forEach((b) => (b.disabled = true));
+    document.getElementById("upload-indicator").classList.add("htmx-request");
+    try {
+      await Promise.all(Array.from(form.elements["files"].files).map((f) => presignedUpload(eventId, f)));
+      document.body.dispatchEvent(new Event("uploadComplete"));
+    } catch (err) {
+      error.textContent = err.message;
+    } finally {
+      buttons.forEach((b) => (b.disabled = false));
+      document.getElementById("upload-indicator").classList.remove("htmx-request");
+    }
+  });
+});
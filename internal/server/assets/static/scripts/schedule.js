@@ -0,0 +1,48 @@
+// datetime-local inputs have no timezone, so they're shown in local time and sent in
+// RFC 3339. Empty ones aren't sent at all, leaving that part of the schedule unset.
+document.addEventListener('htmx:configRequest', function (e) {
+    const form = e.detail.elt.closest('form');
+    if (!form) return;
+    form.querySelectorAll('input[type="datetime-local"][name]').forEach(function (input) {
+        if (input.value) {
+            e.detail.parameters[input.name] = new Date(input.value).toISOString();
+        } else {
+            delete e.detail.parameters[input.name];
+        }
+    });
+});
+
+function pad(n) {
+    return String(n).padStart(2, '0');
+}
+
+// fills datetime-local inputs from their RFC 3339 data-value, in local time
+function fillScheduleInputs(root) {
+    root.querySelectorAll('input[type="datetime-local"][data-value]').forEach(function (input) {
+        const d = new Date(input.dataset.value);
+        input.value = `${d.getFullYear()}-${pad(d.getMonth() + 1)}-${pad(d.getDate())}T${pad(d.getHours())}:${pad(d.getMinutes())}`;
+    });
+}
+
+// counts down to the RFC 3339 data-countdown of elements, after their data-label
+function formatCountdown(ms) {
+    if (ms <= 0) return 'now';
+    const s = Math.floor(ms / 1000);
+    const d = Math.floor(s / 86400), h = Math.floor(s % 86400 / 3600), m = Math.floor(s % 3600 / 60);
+    if (d > 0) return `${d}d ${h}h`;
+    if (h > 0) return `${h}h ${m}m`;
+    return `${m}m ${s % 60}s`;
+}
+
+function updateCountdowns() {
+    document.querySelectorAll('[data-countdown]').forEach(function (el) {
+        const ms = new Date(el.dataset.countdown) - new Date();
+        el.textContent = `${el.dataset.label} ${ms > 0 ? 'in ' : ''}${formatCountdown(ms)}`;
+    });
+}
+
+htmx.onLoad(function (elt) {
+    fillScheduleInputs(elt);
+    updateCountdowns();
+});
+setInterval(updateCountdowns, 1000);
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// how many of the latest entries are shown, the rest are in the export
+const auditPageSize = 200
+
+func getEventAudit(d db.DB, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		event, err := d.GetEvent(c, eventId)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				code = http.StatusNotFound
+			}
+			AbortWithError(c, code, err)
+			return
+		}
+		entries, err := audit.GetAuditLogs(c, lo.ToPtr(uint(eventId)), auditPageSize)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.HTML(http.StatusOK, "audit", gin.H{
+			"title":   "Audit log - " + event.Name,
+			"event":   event,
+			"entries": entries,
+			"export":  fmt.Sprintf("/event/%d/audit/export", eventId),
+		})
+	}
+}
+
+func getAdminAudit(audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entries, err := audit.GetAuditLogs(c, nil, auditPageSize)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.HTML(http.StatusOK, "audit", gin.H{
+			"title":   "Audit log",
+			"admin":   true,
+			"entries": entries,
+			"export":  "/admin/audit/export",
+		})
+	}
+}
+
+func exportEventAudit(audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		exportAudit(c, audit, fmt.Sprintf("event-%d-audit", eventId), lo.ToPtr(uint(eventId)))
+	}
+}
+
+func exportAdminAudit(audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		exportAudit(c, audit, "audit", nil)
+	}
+}
+
+// writes the audit log as a download in the format from the query, CSV by default
+func exportAudit(c *gin.Context, audit *service.Auditor, name string, eventId *uint) {
+	format := c.DefaultQuery("format", service.AuditFormatCSV)
+	if format != service.AuditFormatCSV && format != service.AuditFormatJSON {
+		AbortWithError(c, http.StatusBadRequest, fmt.Errorf("unknown format %q", format))
+		return
+	}
+	var buf bytes.Buffer
+	if err := audit.Export(c, &buf, format, eventId); err != nil {
+		AbortWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, service.AuditExportContentType(format), buf.Bytes())
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestAuditRoutes(t *testing.T) {
+	t.Parallel()
+
+	owner := &db.User{Model: gorm.Model{ID: 1}}
+
+	entries := []*db.AuditLog{{
+		CreatedAt: time.Date(2026, 6, 1, 14, 0, 0, 0, time.UTC),
+		ActorID:   1,
+		ActorName: "bob",
+		IP:        "192.0.2.1",
+		Action:    service.AuditMemberInvite,
+		Target:    "user:3",
+		EventID:   lo.ToPtr(uint(2)),
+		After:     `{"role":"moderator"}`,
+	}}
+
+	t.Run("event audit log", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, owner, 2, db.RoleOwner)
+		router.GET("/event/:id/audit", getEventAudit(mdb, service.NewAuditor(mdb, zap.NewNop())))
+
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(&db.Event{Model: gorm.Model{ID: 2}, Name: "wedding"}, nil)
+		mdb.EXPECT().GetAuditLogs(mock.Anything, lo.ToPtr(uint(2)), auditPageSize).Return(entries, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/audit", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), "wedding")
+		is.Contains(w.Body.String(), "event.member.invite")
+		is.Contains(w.Body.String(), "2026-06-01 14:00:00")
+		is.Contains(w.Body.String(), `href="/event/2/audit/export?format=csv"`)
+	})
+
+	t.Run("admin audit log", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, owner, 0, "")
+		router.GET("/admin/audit", getAdminAudit(service.NewAuditor(mdb, zap.NewNop())))
+
+		mdb.EXPECT().GetAuditLogs(mock.Anything, (*uint)(nil), auditPageSize).Return(entries, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin/audit", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), "event.member.invite")
+		is.Contains(w.Body.String(), `href="/admin/audit/export?format=json"`)
+	})
+
+	t.Run("export csv", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, owner, 2, db.RoleOwner)
+		router.GET("/event/:id/audit/export", exportEventAudit(service.NewAuditor(mdb, zap.NewNop())))
+
+		mdb.EXPECT().GetAuditLogs(mock.Anything, lo.ToPtr(uint(2)), 0).Return(entries, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/audit/export", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Equal("text/csv", w.Header().Get("Content-Type"))
+		is.Contains(w.Header().Get("Content-Disposition"), `attachment; filename="event-2-audit-`)
+		rows, err := csv.NewReader(w.Body).ReadAll()
+		is.NoError(err)
+		is.Len(rows, 2)
+		is.Equal("bob", rows[1][2])
+	})
+
+	t.Run("export json", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, owner, 2, db.RoleOwner)
+		router.GET("/event/:id/audit/export", exportEventAudit(service.NewAuditor(mdb, zap.NewNop())))
+
+		mdb.EXPECT().GetAuditLogs(mock.Anything, lo.ToPtr(uint(2)), 0).Return(entries, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/audit/export?format=json", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Equal("application/json", w.Header().Get("Content-Type"))
+		is.Contains(w.Body.String(), `"action": "event.member.invite"`)
+	})
+
+	t.Run("export unknown format", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, owner, 2, db.RoleOwner)
+		router.GET("/event/:id/audit/export", exportEventAudit(service.NewAuditor(mdb, zap.NewNop())))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/audit/export?format=xml", nil)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gin-gonic/gin"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/branding"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+)
+
+// galleryStyle is what the events gallery is shown with
+func galleryStyle(b *picturev1.Branding) branding.Style {
+	return branding.NewStyle(b.GetPrimaryColor(), b.GetBackgroundColor(), b.GetFont())
+}
+
+// updateEventBranding applies the branding form, storing a new cover image if one is uploaded
+func updateEventBranding(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h := c.MustGet(middleware.HtmxKey).(*htmx.Handler)
+		eventId := c.MustGet("eventId").(uint64)
+
+		req := &picturev1.SetEventBrandingRequest{
+			Id: eventId,
+			Branding: &picturev1.Branding{
+				PrimaryColor:    c.PostForm("primaryColor"),
+				BackgroundColor: c.PostForm("backgroundColor"),
+				Font:            c.PostForm("font"),
+				WelcomeText:     c.PostForm("welcomeText"),
+				Footer:          c.PostForm("footer"),
+			},
+			RemoveCoverImage: c.PostForm("removeCover") == "on",
+		}
+		if _, err := svc.SetEventBranding(c, req); err != nil {
+			AbortWithError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		if cover, err := c.FormFile("cover"); err == nil {
+			if cover.Size > service.MaxCoverSize {
+				AbortWithError(c, http.StatusRequestEntityTooLarge, errors.New("cover image is too big"))
+				return
+			}
+			f, err := cover.Open()
+			if err != nil {
+				AbortWithError(c, http.StatusBadRequest, err)
+				return
+			}
+			defer f.Close()
+			if err := svc.SetEventCover(c, eventId, cover.Filename, f, cover.Header.Get("Content-Type")); err != nil {
+				AbortWithError(c, http.StatusUnprocessableEntity, err)
+				return
+			}
+		}
+
+		c.Status(http.StatusOK)
+		h.Redirect("/events")
+	}
+}
+
+// getEventCover serves the events cover image to anyone who can see its gallery
+func getEventCover(svc service.EventpixService, guest *middleware.Guest) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		event, err := svc.GetEvent(c, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: eventId}})
+		if err != nil {
+			AbortWithError(c, http.StatusNotFound, err)
+			return
+		}
+		if _, ok := authorizeGuest(c, guest, event.GetEvent(), auth.GuestView); !ok {
+			return
+		}
+
+		cover, err := svc.GetEventCover(c, eventId)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, service.ErrNoCover) {
+				code = http.StatusNotFound
+			}
+			AbortWithError(c, code, err)
+			return
+		}
+		// the cover of password protected events is only for their guests
+		c.Header("Cache-Control", "private, max-age=3600")
+		c.Data(http.StatusOK, http.DetectContentType(cover), cover)
+	}
+}
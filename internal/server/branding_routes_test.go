@@ -0,0 +1,234 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+	mockService "github.com/jj-style/eventpix/internal/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestBrandingRoutes(t *testing.T) {
+	t.Parallel()
+
+	branded := &picturev1.Event{
+		Id:   2,
+		Name: "wedding",
+		Live: true,
+		Branding: &picturev1.Branding{
+			PrimaryColor:    "#112233",
+			BackgroundColor: "#445566",
+			Font:            "Lora",
+			CoverImage:      true,
+			WelcomeText:     "**Welcome** to our day <script>alert(1)</script>",
+			Footer:          "Photos by <Jo>",
+		},
+	}
+	expectEvent := func(msvc *mockService.MockEventpixService, event *picturev1.Event) {
+		msvc.EXPECT().
+			GetEvent(mock.Anything, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: 2}}).
+			Return(&picturev1.GetEventResponse{Event: event}, nil)
+	}
+
+	brandingForm := func(fields map[string]string, cover []byte) (*bytes.Buffer, string) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		for name, value := range fields {
+			_ = writer.WriteField(name, value)
+		}
+		if cover != nil {
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Disposition", `form-data; name="cover"; filename="cover.png"`)
+			header.Set("Content-Type", "image/png")
+			part, _ := writer.CreatePart(header)
+			_, _ = part.Write(cover)
+		}
+		_ = writer.Close()
+		return body, writer.FormDataContentType()
+	}
+
+	t.Run("branded gallery", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 0, "")
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/event/:id", getEvent(msvc, middleware.NewGuest("secret", false, mdb)))
+		expectEvent(msvc, branded)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		body := w.Body.String()
+		is.Contains(body, "background-color: #445566")
+		is.Contains(body, "color: #112233")
+		is.Contains(body, `font-family: "Lora", serif`)
+		is.Contains(body, `href="https://fonts.googleapis.com/css2?display=swap&amp;family=Lora"`)
+		is.Contains(body, `src="/event/2/cover"`)
+		is.Contains(body, "<strong>Welcome</strong> to our day")
+		is.NotContains(body, "<script>alert(1)</script>")
+		is.Contains(body, "Photos by &lt;Jo&gt;")
+	})
+
+	t.Run("unbranded gallery", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 0, "")
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/event/:id", getEvent(msvc, middleware.NewGuest("secret", false, mdb)))
+		expectEvent(msvc, &picturev1.Event{Id: 2, Name: "wedding"})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		body := w.Body.String()
+		is.Contains(body, "background-color: #f0ffff")
+		is.Contains(body, `font-family: "Delius Swash Caps", serif`)
+		is.NotContains(body, "/event/2/cover")
+		is.NotContains(body, "<footer")
+	})
+
+	t.Run("qr code colours", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleManager)
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/event/:id/qr/modal", getEventQrModal(msvc, mdb, &config.Config{Server: &config.Server{ServerUrl: "https://eventpix.example.com"}}))
+		expectEvent(msvc, branded)
+		mdb.EXPECT().GetGuestTokens(mock.Anything, uint(2)).Return(nil, nil)
+		mdb.EXPECT().GetShortLinks(mock.Anything, uint(2)).Return(nil, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/qr/modal", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), `name="foreground" value="#112233"`)
+		is.Contains(w.Body.String(), `name="background" value="#445566"`)
+	})
+
+	t.Run("branding form", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleManager)
+		router.GET("/event/:id/edit", getEditEvent(mdb))
+
+		mdb.EXPECT().
+			GetEvent(mock.Anything, uint64(2)).
+			Return(&db.Event{Model: gorm.Model{ID: 2}, Name: "wedding", Branding: &db.EventBranding{Font: "Lora", CoverImage: "branding/cover.png", Footer: "thanks"}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/edit", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		body := w.Body.String()
+		is.Contains(body, `<option value="Lora" selected>`)
+		is.Contains(body, `name="primaryColor" class="form-control form-control-color" value="#ff4f79"`)
+		is.Contains(body, `name="removeCover"`)
+		is.Contains(body, `value="thanks"`)
+	})
+
+	t.Run("update branding with cover", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, _ := newMemberRouter(t, nil, 2, db.RoleManager)
+		msvc := mockService.NewMockEventpixService(t)
+		router.PUT("/event/:id/branding", updateEventBranding(msvc))
+
+		msvc.EXPECT().
+			SetEventBranding(mock.Anything, &picturev1.SetEventBrandingRequest{
+				Id:       2,
+				Branding: &picturev1.Branding{PrimaryColor: "#112233", Font: "Lora", WelcomeText: "hello"},
+			}).
+			Return(&picturev1.SetEventBrandingResponse{}, nil)
+		msvc.EXPECT().
+			SetEventCover(mock.Anything, uint64(2), "cover.png", mock.Anything, "image/png").
+			Return(nil)
+
+		body, contentType := brandingForm(map[string]string{"primaryColor": "#112233", "font": "Lora", "welcomeText": "hello"}, []byte("png"))
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/event/2/branding", body)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("HX-Request", "true")
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Equal("/events", w.Header().Get("HX-Redirect"))
+	})
+
+	t.Run("invalid branding", func(t *testing.T) {
+		t.Parallel()
+		router, _ := newMemberRouter(t, nil, 2, db.RoleManager)
+		msvc := mockService.NewMockEventpixService(t)
+		router.PUT("/event/:id/branding", updateEventBranding(msvc))
+
+		msvc.EXPECT().
+			SetEventBranding(mock.Anything, mock.Anything).
+			Return(nil, errors.New("unknown font"))
+
+		body, contentType := brandingForm(map[string]string{"font": "Comic Sans", "removeCover": "on"}, nil)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/event/2/branding", body)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("HX-Request", "true")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("cover image", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 0, "")
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/event/:id/cover", getEventCover(msvc, middleware.NewGuest("secret", false, mdb)))
+		expectEvent(msvc, branded)
+		png := []byte("\x89PNG\r\n\x1a\n")
+		msvc.EXPECT().GetEventCover(mock.Anything, uint64(2)).Return(png, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/cover", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Equal("image/png", w.Header().Get("Content-Type"))
+		is.Equal(png, w.Body.Bytes())
+	})
+
+	t.Run("no cover image", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, nil, 0, "")
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/event/:id/cover", getEventCover(msvc, middleware.NewGuest("secret", false, mdb)))
+		expectEvent(msvc, &picturev1.Event{Id: 2})
+		msvc.EXPECT().GetEventCover(mock.Anything, uint64(2)).Return(nil, service.ErrNoCover)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/cover", nil)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("cover of password protected event", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, nil, 0, "")
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/event/:id/cover", getEventCover(msvc, middleware.NewGuest("secret", false, mdb)))
+		expectEvent(msvc, &picturev1.Event{Id: 2, PasswordProtected: true, Branding: &picturev1.Branding{CoverImage: true}})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/cover", nil)
+		req.Header.Set("HX-Request", "true")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
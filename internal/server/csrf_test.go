@@ -0,0 +1,202 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCsrf(t *testing.T) {
+	t.Parallel()
+
+	router := newTestRouter()
+	router.Use(middleware.Csrf("secret", true, "/api/"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/", ok)
+	router.POST("/profile", ok)
+	router.DELETE("/event/:id", ok)
+	router.POST("/api/call", ok)
+
+	do := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// visiting a page gets a token
+	w := do(httptest.NewRequest("GET", "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	cookie := cookies[0]
+	require.Equal(t, auth.CsrfCookieName, cookie.Name)
+	require.True(t, cookie.Secure)
+	require.False(t, cookie.HttpOnly)
+	require.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+	require.True(t, auth.VerifyCsrfToken("secret", cookie.Value))
+
+	t.Run("existing token kept", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.AddCookie(cookie)
+		w := do(req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Empty(w.Result().Cookies())
+	})
+
+	t.Run("header", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		req := httptest.NewRequest("DELETE", "/event/1", nil)
+		req.AddCookie(cookie)
+		req.Header.Set(auth.CsrfHeader, cookie.Value)
+		is.Equal(http.StatusOK, do(req).Code)
+	})
+
+	t.Run("form field", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		form := url.Values{auth.CsrfFormField: {cookie.Value}}
+		req := httptest.NewRequest("POST", "/profile", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(cookie)
+		is.Equal(http.StatusOK, do(req).Code)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		req := httptest.NewRequest("POST", "/profile", nil)
+		req.AddCookie(cookie)
+		w := do(req)
+		is.Equal(http.StatusForbidden, w.Code)
+		is.Contains(w.Body.String(), "invalid CSRF token")
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		other, err := auth.CreateCsrfToken("secret")
+		is.NoError(err)
+		req := httptest.NewRequest("POST", "/profile", nil)
+		req.AddCookie(cookie)
+		req.Header.Set(auth.CsrfHeader, other)
+		is.Equal(http.StatusForbidden, do(req).Code)
+	})
+
+	t.Run("forged cookie", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		forged, err := auth.CreateCsrfToken("other secret")
+		is.NoError(err)
+		req := httptest.NewRequest("POST", "/profile", nil)
+		req.AddCookie(&http.Cookie{Name: auth.CsrfCookieName, Value: forged})
+		req.Header.Set(auth.CsrfHeader, forged)
+		w := do(req)
+		is.Equal(http.StatusForbidden, w.Code)
+		// replaced with a real one
+		is.Len(w.Result().Cookies(), 1)
+	})
+
+	t.Run("bearer token", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		req := httptest.NewRequest("POST", "/profile", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		is.Equal(http.StatusOK, do(req).Code)
+	})
+
+	t.Run("skipped path", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		is.Equal(http.StatusOK, do(httptest.NewRequest("POST", "/api/call", nil)).Code)
+	})
+}
+
+func TestCors(t *testing.T) {
+	t.Parallel()
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	newRouter := func(origins ...string) *gin.Engine {
+		router := newTestRouter()
+		router.Use(middleware.Cors(origins))
+		router.GET("/events", ok)
+		router.POST("/events", ok)
+		return router
+	}
+	do := func(router *gin.Engine, method, origin string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/events", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if method == "OPTIONS" {
+			req.Header.Set("Access-Control-Request-Method", "POST")
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("allowed origin", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		router := newRouter("https://example.com")
+		w := do(router, "GET", "https://example.com")
+		is.Equal(http.StatusOK, w.Code)
+		is.Equal("https://example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		is.Equal("true", w.Header().Get("Access-Control-Allow-Credentials"))
+		is.Equal("Origin", w.Header().Get("Vary"))
+
+		w = do(router, "OPTIONS", "https://example.com")
+		is.Equal(http.StatusNoContent, w.Code)
+		is.Contains(w.Header().Get("Access-Control-Allow-Methods"), "POST")
+		is.Contains(w.Header().Get("Access-Control-Allow-Headers"), auth.CsrfHeader)
+	})
+
+	t.Run("other origin", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		router := newRouter("https://example.com")
+		w := do(router, "GET", "https://evil.example")
+		is.Equal(http.StatusOK, w.Code)
+		is.Empty(w.Header().Get("Access-Control-Allow-Origin"))
+
+		w = do(router, "OPTIONS", "https://evil.example")
+		is.Empty(w.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("no origins", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		w := do(newRouter(), "GET", "https://example.com")
+		is.Empty(w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("any origin", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		w := do(newRouter("*"), "GET", "https://example.com")
+		is.Equal("*", w.Header().Get("Access-Control-Allow-Origin"))
+		is.Empty(w.Header().Get("Access-Control-Allow-Credentials"))
+	})
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/branding"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/samber/lo"
+)
+
+func getEditEvent(d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		event, err := d.GetEvent(c, eventId)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		b := lo.FromPtr(event.Branding)
+		c.HTML(http.StatusOK, "editEvent", gin.H{
+			"title":    "Edit " + event.Name,
+			"event":    event,
+			"owner":    c.GetString(middleware.EventRoleKey) == db.RoleOwner,
+			"branding": b,
+			"style":    branding.NewStyle(b.PrimaryColor, b.BackgroundColor, b.Font),
+			"fonts":    branding.Fonts,
+		})
+	}
+}
+
+// updateEvent applies the edit event form. Blank fields are left unchanged,
+// except for the cache checkbox which is always sent with the form.
+func updateEvent(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h := c.MustGet(middleware.HtmxKey).(*htmx.Handler)
+		eventId := c.MustGet("eventId").(uint64)
+
+		cache := c.PostForm("cache") == "on"
+		req := &picturev1.UpdateEventRequest{Id: eventId, Cache: &cache}
+		if name := c.PostForm("name"); name != "" {
+			req.Name = &name
+		}
+		if slug := c.PostForm("slug"); slug != "" {
+			req.Slug = &slug
+		}
+		if c.PostForm("removePassword") == "on" {
+			req.Password = new(string)
+		} else if password := c.PostForm("password"); password != "" {
+			req.Password = &password
+		}
+
+		if accessKey, secretKey := c.PostForm("accessKey"), c.PostForm("secretKey"); accessKey != "" || secretKey != "" {
+			req.Credentials = &picturev1.UpdateEventRequest_S3Credentials{S3Credentials: &picturev1.S3Credentials{AccessKey: accessKey, SecretKey: secretKey}}
+		} else if username, password := c.PostForm("username"), c.PostForm("ftpPassword"); username != "" || password != "" {
+			req.Credentials = &picturev1.UpdateEventRequest_FtpCredentials{FtpCredentials: &picturev1.FtpCredentials{Username: username, Password: password}}
+		}
+		if req.Credentials != nil && c.GetString(middleware.EventRoleKey) != db.RoleOwner {
+			AbortWithError(c, http.StatusForbidden, errors.New("only the event owner can change storage credentials"))
+			return
+		}
+
+		if _, err := svc.UpdateEvent(c, req); err != nil {
+			code := http.StatusUnprocessableEntity
+			if errors.Is(err, db.ErrSlugTaken) {
+				code = http.StatusConflict
+			}
+			AbortWithError(c, code, err)
+			return
+		}
+		c.Status(http.StatusOK)
+		h.Redirect("/events")
+	}
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	mockService "github.com/jj-style/eventpix/internal/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditEventRoutes(t *testing.T) {
+	t.Parallel()
+
+	putForm := func(router *gin.Engine, form url.Values) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/event/2", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("HX-Request", "true")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("update event", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, _ := newMemberRouter(t, nil, 2, db.RoleManager)
+		msvc := mockService.NewMockEventpixService(t)
+		router.PUT("/event/:id", updateEvent(msvc))
+
+		msvc.EXPECT().
+			UpdateEvent(mock.Anything, mock.MatchedBy(func(req *picturev1.UpdateEventRequest) bool {
+				return req.GetId() == 2 &&
+					req.GetName() == "wedding" &&
+					req.GetSlug() == "our-wedding" &&
+					req.Password != nil && req.GetPassword() == "" &&
+					req.Cache != nil && req.GetCache() &&
+					req.GetCredentials() == nil
+			})).
+			Return(&picturev1.UpdateEventResponse{}, nil)
+
+		w := putForm(router, url.Values{
+			"name":           {"wedding"},
+			"slug":           {"our-wedding"},
+			"password":       {""},
+			"removePassword": {"on"},
+			"cache":          {"on"},
+		})
+		is.Equal(http.StatusOK, w.Code)
+		is.Equal("/events", w.Header().Get("HX-Redirect"))
+	})
+
+	t.Run("slug taken", func(t *testing.T) {
+		t.Parallel()
+		router, _ := newMemberRouter(t, nil, 2, db.RoleManager)
+		msvc := mockService.NewMockEventpixService(t)
+		router.PUT("/event/:id", updateEvent(msvc))
+
+		msvc.EXPECT().
+			UpdateEvent(mock.Anything, mock.Anything).
+			Return(nil, db.ErrSlugTaken)
+
+		w := putForm(router, url.Values{"slug": {"taken"}})
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("credentials need owner", func(t *testing.T) {
+		t.Parallel()
+		router, _ := newMemberRouter(t, nil, 2, db.RoleManager)
+		router.PUT("/event/:id", updateEvent(mockService.NewMockEventpixService(t)))
+
+		w := putForm(router, url.Values{"accessKey": {"key"}, "secretKey": {"secret"}})
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("owner rotates credentials", func(t *testing.T) {
+		t.Parallel()
+		router, _ := newMemberRouter(t, nil, 2, db.RoleOwner)
+		msvc := mockService.NewMockEventpixService(t)
+		router.PUT("/event/:id", updateEvent(msvc))
+
+		msvc.EXPECT().
+			UpdateEvent(mock.Anything, mock.MatchedBy(func(req *picturev1.UpdateEventRequest) bool {
+				creds := req.GetFtpCredentials()
+				return creds.GetUsername() == "user" && creds.GetPassword() == "pass" && req.Password == nil
+			})).
+			Return(&picturev1.UpdateEventResponse{}, nil)
+
+		w := putForm(router, url.Values{"username": {"user"}, "ftpPassword": {"pass"}})
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("edit page", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleOwner)
+		router.GET("/event/:id/edit", getEditEvent(mdb))
+
+		mdb.EXPECT().
+			GetEvent(mock.Anything, uint64(2)).
+			Return(&db.Event{Name: "wedding", Slug: "our-wedding", FtpStorage: &db.FtpStorage{Address: "ftp.example.com"}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/edit", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), `value="our-wedding"`)
+		is.Contains(w.Body.String(), `name="ftpPassword"`)
+		is.NotContains(w.Body.String(), `name="secretKey"`)
+	})
+
+	t.Run("old slug redirects", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		// a guest following an old link
+		router, mdb := newMemberRouter(t, nil, 0, "")
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/event/:id", getEvent(msvc, middleware.NewGuest("secret", false, mdb)))
+
+		msvc.EXPECT().
+			GetEvent(mock.Anything, mock.MatchedBy(func(req *picturev1.GetEventRequest) bool { return req.GetSlug() == "old-slug" })).
+			Return(&picturev1.GetEventResponse{Event: &picturev1.Event{Id: 2, Slug: "new-slug"}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/old-slug?guest=abc", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusMovedPermanently, w.Code)
+		is.Equal("/event/new-slug?guest=abc", w.Header().Get("Location"))
+	})
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// ErrEventArchived is returned for the gallery of an event which has expired
+var ErrEventArchived = errors.New("event has ended and its gallery is no longer available")
+
+// checks the request can do what it wants to in the event, returning everything it can do.
+// Guests who can't view the event are sent to log in with the events password.
+func authorizeGuest(c *gin.Context, guest *middleware.Guest, event *picturev1.Event, want string) (string, bool) {
+	// the gallery is hidden from everyone once the event expires
+	if event.GetArchived() {
+		if c.GetHeader("HX-Request") == "" && c.Request.Method == http.MethodGet {
+			c.HTML(http.StatusGone, "eventArchived", gin.H{"title": event.GetName(), "event": event})
+			c.Abort()
+		} else {
+			AbortWithError(c, http.StatusGone, ErrEventArchived)
+		}
+		return "", false
+	}
+	capability, err := guest.Capability(c, event)
+	if err == nil && auth.GuestCan(capability, want) {
+		return capability, true
+	}
+	if want == auth.GuestView && c.GetHeader("HX-Request") == "" && c.Request.Method == http.MethodGet {
+		c.Redirect(http.StatusSeeOther, fmt.Sprintf("/event/%d/login", event.GetId()))
+		c.Abort()
+	} else if want == auth.GuestView {
+		AbortWithError(c, http.StatusUnauthorized, middleware.ErrGuestUnauthorized)
+	} else {
+		AbortWithError(c, http.StatusForbidden, middleware.ErrGuestUnauthorized)
+	}
+	return "", false
+}
+
+func getEventLogin(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		event, err := svc.GetEvent(c, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: eventId}})
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if !event.GetEvent().GetPasswordProtected() {
+			c.Redirect(http.StatusSeeOther, fmt.Sprintf("/event/%d", eventId))
+			return
+		}
+		c.HTML(http.StatusOK, "eventLogin", gin.H{
+			"title": event.GetEvent().GetName(),
+			"event": event.GetEvent(),
+		})
+	}
+}
+
+func postEventLogin(guest *middleware.Guest) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h := c.MustGet(middleware.HtmxKey).(*htmx.Handler)
+		eventId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := guest.Login(c, eventId, c.PostForm("password")); err != nil {
+			if errors.Is(err, middleware.ErrGuestUnauthorized) {
+				AbortWithError(c, http.StatusUnauthorized, errors.New("incorrect password"))
+			} else {
+				AbortWithError(c, http.StatusInternalServerError, err)
+			}
+			return
+		}
+		h.Redirect(fmt.Sprintf("/event/%d", eventId))
+	}
+}
+
+// link to the event which lets guests in with the token
+func guestLink(cfg *config.Server, token *db.GuestToken) (string, error) {
+	signed, err := auth.CreateGuestToken(cfg.SecretKey, uint64(token.EventID), token.ID, token.Capability, token.ExpiresAt)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/event/%d?%s=%s", cfg.ServerUrl, token.EventID, middleware.GuestTokenQuery, url.QueryEscape(signed)), nil
+}
+
+type guestTokenView struct {
+	*db.GuestToken
+	Link string
+}
+
+func getGuestsModal(svc service.EventpixService, d db.DB, cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		event, err := svc.GetEvent(c, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: eventId}})
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		tokens, err := guestTokenViews(c, d, cfg.Server, eventId)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.HTML(http.StatusOK, "guestsModal", gin.H{"event": event.GetEvent(), "eventId": eventId, "tokens": tokens})
+	}
+}
+
+func createGuestToken(d db.DB, cfg *config.Config, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		token := &db.GuestToken{
+			EventID:    uint(eventId),
+			Name:       strings.TrimSpace(c.PostForm("name")),
+			Capability: c.PostForm("capability"),
+		}
+		if token.Name == "" {
+			AbortWithError(c, http.StatusUnprocessableEntity, errors.New("guest link name is required"))
+			return
+		}
+		if token.Capability != auth.GuestView && token.Capability != auth.GuestUpload {
+			AbortWithError(c, http.StatusUnprocessableEntity, fmt.Errorf("unknown capability %s", token.Capability))
+			return
+		}
+		if expires := c.PostForm("expiresAt"); expires != "" {
+			expiresAt, err := time.Parse(time.DateOnly, expires)
+			if err != nil {
+				AbortWithError(c, http.StatusUnprocessableEntity, err)
+				return
+			}
+			// valid for the whole of the day it expires on
+			expiresAt = expiresAt.AddDate(0, 0, 1)
+			token.ExpiresAt = &expiresAt
+		}
+		if err := d.CreateGuestToken(c, token); err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		audit.Record(c, service.Audit{
+			Action:  service.AuditGuestCreate,
+			EventID: &token.EventID,
+			After:   map[string]any{"guest": token.ID, "name": token.Name, "capability": token.Capability, "expiresAt": token.ExpiresAt},
+		})
+		renderGuestTokens(c, d, cfg, eventId, http.StatusCreated)
+	}
+}
+
+func rotateGuestToken(d db.DB, cfg *config.Config, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		guestId, err := strconv.ParseUint(c.Param("guestId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if _, err := d.RotateGuestToken(c, uint(eventId), uint(guestId)); err != nil {
+			abortGuestTokenError(c, err)
+			return
+		}
+		audit.Record(c, service.Audit{Action: service.AuditGuestRotate, EventID: lo.ToPtr(uint(eventId)), After: map[string]any{"guest": guestId}})
+		renderGuestTokens(c, d, cfg, eventId, http.StatusOK)
+	}
+}
+
+func deleteGuestToken(d db.DB, cfg *config.Config, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		guestId, err := strconv.ParseUint(c.Param("guestId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := d.DeleteGuestToken(c, uint(eventId), uint(guestId)); err != nil {
+			abortGuestTokenError(c, err)
+			return
+		}
+		audit.Record(c, service.Audit{Action: service.AuditGuestRevoke, EventID: lo.ToPtr(uint(eventId)), Before: map[string]any{"guest": guestId}})
+		renderGuestTokens(c, d, cfg, eventId, http.StatusOK)
+	}
+}
+
+func abortGuestTokenError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		AbortWithError(c, http.StatusNotFound, errors.New("guest link not found"))
+		return
+	}
+	AbortWithError(c, http.StatusInternalServerError, err)
+}
+
+func renderGuestTokens(c *gin.Context, d db.DB, cfg *config.Config, eventId uint64, code int) {
+	tokens, err := guestTokenViews(c, d, cfg.Server, eventId)
+	if err != nil {
+		AbortWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.HTML(code, "guestTokens", gin.H{"eventId": eventId, "tokens": tokens})
+}
+
+func guestTokenViews(c *gin.Context, d db.DB, cfg *config.Server, eventId uint64) ([]guestTokenView, error) {
+	tokens, err := d.GetGuestTokens(c, uint(eventId))
+	if err != nil {
+		return nil, err
+	}
+	views := make([]guestTokenView, 0, len(tokens))
+	for _, token := range tokens {
+		link, err := guestLink(cfg, token)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, guestTokenView{GuestToken: token, Link: link})
+	}
+	return views, nil
+}
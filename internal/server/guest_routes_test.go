@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+	mockService "github.com/jj-style/eventpix/internal/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestGuestRoutes(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Server: &config.Server{SecretKey: "secret", ServerUrl: "https://eventpix.example.com"}}
+	mdb := mockdb.NewMockDB(t)
+	mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+	audit := service.NewAuditor(mdb, zap.NewNop())
+	msvc := mockService.NewMockEventpixService(t)
+	router := newTestRouter()
+	guest := middleware.NewGuest("secret", false, mdb)
+	router.GET("/event/:id", getEvent(msvc, guest))
+	router.POST("/event/:id/login", postEventLogin(guest))
+	owner := router.Group("/", func(c *gin.Context) { c.Set("eventId", uint64(1)) })
+	owner.POST("/event/:id/guests", createGuestToken(mdb, cfg, audit))
+	owner.POST("/event/:id/guests/:guestId/rotate", rotateGuestToken(mdb, cfg, audit))
+
+	msvc.EXPECT().
+		GetEvent(mock.Anything, mock.Anything).
+		Return(&picturev1.GetEventResponse{Event: &picturev1.Event{Id: 1, Name: "party", PasswordProtected: true, Live: true}}, nil).
+		Maybe()
+
+	t.Run("event needs password or guest link", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		hash, err := auth.EncryptPassword("pwd")
+		is.NoError(err)
+		mdb.EXPECT().GetEventPasswordHash(mock.Anything, uint64(1)).Return(hash, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/1", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusSeeOther, w.Code)
+		is.Equal("/event/1/login", w.Header().Get("Location"))
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", "/event/1", nil)
+		req.Header.Set("HX-Request", "true")
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusUnauthorized, w.Code)
+
+		login := func(password string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/event/1/login", strings.NewReader(url.Values{"password": {password}}.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("HX-Request", "true")
+			router.ServeHTTP(w, req)
+			return w
+		}
+		is.Equal(http.StatusUnauthorized, login("wrong").Code)
+
+		w = login("pwd")
+		is.Equal(http.StatusOK, w.Code)
+		is.Equal("/event/1", w.Header().Get("HX-Redirect"))
+		cookies := w.Result().Cookies()
+		is.Len(cookies, 1)
+
+		mdb.EXPECT().AddGuestVisit(mock.Anything, uint(1), "password-guest").Return(nil)
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", "/event/1", nil)
+		req.AddCookie(cookies[0])
+		req.AddCookie(&http.Cookie{Name: auth.GuestSessionCookieName, Value: "password-guest"})
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), `id="uploadModal"`)
+	})
+
+	t.Run("guest link sets cookie", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb.EXPECT().
+			GetGuestToken(mock.Anything, uint(1), uint(2)).
+			Return(&db.GuestToken{Model: gorm.Model{ID: 2}, EventID: 1, Capability: auth.GuestView}, nil)
+		token, err := auth.CreateGuestToken("secret", 1, 2, auth.GuestView, nil)
+		is.NoError(err)
+		// guests without a session get one, and are counted by it
+		var session string
+		mdb.EXPECT().
+			AddGuestVisit(mock.Anything, uint(1), mock.MatchedBy(func(s string) bool { return s != "password-guest" })).
+			RunAndReturn(func(_ context.Context, _ uint, s string) error { session = s; return nil })
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/1?guest="+token, nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Header().Values("Set-Cookie")[0], auth.GuestCookieName(1)+"="+token)
+		is.NotEmpty(session)
+		is.Contains(w.Header().Values("Set-Cookie")[1], auth.GuestSessionCookieName+"="+session)
+		// view only guests can't upload
+		is.NotContains(w.Body.String(), `id="uploadModal"`)
+	})
+
+	t.Run("create and rotate", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb.EXPECT().
+			CreateGuestToken(mock.Anything, mock.MatchedBy(func(g *db.GuestToken) bool {
+				return g.EventID == 1 && g.Capability == auth.GuestUpload && g.ExpiresAt != nil
+			})).
+			Return(nil)
+		mdb.EXPECT().
+			RotateGuestToken(mock.Anything, uint(1), uint(3)).
+			Return(&db.GuestToken{}, nil)
+		mdb.EXPECT().
+			GetGuestTokens(mock.Anything, uint(1)).
+			Return([]*db.GuestToken{{Model: gorm.Model{ID: 4}, EventID: 1, Name: "family", Capability: auth.GuestUpload}}, nil)
+
+		form := url.Values{"name": {"family"}, "capability": {"upload"}, "expiresAt": {"2030-01-01"}}
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/event/1/guests", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusCreated, w.Code)
+		is.Contains(w.Body.String(), "https://eventpix.example.com/event/1?guest=")
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("POST", "/event/1/guests/3/rotate", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+	})
+}
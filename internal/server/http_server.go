@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"connectrpc.com/connect"
 	"github.com/donseba/go-htmx"
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-contrib/pprof"
@@ -13,7 +14,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jj-style/eventpix/internal/config"
 	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/gen/picture/v1/picturev1connect"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
 	"github.com/jj-style/eventpix/internal/pkg/validate"
+	"github.com/jj-style/eventpix/internal/ratelimit"
 	"github.com/jj-style/eventpix/internal/server/middleware"
 	"github.com/jj-style/eventpix/internal/service"
 	"github.com/nats-io/nats.go"
@@ -30,6 +34,13 @@ func NewHttpServer(
 	storageService service.StorageService,
 	authService *service.AuthService,
 	eventpixSvc service.EventpixService,
+	migrator *service.StorageMigrator,
+	webhooks *service.WebhookDispatcher,
+	sessions *auth.Sessions,
+	settings *service.Settings,
+	accounts *service.Accounts,
+	audit *service.Auditor,
+	limiter ratelimit.Limiter,
 	db db.DB,
 	nc *nats.Conn,
 	logger *zap.Logger,
@@ -45,21 +56,36 @@ func NewHttpServer(
 	r := gin.New()
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
-	r.Use(gzip.Gzip(gzip.DefaultCompression, gzip.WithExcludedExtensions([]string{".jpg", ".jpeg", ".png"})))
+	r.Use(gzip.Gzip(gzip.DefaultCompression,
+		gzip.WithExcludedExtensions([]string{".jpg", ".jpeg", ".png"}),
+		// connect handles its own compression
+		gzip.WithExcludedPaths([]string{"/" + picturev1connect.PictureServiceName + "/"}),
+	))
+	// serve HTTP/2 without TLS for gRPC clients
+	r.UseH2C = true
+	r.Use(middleware.Cors(cfg.Server.CorsOrigins))
+	// the connect API only takes bearer tokens so can't be forged
+	r.Use(middleware.Csrf(cfg.Server.SecretKey, sessions.Secure(), "/"+picturev1connect.PictureServiceName+"/"))
 	pprof.Register(r)
 
 	errorTmpl := template.Must(template.ParseFS(content, "assets/templates/errorToast.html"))
 	htmxMiddleware := middleware.Htmx(htmx, errorTmpl)
 
-	authRequired := middleware.AuthRequired(cfg.Server.SecretKey, db)
+	authRequired := middleware.AuthRequired(sessions, db)
+	guest := middleware.NewGuest(cfg.Server.SecretKey, sessions.Secure(), db)
+
+	rateLimit := newRateLimiter(limiter, cfg.RateLimits)
 
 	authGroup := r.Group("/auth")
 	authGroup.Use(htmxMiddleware)
-	authGroup.POST("/login", authService.Login)
-	if !cfg.Server.SingleEventMode && !cfg.Server.DisableSignups {
-		authGroup.POST("/register", authService.Register)
-	}
-	authGroup.GET("/logout", authRequired, authService.Logout)
+	authGroup.POST("/login", rateLimit("login", middleware.ByIP), authService.Login)
+	authGroup.POST("/register", rateLimit("register", middleware.ByIP), authService.Register)
+	authGroup.GET("/logout", authRequired, middleware.SessionRequired(), authService.Logout)
+	authGroup.POST("/logout/all", authRequired, middleware.SessionRequired(), authService.LogoutEverywhere)
+	authGroup.GET("/oidc/:provider/login", authService.OidcLogin)
+	authGroup.GET("/oidc/:provider/callback", authService.OidcCallback)
+	authGroup.GET("/oidc/:provider/link", authRequired, middleware.SessionRequired(), authService.OidcLink)
+	authGroup.DELETE("/oidc/identities/:identityId", authRequired, middleware.SessionRequired(), authService.OidcUnlink)
 
 	// serve static assets (html/css/js/images)
 	staticFsEmbed, err := static.EmbedFolder(staticFs, "assets/static")
@@ -69,25 +95,32 @@ func NewHttpServer(
 	r.StaticFS("/static", staticFsEmbed)
 
 	// htmx ui / api
-	handleUi(r, htmx, db, eventpixSvc, nc, cfg, validator)
+	handleUi(r, htmx, db, eventpixSvc, migrator, webhooks, guest, sessions, settings, accounts, audit, rateLimit, nc, cfg, validator)
 
 	storageGroup := r.Group("/storage")
 	handleStorage(storageGroup, storageService)
 
 	oauthGroup := r.Group("/oauth2")
-	oauthGroup.Use(authRequired)
-	handleOauth(oauthGroup, googleOauthConfig, db, htmxMiddleware)
+	oauthGroup.Use(authRequired, middleware.SessionRequired())
+	handleOauth(oauthGroup, googleOauthConfig, db, htmxMiddleware, audit)
 
 	// /upload
 	uploadGroup := r.Group("/upload")
-	uploadGroup.Use(htmxMiddleware)
+	uploadGroup.Use(htmxMiddleware, rateLimit("upload", middleware.ByIP))
 	{
-		setupUploadRoutes(uploadGroup, logger, htmx, eventpixSvc)
+		setupUploadRoutes(uploadGroup, logger, htmx, eventpixSvc, guest)
 	}
 
+	// Connect/gRPC/gRPC-web API
+	apiPath, apiHandler := picturev1connect.NewPictureServiceHandler(
+		newPictureServer(db, eventpixSvc, migrator),
+		connect.WithInterceptors(middleware.ConnectAuth(sessions, db, procedureScopes)),
+	)
+	r.Any(apiPath+"*procedure", gin.WrapH(apiHandler))
+
 	server := &http.Server{
 		Addr:              cfg.Server.Address,
-		Handler:           r,
+		Handler:           r.Handler(),
 		ReadHeaderTimeout: time.Second,
 		ReadTimeout:       5 * time.Minute,
 		WriteTimeout:      5 * time.Minute,
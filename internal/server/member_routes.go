@@ -0,0 +1,217 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// middlewares checking the user is at least an owner, manager or moderator of the event in the id path parameter
+func eventRoles(d db.DB) (owner, manager, moderator gin.HandlerFunc) {
+	return middleware.UserAuthorizedForEvent(d, db.RoleOwner, "id", "eventId"),
+		middleware.UserAuthorizedForEvent(d, db.RoleManager, "id", "eventId"),
+		middleware.UserAuthorizedForEvent(d, db.RoleModerator, "id", "eventId")
+}
+
+func getMembersModal(svc service.EventpixService, d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		event, err := svc.GetEvent(c, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: eventId}})
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		data, err := membersData(c, d, eventId)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		data["event"] = event.GetEvent()
+		c.HTML(http.StatusOK, "membersModal", data)
+	}
+}
+
+// inviteMember invites a user to help run the event, by their username or the email of an account they sign in with
+func inviteMember(d db.DB, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		invitee := strings.TrimSpace(c.PostForm("invitee"))
+		role := c.PostForm("role")
+		if invitee == "" {
+			AbortWithError(c, http.StatusUnprocessableEntity, errors.New("username or email is required"))
+			return
+		}
+		if !db.ValidRole(role) {
+			AbortWithError(c, http.StatusUnprocessableEntity, fmt.Errorf("unknown role %s", role))
+			return
+		}
+
+		user, err := d.FindUser(c, invitee)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				AbortWithError(c, http.StatusNotFound, fmt.Errorf("no user %s", invitee))
+				return
+			}
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		members, err := d.GetEventMembers(c, uint(eventId))
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		// the events owner isn't a member, but does have a role
+		existing, err := d.GetEventRole(c, user.ID, uint(eventId))
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		if existing != "" || lo.ContainsBy(members, func(m *db.EventMember) bool { return m.UserID == user.ID }) {
+			AbortWithError(c, http.StatusConflict, fmt.Errorf("%s is already invited to the event", user.Username))
+			return
+		}
+
+		if err := d.CreateEventMember(c, &db.EventMember{EventID: uint(eventId), UserID: user.ID, Role: role}); err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		audit.Record(c, service.Audit{
+			Action:  service.AuditMemberInvite,
+			EventID: lo.ToPtr(uint(eventId)),
+			After:   map[string]any{"user": user.Username, "role": role},
+		})
+		renderMembers(c, d, http.StatusCreated, eventId)
+	}
+}
+
+func setMemberRole(d db.DB, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		memberId, err := strconv.ParseUint(c.Param("memberId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		role := c.PostForm("role")
+		if !db.ValidRole(role) {
+			AbortWithError(c, http.StatusUnprocessableEntity, fmt.Errorf("unknown role %s", role))
+			return
+		}
+		if err := d.SetEventMemberRole(c, uint(eventId), uint(memberId), role); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				AbortWithError(c, http.StatusNotFound, errors.New("member not found"))
+				return
+			}
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		audit.Record(c, service.Audit{
+			Action:  service.AuditMemberRole,
+			EventID: lo.ToPtr(uint(eventId)),
+			After:   map[string]any{"member": memberId, "role": role},
+		})
+		renderMembers(c, d, http.StatusOK, eventId)
+	}
+}
+
+func deleteMember(d db.DB, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		memberId, err := strconv.ParseUint(c.Param("memberId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := d.DeleteEventMember(c, uint(eventId), uint(memberId)); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				AbortWithError(c, http.StatusNotFound, errors.New("member not found"))
+				return
+			}
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		audit.Record(c, service.Audit{
+			Action:  service.AuditMemberRemove,
+			EventID: lo.ToPtr(uint(eventId)),
+			Before:  map[string]any{"member": memberId},
+		})
+		c.Status(http.StatusOK)
+	}
+}
+
+func acceptInvite(d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h := c.MustGet(middleware.HtmxKey).(*htmx.Handler)
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		eventId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := d.AcceptEventInvite(c, user.ID, uint(eventId)); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				AbortWithError(c, http.StatusNotFound, errors.New("invite not found"))
+				return
+			}
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Status(http.StatusOK)
+		// so the event shows up amongst the users other events
+		h.Refresh(true)
+	}
+}
+
+// leaveEvent declines an invite to the event, or stops the user being a member of it
+func leaveEvent(d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		eventId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := d.LeaveEvent(c, user.ID, uint(eventId)); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				AbortWithError(c, http.StatusNotFound, errors.New("not a member of the event"))
+				return
+			}
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+}
+
+func renderMembers(c *gin.Context, d db.DB, code int, eventId uint64) {
+	data, err := membersData(c, d, eventId)
+	if err != nil {
+		AbortWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.HTML(code, "eventMembers", data)
+}
+
+// everything needed to render the events members and the form to invite more
+func membersData(c *gin.Context, d db.DB, eventId uint64) (gin.H, error) {
+	members, err := d.GetEventMembers(c, uint(eventId))
+	if err != nil {
+		return nil, err
+	}
+	return gin.H{
+		"eventId": eventId,
+		"members": members,
+		"roles":   []string{db.RoleModerator, db.RoleManager, db.RoleOwner},
+	}, nil
+}
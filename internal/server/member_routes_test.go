@@ -0,0 +1,99 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestMemberRoutes(t *testing.T) {
+	t.Parallel()
+
+	couple := &db.User{Model: gorm.Model{ID: 1}, Username: "couple"}
+
+	postForm := func(router *gin.Engine, path string, form url.Values) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("invite", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, couple, 0, "")
+		eventOwner, _, _ := eventRoles(mdb)
+		router.POST("/event/:id/members", eventOwner, inviteMember(mdb, service.NewAuditor(mdb, zap.NewNop())))
+
+		mdb.EXPECT().GetEventRole(mock.Anything, uint(1), uint(2)).Return(db.RoleOwner, nil)
+		mdb.EXPECT().FindUser(mock.Anything, "planner@example.com").Return(&db.User{Model: gorm.Model{ID: 3}, Username: "planner"}, nil)
+		mdb.EXPECT().GetEventRole(mock.Anything, uint(3), uint(2)).Return("", nil)
+		mdb.EXPECT().
+			CreateEventMember(mock.Anything, &db.EventMember{EventID: 2, UserID: 3, Role: db.RoleManager}).
+			Return(nil)
+		mdb.EXPECT().GetEventMembers(mock.Anything, uint(2)).Return([]*db.EventMember{}, nil).Once()
+		mdb.EXPECT().
+			GetEventMembers(mock.Anything, uint(2)).
+			Return([]*db.EventMember{{EventID: 2, UserID: 3, User: db.User{Username: "planner"}, Role: db.RoleManager}}, nil).
+			Once()
+
+		w := postForm(router, "/event/2/members", url.Values{"invitee": {"planner@example.com"}, "role": {db.RoleManager}})
+		is.Equal(http.StatusCreated, w.Code)
+		is.Contains(w.Body.String(), "planner")
+		is.Contains(w.Body.String(), "Invited")
+	})
+
+	t.Run("already a member", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, couple, 0, "")
+		eventOwner, _, _ := eventRoles(mdb)
+		router.POST("/event/:id/members", eventOwner, inviteMember(mdb, service.NewAuditor(mdb, zap.NewNop())))
+
+		mdb.EXPECT().GetEventRole(mock.Anything, uint(1), uint(2)).Return(db.RoleOwner, nil)
+		mdb.EXPECT().FindUser(mock.Anything, "planner").Return(&db.User{Model: gorm.Model{ID: 3}, Username: "planner"}, nil)
+		mdb.EXPECT().GetEventRole(mock.Anything, uint(3), uint(2)).Return("", nil)
+		mdb.EXPECT().GetEventMembers(mock.Anything, uint(2)).Return([]*db.EventMember{{EventID: 2, UserID: 3}}, nil)
+
+		w := postForm(router, "/event/2/members", url.Values{"invitee": {"planner"}, "role": {db.RoleModerator}})
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, couple, 0, "")
+		eventOwner, _, _ := eventRoles(mdb)
+		router.POST("/event/:id/members", eventOwner, inviteMember(mdb, service.NewAuditor(mdb, zap.NewNop())))
+
+		mdb.EXPECT().GetEventRole(mock.Anything, uint(1), uint(2)).Return(db.RoleOwner, nil)
+
+		w := postForm(router, "/event/2/members", url.Values{"invitee": {"planner"}, "role": {"admin"}})
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("roles enforced", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, couple, 0, "")
+		eventOwner, eventManager, _ := eventRoles(mdb)
+		router.POST("/event/:id/members", eventOwner, inviteMember(mdb, service.NewAuditor(mdb, zap.NewNop())))
+		router.POST("/event/:id/live", eventManager, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		mdb.EXPECT().GetEventRole(mock.Anything, uint(1), uint(2)).Return(db.RoleManager, nil)
+		mdb.EXPECT().GetEventRole(mock.Anything, uint(1), uint(3)).Return("", nil)
+
+		is.Equal(http.StatusOK, postForm(router, "/event/2/live", nil).Code)
+		is.Equal(http.StatusForbidden, postForm(router, "/event/2/members", url.Values{"invitee": {"planner"}, "role": {db.RoleOwner}}).Code)
+		is.Equal(http.StatusUnauthorized, postForm(router, "/event/3/live", nil).Code)
+	})
+}
@@ -1,46 +1,58 @@
 package middleware
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jj-style/eventpix/internal/data/db"
 	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
 )
 
-// Middleware to parse and validate an auth cookie.
+// Key in the gin request context of the scopes the request is limited to.
+// Only set for requests authenticated with an API token, sessions can do anything.
+const ScopesKey = "__scopes_ctx_key__"
+
+// EventRoleKey is the gin context key the users role on the event is set under by `UserAuthorizedForEvent`
+const EventRoleKey = "__event_role_ctx_key__"
+
+// Middleware to parse and validate an `Authorization: Bearer` token or auth cookie.
 // If valid, the user is retrieved and added to the gin request context.
-func AuthRequired(secretKey string, db db.DB) gin.HandlerFunc {
+// Sessions from the cookie are refreshed as they're used.
+func AuthRequired(sessions *auth.Sessions, db db.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// before request
-		cookie, err := c.Cookie(auth.CookieName)
-		if err != nil {
-			if errors.Is(err, http.ErrNoCookie) {
-				c.Redirect(http.StatusTemporaryRedirect, "/login")
-				c.Abort()
+		token, bearer := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !bearer {
+			cookie, err := c.Cookie(auth.CookieName)
+			if err != nil {
+				if errors.Is(err, http.ErrNoCookie) {
+					c.Redirect(http.StatusTemporaryRedirect, "/login")
+					c.Abort()
+					return
+				}
+				c.AbortWithError(http.StatusUnauthorized, err)
 				return
 			}
-			c.AbortWithError(http.StatusUnauthorized, err)
-			return
+			token = cookie
 		}
-		claims, err := auth.VerifyToken(secretKey, cookie)
+		user, scopes, err := Authenticate(c, sessions, db, token)
 		if err != nil {
 			c.AbortWithError(http.StatusUnauthorized, err)
 			return
 		}
-		subj, err := claims.GetSubject()
-		if err != nil {
-			c.AbortWithError(http.StatusUnauthorized, err)
-			return
+		c.Set(gin.AuthUserKey, user)
+		if scopes != nil {
+			c.Set(ScopesKey, scopes)
 		}
-		user, err := db.GetUser(c, subj)
-		if err != nil {
-			c.AbortWithError(http.StatusUnauthorized, err)
-			return
+		if !bearer {
+			refreshSession(c, sessions, token)
 		}
-		c.Set(gin.AuthUserKey, user)
 
 		// handle request
 		c.Next()
@@ -49,27 +61,111 @@ func AuthRequired(secretKey string, db db.DB) gin.HandlerFunc {
 	}
 }
 
-// Middleware to parse and validate an auth cookie.
-// If valid, the the request is redirected to the given page
-func AuthRedirect(secretKey string, db db.DB, redirect string) gin.HandlerFunc {
+// Middleware to only allow requests with the scope.
+//
+// Notes
+// Must be used after `AuthRequired`
+func RequireScope(scope string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// before request
-		cookie, err := c.Cookie(auth.CookieName)
-		if err != nil {
-			c.Next()
+		if scopes, limited := c.Get(ScopesKey); limited && !slices.Contains(scopes.([]string), scope) {
+			c.AbortWithError(http.StatusForbidden, fmt.Errorf("token missing %s scope", scope))
 			return
 		}
-		claims, err := auth.VerifyToken(secretKey, cookie)
-		if err != nil {
-			c.Next()
+		c.Next()
+	}
+}
+
+// Middleware to only allow admins.
+//
+// Notes
+// Must be used after `AuthRequired`
+func AdminRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if user := c.MustGet(gin.AuthUserKey).(*db.User); !user.Admin {
+			c.AbortWithError(http.StatusForbidden, errors.New("admins only"))
 			return
 		}
-		subj, err := claims.GetSubject()
+		c.Next()
+	}
+}
+
+// Middleware to only allow requests from a logged in session, not API tokens.
+//
+// Notes
+// Must be used after `AuthRequired`
+func SessionRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get(ScopesKey); ok {
+			c.AbortWithError(http.StatusForbidden, errors.New("not allowed with an api token"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// Verifies the token, either an API token or a session token, and gets the user it was issued to
+// along with the scopes the token is limited to. Scopes are nil for session tokens.
+func Authenticate(ctx context.Context, sessions *auth.Sessions, db db.DB, token string) (*db.User, []string, error) {
+	if auth.IsApiToken(token) {
+		apiToken, err := db.GetApiToken(ctx, auth.HashApiToken(token))
+		if err != nil {
+			return nil, nil, errors.New("invalid api token")
+		}
+		if apiToken.User.Disabled {
+			return nil, nil, errors.New("user disabled")
+		}
+		return &apiToken.User, strings.Split(apiToken.Scopes, ","), nil
+	}
+	user, err := UserFromToken(ctx, sessions, db, token)
+	return user, nil, err
+}
+
+// Verifies the session token and gets the user it was issued to,
+// as long as they haven't logged out everywhere since
+func UserFromToken(ctx context.Context, sessions *auth.Sessions, db db.DB, token string) (*db.User, error) {
+	claims, err := sessions.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	subj, err := claims.GetSubject()
+	if err != nil {
+		return nil, err
+	}
+	user, err := db.GetUser(ctx, subj)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Version != user.TokenVersion {
+		return nil, errors.New("session revoked")
+	}
+	if user.Disabled {
+		return nil, errors.New("user disabled")
+	}
+	return user, nil
+}
+
+// reissues the session cookie if it's due to be refreshed
+func refreshSession(c *gin.Context, sessions *auth.Sessions, token string) {
+	claims, err := sessions.Verify(token)
+	if err != nil {
+		return
+	}
+	if refreshed, expiresAt, ok := sessions.Refresh(claims); ok {
+		sessions.SetCookie(c.Writer, refreshed, expiresAt)
+	}
+}
+
+// Middleware to parse and validate an auth cookie.
+// If valid, the the request is redirected to the given page
+func AuthRedirect(sessions *auth.Sessions, db db.DB, redirect string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// before request
+		cookie, err := c.Cookie(auth.CookieName)
 		if err != nil {
 			c.Next()
 			return
 		}
-		user, err := db.GetUser(c, subj)
+		user, err := UserFromToken(c, sessions, db, cookie)
 		if err != nil {
 			c.Next()
 			return
@@ -82,16 +178,17 @@ func AuthRedirect(secretKey string, db db.DB, redirect string) gin.HandlerFunc {
 }
 
 // Middleware to obtain the user from the context set from `AuthRequired`.
-// It extracts the event ID from the path parameter and queries to DB to ensure the user is authorized
-// to act on the event.
+// It extracts the event ID from the path parameter and queries to DB to ensure the user has at least
+// the role on the event, setting their role under `EventRoleKey`.
 // Arguments
 //
+// - `role` is the least role the user needs on the event, see db.RoleOwner etc.
 // - `eventIdParam` is the URL path parameter to extract the event ID for.
 // - `eventIdKey` is the gin context Key to set to pull out (as this function handles parsing and validating) may as well piggy back off it.
 //
 // Notes
 // Must be used after `AuthRequred`
-func UserAuthorizedForEvent(d db.DB, eventIdParam, eventIdKey string) gin.HandlerFunc {
+func UserAuthorizedForEvent(d db.DB, role, eventIdParam, eventIdKey string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		pEventId := c.Param(eventIdParam)
 		eventId, err := strconv.ParseUint(pEventId, 10, 64)
@@ -103,15 +200,20 @@ func UserAuthorizedForEvent(d db.DB, eventIdParam, eventIdKey string) gin.Handle
 
 		user := c.MustGet(gin.AuthUserKey).(*db.User)
 
-		ok, err := d.UserAuthorizedForEvent(c, user.ID, uint(eventId))
+		have, err := d.GetEventRole(c, user.ID, uint(eventId))
 		if err != nil {
 			c.AbortWithError(http.StatusInternalServerError, err)
 			return
 		}
-		if !ok {
+		if have == "" {
 			c.AbortWithError(http.StatusUnauthorized, errors.New("user not authorized for event"))
 			return
 		}
+		if !db.RoleAtLeast(have, role) {
+			c.AbortWithError(http.StatusForbidden, fmt.Errorf("must be an event %s", role))
+			return
+		}
+		c.Set(EventRoleKey, have)
 
 		c.Next()
 	}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+)
+
+type userContextKey struct{}
+
+// Interceptor to authenticate Connect/gRPC requests from the `Authorization: Bearer <token>` header.
+// If valid, the user is added to the request context and can be got with `UserFromContext`.
+// Requests with an API token must have the scope the procedure requires in `procedureScopes`,
+// procedures missing from it can't be called with an API token at all.
+func ConnectAuth(sessions *auth.Sessions, db db.DB, procedureScopes map[string]string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			token, ok := strings.CutPrefix(req.Header().Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing bearer token"))
+			}
+			user, scopes, err := Authenticate(ctx, sessions, db, token)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid token"))
+			}
+			if scopes != nil {
+				scope, ok := procedureScopes[req.Spec().Procedure]
+				if !ok || !slices.Contains(scopes, scope) {
+					return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("token missing %s scope", scope))
+				}
+			}
+			return next(context.WithValue(ctx, userContextKey{}, user), req)
+		}
+	}
+}
+
+// Gets the user set by `ConnectAuth`
+func UserFromContext(ctx context.Context) *db.User {
+	user, _ := ctx.Value(userContextKey{}).(*db.User)
+	return user
+}
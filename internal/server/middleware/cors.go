@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+)
+
+var (
+	corsAllowMethods = strings.Join([]string{
+		http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+	}, ", ")
+	corsAllowHeaders = strings.Join([]string{
+		"Authorization", "Content-Type", auth.CsrfHeader,
+		// connect protocol headers
+		"Connect-Protocol-Version", "Connect-Timeout-Ms", "Grpc-Timeout", "X-Grpc-Web", "X-User-Agent",
+	}, ", ")
+	corsExposeHeaders = strings.Join([]string{
+		"Retry-After", "Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin",
+	}, ", ")
+)
+
+// Cors lets the allowed origins make cross origin requests, with credentials.
+// Origins are matched exactly, e.g. `https://example.com`, or `*` allows any origin
+// but without credentials. Requests from other origins get no CORS headers, so browsers
+// won't let them read responses. Preflight requests are answered here.
+func Cors(origins []string) gin.HandlerFunc {
+	anyOrigin := slices.Contains(origins, "*")
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		c.Writer.Header().Add("Vary", "Origin")
+
+		switch {
+		case slices.Contains(origins, origin):
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Access-Control-Allow-Credentials", "true")
+		case anyOrigin:
+			c.Header("Access-Control-Allow-Origin", "*")
+		default:
+			c.Next()
+			return
+		}
+		c.Header("Access-Control-Expose-Headers", corsExposeHeaders)
+
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			c.Header("Access-Control-Allow-Methods", corsAllowMethods)
+			c.Header("Access-Control-Allow-Headers", corsAllowHeaders)
+			c.Header("Access-Control-Max-Age", "7200")
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+)
+
+// Csrf protects cookie authenticated requests from being forged by other sites, with a signed
+// double submit token. Every response makes sure the browser has a token in the CSRF cookie,
+// and requests which change anything must send it back in the `X-CSRF-Token` header or the
+// `csrf_token` form field. Other sites can't read the cookie so can't send it back.
+// Requests with an `Authorization` header aren't checked, browsers don't send it on their own,
+// and neither are requests to the skipped path prefixes.
+func Csrf(secretKey string, secure bool, skip ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := c.Cookie(auth.CsrfCookieName)
+		if err != nil || !auth.VerifyCsrfToken(secretKey, token) {
+			if token, err = auth.CreateCsrfToken(secretKey); err != nil {
+				c.AbortWithError(http.StatusInternalServerError, err)
+				return
+			}
+			http.SetCookie(c.Writer, &http.Cookie{
+				Name:     auth.CsrfCookieName,
+				Value:    token,
+				Path:     "/",
+				Secure:   secure,
+				HttpOnly: false, // scripts send it back in the header
+				SameSite: http.SameSiteLaxMode,
+			})
+			// a new token is never what the request sent, so stop here if it needed one
+			token = ""
+		}
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+			c.Next()
+			return
+		}
+		if c.GetHeader("Authorization") != "" {
+			c.Next()
+			return
+		}
+		for _, prefix := range skip {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		sent := c.GetHeader(auth.CsrfHeader)
+		if sent == "" {
+			sent = c.PostForm(auth.CsrfFormField)
+		}
+		if token == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+			c.String(http.StatusForbidden, "invalid CSRF token, refresh the page and try again")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+)
+
+const (
+	// query parameter guest links carry their token in
+	GuestTokenQuery = "guest"
+	// how long to keep a guest token which never expires in a cookie
+	guestCookieMaxAge = 365 * 24 * time.Hour
+	// how long guests stay logged into an event after entering its password
+	guestLoginMaxAge = 30 * 24 * time.Hour
+	// how long a guests browser keeps its session id
+	guestSessionMaxAge = 365 * 24 * time.Hour
+	// set to the name of the guest link the guest got into the event with, if they did
+	GuestLinkKey = "__guest_link_key__"
+)
+
+var ErrGuestUnauthorized = errors.New("guest not authorized for event")
+
+// Guest works out what guests can do in password protected events.
+// Guests get in with a guest link, or by logging in with the event password.
+type Guest struct {
+	secretKey string
+	// only send guest cookies over HTTPS
+	secure bool
+	db     db.DB
+}
+
+func NewGuest(secretKey string, secure bool, db db.DB) *Guest {
+	return &Guest{secretKey: secretKey, secure: secure, db: db}
+}
+
+// Capability gets what the request can do in the event. Anyone can view and upload to events
+// without a password. Otherwise it's up to the guest token, from the `guest` query parameter
+// or the events guest cookie. A token in the query is kept in the cookie so the guest stays
+// in the event after the first visit.
+func (g *Guest) Capability(c *gin.Context, event *picturev1.Event) (string, error) {
+	if !event.GetPasswordProtected() {
+		return auth.GuestUpload, nil
+	}
+
+	eventId := event.GetId()
+	if token := c.Query(GuestTokenQuery); token != "" {
+		if claims, link, err := g.verify(c, eventId, token); err == nil {
+			maxAge := guestCookieMaxAge
+			if claims.ExpiresAt != nil {
+				maxAge = time.Until(claims.ExpiresAt.Time)
+			}
+			g.setCookie(c, auth.GuestCookieName(eventId), token, maxAge)
+			g.setGuestLink(c, link)
+			return claims.Capability, nil
+		}
+	}
+	if token, err := c.Cookie(auth.GuestCookieName(eventId)); err == nil {
+		if claims, link, err := g.verify(c, eventId, token); err == nil {
+			g.setGuestLink(c, link)
+			return claims.Capability, nil
+		}
+	}
+	return "", ErrGuestUnauthorized
+}
+
+// Login checks the events password, keeping the guest logged into the event if it's right
+func (g *Guest) Login(c *gin.Context, eventId uint64, password string) error {
+	hash, err := g.db.GetEventPasswordHash(c, eventId)
+	if err != nil {
+		return err
+	}
+	if hash == "" || !auth.ComparePassword(password, hash) {
+		return ErrGuestUnauthorized
+	}
+	token, err := auth.CreateEventLoginToken(g.secretKey, eventId, hash, time.Now().Add(guestLoginMaxAge))
+	if err != nil {
+		return err
+	}
+	g.setCookie(c, auth.GuestCookieName(eventId), token, guestLoginMaxAge)
+	return nil
+}
+
+// Session gets the random id the guests browser is told apart by, giving it one if it hasn't got one
+func (g *Guest) Session(c *gin.Context) string {
+	if session, err := c.Cookie(auth.GuestSessionCookieName); err == nil && session != "" && len(session) <= 64 {
+		return session
+	}
+	session := rand.Text()
+	g.setCookie(c, auth.GuestSessionCookieName, session, guestSessionMaxAge)
+	// requests later on in this one see it too
+	c.Request.AddCookie(&http.Cookie{Name: auth.GuestSessionCookieName, Value: session})
+	return session
+}
+
+// Visit records the guests browser opening the events gallery
+func (g *Guest) Visit(c *gin.Context, eventId uint64) error {
+	return g.db.AddGuestVisit(c, uint(eventId), g.Session(c))
+}
+
+func (g *Guest) setGuestLink(c *gin.Context, link *db.GuestToken) {
+	if link != nil {
+		c.Set(GuestLinkKey, link.Name)
+	}
+}
+
+func (g *Guest) setCookie(c *gin.Context, name, value string, maxAge time.Duration) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(maxAge.Seconds()),
+		Secure:   g.secure,
+		HttpOnly: true,
+		// lax so guest links opened from other sites still get into the event
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// verifies the token is for the event and hasn't been revoked, or the password changed,
+// getting the guest link it's for if it isn't from logging in with the password
+func (g *Guest) verify(ctx context.Context, eventId uint64, token string) (*auth.GuestClaims, *db.GuestToken, error) {
+	claims, err := auth.VerifyGuestToken(g.secretKey, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if claims.EventID != eventId {
+		return nil, nil, ErrGuestUnauthorized
+	}
+	if claims.PasswordLogin() {
+		hash, err := g.db.GetEventPasswordHash(ctx, eventId)
+		if err != nil || !claims.MatchesPassword(hash) {
+			return nil, nil, ErrGuestUnauthorized
+		}
+		return claims, nil, nil
+	}
+	tokenId, err := claims.TokenID()
+	if err != nil {
+		return nil, nil, err
+	}
+	stored, err := g.db.GetGuestToken(ctx, uint(eventId), tokenId)
+	if err != nil {
+		return nil, nil, ErrGuestUnauthorized
+	}
+	// capability could only differ if the token was tampered with, but trust what we stored
+	claims.Capability = stored.Capability
+	return claims, stored, nil
+}
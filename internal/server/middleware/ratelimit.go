@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/ratelimit"
+)
+
+// RateLimitKey picks part of what requests are counted against
+type RateLimitKey func(c *gin.Context) string
+
+// ByIP counts requests from the same address together
+func ByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// ByUser counts requests from the same logged in user together, falling back to their address
+func ByUser(c *gin.Context) string {
+	if user, ok := c.Get(gin.AuthUserKey); ok {
+		return "user:" + strconv.FormatUint(uint64(user.(*db.User).ID), 10)
+	}
+	return ByIP(c)
+}
+
+// ByEvent counts requests for the same event together, from the path parameter
+func ByEvent(param string) RateLimitKey {
+	return func(c *gin.Context) string {
+		return "event:" + c.Param(param)
+	}
+}
+
+// RateLimit allows as many requests as the limit in each window, counted against the keys
+// together, responding 429 Too Many Requests once it's reached. Requests are let through if
+// they can't be counted, so the limiter being down doesn't take everything down with it.
+func RateLimit(limiter ratelimit.Limiter, name string, limit int, window time.Duration, keys ...RateLimitKey) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+		parts := make([]string, 0, len(keys)+2)
+		parts = append(parts, "ratelimit", name)
+		for _, key := range keys {
+			parts = append(parts, key(c))
+		}
+		ok, retryAfter, err := limiter.Allow(c, strings.Join(parts, ":"), limit, window)
+		if err != nil {
+			log.Printf("rate limiting %s: %v", name, err)
+			c.Next()
+			return
+		}
+		if !ok {
+			AbortTooManyRequests(c, retryAfter)
+			return
+		}
+		c.Next()
+	}
+}
+
+// AbortTooManyRequests responds 429 Too Many Requests, telling the client when to try again
+func AbortTooManyRequests(c *gin.Context, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.AbortWithError(http.StatusTooManyRequests, fmt.Errorf("too many attempts, try again in %s", time.Duration(seconds)*time.Second))
+}
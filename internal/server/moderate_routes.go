@@ -0,0 +1,96 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/service"
+	"gorm.io/gorm"
+)
+
+// how many media are loaded at a time while moderating
+const moderatePageSize = 24
+
+func getModerate(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		event, err := svc.GetEvent(c, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: eventId}})
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		data, ok := moderateMedia(c, svc, eventId)
+		if !ok {
+			return
+		}
+		data["title"] = "Moderate - " + event.GetEvent().GetName()
+		data["event"] = event.GetEvent()
+		c.HTML(http.StatusOK, "moderate", data)
+	}
+}
+
+func getModerateMedia(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if data, ok := moderateMedia(c, svc, c.MustGet("eventId").(uint64)); ok {
+			c.HTML(http.StatusOK, "moderateMedia", data)
+		}
+	}
+}
+
+// the page of the events media to moderate, hidden media included
+func moderateMedia(c *gin.Context, svc service.EventpixService, eventId uint64) (gin.H, bool) {
+	page, err := strconv.ParseInt(c.DefaultQuery("page", "0"), 10, 64)
+	if err != nil {
+		AbortWithError(c, http.StatusBadRequest, err)
+		return nil, false
+	}
+	thumbnails, err := svc.GetThumbnails(c, &picturev1.GetThumbnailsRequest{
+		EventId:       eventId,
+		Limit:         moderatePageSize,
+		Offset:        moderatePageSize * page,
+		IncludeHidden: true,
+	})
+	if err != nil {
+		AbortWithError(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	return gin.H{"eventId": eventId, "thumbnails": thumbnails.GetThumbnails(), "nextPage": page + 1}, true
+}
+
+func hideMedia(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		hidden, err := strconv.ParseBool(c.PostForm("hidden"))
+		if err != nil {
+			AbortWithError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		req := &picturev1.HideMediaRequest{EventId: eventId, FileId: c.Param("fileId"), Hidden: hidden}
+		if _, err := svc.HideMedia(c, req); err != nil {
+			AbortWithError(c, mediaErrorCode(err), err)
+			return
+		}
+		c.HTML(http.StatusOK, "moderateActions", gin.H{"eventId": eventId, "fileId": req.GetFileId(), "hidden": hidden})
+	}
+}
+
+func deleteMedia(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := &picturev1.DeleteMediaRequest{EventId: c.MustGet("eventId").(uint64), FileId: c.Param("fileId")}
+		if _, err := svc.DeleteMedia(c, req); err != nil {
+			AbortWithError(c, mediaErrorCode(err), err)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+}
+
+func mediaErrorCode(err error) int {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	service "github.com/jj-style/eventpix/internal/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+func TestModerateRoutes(t *testing.T) {
+	t.Parallel()
+	router, _ := newMemberRouter(t, nil, 2, db.RoleModerator)
+	msvc := service.NewMockEventpixService(t)
+	router.GET("/event/:id/moderate", getModerate(msvc))
+	router.GET("/event/:id/moderate/media", getModerateMedia(msvc))
+	router.POST("/event/:id/media/:fileId/hide", hideMedia(msvc))
+	router.DELETE("/event/:id/media/:fileId", deleteMedia(msvc))
+
+	t.Run("moderate page includes hidden media", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		msvc.EXPECT().
+			GetEvent(mock.Anything, mock.Anything).
+			Return(&picturev1.GetEventResponse{Event: &picturev1.Event{Id: 2, Name: "wedding"}}, nil)
+		msvc.EXPECT().
+			GetThumbnails(mock.Anything, &picturev1.GetThumbnailsRequest{EventId: 2, Limit: moderatePageSize, IncludeHidden: true}).
+			Return(&picturev1.GetThumbnailsResponse{Thumbnails: []*picturev1.Thumbnail{
+				{Id: "thumb_rude", FileInfo: &picturev1.FileInfo{Id: "rude", Name: "rude.jpg", Hidden: true}},
+			}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/moderate", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		body := w.Body.String()
+		is.Contains(body, "wedding")
+		is.Contains(body, "rude.jpg")
+		is.Contains(body, "Hidden")
+		is.Contains(body, `hx-get="/event/2/moderate/media?page=1"`)
+	})
+
+	t.Run("hide", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		msvc.EXPECT().
+			HideMedia(mock.Anything, &picturev1.HideMediaRequest{EventId: 2, FileId: "cake", Hidden: true}).
+			Return(&emptypb.Empty{}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/event/2/media/cake/hide", strings.NewReader(url.Values{"hidden": {"true"}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), "Hidden")
+		is.Contains(w.Body.String(), `title="Show"`)
+	})
+
+	t.Run("delete media not in event", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		msvc.EXPECT().
+			DeleteMedia(mock.Anything, &picturev1.DeleteMediaRequest{EventId: 2, FileId: "other"}).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/event/2/media/other", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusNotFound, w.Code)
+	})
+}
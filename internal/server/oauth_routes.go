@@ -10,15 +10,16 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jj-style/eventpix/internal/data/db"
 	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
 	"golang.org/x/oauth2"
 )
 
-func handleOauth(r *gin.RouterGroup, cfg *oauth2.Config, db db.DB, htmxMiddleware gin.HandlerFunc) {
-	r.GET("/redirect/google", handleGoogleRedirect(cfg, db))
-	r.DELETE("/google", htmxMiddleware, deleteGoogleToken(db))
+func handleOauth(r *gin.RouterGroup, cfg *oauth2.Config, db db.DB, htmxMiddleware gin.HandlerFunc, audit *service.Auditor) {
+	r.GET("/redirect/google", handleGoogleRedirect(cfg, db, audit))
+	r.DELETE("/google", htmxMiddleware, deleteGoogleToken(db, audit))
 }
 
-func deleteGoogleToken(db2 db.DB) gin.HandlerFunc {
+func deleteGoogleToken(db2 db.DB, audit *service.Auditor) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := c.MustGet(gin.AuthUserKey).(*db.User)
 		if user.GoogleDriveToken == nil {
@@ -30,6 +31,7 @@ func deleteGoogleToken(db2 db.DB) gin.HandlerFunc {
 			AbortWithError(c, http.StatusInternalServerError, fmt.Errorf("deleting google token for user: %v", err))
 			return
 		}
+		audit.Record(c, service.Audit{Action: service.AuditGoogleDisconnect, Target: service.UserTarget(user.ID)})
 
 		h, _ := c.MustGet(middleware.HtmxKey).(*htmx.Handler)
 		if h.IsHxRequest() {
@@ -39,7 +41,7 @@ func deleteGoogleToken(db2 db.DB) gin.HandlerFunc {
 	}
 }
 
-func handleGoogleRedirect(cfg *oauth2.Config, db2 db.DB) gin.HandlerFunc {
+func handleGoogleRedirect(cfg *oauth2.Config, db2 db.DB, audit *service.Auditor) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := c.MustGet(gin.AuthUserKey).(*db.User)
 
@@ -68,6 +70,7 @@ func handleGoogleRedirect(cfg *oauth2.Config, db2 db.DB) gin.HandlerFunc {
 			AbortWithError(c, http.StatusInternalServerError, fmt.Errorf("storing users access token: %v", err))
 			return
 		}
+		audit.Record(c, service.Audit{Action: service.AuditGoogleConnect, Target: service.UserTarget(user.ID)})
 
 		c.Redirect(http.StatusTemporaryRedirect, "/profile")
 	}
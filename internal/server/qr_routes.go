@@ -0,0 +1,238 @@
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/g4s8/hexcolor"
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/pkg/qr"
+	"github.com/samber/lo"
+	_ "golang.org/x/image/webp"
+)
+
+// Formats QR codes can be downloaded in
+const (
+	qrFormatPDF = "pdf"
+	qrFormatSVG = "svg"
+	qrFormatPNG = "png"
+)
+
+// widest PNG QR code which can be downloaded, and the width when none is asked for
+const (
+	maxQrSize     = 4096
+	defaultQrSize = 1024
+)
+
+// biggest logo which can be put in a printed QR code
+const maxLogoSize = 2 << 20
+
+// widest and tallest logo which is decoded, a small file can still be a huge image
+const maxLogoPixels = 4096
+
+// longest instructions printed under a QR code
+const maxInstructions = 200
+
+// the colours and link shared by the QR code preview and printing
+type qrRequest struct {
+	Foreground string `form:"foreground"`
+	Background string `form:"background"`
+	GuestToken uint   `form:"guestToken"`
+	// a short link to the event, which makes a simpler code, instead of the full link
+	ShortLink uint `form:"shortLink"`
+}
+
+// code makes the QR code for the event the request is for, aborting the request if it can't
+func (r *qrRequest) code(c *gin.Context, cfg *config.Config, d db.DB) (*qr.Code, bool) {
+	eventId := c.MustGet("eventId").(uint64)
+	eventUrl := fmt.Sprintf("%s/event/%d", cfg.Server.ServerUrl, eventId)
+
+	// optionally use a guest link so guests can scan straight into a password protected event
+	switch {
+	case r.ShortLink != 0:
+		links, err := d.GetShortLinks(c, uint(eventId))
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return nil, false
+		}
+		link, ok := lo.Find(links, func(l *db.ShortLink) bool { return l.ID == r.ShortLink })
+		if !ok {
+			AbortWithError(c, http.StatusNotFound, errors.New("short link not found"))
+			return nil, false
+		}
+		eventUrl = shortLinkUrl(cfg.Server, link)
+	case r.GuestToken != 0:
+		guestToken, err := d.GetGuestToken(c, uint(eventId), r.GuestToken)
+		if err != nil {
+			AbortWithError(c, http.StatusNotFound, errors.New("guest link not found"))
+			return nil, false
+		}
+		eventUrl, err = guestLink(cfg.Server, guestToken)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return nil, false
+		}
+	}
+
+	foreground, err := hexcolor.Parse(r.Foreground)
+	if err != nil {
+		AbortWithError(c, http.StatusUnprocessableEntity, err)
+		return nil, false
+	}
+	background, err := hexcolor.Parse(r.Background)
+	if err != nil {
+		AbortWithError(c, http.StatusUnprocessableEntity, err)
+		return nil, false
+	}
+	return &qr.Code{URL: eventUrl, Foreground: foreground, Background: background}, true
+}
+
+func getQrCode(cfg *config.Config, d db.DB) gin.HandlerFunc {
+	type request struct {
+		qrRequest
+		Size int `form:"size"`
+	}
+
+	return func(c *gin.Context) {
+		var req request
+		if err := c.Bind(&req); err != nil {
+			AbortWithError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		code, ok := req.code(c, cfg, d)
+		if !ok {
+			return
+		}
+		png, err := code.PNG(req.Size)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		b64 := base64.StdEncoding.EncodeToString(png)
+
+		c.String(http.StatusOK, `<img id="eventQrCode" class="mx-auto" src="data:image/png;base64, %s" alt="QR code for %s" />`, b64, code.URL)
+	}
+}
+
+// downloadQrCode downloads the events QR code as an SVG, a high resolution PNG or laid out in a PDF to print,
+// with an optional logo in the middle when it's posted
+func downloadQrCode(cfg *config.Config, d db.DB) gin.HandlerFunc {
+	type request struct {
+		qrRequest
+		Format string `form:"format"`
+		// width of PNGs in pixels
+		Width int `form:"width"`
+		// how PDFs are laid out
+		Layout       string `form:"layout"`
+		Cards        int    `form:"cards"`
+		Instructions string `form:"instructions"`
+	}
+
+	return func(c *gin.Context) {
+		var req request
+		if err := c.Bind(&req); err != nil {
+			AbortWithError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if req.Width == 0 {
+			req.Width = defaultQrSize
+		}
+		if req.Width < 0 || req.Width > maxQrSize {
+			AbortWithError(c, http.StatusUnprocessableEntity, fmt.Errorf("QR codes can be at most %d pixels wide", maxQrSize))
+			return
+		}
+		if utf8.RuneCountInString(req.Instructions) > maxInstructions {
+			AbortWithError(c, http.StatusUnprocessableEntity, fmt.Errorf("instructions can be at most %d characters", maxInstructions))
+			return
+		}
+
+		eventId := c.MustGet("eventId").(uint64)
+		event, err := d.GetEvent(c, eventId)
+		if err != nil {
+			AbortWithError(c, http.StatusNotFound, err)
+			return
+		}
+
+		code, ok := req.code(c, cfg, d)
+		if !ok {
+			return
+		}
+
+		if logo, err := c.FormFile("logo"); err == nil {
+			if logo.Size > maxLogoSize {
+				AbortWithError(c, http.StatusRequestEntityTooLarge, errors.New("logo is too big"))
+				return
+			}
+			f, err := logo.Open()
+			if err != nil {
+				AbortWithError(c, http.StatusBadRequest, err)
+				return
+			}
+			defer f.Close()
+			logoErr := errors.New("logo must be a PNG, JPEG, GIF or WebP image")
+			size, _, err := image.DecodeConfig(f)
+			if err != nil {
+				AbortWithError(c, http.StatusUnprocessableEntity, logoErr)
+				return
+			}
+			if size.Width > maxLogoPixels || size.Height > maxLogoPixels {
+				AbortWithError(c, http.StatusRequestEntityTooLarge, errors.New("logo is too big"))
+				return
+			}
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				AbortWithError(c, http.StatusInternalServerError, err)
+				return
+			}
+			if code.Logo, _, err = image.Decode(f); err != nil {
+				AbortWithError(c, http.StatusUnprocessableEntity, logoErr)
+				return
+			}
+		}
+
+		var (
+			data        []byte
+			contentType string
+			filename    = fmt.Sprintf("%s-qr.%s", event.Slug, req.Format)
+		)
+		switch req.Format {
+		case qrFormatPDF:
+			page := &qr.Print{Layout: req.Layout, Cards: req.Cards, Title: event.Name, Instructions: req.Instructions}
+			var buf bytes.Buffer
+			if err := page.PDF(&buf, code); err != nil {
+				AbortWithError(c, http.StatusUnprocessableEntity, err)
+				return
+			}
+			data, contentType = buf.Bytes(), "application/pdf"
+			filename = fmt.Sprintf("%s-%s.pdf", event.Slug, req.Layout)
+		case qrFormatSVG:
+			data, err = code.SVG()
+			contentType = "image/svg+xml"
+		case qrFormatPNG:
+			data, err = code.PNG(req.Width)
+			contentType = "image/png"
+		default:
+			AbortWithError(c, http.StatusUnprocessableEntity, fmt.Errorf("unknown format %q", req.Format))
+			return
+		}
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Data(http.StatusOK, contentType, data)
+	}
+}
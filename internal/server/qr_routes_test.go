@@ -0,0 +1,288 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestQrRoutes(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Server: &config.Server{SecretKey: "secret", ServerUrl: "https://eventpix.example.com"}}
+
+	printForm := func(fields map[string]string, logo []byte) (*bytes.Buffer, string) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		_ = writer.WriteField("foreground", "#112233")
+		_ = writer.WriteField("background", "#ffffff")
+		_ = writer.WriteField("format", "pdf")
+		for name, value := range fields {
+			_ = writer.WriteField(name, value)
+		}
+		if logo != nil {
+			part, _ := writer.CreateFormFile("logo", "logo.png")
+			_, _ = part.Write(logo)
+		}
+		_ = writer.Close()
+		return body, writer.FormDataContentType()
+	}
+	logoPng := func(w, h int) []byte {
+		var buf bytes.Buffer
+		_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h)))
+		return buf.Bytes()
+	}
+	wedding := &db.Event{Model: gorm.Model{ID: 2}, Name: "Jo & Sam's Wedding", Slug: "wedding"}
+
+	t.Run("preview", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleModerator)
+		router.GET("/event/:id/qr", getQrCode(cfg, mdb))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/qr?size=128&foreground=%23000000&background=%23ffffff", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), `src="data:image/png;base64, `)
+		is.Contains(w.Body.String(), `alt="QR code for https://eventpix.example.com/event/2"`)
+	})
+
+	t.Run("preview of a guest link", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleModerator)
+		router.GET("/event/:id/qr", getQrCode(cfg, mdb))
+		mdb.EXPECT().
+			GetGuestToken(mock.Anything, uint(2), uint(3)).
+			Return(&db.GuestToken{Model: gorm.Model{ID: 3}, EventID: 2, Capability: "view"}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/qr?size=128&foreground=%23000000&background=%23ffffff&guestToken=3", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), `alt="QR code for https://eventpix.example.com/event/2?`+middleware.GuestTokenQuery+`=`)
+	})
+
+	t.Run("print", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			fields   map[string]string
+			logo     []byte
+			filename string
+		}{
+			{name: "poster", fields: map[string]string{"layout": "poster"}, filename: "wedding-poster.pdf"},
+			{name: "card with instructions", fields: map[string]string{"layout": "card", "instructions": "Share your photos!"}, filename: "wedding-card.pdf"},
+			{name: "sheet with a logo", fields: map[string]string{"layout": "sheet", "cards": "6"}, logo: logoPng(64, 32), filename: "wedding-sheet.pdf"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+				is := require.New(t)
+				router, mdb := newMemberRouter(t, nil, 2, db.RoleModerator)
+				router.POST("/event/:id/qr/download", downloadQrCode(cfg, mdb))
+				mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(wedding, nil)
+
+				body, contentType := printForm(tt.fields, tt.logo)
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest("POST", "/event/2/qr/download", body)
+				req.Header.Set("Content-Type", contentType)
+				router.ServeHTTP(w, req)
+				is.Equal(http.StatusOK, w.Code)
+				is.Equal("application/pdf", w.Header().Get("Content-Type"))
+				is.Equal(`attachment; filename="`+tt.filename+`"`, w.Header().Get("Content-Disposition"))
+				is.True(bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")))
+			})
+		}
+	})
+
+	t.Run("print errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			fields map[string]string
+			logo   []byte
+			code   int
+		}{
+			{name: "unknown layout", fields: map[string]string{"layout": "billboard"}, code: http.StatusUnprocessableEntity},
+			{name: "too many cards", fields: map[string]string{"layout": "sheet", "cards": "100"}, code: http.StatusUnprocessableEntity},
+			{name: "logo isn't an image", fields: map[string]string{"layout": "poster"}, logo: []byte("<svg></svg>"), code: http.StatusUnprocessableEntity},
+			{name: "logo too big", fields: map[string]string{"layout": "poster"}, logo: logoPng(5000, 1), code: http.StatusRequestEntityTooLarge},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+				router, mdb := newMemberRouter(t, nil, 2, db.RoleModerator)
+				router.POST("/event/:id/qr/download", downloadQrCode(cfg, mdb))
+				mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(wedding, nil)
+
+				body, contentType := printForm(tt.fields, tt.logo)
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest("POST", "/event/2/qr/download", body)
+				req.Header.Set("Content-Type", contentType)
+				router.ServeHTTP(w, req)
+				require.Equal(t, tt.code, w.Code)
+			})
+		}
+	})
+
+	t.Run("instructions too long", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleModerator)
+		router.POST("/event/:id/qr/download", downloadQrCode(cfg, mdb))
+
+		body, contentType := printForm(map[string]string{"layout": "card", "instructions": strings.Repeat("a", 201)}, nil)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/event/2/qr/download", body)
+		req.Header.Set("Content-Type", contentType)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("preview of a short link", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleModerator)
+		router.GET("/event/:id/qr", getQrCode(cfg, mdb))
+		mdb.EXPECT().
+			GetShortLinks(mock.Anything, uint(2)).
+			Return([]*db.ShortLink{{Model: gorm.Model{ID: 4}, EventID: 2, Code: "Ab3xyz"}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/qr?size=128&foreground=%23000000&background=%23ffffff&shortLink=4&guestToken=3", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), `alt="QR code for https://eventpix.example.com/e/Ab3xyz"`)
+	})
+
+	t.Run("preview of another events short link", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleModerator)
+		router.GET("/event/:id/qr", getQrCode(cfg, mdb))
+		mdb.EXPECT().GetShortLinks(mock.Anything, uint(2)).Return(nil, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/qr?size=128&foreground=%23000000&background=%23ffffff&shortLink=4", nil)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("download", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			query       string
+			contentType string
+			filename    string
+			check       func(is *require.Assertions, body []byte)
+		}{
+			{
+				name:        "svg",
+				query:       "format=svg",
+				contentType: "image/svg+xml",
+				filename:    "wedding-qr.svg",
+				check: func(is *require.Assertions, body []byte) {
+					is.True(bytes.HasPrefix(body, []byte("<svg ")))
+					is.Contains(string(body), `fill="#112233"`)
+				},
+			},
+			{
+				name:        "high resolution png",
+				query:       "format=png&width=4096",
+				contentType: "image/png",
+				filename:    "wedding-qr.png",
+				check: func(is *require.Assertions, body []byte) {
+					img, err := png.Decode(bytes.NewReader(body))
+					is.NoError(err)
+					is.Equal(4096, img.Bounds().Dx())
+				},
+			},
+			{
+				name:        "png at the default width",
+				query:       "format=png",
+				contentType: "image/png",
+				filename:    "wedding-qr.png",
+				check: func(is *require.Assertions, body []byte) {
+					img, err := png.Decode(bytes.NewReader(body))
+					is.NoError(err)
+					is.Equal(1024, img.Bounds().Dx())
+				},
+			},
+			{
+				name:        "pdf",
+				query:       "format=pdf&layout=card",
+				contentType: "application/pdf",
+				filename:    "wedding-card.pdf",
+				check: func(is *require.Assertions, body []byte) {
+					is.True(bytes.HasPrefix(body, []byte("%PDF-")))
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+				is := require.New(t)
+				router, mdb := newMemberRouter(t, nil, 2, db.RoleModerator)
+				router.GET("/event/:id/qr/download", downloadQrCode(cfg, mdb))
+				mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(wedding, nil)
+
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest("GET", "/event/2/qr/download?foreground=%23112233&background=%23ffffff&"+tt.query, nil)
+				router.ServeHTTP(w, req)
+				is.Equal(http.StatusOK, w.Code)
+				is.Equal(tt.contentType, w.Header().Get("Content-Type"))
+				is.Equal(`attachment; filename="`+tt.filename+`"`, w.Header().Get("Content-Disposition"))
+				tt.check(is, w.Body.Bytes())
+			})
+		}
+	})
+
+	t.Run("download errors", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			query string
+		}{
+			{name: "unknown format", query: "format=gif"},
+			{name: "too wide", query: "format=png&width=10000"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+				router, mdb := newMemberRouter(t, nil, 2, db.RoleModerator)
+				router.GET("/event/:id/qr/download", downloadQrCode(cfg, mdb))
+				mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(wedding, nil).Maybe()
+
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest("GET", "/event/2/qr/download?foreground=%23112233&background=%23ffffff&"+tt.query, nil)
+				router.ServeHTTP(w, req)
+				require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			})
+		}
+	})
+
+	t.Run("print unknown guest link", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleModerator)
+		router.POST("/event/:id/qr/download", downloadQrCode(cfg, mdb))
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(wedding, nil)
+		mdb.EXPECT().GetGuestToken(mock.Anything, uint(2), uint(9)).Return(nil, errors.New("not found"))
+
+		body, contentType := printForm(map[string]string{"layout": "poster", "guestToken": "9"}, nil)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/event/2/qr/download", body)
+		req.Header.Set("Content-Type", contentType)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
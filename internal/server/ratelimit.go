@@ -0,0 +1,35 @@
+package server
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/ratelimit"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+)
+
+// rate limits used unless config overrides them
+var defaultRateLimits = map[string]config.RateLimit{
+	"login":         {Requests: 10, Window: time.Minute},
+	"register":      {Requests: 5, Window: time.Hour},
+	"guestlogin":    {Requests: 10, Window: time.Minute},
+	"passwordreset": {Requests: 5, Window: 15 * time.Minute},
+	// guests at the same venue often share an address, and upload lots at once
+	"upload": {Requests: 600, Window: time.Minute},
+	// short links are guessed at, and some let guests into password protected events
+	"shortlink": {Requests: 120, Window: time.Minute},
+}
+
+// rateLimiter creates middleware limiting the named routes, counting requests against the keys
+type rateLimiter func(name string, keys ...middleware.RateLimitKey) gin.HandlerFunc
+
+func newRateLimiter(limiter ratelimit.Limiter, limits map[string]*config.RateLimit) rateLimiter {
+	return func(name string, keys ...middleware.RateLimitKey) gin.HandlerFunc {
+		limit := defaultRateLimits[name]
+		if override, ok := limits[name]; ok && override != nil {
+			limit = *override
+		}
+		return middleware.RateLimit(limiter, name, limit.Requests, limit.Window, keys...)
+	}
+}
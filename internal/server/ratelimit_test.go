@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/ratelimit"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimits(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+
+	rateLimit := newRateLimiter(ratelimit.NewMemoryLimiter(), map[string]*config.RateLimit{
+		"login": {Requests: 2, Window: time.Minute},
+	})
+	router := newTestRouter()
+	router.POST("/login", rateLimit("login", middleware.ByIP), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/event/:id/login", rateLimit("guestlogin", middleware.ByIP, middleware.ByEvent("id")), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	post := func(path, ip string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, nil)
+		req.RemoteAddr = ip + ":1234"
+		req.Header.Set("HX-Request", "true")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// overridden by config
+	is.Equal(http.StatusOK, post("/login", "10.0.0.1").Code)
+	is.Equal(http.StatusOK, post("/login", "10.0.0.1").Code)
+	w := post("/login", "10.0.0.1")
+	is.Equal(http.StatusTooManyRequests, w.Code)
+	is.Equal("60", w.Header().Get("Retry-After"))
+	// shown in the error toast
+	is.Contains(w.Body.String(), "too many attempts")
+	is.Equal(http.StatusOK, post("/login", "10.0.0.2").Code)
+
+	// defaults, counted per event
+	for range defaultRateLimits["guestlogin"].Requests {
+		is.Equal(http.StatusOK, post("/event/1/login", "10.0.0.1").Code)
+	}
+	is.Equal(http.StatusTooManyRequests, post("/event/1/login", "10.0.0.1").Code)
+	is.Equal(http.StatusOK, post("/event/2/login", "10.0.0.1").Code)
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestRouter is a router with the templates and htmx middleware the ui routes use
+func newTestRouter() *gin.Engine {
+	errorTmpl := template.Must(template.ParseFS(content, "assets/templates/errorToast.html"))
+	router := gin.New()
+	router.HTMLRender = createRenderer()
+	router.Use(middleware.Htmx(htmx.New(), errorTmpl))
+	return router
+}
+
+// newMemberRouter is a test router whose requests come from the user with the role on the event,
+// as authRequired and eventRoles would have set them, and a mock DB the audit log and guests visits
+// can be written to. The user, event and role are left unset when empty.
+func newMemberRouter(t *testing.T, user *db.User, eventId uint64, role string) (*gin.Engine, *mockdb.MockDB) {
+	mdb := mockdb.NewMockDB(t)
+	mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+	mdb.EXPECT().AddGuestVisit(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	router := newTestRouter()
+	router.Use(func(c *gin.Context) {
+		if user != nil {
+			c.Set(gin.AuthUserKey, user)
+		}
+		if eventId != 0 {
+			c.Set("eventId", eventId)
+		}
+		if role != "" {
+			c.Set(middleware.EventRoleKey, role)
+		}
+	})
+	return router, mdb
+}
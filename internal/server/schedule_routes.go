@@ -0,0 +1,102 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gin-gonic/gin"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// scheduleStep is the next thing an events schedule does
+type scheduleStep struct {
+	Label string
+	At    time.Time
+	// how long until it happens, e.g. 2d 3h
+	In string
+}
+
+// nextScheduled gets the next step in the events schedule, nil if there's nothing left to do
+func nextScheduled(event *picturev1.Event) *scheduleStep {
+	now := time.Now()
+	steps := []struct {
+		label string
+		at    *timestamppb.Timestamp
+	}{
+		{"Live", event.GetStartsAt()},
+		{"Ends", event.GetEndsAt()},
+		{"Expires", event.GetExpiresAt()},
+	}
+	for _, step := range steps {
+		if step.at == nil {
+			continue
+		}
+		if at := step.at.AsTime(); at.After(now) {
+			return &scheduleStep{Label: step.label, At: at, In: formatCountdown(at.Sub(now))}
+		}
+	}
+	return nil
+}
+
+// formatCountdown shows the duration in its two largest units, like schedule.js does
+func formatCountdown(d time.Duration) string {
+	s := int64(d.Seconds())
+	days, hours, minutes := s/86400, s%86400/3600, s%3600/60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm %ds", minutes, s%60)
+	}
+}
+
+func getScheduleModal(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		event, err := svc.GetEvent(c, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: eventId}})
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.HTML(http.StatusOK, "scheduleModal", gin.H{"event": event.GetEvent()})
+	}
+}
+
+// setEventSchedule changes when the event is live and archived, from RFC 3339 times with empty ones unscheduled
+func setEventSchedule(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h := c.MustGet(middleware.HtmxKey).(*htmx.Handler)
+		eventId := c.MustGet("eventId").(uint64)
+		req := &picturev1.SetEventScheduleRequest{Id: eventId}
+		for field, ts := range map[string]**timestamppb.Timestamp{
+			"startsAt":  &req.StartsAt,
+			"endsAt":    &req.EndsAt,
+			"expiresAt": &req.ExpiresAt,
+		} {
+			value := c.PostForm(field)
+			if value == "" {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				AbortWithError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid %s time: %w", field, err))
+				return
+			}
+			*ts = timestamppb.New(t)
+		}
+
+		if _, err := svc.SetEventSchedule(c, req); err != nil {
+			AbortWithError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		c.Status(http.StatusOK)
+		h.Refresh(true)
+	}
+}
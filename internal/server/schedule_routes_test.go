@@ -0,0 +1,123 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	mockService "github.com/jj-style/eventpix/internal/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestScheduleRoutes(t *testing.T) {
+	t.Parallel()
+
+	postForm := func(router *gin.Engine, path string, form url.Values) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("HX-Request", "true")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("set schedule", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, _ := newMemberRouter(t, nil, 2, db.RoleManager)
+		msvc := mockService.NewMockEventpixService(t)
+		router.POST("/event/:id/schedule", setEventSchedule(msvc))
+
+		startsAt := time.Date(2026, 6, 1, 14, 0, 0, 0, time.UTC)
+		msvc.EXPECT().
+			SetEventSchedule(mock.Anything, mock.MatchedBy(func(req *picturev1.SetEventScheduleRequest) bool {
+				return req.GetId() == 2 &&
+					req.GetStartsAt().AsTime().Equal(startsAt) &&
+					req.GetEndsAt() == nil &&
+					req.GetExpiresAt().AsTime().Equal(startsAt.Add(30*24*time.Hour))
+			})).
+			Return(&picturev1.SetEventScheduleResponse{}, nil)
+
+		w := postForm(router, "/event/2/schedule", url.Values{
+			"startsAt":  {"2026-06-01T14:00:00Z"},
+			"endsAt":    {""},
+			"expiresAt": {"2026-07-01T15:00:00+01:00"},
+		})
+		is.Equal(http.StatusOK, w.Code)
+		is.Equal("true", w.Header().Get("HX-Refresh"))
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		t.Parallel()
+		router, _ := newMemberRouter(t, nil, 2, db.RoleManager)
+		router.POST("/event/:id/schedule", setEventSchedule(mockService.NewMockEventpixService(t)))
+
+		w := postForm(router, "/event/2/schedule", url.Values{"startsAt": {"2026-06-01T14:00"}})
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("modal", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, _ := newMemberRouter(t, nil, 2, db.RoleManager)
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/event/:id/schedule/modal", getScheduleModal(msvc))
+
+		msvc.EXPECT().
+			GetEvent(mock.Anything, mock.Anything).
+			Return(&picturev1.GetEventResponse{Event: &picturev1.Event{Id: 2, Name: "wedding", StartsAt: timestamppb.New(time.Date(2026, 6, 1, 14, 0, 0, 0, time.UTC))}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/schedule/modal", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), `data-value="2026-06-01T14:00:00Z"`)
+	})
+
+	t.Run("archived gallery hidden", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 0, "")
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/event/:id", getEvent(msvc, middleware.NewGuest("secret", false, mdb)))
+
+		msvc.EXPECT().
+			GetEvent(mock.Anything, mock.Anything).
+			Return(&picturev1.GetEventResponse{Event: &picturev1.Event{Id: 3, Name: "party", Archived: true}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/3", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusGone, w.Code)
+		is.Contains(w.Body.String(), "This event has ended")
+	})
+}
+
+func TestNextScheduled(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+
+	now := time.Now()
+	at := func(d time.Duration) *timestamppb.Timestamp { return timestamppb.New(now.Add(d)) }
+
+	is.Nil(nextScheduled(&picturev1.Event{}))
+	is.Nil(nextScheduled(&picturev1.Event{StartsAt: at(-time.Hour)}))
+
+	step := nextScheduled(&picturev1.Event{StartsAt: at(-time.Hour), EndsAt: at(26*time.Hour + time.Minute), ExpiresAt: at(48 * time.Hour)})
+	is.NotNil(step)
+	is.Equal("Ends", step.Label)
+	is.Equal("1d 2h", step.In)
+
+	step = nextScheduled(&picturev1.Event{StartsAt: at(90*time.Second + 500*time.Millisecond)})
+	is.Equal("Live", step.Label)
+	is.Equal("1m 30s", step.In)
+}
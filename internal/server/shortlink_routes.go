@@ -0,0 +1,165 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+type shortLinkView struct {
+	*db.ShortLink
+	URL string
+	// name of the guest link it lets guests in with
+	GuestName string
+	// the guest link it lets guests in with has been rotated or revoked
+	Broken bool
+}
+
+func shortLinkUrl(cfg *config.Server, link *db.ShortLink) string {
+	return fmt.Sprintf("%s/e/%s", cfg.ServerUrl, link.Code)
+}
+
+func shortLinkViews(c *gin.Context, d db.DB, cfg *config.Server, eventId uint64, guestTokens []*db.GuestToken) ([]shortLinkView, error) {
+	links, err := d.GetShortLinks(c, uint(eventId))
+	if err != nil {
+		return nil, err
+	}
+	views := make([]shortLinkView, 0, len(links))
+	for _, link := range links {
+		view := shortLinkView{ShortLink: link, URL: shortLinkUrl(cfg, link)}
+		if link.GuestTokenID != nil {
+			token, ok := lo.Find(guestTokens, func(t *db.GuestToken) bool { return t.ID == *link.GuestTokenID })
+			view.Broken = !ok
+			if ok {
+				view.GuestName = token.Name
+			}
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+func createShortLink(d db.DB, cfg *config.Config, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		link := &db.ShortLink{
+			EventID: uint(eventId),
+			Name:    strings.TrimSpace(c.PostForm("name")),
+		}
+		if link.Name == "" {
+			AbortWithError(c, http.StatusUnprocessableEntity, errors.New("short link name is required"))
+			return
+		}
+		if guestToken := c.PostForm("guestToken"); guestToken != "" {
+			guestId, err := strconv.ParseUint(guestToken, 10, 64)
+			if err != nil {
+				AbortWithError(c, http.StatusBadRequest, err)
+				return
+			}
+			token, err := d.GetGuestToken(c, uint(eventId), uint(guestId))
+			if err != nil {
+				AbortWithError(c, http.StatusNotFound, errors.New("guest link not found"))
+				return
+			}
+			link.GuestTokenID = &token.ID
+		}
+		if err := d.CreateShortLink(c, link); err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		audit.Record(c, service.Audit{
+			Action:  service.AuditShortLinkCreate,
+			EventID: &link.EventID,
+			After:   map[string]any{"link": link.ID, "name": link.Name, "code": link.Code, "guest": link.GuestTokenID},
+		})
+		renderShortLinks(c, d, cfg, eventId, http.StatusCreated)
+	}
+}
+
+func deleteShortLink(d db.DB, cfg *config.Config, audit *service.Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		linkId, err := strconv.ParseUint(c.Param("linkId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := d.DeleteShortLink(c, uint(eventId), uint(linkId)); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				AbortWithError(c, http.StatusNotFound, errors.New("short link not found"))
+				return
+			}
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		audit.Record(c, service.Audit{Action: service.AuditShortLinkDelete, EventID: lo.ToPtr(uint(eventId)), Before: map[string]any{"link": linkId}})
+		renderShortLinks(c, d, cfg, eventId, http.StatusOK)
+	}
+}
+
+// renders the events short links, and the choice of them for the QR code out of band
+func renderShortLinks(c *gin.Context, d db.DB, cfg *config.Config, eventId uint64, code int) {
+	guestTokens, err := d.GetGuestTokens(c, uint(eventId))
+	if err != nil {
+		AbortWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	links, err := shortLinkViews(c, d, cfg.Server, eventId, guestTokens)
+	if err != nil {
+		AbortWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.HTML(code, "shortLinks", gin.H{
+		"eventId":     eventId,
+		"shortLinks":  links,
+		"guestTokens": guestTokens,
+		"canManage":   true,
+		"oob":         true,
+	})
+}
+
+// followShortLink counts the scan and sends the guest on to the event
+func followShortLink(d db.DB, cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		link, err := d.GetShortLink(c, c.Param("code"))
+		if err != nil {
+			AbortWithError(c, http.StatusNotFound, errors.New("short link not found"))
+			return
+		}
+
+		target := fmt.Sprintf("/event/%d", link.EventID)
+		if link.GuestTokenID != nil {
+			token, err := d.GetGuestToken(c, link.EventID, *link.GuestTokenID)
+			if err != nil {
+				AbortWithError(c, http.StatusNotFound, errors.New("short link no longer works"))
+				return
+			}
+			if target, err = guestLink(cfg.Server, token); err != nil {
+				AbortWithError(c, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		// a scan which isn't counted shouldn't stop the guest getting to the event
+		_ = d.CountShortLinkScan(c, link.ID)
+		// not a permanent redirect, browsers would remember it and skip counting the next scan
+		c.Redirect(http.StatusFound, target)
+	}
+}
+
+// canManageEvent is whether the user can change the events settings, as well as moderate it
+func canManageEvent(c *gin.Context) bool {
+	role, _ := c.Get(middleware.EventRoleKey)
+	r, _ := role.(string)
+	return db.RoleAtLeast(r, db.RoleManager)
+}
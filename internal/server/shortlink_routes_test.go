@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+	mockService "github.com/jj-style/eventpix/internal/service/mocks"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestShortLinkRoutes(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Server: &config.Server{SecretKey: "secret", ServerUrl: "https://eventpix.example.com"}}
+
+	guests := []*db.GuestToken{{Model: gorm.Model{ID: 3}, EventID: 2, Name: "family", Capability: "upload"}}
+	links := []*db.ShortLink{
+		{Model: gorm.Model{ID: 4}, EventID: 2, Code: "Ab3xyz", Name: "tables", Scans: 12},
+		{Model: gorm.Model{ID: 5}, EventID: 2, Code: "Cd4uvw", Name: "family", GuestTokenID: lo.ToPtr(uint(3))},
+		{Model: gorm.Model{ID: 6}, EventID: 2, Code: "Ef5rst", Name: "old", GuestTokenID: lo.ToPtr(uint(1))},
+	}
+
+	t.Run("qr modal lists the links", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleManager)
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/event/:id/qr/modal", getEventQrModal(msvc, mdb, cfg))
+		msvc.EXPECT().GetEvent(mock.Anything, mock.Anything).Return(&picturev1.GetEventResponse{Event: &picturev1.Event{Id: 2}}, nil)
+		mdb.EXPECT().GetGuestTokens(mock.Anything, uint(2)).Return(guests, nil)
+		mdb.EXPECT().GetShortLinks(mock.Anything, uint(2)).Return(links, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/qr/modal", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		body := w.Body.String()
+		is.Contains(body, `value="https://eventpix.example.com/e/Ab3xyz"`)
+		is.Contains(body, "<td>12</td>")
+		is.Contains(body, "Guest link family")
+		is.Contains(body, "Guest link rotated or revoked")
+		// links which no longer work can't be put in a QR code
+		is.Contains(body, `<option value="4">tables (https://eventpix.example.com/e/Ab3xyz)</option>`)
+		is.NotContains(body, `old (https://eventpix.example.com/e/Ef5rst)`)
+		is.Contains(body, `hx-post="/event/2/links"`)
+		is.NotContains(body, "hx-swap-oob")
+	})
+
+	t.Run("moderators can't change the links", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleModerator)
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/event/:id/qr/modal", getEventQrModal(msvc, mdb, cfg))
+		msvc.EXPECT().GetEvent(mock.Anything, mock.Anything).Return(&picturev1.GetEventResponse{Event: &picturev1.Event{Id: 2}}, nil)
+		mdb.EXPECT().GetGuestTokens(mock.Anything, uint(2)).Return(nil, nil)
+		mdb.EXPECT().GetShortLinks(mock.Anything, uint(2)).Return(links[:1], nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/qr/modal", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), "/e/Ab3xyz")
+		is.NotContains(w.Body.String(), `hx-post="/event/2/links"`)
+		is.NotContains(w.Body.String(), `hx-delete="/event/2/links/4"`)
+	})
+
+	t.Run("create link to a guest link", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleManager)
+		router.POST("/event/:id/links", createShortLink(mdb, cfg, service.NewAuditor(mdb, zap.NewNop())))
+		mdb.EXPECT().GetGuestToken(mock.Anything, uint(2), uint(3)).Return(guests[0], nil)
+		mdb.EXPECT().
+			CreateShortLink(mock.Anything, &db.ShortLink{EventID: 2, Name: "family", GuestTokenID: lo.ToPtr(uint(3))}).
+			RunAndReturn(func(_ context.Context, link *db.ShortLink) error { link.ID, link.Code = 5, "Cd4uvw"; return nil })
+		mdb.EXPECT().GetGuestTokens(mock.Anything, uint(2)).Return(guests, nil)
+		mdb.EXPECT().GetShortLinks(mock.Anything, uint(2)).Return(links[1:2], nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/event/2/links", strings.NewReader(url.Values{"name": {" family "}, "guestToken": {"3"}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusCreated, w.Code)
+		is.Contains(w.Body.String(), "/e/Cd4uvw")
+		// the choice of link for the QR code is updated too
+		is.Contains(w.Body.String(), `<select class="form-select" id="shortLink" name="shortLink" hx-swap-oob="true">`)
+	})
+
+	t.Run("create link needs a name", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleManager)
+		router.POST("/event/:id/links", createShortLink(mdb, cfg, service.NewAuditor(mdb, zap.NewNop())))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/event/2/links", strings.NewReader(url.Values{"name": {" "}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("create link to another events guest link", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleManager)
+		router.POST("/event/:id/links", createShortLink(mdb, cfg, service.NewAuditor(mdb, zap.NewNop())))
+		mdb.EXPECT().GetGuestToken(mock.Anything, uint(2), uint(8)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/event/2/links", strings.NewReader(url.Values{"name": {"sneaky"}, "guestToken": {"8"}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("delete link", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleManager)
+		router.DELETE("/event/:id/links/:linkId", deleteShortLink(mdb, cfg, service.NewAuditor(mdb, zap.NewNop())))
+		mdb.EXPECT().DeleteShortLink(mock.Anything, uint(2), uint(4)).Return(nil)
+		mdb.EXPECT().GetGuestTokens(mock.Anything, uint(2)).Return(nil, nil)
+		mdb.EXPECT().GetShortLinks(mock.Anything, uint(2)).Return(nil, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/event/2/links/4", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), "No short links")
+
+		mdb.EXPECT().DeleteShortLink(mock.Anything, uint(2), uint(9)).Return(gorm.ErrRecordNotFound)
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("DELETE", "/event/2/links/9", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusNotFound, w.Code)
+	})
+
+	t.Run("follow link to the event", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 0, "")
+		router.GET("/e/:code", followShortLink(mdb, cfg))
+		mdb.EXPECT().GetShortLink(mock.Anything, "Ab3xyz").Return(links[0], nil)
+		mdb.EXPECT().CountShortLinkScan(mock.Anything, uint(4)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/e/Ab3xyz", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusFound, w.Code)
+		is.Equal("/event/2", w.Header().Get("Location"))
+	})
+
+	t.Run("follow link to a guest link", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 0, "")
+		router.GET("/e/:code", followShortLink(mdb, cfg))
+		mdb.EXPECT().GetShortLink(mock.Anything, "Cd4uvw").Return(links[1], nil)
+		mdb.EXPECT().GetGuestToken(mock.Anything, uint(2), uint(3)).Return(guests[0], nil)
+		mdb.EXPECT().CountShortLinkScan(mock.Anything, uint(5)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/e/Cd4uvw", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusFound, w.Code)
+		is.True(strings.HasPrefix(w.Header().Get("Location"), "https://eventpix.example.com/event/2?"+middleware.GuestTokenQuery+"="))
+	})
+
+	t.Run("follow link to a revoked guest link", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, nil, 0, "")
+		router.GET("/e/:code", followShortLink(mdb, cfg))
+		mdb.EXPECT().GetShortLink(mock.Anything, "Ef5rst").Return(links[2], nil)
+		mdb.EXPECT().GetGuestToken(mock.Anything, uint(2), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/e/Ef5rst", nil)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("follow unknown link", func(t *testing.T) {
+		t.Parallel()
+		router, mdb := newMemberRouter(t, nil, 0, "")
+		router.GET("/e/:code", followShortLink(mdb, cfg))
+		mdb.EXPECT().GetShortLink(mock.Anything, "nope").Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/e/nope", nil)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
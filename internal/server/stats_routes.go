@@ -0,0 +1,60 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"gorm.io/gorm"
+)
+
+// how many of the most viewed media and busiest guest links are in the stats
+const statsTop = 10
+
+func getEventStats(d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		event, err := d.GetEvent(c, eventId)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				code = http.StatusNotFound
+			}
+			AbortWithError(c, code, err)
+			return
+		}
+		stats, err := d.GetEventStats(c, uint(eventId), statsTop)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		// uploads are counted by the day for longer events
+		timeFormat := "Mon 2 Jan 15:04"
+		if len(stats.Uploads) > 1 && stats.Uploads[1].Time.Sub(stats.Uploads[0].Time) > time.Hour {
+			timeFormat = "Mon 2 Jan"
+		}
+		c.HTML(http.StatusOK, "eventStats", gin.H{
+			"title":      "Stats - " + event.Name,
+			"event":      event,
+			"stats":      stats,
+			"media":      stats.Photos + stats.Videos,
+			"timeFormat": timeFormat,
+			"json":       fmt.Sprintf("/event/%d/stats.json", eventId),
+		})
+	}
+}
+
+func getEventStatsJSON(d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		stats, err := d.GetEventStats(c, uint(eventId), statsTop)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.JSON(http.StatusOK, stats)
+	}
+}
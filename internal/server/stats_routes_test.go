@@ -0,0 +1,93 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestStatsRoutes(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 6, 1, 14, 0, 0, 0, time.UTC)
+	uploads := []db.UploadCount{{Time: start, Count: 2}, {Time: start.Add(time.Hour), Count: 0}, {Time: start.Add(2 * time.Hour), Count: 4}}
+	stats := &db.EventStats{
+		Photos:            5,
+		Videos:            1,
+		Bytes:             3 << 20,
+		GuestSessions:     7,
+		UploadingSessions: 3,
+		Views:             40,
+		Downloads:         6,
+		Uploads:           uploads,
+		Peak:              &uploads[2],
+		TopMedia:          []db.MediaStats{{ID: "a.jpg", Name: "cake.jpg", Views: 30, Downloads: 5}},
+		TopUploaders:      []db.UploaderStats{{Name: "family", Uploads: 4, Bytes: 2 << 20}},
+	}
+
+	t.Run("stats page", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleOwner)
+		router.GET("/event/:id/stats", getEventStats(mdb))
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(&db.Event{Model: gorm.Model{ID: 2}, Name: "wedding"}, nil)
+		mdb.EXPECT().GetEventStats(mock.Anything, uint(2), statsTop).Return(stats, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/stats", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		body := w.Body.String()
+		is.Contains(body, "wedding")
+		is.Contains(body, "3.1 MB stored")
+		is.Contains(body, "Busiest at Mon 1 Jun 16:00 with 4 uploads")
+		// bars are scaled to the busiest hour
+		is.Contains(body, `height: 50%;" title="Mon 1 Jun 14:00: 2"`)
+		is.Contains(body, `height: 100%;" title="Mon 1 Jun 16:00: 4"`)
+		is.Contains(body, "cake.jpg")
+		is.Contains(body, "family")
+		is.Contains(body, `href="/event/2/stats.json"`)
+	})
+
+	t.Run("stats page with nothing uploaded", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleOwner)
+		router.GET("/event/:id/stats", getEventStats(mdb))
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(&db.Event{Model: gorm.Model{ID: 2}, Name: "wedding"}, nil)
+		mdb.EXPECT().GetEventStats(mock.Anything, uint(2), statsTop).
+			Return(&db.EventStats{Uploads: []db.UploadCount{}, TopMedia: []db.MediaStats{}, TopUploaders: []db.UploaderStats{}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/stats", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), "Nothing has been uploaded yet.")
+		is.NotContains(w.Body.String(), "Top guest links")
+	})
+
+	t.Run("stats json", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, mdb := newMemberRouter(t, nil, 2, db.RoleOwner)
+		router.GET("/event/:id/stats.json", getEventStatsJSON(mdb))
+		mdb.EXPECT().GetEventStats(mock.Anything, uint(2), statsTop).Return(stats, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/2/stats.json", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		var got db.EventStats
+		is.NoError(json.Unmarshal(w.Body.Bytes(), &got))
+		is.Equal(int64(40), got.Views)
+		is.Equal(int64(4), got.Peak.Count)
+		is.Equal("family", got.TopUploaders[0].Name)
+	})
+}
@@ -3,14 +3,28 @@ package server
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jj-style/eventpix/internal/service"
 )
 
+// redirects are cached for less time than the presigned URL is valid for,
+// so a cached redirect never points at an expired URL
+var presignedMaxAge = int((service.PresignDownloadExpiry * 3 / 4).Seconds())
+
 func handleStorage(r *gin.RouterGroup, svc service.StorageService) {
-	r.GET("/thumbnail/:id", func(c *gin.Context) {
-		id := c.Param("id")
+	// ids are keys in the events storage, which can contain slashes
+	r.GET("/thumbnail/*id", func(c *gin.Context) {
+		id := strings.TrimPrefix(c.Param("id"), "/")
+		if url, err := svc.GetThumbnailURL(c, id); err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		} else if url != "" {
+			c.Header("Cache-Control", fmt.Sprintf("max-age=%d", presignedMaxAge))
+			c.Redirect(http.StatusFound, url)
+			return
+		}
 		fname, got, err := svc.GetThumbnail(c, id)
 		if err != nil {
 			c.AbortWithError(http.StatusInternalServerError, err)
@@ -20,15 +34,40 @@ func handleStorage(r *gin.RouterGroup, svc service.StorageService) {
 		c.Header("Cache-Control", "max-age=3600") // proxies cache for 1 hour
 		c.Data(http.StatusOK, "application/octet-stream", got)
 	})
-	r.GET("/picture/:id", func(c *gin.Context) {
-		id := c.Param("id")
+	// the gallery links here, so what's served is counted as a view
+	r.GET("/picture/*id", servePicture(svc, true))
+	// the thumbnailer fetches the original from here, which isn't a guest seeing it
+	r.GET("/original/*id", servePicture(svc, false))
+}
+
+func servePicture(svc service.StorageService, count bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := strings.TrimPrefix(c.Param("id"), "/")
+		// the gallery downloads with ?download=1, so it's cached apart from viewing too
+		download := c.Query("download") != ""
+		// videos are played in ranges, only the one from the start is a new view
+		view := count && (c.GetHeader("Range") == "" || strings.HasPrefix(c.GetHeader("Range"), "bytes=0-"))
+		if url, err := svc.GetPictureURL(c, id); err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		} else if url != "" {
+			if view {
+				svc.CountView(c, id, download)
+			}
+			c.Header("Cache-Control", fmt.Sprintf("max-age=%d", presignedMaxAge))
+			c.Redirect(http.StatusFound, url)
+			return
+		}
 		fname, got, err := svc.GetPicture(c, id)
 		if err != nil {
 			c.AbortWithError(http.StatusInternalServerError, err)
 			return
 		}
+		if view {
+			svc.CountView(c, id, download)
+		}
 		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fname))
 		c.Header("Cache-Control", "max-age=3600") // proxies cache for 1 hour
 		c.Data(http.StatusOK, "application/octet-stream", got)
-	})
+	}
 }
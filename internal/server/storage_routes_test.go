@@ -22,9 +22,13 @@ func TestStorageRoutes(t *testing.T) {
 	t.Run("happy picture", func(t *testing.T) {
 		t.Parallel()
 
+		msvc.EXPECT().
+			GetPictureURL(mock.Anything, "happyPicture").
+			Return("", nil)
 		msvc.EXPECT().
 			GetPicture(mock.Anything, "happyPicture").
 			Return("file.jpg", []byte("data"), nil)
+		msvc.EXPECT().CountView(mock.Anything, "happyPicture", false).Return()
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/picture/happyPicture", nil)
@@ -38,6 +42,9 @@ func TestStorageRoutes(t *testing.T) {
 	t.Run("unhappy picture", func(t *testing.T) {
 		t.Parallel()
 
+		msvc.EXPECT().
+			GetPictureURL(mock.Anything, "unhappyPicture").
+			Return("", nil)
 		msvc.EXPECT().
 			GetPicture(mock.Anything, "unhappyPicture").
 			Return("", []byte(nil), errors.New("boom"))
@@ -49,9 +56,50 @@ func TestStorageRoutes(t *testing.T) {
 		is.Equal(500, w.Code)
 	})
 
+	t.Run("thumbnailer fetching the original isn't a view", func(t *testing.T) {
+		t.Parallel()
+
+		msvc.EXPECT().
+			GetPictureURL(mock.Anything, "originalPicture").
+			Return("", nil)
+		msvc.EXPECT().
+			GetPicture(mock.Anything, "originalPicture").
+			Return("file.jpg", []byte("data"), nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/original/originalPicture", nil)
+		router.ServeHTTP(w, req)
+
+		is.Equal(200, w.Code)
+		is.Equal("data", w.Body.String())
+	})
+
+	t.Run("video counted once across ranges", func(t *testing.T) {
+		t.Parallel()
+
+		msvc.EXPECT().
+			GetPictureURL(mock.Anything, "rangedVideo").
+			Return("", nil)
+		msvc.EXPECT().
+			GetPicture(mock.Anything, "rangedVideo").
+			Return("file.mp4", []byte("data"), nil)
+		msvc.EXPECT().CountView(mock.Anything, "rangedVideo", false).Return().Once()
+
+		for _, rng := range []string{"bytes=0-", "bytes=1024-2047", "bytes=2048-"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/picture/rangedVideo", nil)
+			req.Header.Set("Range", rng)
+			router.ServeHTTP(w, req)
+			is.Equal(200, w.Code)
+		}
+	})
+
 	t.Run("happy thumbnail", func(t *testing.T) {
 		t.Parallel()
 
+		msvc.EXPECT().
+			GetThumbnailURL(mock.Anything, "happyThumb").
+			Return("", nil)
 		msvc.EXPECT().
 			GetThumbnail(mock.Anything, "happyThumb").
 			Return("file.jpg", []byte("data"), nil)
@@ -68,6 +116,9 @@ func TestStorageRoutes(t *testing.T) {
 	t.Run("unhappy thumbnail", func(t *testing.T) {
 		t.Parallel()
 
+		msvc.EXPECT().
+			GetThumbnailURL(mock.Anything, "unhappyThumb").
+			Return("", nil)
 		msvc.EXPECT().
 			GetThumbnail(mock.Anything, "unhappyThumb").
 			Return("", []byte(nil), errors.New("boom"))
@@ -78,4 +129,35 @@ func TestStorageRoutes(t *testing.T) {
 
 		is.Equal(500, w.Code)
 	})
+
+	t.Run("presigned picture", func(t *testing.T) {
+		t.Parallel()
+
+		msvc.EXPECT().
+			GetPictureURL(mock.Anything, "presignedPicture").
+			Return("https://s3.example.com/bucket/presignedPicture?X-Amz-Signature=abc", nil)
+		msvc.EXPECT().CountView(mock.Anything, "presignedPicture", true).Return()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/picture/presignedPicture?download=1", nil)
+		router.ServeHTTP(w, req)
+
+		is.Equal(http.StatusFound, w.Code)
+		is.Equal("https://s3.example.com/bucket/presignedPicture?X-Amz-Signature=abc", w.Header().Get("Location"))
+	})
+
+	t.Run("presigned thumbnail", func(t *testing.T) {
+		t.Parallel()
+
+		msvc.EXPECT().
+			GetThumbnailURL(mock.Anything, "presignedThumb").
+			Return("https://s3.example.com/bucket/presignedThumb?X-Amz-Signature=abc", nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/thumbnail/presignedThumb", nil)
+		router.ServeHTTP(w, req)
+
+		is.Equal(http.StatusFound, w.Code)
+		is.Equal("https://s3.example.com/bucket/presignedThumb?X-Amz-Signature=abc", w.Header().Get("Location"))
+	})
 }
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/server/middleware"
+	"github.com/jj-style/eventpix/internal/service"
+	"gorm.io/gorm"
+)
+
+// saveEventTemplate saves the events settings as a template named by the HTMX prompt,
+// then opens the new event page with it
+func saveEventTemplate(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		name := c.GetHeader("HX-Prompt")
+		if name == "" {
+			name = c.PostForm("name")
+		}
+		template, err := svc.SaveEventTemplate(c, user.ID, &picturev1.SaveEventTemplateRequest{EventId: eventId, Name: name})
+		if err != nil {
+			AbortWithError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		h := c.MustGet(middleware.HtmxKey).(*htmx.Handler)
+		c.Status(http.StatusCreated)
+		h.Redirect(fmt.Sprintf("/event/new?template=%d", template.GetId()))
+	}
+}
+
+func deleteEventTemplate(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		templateId, err := strconv.ParseUint(c.Param("templateId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if _, err := svc.DeleteEventTemplate(c, user.ID, &picturev1.DeleteEventTemplateRequest{Id: templateId}); err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				code = http.StatusNotFound
+			}
+			AbortWithError(c, code, err)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+}
+
+// where a new event is started from, a template or an event to duplicate
+type startFrom struct {
+	// name of the request field it's sent in
+	Field string
+	Id    uint64
+	Name  string
+	// the settings it has, storage is only known for templates
+	Storage           string
+	Cache             bool
+	Encrypted         bool
+	PasswordProtected bool
+	KeyTemplate       string
+}
+
+// the template or event to duplicate in the query of the new event page, if it's one the user has
+func newEventStartFrom(c *gin.Context, templates []*picturev1.EventTemplate, events []*picturev1.Event) *startFrom {
+	if id, err := strconv.ParseUint(c.Query("template"), 10, 64); err == nil {
+		for _, t := range templates {
+			if t.GetId() == id {
+				return &startFrom{Field: "templateId", Id: id, Name: t.GetName(), Storage: t.GetStorageType(), Cache: t.GetCache(), Encrypted: t.GetEncrypted(), PasswordProtected: t.GetPasswordProtected(), KeyTemplate: t.GetKeyTemplate()}
+			}
+		}
+	}
+	if id, err := strconv.ParseUint(c.Query("duplicate"), 10, 64); err == nil {
+		for _, e := range events {
+			if e.GetId() == id {
+				return &startFrom{Field: "duplicateEventId", Id: id, Name: e.GetName(), Cache: e.GetCache(), Encrypted: e.GetEncrypted(), PasswordProtected: e.GetPasswordProtected(), KeyTemplate: e.GetKeyTemplate()}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/donseba/go-htmx"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/service"
+	mockService "github.com/jj-style/eventpix/internal/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTemplateRoutes(t *testing.T) {
+	t.Parallel()
+
+	router, mdb := newMemberRouter(t, &db.User{Model: gorm.Model{ID: 1}}, 3, db.RoleOwner)
+	mdb.EXPECT().GetSetting(mock.Anything, "disableSignups").Return("", gorm.ErrRecordNotFound).Maybe()
+	settings, err := service.NewSettings(&config.Config{Server: &config.Server{}}, mdb)
+	require.NoError(t, err)
+	msvc := mockService.NewMockEventpixService(t)
+	router.GET("/event/new", getCreateEvent(msvc, settings))
+	router.POST("/event", createEvent(msvc, htmx.New()))
+	router.POST("/event/:id/template", saveEventTemplate(msvc))
+	router.DELETE("/templates/:templateId", deleteEventTemplate(msvc))
+
+	// the new event page lists the users templates and the events they can duplicate
+	msvc.EXPECT().
+		GetEventTemplates(mock.Anything, uint(1), mock.Anything).
+		Return(&picturev1.GetEventTemplatesResponse{Templates: []*picturev1.EventTemplate{{Id: 2, Name: "weddings", StorageType: "S3", Cache: true, PasswordProtected: true}}}, nil)
+	msvc.EXPECT().
+		GetEvents(mock.Anything, mock.Anything, uint(1)).
+		Return(&picturev1.GetEventsResponse{Events: []*picturev1.Event{
+			{Id: 3, Name: "wedding", Role: db.RoleOwner},
+			{Id: 4, Name: "helping out", Role: db.RoleManager},
+		}}, nil)
+
+	t.Run("new event from template", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/new?template=2", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		body := w.Body.String()
+		is.Contains(body, `<input type="hidden" name="templateId" value="2" />`)
+		is.Contains(body, "Keep the storage of weddings")
+		is.Contains(body, "leave blank to keep the same password")
+		is.Contains(body, `href="/event/new?duplicate=3"`)
+		// only owners can duplicate
+		is.NotContains(body, `href="/event/new?duplicate=4"`)
+	})
+
+	t.Run("new event duplicating", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/new?duplicate=3", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), `<input type="hidden" name="duplicateEventId" value="3" />`)
+	})
+
+	t.Run("new event can't duplicate events it doesn't own", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/event/new?duplicate=4", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.NotContains(w.Body.String(), `name="duplicateEventId"`)
+	})
+
+	t.Run("create duplicate not owner", func(t *testing.T) {
+		t.Parallel()
+		msvc.EXPECT().
+			CreateEvent(mock.Anything, uint(1), mock.MatchedBy(func(req *picturev1.CreateEventRequest) bool { return req.GetDuplicateEventId() == 4 })).
+			Return(nil, service.ErrNotEventOwner)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/event", strings.NewReader(`{"name":"party","slug":"party","duplicateEventId":"4"}`))
+		req.Header.Set("HX-Request", "true")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("save template", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		msvc.EXPECT().
+			SaveEventTemplate(mock.Anything, uint(1), &picturev1.SaveEventTemplateRequest{EventId: 3, Name: "weddings"}).
+			Return(&picturev1.EventTemplate{Id: 2, Name: "weddings"}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/event/3/template", nil)
+		req.Header.Set("HX-Request", "true")
+		req.Header.Set("HX-Prompt", "weddings")
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusCreated, w.Code)
+		is.Equal("/event/new?template=2", w.Header().Get("HX-Redirect"))
+	})
+
+	t.Run("delete template not found", func(t *testing.T) {
+		t.Parallel()
+		msvc.EXPECT().
+			DeleteEventTemplate(mock.Anything, uint(1), &picturev1.DeleteEventTemplateRequest{Id: 5}).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/templates/5", nil)
+		req.Header.Set("HX-Request", "true")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
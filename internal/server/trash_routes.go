@@ -0,0 +1,56 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/service"
+	"gorm.io/gorm"
+)
+
+type trashedEvent struct {
+	*picturev1.Event
+	// when the event will be permanently deleted
+	PurgeAt time.Time
+}
+
+func getTrash(svc service.EventpixService, cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		resp, err := svc.GetTrashedEvents(c, &picturev1.GetTrashedEventsRequest{}, user.ID)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		retention := service.TrashRetention(cfg)
+		events := make([]trashedEvent, 0, len(resp.GetEvents()))
+		for _, event := range resp.GetEvents() {
+			events = append(events, trashedEvent{Event: event, PurgeAt: event.GetDeletedAt().AsTime().Add(retention)})
+		}
+		c.HTML(http.StatusOK, "trash", gin.H{
+			"title":  "Trash",
+			"events": events,
+		})
+	}
+}
+
+func restoreEvent(svc service.EventpixService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		if _, err := svc.RestoreEvent(c, &picturev1.RestoreEventRequest{Id: eventId}); err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				code = http.StatusNotFound
+			}
+			AbortWithError(c, code, err)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+}
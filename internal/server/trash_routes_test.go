@@ -0,0 +1,76 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	mockService "github.com/jj-style/eventpix/internal/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func TestTrashRoutes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("trash", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		router, _ := newMemberRouter(t, &db.User{Model: gorm.Model{ID: 1}}, 0, "")
+		msvc := mockService.NewMockEventpixService(t)
+		router.GET("/events/trash", getTrash(msvc, &config.Config{Trash: &config.Trash{Retention: 7 * 24 * time.Hour}}))
+
+		deletedAt := time.Date(2026, 6, 1, 14, 0, 0, 0, time.UTC)
+		msvc.EXPECT().
+			GetTrashedEvents(mock.Anything, mock.Anything, uint(1)).
+			Return(&picturev1.GetEventsResponse{Events: []*picturev1.Event{{Id: 2, Name: "wedding", Slug: "wedding", DeletedAt: timestamppb.New(deletedAt)}}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/events/trash", nil)
+		router.ServeHTTP(w, req)
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), "wedding")
+		is.Contains(w.Body.String(), "2026-06-08 14:00")
+		is.Contains(w.Body.String(), `hx-post="/event/2/restore"`)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		t.Parallel()
+		router, _ := newMemberRouter(t, &db.User{Model: gorm.Model{ID: 1}}, 2, db.RoleOwner)
+		msvc := mockService.NewMockEventpixService(t)
+		router.POST("/event/:id/restore", restoreEvent(msvc))
+
+		msvc.EXPECT().
+			RestoreEvent(mock.Anything, &picturev1.RestoreEventRequest{Id: 2}).
+			Return(&picturev1.RestoreEventResponse{Event: &picturev1.Event{Id: 2}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/event/2/restore", nil)
+		req.Header.Set("HX-Request", "true")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("restore not in trash", func(t *testing.T) {
+		t.Parallel()
+		router, _ := newMemberRouter(t, &db.User{Model: gorm.Model{ID: 1}}, 2, db.RoleOwner)
+		msvc := mockService.NewMockEventpixService(t)
+		router.POST("/event/:id/restore", restoreEvent(msvc))
+
+		msvc.EXPECT().
+			RestoreEvent(mock.Anything, mock.Anything).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/event/2/restore", nil)
+		req.Header.Set("HX-Request", "true")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
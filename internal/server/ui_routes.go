@@ -9,7 +9,6 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
-	"io"
 	"log"
 	"net/http"
 	"net/url"
@@ -19,11 +18,15 @@ import (
 
 	"github.com/YamiOdymel/multitemplate"
 	"github.com/donseba/go-htmx"
-	"github.com/g4s8/hexcolor"
+	"github.com/dustin/go-humanize"
 	"github.com/gin-gonic/gin"
 	"github.com/jj-style/eventpix/internal/config"
 	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/data/storage"
 	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/branding"
+	"github.com/jj-style/eventpix/internal/pkg/qr"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
 	"github.com/jj-style/eventpix/internal/pkg/validate"
 	"github.com/jj-style/eventpix/internal/server/middleware"
 	"github.com/jj-style/eventpix/internal/server/sse"
@@ -31,27 +34,32 @@ import (
 	"github.com/jj-style/eventpix/internal/service"
 	"github.com/nats-io/nats.go"
 	"github.com/samber/lo"
-	"github.com/skip2/go-qrcode"
 	"golang.org/x/oauth2"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/proto"
 	"gorm.io/gorm"
 )
 
 //go:embed assets/templates/*
 var content embed.FS
 
-var (
-	showRegister bool = false
-)
-
 func createRenderer() multitemplate.Renderer {
 	r := multitemplate.NewRenderer()
 	fm := template.FuncMap{
 		"isLast": func(index, len int) bool {
 			return index+1 == len
 		},
-		"upper": strings.ToUpper,
+		"upper":         strings.ToUpper,
+		"deref":         func(p *uint) uint { return *p },
+		"lower":         strings.ToLower,
+		"bytes":         func(n int64) string { return humanize.Bytes(uint64(n)) },
+		"hasRole":       db.RoleAtLeast,
+		"nextScheduled": nextScheduled,
+		"markdown":      branding.Markdown,
+		"percent": func(n, total int64) int64 {
+			if total == 0 {
+				return 0
+			}
+			return n * 100 / total
+		},
 		// https://stackoverflow.com/a/18276968
 		"dict": func(values ...interface{}) (map[string]interface{}, error) {
 			if len(values)%2 != 0 {
@@ -72,27 +80,56 @@ func createRenderer() multitemplate.Renderer {
 
 	r.AddFromFSFuncs("index", fm, content, base, "assets/templates/index.html")
 	r.AddFromFSFuncs("noActiveEvent", fm, content, base, "assets/templates/noActiveEvent.html")
-	r.AddFromFS("eventGallery", content, base, "assets/templates/eventGallery.html")
+	r.AddFromFSFuncs("eventGallery", fm, content, base, "assets/templates/eventGallery.html")
+	r.AddFromFS("eventArchived", content, base, "assets/templates/eventArchived.html")
 	r.AddFromFSFuncs("thumbnails", fm, content, "assets/templates/thumbnails.html")
 
 	r.AddFromFSFuncs("listEvents", fm, content, base, "assets/templates/eventRow.html", "assets/templates/events.html")
-	r.AddFromFS("eventRow", content, "assets/templates/eventRow.html")
-	r.AddFromFS("createEvent", content, base, "assets/templates/partials/createEventSlug.html", "assets/templates/createEventForm.html")
+	r.AddFromFSFuncs("eventRow", fm, content, "assets/templates/eventRow.html")
+	r.AddFromFS("createEvent", content, base, "assets/templates/partials/createEventSlug.html", "assets/templates/partials/scheduleInputs.html", "assets/templates/createEventForm.html")
+	r.AddFromFS("trash", content, base, "assets/templates/trash.html")
+	r.AddFromFS("audit", content, base, "assets/templates/audit.html")
+	r.AddFromFSFuncs("eventStats", fm, content, base, "assets/templates/eventStats.html")
+	r.AddFromFSFuncs("moderate", fm, content, base, "assets/templates/partials/moderateActions.html", "assets/templates/partials/moderateMedia.html", "assets/templates/moderate.html")
+	r.AddFromFSFuncs("moderateMedia", fm, content, "assets/templates/partials/moderateActions.html", "assets/templates/partials/moderateMedia.html")
+	r.AddFromFS("moderateActions", content, "assets/templates/partials/moderateActions.html")
+	r.AddFromFSFuncs("editEvent", fm, content, base, "assets/templates/partials/createEventSlug.html", "assets/templates/editEventForm.html")
 	r.AddFromFS("filesystem", content, "assets/templates/forms/filesystem.html")
 	r.AddFromFS("s3", content, "assets/templates/forms/s3.html")
 	r.AddFromFS("google", content, "assets/templates/forms/google.html")
 	r.AddFromFS("ftp", content, "assets/templates/forms/ftp.html")
 
 	r.AddFromFS("login", content, base, "assets/templates/login.html")
+	r.AddFromFS("eventLogin", content, base, "assets/templates/eventLogin.html")
 	r.AddFromFS("register", content, base, "assets/templates/register.html")
-	r.AddFromFS("profile", content, base, "assets/templates/profile.html")
-
-	r.AddFromFS("qrModal", content, "assets/templates/components/qrModal.html")
+	r.AddFromFSFuncs("profile", fm, content, base, "assets/templates/partials/account.html", "assets/templates/partials/apiTokens.html", "assets/templates/partials/webhooks.html", "assets/templates/profile.html")
+	r.AddFromFS("forgotPassword", content, base, "assets/templates/forgotPassword.html")
+	r.AddFromFS("resetPassword", content, base, "assets/templates/resetPassword.html")
+	r.AddFromFSFuncs("apiTokens", fm, content, "assets/templates/partials/apiTokens.html")
+	r.AddFromFSFuncs("webhooks", fm, content, "assets/templates/partials/webhooks.html")
+	r.AddFromFS("account", content, "assets/templates/partials/account.html")
+	r.AddFromFS("passwordResetSent", content, "assets/templates/partials/passwordResetSent.html")
+	r.AddFromFS("webhookDeliveries", content, "assets/templates/partials/webhookDeliveries.html")
+
+	r.AddFromFS("qrModal", content, "assets/templates/components/qrModal.html", "assets/templates/partials/shortLinks.html")
+	r.AddFromFS("shortLinks", content, "assets/templates/partials/shortLinks.html")
+	r.AddFromFS("scheduleModal", content, "assets/templates/components/scheduleModal.html", "assets/templates/partials/scheduleInputs.html")
+	r.AddFromFS("guestsModal", content, "assets/templates/components/guestsModal.html", "assets/templates/partials/guestTokens.html")
+	r.AddFromFS("guestTokens", content, "assets/templates/partials/guestTokens.html")
+	r.AddFromFS("membersModal", content, "assets/templates/components/membersModal.html", "assets/templates/partials/eventMembers.html")
+	r.AddFromFS("eventMembers", content, "assets/templates/partials/eventMembers.html")
+	r.AddFromFSFuncs("storageModal", fm, content, "assets/templates/components/storageModal.html", "assets/templates/partials/storageMigration.html")
+	r.AddFromFSFuncs("storageMigration", fm, content, "assets/templates/partials/storageMigration.html")
 	r.AddFromFS("createEventSlug", content, "assets/templates/partials/createEventSlug.html")
+
+	r.AddFromFSFuncs("admin", fm, content, base, "assets/templates/partials/adminSettings.html", "assets/templates/partials/adminUsers.html", "assets/templates/partials/adminEvents.html", "assets/templates/admin.html")
+	r.AddFromFS("adminSettings", content, "assets/templates/partials/adminSettings.html")
+	r.AddFromFS("adminUsers", content, "assets/templates/partials/adminUsers.html")
+	r.AddFromFSFuncs("adminEvents", fm, content, "assets/templates/partials/adminEvents.html")
 	return r
 }
 
-func handleUi(r *gin.Engine, htmx *htmx.HTMX, db db.DB, svc service.EventpixService, nc *nats.Conn, cfg *config.Config, validator validate.Validator) {
+func handleUi(r *gin.Engine, htmx *htmx.HTMX, db db.DB, svc service.EventpixService, migrator *service.StorageMigrator, webhooks *service.WebhookDispatcher, guest *middleware.Guest, sessions *auth.Sessions, settings *service.Settings, accounts *service.Accounts, audit *service.Auditor, rateLimit rateLimiter, nc *nats.Conn, cfg *config.Config, validator validate.Validator) {
 	r.HTMLRender = createRenderer()
 
 	errorTmpl := template.Must(template.ParseFS(content, "assets/templates/errorToast.html"))
@@ -101,9 +138,9 @@ func handleUi(r *gin.Engine, htmx *htmx.HTMX, db db.DB, svc service.EventpixServ
 	broker := sse.NewBroker()
 	go broker.Listen()
 
-	authRequired := middleware.AuthRequired(cfg.Server.SecretKey, db)
-	userEventMiddleware := middleware.UserAuthorizedForEvent(db, "id", "eventId")
-	authRedirectMiddleware := middleware.AuthRedirect(cfg.Server.SecretKey, db, "/events")
+	authRequired := middleware.AuthRequired(sessions, db)
+	eventOwner, eventManager, eventModerator := eventRoles(db)
+	authRedirectMiddleware := middleware.AuthRedirect(sessions, db, "/events")
 
 	// htmx middleware to handle errors nicely
 	hr := r.Group("/")
@@ -115,32 +152,106 @@ func handleUi(r *gin.Engine, htmx *htmx.HTMX, db db.DB, svc service.EventpixServ
 
 	// public static pages
 	if !cfg.Server.SingleEventMode {
-		r.GET("/", getIndex())
+		r.GET("/", getIndex(settings))
 	} else {
-		hr.GET("/", getActiveEvent(svc))
+		hr.GET("/", getActiveEvent(svc, guest))
 		hr.POST("/event/:id/active", setActiveEvent(svc))
 	}
 
-	hra.GET("/event/new", getCreateEvent())
-	hra.POST("/event", createEvent(svc, htmx))
-	hra.GET("/events", getEvents(svc, cfg.Server))
-	hra.GET("/event/:id/qr/modal", userEventMiddleware, getEventQrModal(svc))
-	hra.GET("/event/:id/qr", userEventMiddleware, getQrCode(cfg, svc))
-	hra.GET("/profile", getProfile(cfg.OauthSecrets))
-	hra.GET("/storageForm", getStorageForm())
-	hra.GET("/googleDrivePicker", getDrivePicker(cfg.OauthSecrets))
-
-	hra.DELETE("/event/:id", userEventMiddleware, deleteEvent(svc))
-	hra.POST("/event/:id/live", userEventMiddleware, setEventLive(svc, cfg.Server))
+	// requests with an API token are limited to its scopes
+	readEvents := middleware.RequireScope(auth.ScopeReadEvents)
+	manageEvents := middleware.RequireScope(auth.ScopeManageEvents)
+	moderate := middleware.RequireScope(auth.ScopeModerate)
+	sessionRequired := middleware.SessionRequired()
+
+	hra.GET("/event/new", manageEvents, getCreateEvent(svc, settings))
+	hra.POST("/event", manageEvents, createEvent(svc, htmx))
+	hra.GET("/events", readEvents, getEvents(svc, db, cfg.Server, settings))
+	hra.GET("/event/:id/qr/modal", readEvents, eventModerator, getEventQrModal(svc, db, cfg))
+	hra.GET("/event/:id/qr", readEvents, eventModerator, getQrCode(cfg, db))
+	hra.GET("/event/:id/qr/download", readEvents, eventModerator, downloadQrCode(cfg, db))
+	// posted when there's a logo to put in the code
+	hra.POST("/event/:id/qr/download", readEvents, eventModerator, downloadQrCode(cfg, db))
+	hra.GET("/profile", sessionRequired, getProfile(db, cfg.OauthSecrets, cfg.Oidc, settings))
+	hra.POST("/profile/account", sessionRequired, updateAccount(db, accounts, sessions))
+	// confirmed with the users password, so limited like logging in
+	hra.POST("/profile/password", sessionRequired, rateLimit("login", middleware.ByUser), changePassword(db, accounts, sessions))
+	// a POST as the password confirming it would end up in the URL of a DELETE
+	hra.POST("/profile/delete", sessionRequired, rateLimit("login", middleware.ByUser), deleteAccount(accounts, sessions))
+	hra.GET("/storageForm", manageEvents, getStorageForm())
+	hra.GET("/googleDrivePicker", sessionRequired, getDrivePicker(cfg.OauthSecrets))
+
+	hra.DELETE("/event/:id", manageEvents, eventOwner, deleteEvent(svc))
+	hra.GET("/events/trash", readEvents, getTrash(svc, cfg))
+	hra.POST("/event/:id/restore", manageEvents, eventOwner, restoreEvent(svc))
+	hra.POST("/event/:id/template", manageEvents, eventOwner, saveEventTemplate(svc))
+	hra.DELETE("/templates/:templateId", manageEvents, deleteEventTemplate(svc))
+	hra.GET("/event/:id/audit", readEvents, eventOwner, getEventAudit(db, audit))
+	hra.GET("/event/:id/audit/export", readEvents, eventOwner, exportEventAudit(audit))
+	hra.GET("/event/:id/stats", readEvents, eventOwner, getEventStats(db))
+	hra.GET("/event/:id/stats.json", readEvents, eventOwner, getEventStatsJSON(db))
+	hra.GET("/event/:id/moderate", moderate, eventModerator, getModerate(svc))
+	hra.GET("/event/:id/moderate/media", moderate, eventModerator, getModerateMedia(svc))
+	hra.POST("/event/:id/media/:fileId/hide", moderate, eventModerator, hideMedia(svc))
+	hra.DELETE("/event/:id/media/:fileId", moderate, eventModerator, deleteMedia(svc))
+	hra.POST("/event/:id/live", manageEvents, eventManager, setEventLive(svc, cfg.Server))
+	hra.GET("/event/:id/edit", manageEvents, eventManager, getEditEvent(db))
+	hra.PUT("/event/:id", manageEvents, eventManager, updateEvent(svc))
+	hra.PUT("/event/:id/branding", manageEvents, eventManager, updateEventBranding(svc))
+	hra.GET("/event/:id/schedule/modal", manageEvents, eventManager, getScheduleModal(svc))
+	hra.POST("/event/:id/schedule", manageEvents, eventManager, setEventSchedule(svc))
+	hra.GET("/event/:id/storage/modal", manageEvents, eventOwner, getEventStorageModal(svc, migrator))
+	hra.POST("/event/:id/storage/migrate", manageEvents, eventOwner, migrateEventStorage(migrator))
+	hra.GET("/event/:id/storage/migration", readEvents, eventOwner, getStorageMigration(migrator))
+	hra.GET("/event/:id/guests/modal", manageEvents, eventManager, getGuestsModal(svc, db, cfg))
+	hra.POST("/event/:id/guests", manageEvents, eventManager, createGuestToken(db, cfg, audit))
+	hra.POST("/event/:id/guests/:guestId/rotate", manageEvents, eventManager, rotateGuestToken(db, cfg, audit))
+	hra.DELETE("/event/:id/guests/:guestId", manageEvents, eventManager, deleteGuestToken(db, cfg, audit))
+	hra.POST("/event/:id/links", manageEvents, eventManager, createShortLink(db, cfg, audit))
+	hra.DELETE("/event/:id/links/:linkId", manageEvents, eventManager, deleteShortLink(db, cfg, audit))
+	hra.GET("/event/:id/members/modal", manageEvents, eventOwner, getMembersModal(svc, db))
+	hra.POST("/event/:id/members", manageEvents, eventOwner, inviteMember(db, audit))
+	hra.POST("/event/:id/members/:memberId/role", manageEvents, eventOwner, setMemberRole(db, audit))
+	hra.DELETE("/event/:id/members/:memberId", manageEvents, eventOwner, deleteMember(db, audit))
+
+	// invites can only be answered when logged in, so a token can't join its user to events
+	hra.POST("/event/:id/invite", sessionRequired, acceptInvite(db))
+	hra.DELETE("/event/:id/invite", sessionRequired, leaveEvent(db))
+
+	// tokens can only be managed when logged in, so a token can't mint more tokens
+	hra.GET("/profile/tokens", sessionRequired, getApiTokens(db))
+	hra.POST("/profile/tokens", sessionRequired, createApiToken(db))
+	hra.DELETE("/profile/tokens/:tokenId", sessionRequired, deleteApiToken(db))
+
+	hra.GET("/profile/webhooks", sessionRequired, getWebhooks(db))
+	hra.POST("/profile/webhooks", sessionRequired, createWebhook(db, webhooks))
+	hra.DELETE("/profile/webhooks/:webhookId", sessionRequired, deleteWebhook(db))
+	hra.GET("/profile/webhooks/:webhookId/deliveries", sessionRequired, getWebhookDeliveries(db))
+
+	admin := hra.Group("/admin", sessionRequired, middleware.AdminRequired())
+	admin.GET("", getAdmin(db, settings))
+	admin.POST("/users/:userId/disable", setUserDisabled(db, audit, true))
+	admin.POST("/users/:userId/enable", setUserDisabled(db, audit, false))
+	admin.POST("/users/:userId/password", resetUserPassword(db, audit))
+	admin.DELETE("/users/:userId", deleteUser(db, audit))
+	admin.POST("/events/:eventId/transfer", transferEvent(db, settings, audit))
+	admin.POST("/settings/signups", setSignups(settings, audit))
+	admin.GET("/audit", getAdminAudit(audit))
+	admin.GET("/audit/export", exportAdminAudit(audit))
 
 	// public view
-	hr.GET("/login", authRedirectMiddleware, getLoginForm())
-	if !cfg.Server.SingleEventMode && !cfg.Server.DisableSignups {
-		showRegister = true
-		hr.GET("/register", authRedirectMiddleware, getRegisterForm())
-	}
-	hr.GET("/event/:id", getEvent(svc))
-	hr.GET("/thumbnails/:id", getThumbnails(svc))
+	hr.GET("/login", authRedirectMiddleware, getLoginForm(cfg.Oidc, settings))
+	hr.GET("/register", authRedirectMiddleware, getRegisterForm(settings))
+	hr.GET("/forgot-password", authRedirectMiddleware, getForgotPassword())
+	hr.POST("/forgot-password", rateLimit("passwordreset", middleware.ByIP), postForgotPassword(accounts))
+	hr.GET("/reset-password", getResetPassword())
+	hr.POST("/reset-password", rateLimit("passwordreset", middleware.ByIP), postResetPassword(accounts))
+	hr.GET("/event/:id", getEvent(svc, guest))
+	hr.GET("/event/:id/cover", getEventCover(svc, guest))
+	hr.GET("/event/:id/login", getEventLogin(svc))
+	hr.GET("/e/:code", rateLimit("shortlink", middleware.ByIP), followShortLink(db, cfg))
+	hr.POST("/event/:id/login", rateLimit("guestlogin", middleware.ByIP, middleware.ByEvent("id")), postEventLogin(guest))
+	hr.GET("/thumbnails/:id", getThumbnails(svc, guest))
 	hr.POST("/contact", postContactForm(&http.Client{}, cfg.Server.FormbeeKey))
 	hr.POST("/validate/createEvent/slug", postValidateCreateEventSlug(validator))
 
@@ -148,7 +259,7 @@ func handleUi(r *gin.Engine, htmx *htmx.HTMX, db db.DB, svc service.EventpixServ
 	hr.GET("/sse", broker.ServeHTTP)
 	thumbnailTmpl := template.Must(template.ParseFS(content, "assets/templates/thumbnail.html"))
 	go func() {
-		sub, err := nc.Subscribe("new-thumbnail", func(msg *nats.Msg) {
+		sub, err := nc.Subscribe(service.SubjectNewThumbnail, func(msg *nats.Msg) {
 			ti, err := svc.GetThumbnailInfo(context.Background(), string(msg.Data))
 			if err != nil {
 				log.Printf("error getting thumbnail info: %v", err)
@@ -189,21 +300,10 @@ func deleteEvent(svc service.EventpixService) gin.HandlerFunc {
 }
 
 func createEvent(svc service.EventpixService, htmx *htmx.HTMX) gin.HandlerFunc {
-	bind := func(c *gin.Context, m proto.Message) error {
-		if c.Request == nil || c.Request.Body == nil {
-			return errors.New("invalid request")
-		}
-		b, err := io.ReadAll(c.Request.Body)
-		if err != nil {
-			return errors.New("reading body")
-		}
-		return protojson.Unmarshal(b, m)
-	}
-
 	return func(c *gin.Context) {
 		h := htmx.NewHandler(c.Writer, c.Request)
 		var req = new(picturev1.CreateEventRequest)
-		if err := bind(c, req); err != nil {
+		if err := bindProtojson(c, req); err != nil {
 			AbortWithError(c, http.StatusUnprocessableEntity, err)
 			return
 		}
@@ -221,7 +321,16 @@ func createEvent(svc service.EventpixService, htmx *htmx.HTMX) gin.HandlerFunc {
 
 		resp, err := svc.CreateEvent(c, user.ID, req)
 		if err != nil {
-			AbortWithError(c, http.StatusInternalServerError, err)
+			code := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, service.ErrNotEventOwner):
+				code = http.StatusForbidden
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				code = http.StatusNotFound
+			case errors.Is(err, service.ErrEncryptedPresigned):
+				code = http.StatusUnprocessableEntity
+			}
+			AbortWithError(c, code, err)
 			return
 		}
 
@@ -253,11 +362,75 @@ func setEventLive(svc service.EventpixService, cfg *config.Server) gin.HandlerFu
 			return
 		}
 
-		c.HTML(200, "eventRow", gin.H{"event": evt.GetEvent(), "config": cfg})
+		event := evt.GetEvent()
+		event.Role = c.GetString(middleware.EventRoleKey)
+		c.HTML(200, "eventRow", gin.H{"event": event, "config": cfg})
 	}
 }
 
-func getThumbnails(svc service.EventpixService) gin.HandlerFunc {
+func getEventStorageModal(svc service.EventpixService, migrator *service.StorageMigrator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		event, err := svc.GetEvent(c, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: eventId}})
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		migration, err := migrator.GetStorageMigration(c, &picturev1.GetStorageMigrationRequest{EventId: eventId})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.HTML(http.StatusOK, "storageModal", gin.H{
+			"event":        event.GetEvent(),
+			"migration":    migration,
+			"storageTypes": storageTypes(user),
+		})
+	}
+}
+
+func migrateEventStorage(migrator *service.StorageMigrator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req = new(picturev1.MigrateEventStorageRequest)
+		if err := bindProtojson(c, req); err != nil {
+			AbortWithError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		req.EventId = c.MustGet("eventId").(uint64)
+
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		if req.GetGoogleDrive() != nil && user.GoogleDriveToken == nil {
+			AbortWithError(c, http.StatusUnprocessableEntity, errors.New("google drive integration not setup for user"))
+			return
+		}
+
+		migration, err := migrator.Start(c, req)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.HTML(http.StatusAccepted, "storageMigration", gin.H{"migration": migration})
+	}
+}
+
+func getStorageMigration(migrator *service.StorageMigrator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventId := c.MustGet("eventId").(uint64)
+		migration, err := migrator.GetStorageMigration(c, &picturev1.GetStorageMigrationRequest{EventId: eventId})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.HTML(http.StatusOK, "storageMigration", gin.H{"migration": migration})
+	}
+}
+
+func getThumbnails(svc service.EventpixService, guest *middleware.Guest) gin.HandlerFunc {
 	limit := int64(20)
 	return func(c *gin.Context) {
 		h := c.MustGet(middleware.HtmxKey).(*htmx.Handler)
@@ -268,6 +441,15 @@ func getThumbnails(svc service.EventpixService) gin.HandlerFunc {
 			return
 		}
 
+		event, err := svc.GetEvent(c, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: eventId}})
+		if err != nil {
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		if _, ok := authorizeGuest(c, guest, event.GetEvent(), auth.GuestView); !ok {
+			return
+		}
+
 		qpage := c.DefaultQuery("page", "0")
 		page, err := strconv.ParseInt(qpage, 10, 64)
 		if err != nil {
@@ -346,89 +528,40 @@ func postContactForm(client *http.Client, apiKey string) gin.HandlerFunc {
 	}
 }
 
-func getEventQrModal(svc service.EventpixService) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		eventId := c.MustGet("eventId").(uint64)
-		event, err := svc.GetEvent(c, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: eventId}})
-		if err != nil {
-			AbortWithError(c, http.StatusInternalServerError, err)
-			return
-		}
-
-		c.HTML(http.StatusOK, "qrModal", gin.H{
-			"event": event.GetEvent(),
-		})
-	}
-}
-
-func getQrCode(cfg *config.Config, svc service.EventpixService) gin.HandlerFunc {
-	type request struct {
-		Size            int    `form:"size"`
-		Foreground      string `form:"foreground"`
-		Background      string `form:"background"`
-		IncludePassword string `form:"includePassword"`
-	}
-
+func getEventQrModal(svc service.EventpixService, d db.DB, cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req request
-		if err := c.Bind(&req); err != nil {
-			AbortWithError(c, http.StatusUnprocessableEntity, err)
-			return
-		}
-
 		eventId := c.MustGet("eventId").(uint64)
 		event, err := svc.GetEvent(c, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: eventId}})
 		if err != nil {
 			AbortWithError(c, http.StatusInternalServerError, err)
 			return
 		}
-
-		eventUrlString := fmt.Sprintf("%s/event/%d", cfg.Server.ServerUrl, eventId)
-
-		eventUrl, err := url.Parse(eventUrlString)
-		if err != nil {
-			AbortWithError(c, http.StatusInternalServerError, err)
-			return
-		}
-
-		// optionally encode basic auth into QR code so guest can scan straight into event
-		if req.IncludePassword == "on" && event.GetEvent().GetPassword() != nil {
-			eventUrl.User = url.UserPassword("guest", event.GetEvent().GetPassword().GetValue())
-		}
-
-		q, err := qrcode.New(eventUrl.String(), qrcode.Medium)
+		guestTokens, err := d.GetGuestTokens(c, uint(eventId))
 		if err != nil {
 			AbortWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 
-		foreground, err := hexcolor.Parse(req.Foreground)
-		if err != nil {
-			AbortWithError(c, http.StatusUnprocessableEntity, err)
-			return
-		}
-		background, err := hexcolor.Parse(req.Background)
-		if err != nil {
-			AbortWithError(c, http.StatusUnprocessableEntity, err)
-			return
-		}
-		q.ForegroundColor = foreground
-		q.BackgroundColor = background
-
-		png, err := q.PNG(req.Size)
+		shortLinks, err := shortLinkViews(c, d, cfg.Server, eventId, guestTokens)
 		if err != nil {
 			AbortWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 
-		b64 := base64.StdEncoding.EncodeToString(png)
-
-		c.String(http.StatusOK, `<img id="eventQrCode" class="mx-auto" src="data:image/png;base64, %s" alt="QR code for %s" />`, b64, eventUrl)
-
+		c.HTML(http.StatusOK, "qrModal", gin.H{
+			"event":       event.GetEvent(),
+			"eventId":     eventId,
+			"guestTokens": guestTokens,
+			"shortLinks":  shortLinks,
+			"canManage":   canManageEvent(c),
+			"layouts":     []string{qr.LayoutPoster, qr.LayoutCard, qr.LayoutSheet},
+			"cards":       qr.CardsPerSheet,
+			"widths":      []int{defaultQrSize, 2048, maxQrSize},
+		})
 	}
 }
 
-func getActiveEvent(svc service.EventpixService) gin.HandlerFunc {
+func getActiveEvent(svc service.EventpixService, guest *middleware.Guest) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		event, err := svc.GetActiveEvent(c, &picturev1.GetActiveEventRequest{})
 		if err != nil {
@@ -441,10 +574,18 @@ func getActiveEvent(svc service.EventpixService) gin.HandlerFunc {
 			}
 		}
 
-		if pwd := event.GetEvent().GetPassword(); pwd != nil {
-			gin.BasicAuth(gin.Accounts{"guest": pwd.GetValue()})(c)
+		capability, ok := authorizeGuest(c, guest, event.GetEvent(), auth.GuestView)
+		if !ok {
+			return
 		}
-		c.HTML(http.StatusOK, "eventGallery", gin.H{"title": event.Event.Name, "event": event.Event})
+		// only the events stats miss out if the visit isn't recorded
+		_ = guest.Visit(c, event.GetEvent().GetId())
+		c.HTML(http.StatusOK, "eventGallery", gin.H{
+			"title":     event.Event.Name,
+			"event":     event.Event,
+			"style":     galleryStyle(event.Event.GetBranding()),
+			"canUpload": auth.GuestCan(capability, auth.GuestUpload),
+		})
 	}
 }
 
@@ -471,13 +612,13 @@ func setActiveEvent(svc service.EventpixService) gin.HandlerFunc {
 
 // === PAGES ===
 
-func getIndex() gin.HandlerFunc {
+func getIndex(settings *service.Settings) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.HTML(http.StatusOK, "index", gin.H{
 			"title":        "eventpix",
 			"features":     templatedata.IndexFeatures,
 			"pricing":      templatedata.IndexPriceTiers,
-			"showRegister": showRegister,
+			"showRegister": settings.SignupsEnabled(),
 			"nav": gin.H{
 				"dark": true,
 				"items": []gin.H{
@@ -502,9 +643,25 @@ func getIndex() gin.HandlerFunc {
 	}
 }
 
-func getProfile(oauthCfg *config.OauthSecrets) gin.HandlerFunc {
+// sign in provider on the profile page, with the users identity there if they've linked one
+type oidcProviderView struct {
+	*config.OidcProvider
+	Identity *db.OidcIdentity
+}
+
+func getProfile(d db.DB, oauthCfg *config.OauthSecrets, oidcProviders []*config.OidcProvider, settings *service.Settings) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		tokens, err := d.GetApiTokens(c, user.ID)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		data, err := webhooksData(c, d)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
 		var googleToken oauth2.Token
 		if user.GoogleDriveToken != nil {
 			googleTokenRaw, _ := base64.StdEncoding.DecodeString(user.GoogleDriveToken.Token.Raw.(string))
@@ -514,41 +671,131 @@ func getProfile(oauthCfg *config.OauthSecrets) gin.HandlerFunc {
 				return
 			}
 		}
-		c.HTML(200, "profile", gin.H{
-			"title":        "Profile",
-			"user":         user,
-			"googleToken":  googleToken,
-			"oauthConfig":  oauthCfg,
-			"showRegister": showRegister,
-			"nav": gin.H{
-				"dark": true,
-				"items": []gin.H{
-					{
-						"name": "Events",
-						"href": "/events",
-					},
-					{
-						"name":         "Profile",
-						"href":         "/profile",
-						"active":       true,
-						"userRequired": true,
-					},
-					{
-						"name":         "Logout",
-						"href":         "/auth/logout",
-						"userRequired": true,
-					},
+		providers := lo.Map(oidcProviders, func(p *config.OidcProvider, _ int) oidcProviderView {
+			identity, _ := lo.Find(user.OidcIdentities, func(i db.OidcIdentity) bool { return i.Provider == p.Name })
+			return oidcProviderView{OidcProvider: p, Identity: lo.Ternary(identity.ID != 0, &identity, nil)}
+		})
+		data["title"] = "Profile"
+		data["user"] = user
+		data["oidcProviders"] = providers
+		data["googleToken"] = googleToken
+		data["oauthConfig"] = oauthCfg
+		data["tokens"] = tokens
+		data["scopes"] = auth.Scopes
+		data["showRegister"] = settings.SignupsEnabled()
+		data["nav"] = gin.H{
+			"dark": true,
+			"items": []gin.H{
+				{
+					"name": "Events",
+					"href": "/events",
+				},
+				{
+					"name":         "Profile",
+					"href":         "/profile",
+					"active":       true,
+					"userRequired": true,
+				},
+				{
+					"name":          "Admin",
+					"href":          "/admin",
+					"adminRequired": true,
+				},
+				{
+					"name":         "Logout",
+					"href":         "/auth/logout",
+					"userRequired": true,
 				},
 			},
-		})
+		}
+		c.HTML(200, "profile", data)
+	}
+}
+
+func getApiTokens(d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		tokens, err := d.GetApiTokens(c, user.ID)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.HTML(http.StatusOK, "apiTokens", gin.H{"tokens": tokens, "scopes": auth.Scopes})
+	}
+}
+
+func createApiToken(d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		name := strings.TrimSpace(c.PostForm("name"))
+		if name == "" {
+			AbortWithError(c, http.StatusUnprocessableEntity, errors.New("token name is required"))
+			return
+		}
+		scopes := c.PostFormArray("scopes")
+		if len(scopes) == 0 {
+			AbortWithError(c, http.StatusUnprocessableEntity, errors.New("token must have at least one scope"))
+			return
+		}
+		for _, scope := range scopes {
+			if !auth.ValidScope(scope) {
+				AbortWithError(c, http.StatusUnprocessableEntity, fmt.Errorf("unknown scope %s", scope))
+				return
+			}
+		}
+
+		token, hash, err := auth.NewApiToken()
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		if err := d.CreateApiToken(c, &db.ApiToken{
+			UserID: user.ID,
+			Name:   name,
+			Hash:   hash,
+			Prefix: token[:len(auth.ApiTokenPrefix)+6],
+			Scopes: strings.Join(lo.Uniq(scopes), ","),
+		}); err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		tokens, err := d.GetApiTokens(c, user.ID)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		// the token is only shown this once, only its hash is kept
+		c.HTML(http.StatusCreated, "apiTokens", gin.H{"tokens": tokens, "scopes": auth.Scopes, "newToken": token})
+	}
+}
+
+func deleteApiToken(d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		tokenId, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := d.DeleteApiToken(c, user.ID, uint(tokenId)); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				AbortWithError(c, http.StatusNotFound, errors.New("token not found"))
+				return
+			}
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Status(http.StatusOK)
 	}
 }
 
-func getLoginForm() gin.HandlerFunc {
+func getLoginForm(oidcProviders []*config.OidcProvider, settings *service.Settings) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.HTML(200, "login", gin.H{
-			"title":        "Login",
-			"showRegister": showRegister,
+			"title":         "Login",
+			"showRegister":  settings.SignupsEnabled(),
+			"oidcProviders": oidcProviders,
 			"nav": gin.H{
 				"dark": true,
 				"items": []gin.H{
@@ -562,11 +809,15 @@ func getLoginForm() gin.HandlerFunc {
 	}
 }
 
-func getRegisterForm() gin.HandlerFunc {
+func getRegisterForm(settings *service.Settings) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if !settings.SignupsEnabled() {
+			AbortWithError(c, http.StatusNotFound, errors.New("signups are disabled"))
+			return
+		}
 		c.HTML(200, "register", gin.H{
 			"title":        "Register",
-			"showRegister": showRegister,
+			"showRegister": settings.SignupsEnabled(),
 			"nav": gin.H{
 				"dark": true,
 				"items": []gin.H{
@@ -580,23 +831,30 @@ func getRegisterForm() gin.HandlerFunc {
 	}
 }
 
-func getCreateEvent() gin.HandlerFunc {
+func getCreateEvent(svc service.EventpixService, settings *service.Settings) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := c.MustGet(gin.AuthUserKey).(*db.User)
-		stTypes := []struct {
-			Name     string
-			Value    string
-			Disabled bool
-		}{
-			{Name: "Filesystem", Value: "filesystem"},
-			{Name: "S3", Value: "s3"},
-			{Name: "Ftp", Value: "ftp"},
-			{Name: "Google", Value: "google", Disabled: user.GoogleDriveToken == nil},
+		templates, err := svc.GetEventTemplates(c, user.ID, &picturev1.GetEventTemplatesRequest{})
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		events, err := svc.GetEvents(c, &picturev1.GetEventsRequest{}, user.ID)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
 		}
+		// only the owner can duplicate an event, as it copies its storage credentials
+		owned := lo.Filter(events.GetEvents(), func(e *picturev1.Event, _ int) bool { return e.GetRole() == db.RoleOwner })
+
 		c.HTML(200, "createEvent", gin.H{
-			"title":        "New Event",
-			"user":         c.MustGet(gin.AuthUserKey).(*db.User),
-			"showRegister": showRegister,
+			"title":              "New Event",
+			"templates":          templates.GetTemplates(),
+			"events":             owned,
+			"from":               newEventStartFrom(c, templates.GetTemplates(), owned),
+			"user":               c.MustGet(gin.AuthUserKey).(*db.User),
+			"showRegister":       settings.SignupsEnabled(),
+			"defaultKeyTemplate": storage.DefaultKeyTemplate,
 			"nav": gin.H{
 				"dark": true,
 				"items": []gin.H{
@@ -616,12 +874,28 @@ func getCreateEvent() gin.HandlerFunc {
 					},
 				},
 			},
-			"storageTypes": stTypes,
+			"storageTypes": storageTypes(user),
 		})
 	}
 }
 
-func getEvents(svc service.EventpixService, cfg *config.Server) gin.HandlerFunc {
+type storageType struct {
+	Name     string
+	Value    string
+	Disabled bool
+}
+
+// storage types available to the user when configuring an event
+func storageTypes(user *db.User) []storageType {
+	return []storageType{
+		{Name: "Filesystem", Value: "filesystem"},
+		{Name: "S3", Value: "s3"},
+		{Name: "Ftp", Value: "ftp"},
+		{Name: "Google", Value: "google", Disabled: user.GoogleDriveToken == nil},
+	}
+}
+
+func getEvents(svc service.EventpixService, d db.DB, cfg *config.Server, settings *service.Settings) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := c.MustGet(gin.AuthUserKey).(*db.User)
 		events, err := svc.GetEvents(c, &picturev1.GetEventsRequest{}, user.ID)
@@ -629,12 +903,18 @@ func getEvents(svc service.EventpixService, cfg *config.Server) gin.HandlerFunc
 			c.AbortWithError(http.StatusInternalServerError, err)
 			return
 		}
+		invites, err := d.GetEventInvites(c, user.ID)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
 
 		c.HTML(200, "listEvents", gin.H{
 			"title":        "Events",
 			"events":       events.GetEvents(),
+			"invites":      invites,
 			"user":         user,
-			"showRegister": showRegister,
+			"showRegister": settings.SignupsEnabled(),
 			"config":       cfg,
 			"nav": gin.H{
 				"dark": true,
@@ -649,6 +929,11 @@ func getEvents(svc service.EventpixService, cfg *config.Server) gin.HandlerFunc
 						"href":         "/profile",
 						"userRequired": true,
 					},
+					{
+						"name":          "Admin",
+						"href":          "/admin",
+						"adminRequired": true,
+					},
 					{
 						"name":         "Logout",
 						"href":         "/auth/logout",
@@ -660,7 +945,7 @@ func getEvents(svc service.EventpixService, cfg *config.Server) gin.HandlerFunc
 	}
 }
 
-func getEvent(svc service.EventpixService) gin.HandlerFunc {
+func getEvent(svc service.EventpixService, guest *middleware.Guest) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		request := &picturev1.GetEventRequest{}
 		pEventId := c.Param("id")
@@ -672,16 +957,30 @@ func getEvent(svc service.EventpixService) gin.HandlerFunc {
 			request.Value = &picturev1.GetEventRequest_Id{Id: eventId}
 		}
 		event, err := svc.GetEvent(c, request)
+		if err != nil {
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
 
-		if pwd := event.GetEvent().GetPassword(); pwd != nil {
-			gin.BasicAuth(gin.Accounts{"guest": pwd.GetValue()})(c)
+		// the event was found through an old slug, send guests to the current one
+		if slug := request.GetSlug(); slug != "" && event.GetEvent().GetSlug() != "" && slug != event.GetEvent().GetSlug() {
+			location := url.URL{Path: "/event/" + event.GetEvent().GetSlug(), RawQuery: c.Request.URL.RawQuery}
+			c.Redirect(http.StatusMovedPermanently, location.String())
+			return
 		}
 
-		if err != nil {
-			c.AbortWithError(http.StatusBadRequest, err)
+		capability, ok := authorizeGuest(c, guest, event.GetEvent(), auth.GuestView)
+		if !ok {
 			return
 		}
-		c.HTML(http.StatusOK, "eventGallery", gin.H{"title": event.Event.Name, "event": event.Event})
+		// only the events stats miss out if the visit isn't recorded
+		_ = guest.Visit(c, event.GetEvent().GetId())
+		c.HTML(http.StatusOK, "eventGallery", gin.H{
+			"title":     event.Event.Name,
+			"event":     event.Event,
+			"style":     galleryStyle(event.Event.GetBranding()),
+			"canUpload": auth.GuestCan(capability, auth.GuestUpload),
+		})
 	}
 }
 
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -8,16 +9,37 @@ import (
 
 	"github.com/donseba/go-htmx"
 	"github.com/gin-gonic/gin"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/server/middleware"
 	"github.com/jj-style/eventpix/internal/service"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
-func setupUploadRoutes(r *gin.RouterGroup, logger *zap.Logger, htmx *htmx.HTMX, svc service.EventpixService) {
-	r.POST("", handleUpload(logger.Sugar(), htmx, svc))
+func setupUploadRoutes(r *gin.RouterGroup, logger *zap.Logger, htmx *htmx.HTMX, svc service.EventpixService, guest *middleware.Guest) {
+	r.POST("", handleUpload(logger.Sugar(), htmx, svc, guest))
+	r.POST("/presign", handlePresignUpload(svc, guest))
+	r.POST("/complete", handleCompleteUpload(svc, guest))
 }
 
-func handleUpload(log *zap.SugaredLogger, htmx *htmx.HTMX, svc service.EventpixService) gin.HandlerFunc {
+// checks the guest is allowed to upload to the event
+func authorizeUpload(c *gin.Context, svc service.EventpixService, guest *middleware.Guest, eventId uint64) bool {
+	event, err := svc.GetEvent(c, &picturev1.GetEventRequest{Value: &picturev1.GetEventRequest_Id{Id: eventId}})
+	if err != nil {
+		AbortWithError(c, http.StatusNotFound, err)
+		return false
+	}
+	_, ok := authorizeGuest(c, guest, event.GetEvent(), auth.GuestUpload)
+	return ok
+}
+
+// who's uploading, for the events stats
+func uploaderContext(c *gin.Context, guest *middleware.Guest) context.Context {
+	return service.WithUploader(c, service.Uploader{Session: guest.Session(c), Name: c.GetString(middleware.GuestLinkKey)})
+}
+
+func handleUpload(log *zap.SugaredLogger, htmx *htmx.HTMX, svc service.EventpixService, guest *middleware.Guest) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		h := htmx.NewHandler(c.Writer, c.Request)
 
@@ -36,6 +58,10 @@ func handleUpload(log *zap.SugaredLogger, htmx *htmx.HTMX, svc service.EventpixS
 			AbortWithError(c, http.StatusBadRequest, fmt.Errorf("parsing eventId: %v", err))
 			return
 		}
+		if !authorizeUpload(c, svc, guest, eventId) {
+			return
+		}
+		ctx := uploaderContext(c, guest)
 		var g errgroup.Group
 		for _, file := range form.File["files"] {
 			file := file
@@ -46,7 +72,7 @@ func handleUpload(log *zap.SugaredLogger, htmx *htmx.HTMX, svc service.EventpixS
 					return err
 				}
 				defer f.Close()
-				return svc.Upload(c, eventId, file.Filename, f, file.Header.Get("Content-Type"))
+				return svc.Upload(ctx, eventId, file.Filename, f, file.Header.Get("Content-Type"))
 			})
 		}
 		if err := g.Wait(); err != nil {
@@ -58,3 +84,46 @@ func handleUpload(log *zap.SugaredLogger, htmx *htmx.HTMX, svc service.EventpixS
 		}
 	}
 }
+
+// Hands out a URL for the client to upload a file straight to the events storage
+func handlePresignUpload(svc service.EventpixService, guest *middleware.Guest) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req = new(picturev1.PresignUploadRequest)
+		if err := bindProtojson(c, req); err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if !authorizeUpload(c, svc, guest, req.GetEventId()) {
+			return
+		}
+		resp, err := svc.PresignUpload(c, req)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// Adds a file the client uploaded straight to the events storage to the event
+func handleCompleteUpload(svc service.EventpixService, guest *middleware.Guest) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req = new(picturev1.CompleteUploadRequest)
+		if err := bindProtojson(c, req); err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if !authorizeUpload(c, svc, guest, req.GetEventId()) {
+			return
+		}
+		if _, err := svc.CompleteUpload(uploaderContext(c, guest), req); err != nil {
+			if errors.Is(err, service.ErrUploadNotPresigned) {
+				AbortWithError(c, http.StatusForbidden, err)
+				return
+			}
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
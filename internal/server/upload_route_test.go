@@ -2,6 +2,7 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"embed"
 	"errors"
 	"io"
@@ -10,10 +11,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/donseba/go-htmx"
 	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/server/middleware"
 	mockService "github.com/jj-style/eventpix/internal/service/mocks"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -28,7 +35,9 @@ func TestUploadRoute(t *testing.T) {
 	is := require.New(t)
 	router := gin.Default()
 	msvc := mockService.NewMockEventpixService(t)
-	setupUploadRoutes(router.Group("/upload"), zap.NewNop(), htmx.New(), msvc)
+	mdb := mockdb.NewMockDB(t)
+	setupUploadRoutes(router.Group("/upload"), zap.NewNop(), htmx.New(), msvc, middleware.NewGuest("secret", false, mdb))
+	expectOpenEvents(msvc)
 
 	t.Run("missing eventId", func(t *testing.T) {
 		t.Parallel()
@@ -93,6 +102,124 @@ func TestUploadRoute(t *testing.T) {
 
 		is.Equal(500, w.Code)
 	})
+
+	t.Run("password protected", func(t *testing.T) {
+		t.Parallel()
+
+		msvc.EXPECT().
+			GetEvent(mock.Anything, mock.MatchedBy(func(req *picturev1.GetEventRequest) bool { return req.GetId() == 3 })).
+			Return(&picturev1.GetEventResponse{Event: &picturev1.Event{Id: 3, PasswordProtected: true}}, nil)
+		mdb.EXPECT().
+			GetGuestToken(mock.Anything, uint(3), uint(4)).
+			Return(&db.GuestToken{EventID: 3, Capability: auth.GuestView}, nil)
+		mdb.EXPECT().
+			GetGuestToken(mock.Anything, uint(3), uint(5)).
+			Return(&db.GuestToken{EventID: 3, Capability: auth.GuestUpload}, nil)
+		msvc.EXPECT().Upload(mock.Anything, uint64(3), "0.jpg", mock.Anything, "application/octet-stream").Return(nil)
+
+		upload := func(query string, cookie *http.Cookie) int {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			writer.WriteField("eventId", "3")
+			multipartFilesUpload(t, writer, "files", testData, []string{"test/data/0.jpg"})
+			is.NoError(writer.Close())
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/upload"+query, body)
+			req.Header.Add("Content-Type", writer.FormDataContentType())
+			if cookie != nil {
+				req.AddCookie(cookie)
+			}
+			router.ServeHTTP(w, req)
+			return w.Code
+		}
+
+		is.Equal(http.StatusForbidden, upload("", nil))
+
+		viewToken, err := auth.CreateGuestToken("secret", 3, 4, auth.GuestView, nil)
+		is.NoError(err)
+		is.Equal(http.StatusForbidden, upload("", &http.Cookie{Name: auth.GuestCookieName(3), Value: viewToken}))
+
+		uploadToken, err := auth.CreateGuestToken("secret", 3, 5, auth.GuestUpload, nil)
+		is.NoError(err)
+		is.Equal(http.StatusOK, upload("?guest="+uploadToken, nil))
+	})
+}
+
+// events which aren't password protected, so anyone can upload
+func expectOpenEvents(msvc *mockService.MockEventpixService) {
+	msvc.EXPECT().
+		GetEvent(mock.Anything, mock.MatchedBy(func(req *picturev1.GetEventRequest) bool { return req.GetId() == 1 || req.GetId() == 2 })).
+		RunAndReturn(func(_ context.Context, req *picturev1.GetEventRequest) (*picturev1.GetEventResponse, error) {
+			return &picturev1.GetEventResponse{Event: &picturev1.Event{Id: req.GetId()}}, nil
+		}).
+		Maybe()
+}
+
+func TestPresignedUploadRoutes(t *testing.T) {
+	t.Parallel()
+	is := require.New(t)
+	router := gin.Default()
+	msvc := mockService.NewMockEventpixService(t)
+	setupUploadRoutes(router.Group("/upload"), zap.NewNop(), htmx.New(), msvc, middleware.NewGuest("secret", false, mockdb.NewMockDB(t)))
+	expectOpenEvents(msvc)
+
+	t.Run("happy presign", func(t *testing.T) {
+		t.Parallel()
+
+		msvc.EXPECT().
+			PresignUpload(mock.Anything, mock.MatchedBy(func(req *picturev1.PresignUploadRequest) bool {
+				return req.GetEventId() == 1 && req.GetName() == "0.jpg" && req.GetContentType() == "image/jpeg"
+			})).
+			Return(&picturev1.PresignUploadResponse{Id: "abc", Url: "https://s3.example.com/bucket/abc"}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/upload/presign", strings.NewReader(`{"eventId":1,"name":"0.jpg","contentType":"image/jpeg"}`))
+		router.ServeHTTP(w, req)
+
+		is.Equal(200, w.Code)
+		is.JSONEq(`{"id":"abc","url":"https://s3.example.com/bucket/abc"}`, w.Body.String())
+	})
+
+	t.Run("unhappy presign", func(t *testing.T) {
+		t.Parallel()
+
+		msvc.EXPECT().
+			PresignUpload(mock.Anything, mock.MatchedBy(func(req *picturev1.PresignUploadRequest) bool { return req.GetEventId() == 2 })).
+			Return(nil, errors.New("boom"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/upload/presign", strings.NewReader(`{"eventId":2,"name":"0.jpg","contentType":"image/jpeg"}`))
+		router.ServeHTTP(w, req)
+
+		is.Equal(500, w.Code)
+	})
+
+	t.Run("happy complete", func(t *testing.T) {
+		t.Parallel()
+
+		msvc.EXPECT().
+			CompleteUpload(mock.Anything, mock.MatchedBy(func(req *picturev1.CompleteUploadRequest) bool {
+				return req.GetEventId() == 1 && req.GetId() == "abc" && req.GetName() == "0.jpg"
+			})).
+			Return(&picturev1.UploadResponse{}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/upload/complete", strings.NewReader(`{"eventId":1,"id":"abc","name":"0.jpg","contentType":"image/jpeg"}`))
+		router.ServeHTTP(w, req)
+
+		is.Equal(http.StatusNoContent, w.Code)
+	})
+
+	t.Run("invalid complete", func(t *testing.T) {
+		t.Parallel()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/upload/complete", strings.NewReader(`not json`))
+		router.ServeHTTP(w, req)
+
+		is.Equal(400, w.Code)
+	})
 }
 
 func multipartFilesUpload(t *testing.T, writer *multipart.Writer, paramName string, fs fs.FS, filenames []string) {
@@ -1,7 +1,12 @@
 package server
 
 import (
+	"errors"
+	"io"
+
 	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 func AbortWithStatus(c *gin.Context, code int) {
@@ -14,3 +19,15 @@ func AbortWithError(c *gin.Context, code int, err error) {
 	c.Error(err)
 	c.Abort()
 }
+
+// binds the JSON request body into the proto message
+func bindProtojson(c *gin.Context, m proto.Message) error {
+	if c.Request == nil || c.Request.Body == nil {
+		return errors.New("invalid request")
+	}
+	b, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return errors.New("reading body")
+	}
+	return protojson.Unmarshal(b, m)
+}
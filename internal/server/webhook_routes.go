@@ -0,0 +1,117 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/service"
+	"gorm.io/gorm"
+)
+
+// how many of the latest deliveries to show for a webhook
+const webhookDeliveriesShown = 50
+
+func getWebhooks(d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		data, err := webhooksData(c, d)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.HTML(http.StatusOK, "webhooks", data)
+	}
+}
+
+func createWebhook(d db.DB, webhooks *service.WebhookDispatcher) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		var eventId *uint
+		if pEventId := c.PostForm("eventId"); pEventId != "" {
+			id, err := strconv.ParseUint(pEventId, 10, 64)
+			if err != nil {
+				AbortWithError(c, http.StatusBadRequest, err)
+				return
+			}
+			eventId = new(uint)
+			*eventId = uint(id)
+		}
+
+		_, secret, err := webhooks.CreateWebhook(c, user.ID, eventId, c.PostForm("url"), c.PostFormArray("subjects"))
+		if err != nil {
+			AbortWithError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		data, err := webhooksData(c, d)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		// the secret is shown once, so the receiver can be set up to check signatures
+		data["newSecret"] = secret
+		c.HTML(http.StatusCreated, "webhooks", data)
+	}
+}
+
+func deleteWebhook(d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		webhookId, err := strconv.ParseUint(c.Param("webhookId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := d.DeleteWebhook(c, user.ID, uint(webhookId)); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				AbortWithError(c, http.StatusNotFound, errors.New("webhook not found"))
+				return
+			}
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+}
+
+func getWebhookDeliveries(d db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(gin.AuthUserKey).(*db.User)
+		webhookId, err := strconv.ParseUint(c.Param("webhookId"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		deliveries, err := d.GetWebhookDeliveries(c, user.ID, uint(webhookId), webhookDeliveriesShown)
+		if err != nil {
+			AbortWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.HTML(http.StatusOK, "webhookDeliveries", gin.H{"webhookId": webhookId, "deliveries": deliveries})
+	}
+}
+
+// everything needed to render the user's webhooks and the form to create one
+func webhooksData(c *gin.Context, d db.DB) (gin.H, error) {
+	user := c.MustGet(gin.AuthUserKey).(*db.User)
+	webhooks, err := d.GetWebhooks(c, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := d.GetEvents(c, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	eventNames := make(map[uint]string, len(events))
+	for _, evt := range events {
+		eventNames[evt.ID] = evt.Name
+	}
+	return gin.H{
+		"webhooks":   webhooks,
+		"events":     events,
+		"eventNames": eventNames,
+		"subjects":   service.WebhookSubjects,
+	}, nil
+}
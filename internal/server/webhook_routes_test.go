@@ -0,0 +1,78 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestWebhookRoutes(t *testing.T) {
+	t.Parallel()
+
+	mdb := mockdb.NewMockDB(t)
+	router := newTestRouter()
+	router.Use(func(c *gin.Context) {
+		c.Set(gin.AuthUserKey, &db.User{Model: gorm.Model{ID: 1}})
+	})
+	router.POST("/profile/webhooks", createWebhook(mdb, service.NewWebhookDispatcher(mdb, nil, zap.NewNop())))
+	router.GET("/profile/webhooks/:webhookId/deliveries", getWebhookDeliveries(mdb))
+
+	eventId := uint(2)
+	mdb.EXPECT().
+		GetEvents(mock.Anything, uint(1)).
+		Return([]*db.Event{{Model: gorm.Model{ID: 2}, Name: "party"}}, nil).
+		Maybe()
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb.EXPECT().
+			UserAuthorizedForEvent(mock.Anything, uint(1), eventId, db.RoleManager).
+			Return(true, nil)
+		mdb.EXPECT().
+			CreateWebhook(mock.Anything, mock.Anything).
+			Return(nil)
+		mdb.EXPECT().
+			GetWebhooks(mock.Anything, uint(1)).
+			Return([]*db.Webhook{{Model: gorm.Model{ID: 3}, EventID: &eventId, Url: "https://example.com", Subjects: "new-photo"}}, nil)
+
+		// an address rather than a host name, so it's checked without looking it up
+		form := url.Values{"url": {"https://203.0.113.10/hooks"}, "eventId": {"2"}, "subjects": {"new-photo"}}
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/profile/webhooks", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+
+		is.Equal(http.StatusCreated, w.Code)
+		is.Contains(w.Body.String(), "whsec_")
+		is.Contains(w.Body.String(), "<td>party</td>")
+	})
+
+	t.Run("deliveries", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb.EXPECT().
+			GetWebhookDeliveries(mock.Anything, uint(1), uint(4), webhookDeliveriesShown).
+			Return([]*db.WebhookDelivery{{Subject: "new-photo", Status: db.DeliveryFailed, Attempts: 5, StatusCode: 500}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/profile/webhooks/4/deliveries", nil)
+		router.ServeHTTP(w, req)
+
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Body.String(), "failed")
+	})
+}
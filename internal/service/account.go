@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/data/storage"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/mailer"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrWrongPassword is returned when the password a user confirmed an action with isn't theirs
+	ErrWrongPassword = errors.New("wrong password")
+	// ErrInvalidResetToken is returned resetting a password with a link which has expired or already been used
+	ErrInvalidResetToken = errors.New("password reset link is invalid or has expired")
+	// ErrInvalidProfile is returned updating a users profile with a missing username or malformed email
+	ErrInvalidProfile = errors.New("invalid profile")
+)
+
+// Accounts lets users look after their own account: renaming it, changing or resetting
+// their password, and deleting it along with their events.
+type Accounts struct {
+	db        db.DB
+	svc       EventpixService
+	mailer    mailer.Mailer
+	log       *zap.SugaredLogger
+	audit     *Auditor
+	secretKey string
+	serverUrl string
+}
+
+func NewAccounts(cfg *config.Config, d db.DB, svc EventpixService, m mailer.Mailer, logger *zap.Logger, audit *Auditor) *Accounts {
+	return &Accounts{
+		db:        d,
+		svc:       svc,
+		mailer:    m,
+		log:       logger.Sugar(),
+		audit:     audit,
+		secretKey: cfg.Server.SecretKey,
+		serverUrl: cfg.Server.ServerUrl,
+	}
+}
+
+// UpdateProfile changes the users username and email, which can be left empty
+func (a *Accounts) UpdateProfile(ctx context.Context, user *db.User, username, email string) error {
+	username = strings.TrimSpace(username)
+	email = strings.TrimSpace(email)
+	if username == "" {
+		return fmt.Errorf("%w: username is required", ErrInvalidProfile)
+	}
+	if email != "" {
+		addr, err := mail.ParseAddress(email)
+		if err != nil || addr.Address != email {
+			return fmt.Errorf("%w: invalid email %s", ErrInvalidProfile, email)
+		}
+	}
+	if err := a.db.UpdateUser(ctx, user.ID, username, email); err != nil {
+		return err
+	}
+	a.audit.Record(ctx, Audit{
+		Action: AuditProfileUpdate,
+		Target: UserTarget(user.ID),
+		Before: map[string]any{"username": user.Username, "email": user.Email},
+		After:  map[string]any{"username": username, "email": email},
+	})
+	return nil
+}
+
+// ChangePassword sets a new password for the user, who has to know their current one if they have one.
+// They are logged out everywhere, so need a new session.
+func (a *Accounts) ChangePassword(ctx context.Context, user *db.User, current, password string) error {
+	if !a.confirmPassword(user, current) {
+		return ErrWrongPassword
+	}
+	if err := a.db.ResetUserPassword(ctx, user.ID, password); err != nil {
+		return err
+	}
+	a.audit.Record(ctx, Audit{Action: AuditPasswordChange, Target: UserTarget(user.ID)})
+	return nil
+}
+
+// RequestPasswordReset sends a link to reset their password to the user with the username or email.
+// Users without an email have the link logged for an admin to pass on instead.
+// Nothing says whether the user exists, so it can't be used to find out who has an account.
+func (a *Accounts) RequestPasswordReset(ctx context.Context, usernameOrEmail string) error {
+	user, err := a.db.FindUser(ctx, strings.TrimSpace(usernameOrEmail))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			a.log.Infof("password reset asked for unknown user %s", usernameOrEmail)
+			return nil
+		}
+		return err
+	}
+	if user.Disabled {
+		a.log.Infof("password reset asked for disabled user %s", user.Username)
+		return nil
+	}
+
+	token, err := auth.CreatePasswordResetToken(a.secretKey, user.ID, user.Password)
+	if err != nil {
+		return err
+	}
+	link := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimSuffix(a.serverUrl, "/"), url.QueryEscape(token))
+	if user.Email == "" {
+		a.log.Infow("password reset asked for a user without an email, pass the link on to them", "user", user.Username, "link", link)
+		return nil
+	}
+
+	body := fmt.Sprintf(`Hi %s,
+
+Someone asked to reset the password of your eventpix account. If it was you, set a new one here:
+
+%s
+
+The link expires in %s. If it wasn't you, you can ignore this email.
+`, user.Username, link, auth.PasswordResetExpiry)
+	if err := a.mailer.Send(ctx, user.Email, "Reset your eventpix password", body); err != nil {
+		a.log.Errorf("sending password reset to user(%d): %v", user.ID, err)
+		return err
+	}
+	return nil
+}
+
+// ResetPassword sets a new password for the user the reset link was sent to, returning them
+func (a *Accounts) ResetPassword(ctx context.Context, token, password string) (*db.User, error) {
+	claims, err := auth.VerifyPasswordResetToken(a.secretKey, token)
+	if err != nil {
+		return nil, ErrInvalidResetToken
+	}
+	userId, err := claims.UserID()
+	if err != nil {
+		return nil, ErrInvalidResetToken
+	}
+	user, err := a.db.GetUserByID(ctx, userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidResetToken
+		}
+		return nil, err
+	}
+	// the password has changed since, so the link has been used
+	if user.Disabled || !claims.MatchesPassword(user.Password) {
+		return nil, ErrInvalidResetToken
+	}
+	if err := a.db.ResetUserPassword(ctx, user.ID, password); err != nil {
+		return nil, err
+	}
+	// reset with a link, so they aren't logged in
+	actor := Actor{UserID: user.ID, Username: user.Username, IP: ActorFromContext(ctx).IP}
+	a.audit.Record(WithActor(ctx, actor), Audit{Action: AuditPasswordChange, Target: UserTarget(user.ID), After: map[string]any{"reset": true}})
+	return user, nil
+}
+
+// DeleteAccount deletes the user along with every event they created, and optionally
+// the photos and videos in the events storage. Events they are a member of are kept,
+// and events with another owner are handed over to them rather than deleted.
+func (a *Accounts) DeleteAccount(ctx context.Context, user *db.User, password string, deleteMedia bool) error {
+	if !a.confirmPassword(user, password) {
+		return ErrWrongPassword
+	}
+	events, err := a.db.GetEvents(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if event.UserID != user.ID {
+			continue
+		}
+		if transferred, err := a.transferToCoOwner(ctx, event); err != nil {
+			return err
+		} else if transferred {
+			continue
+		}
+		if _, err := a.svc.DeleteEvent(ctx, &picturev1.DeleteEventRequest{Id: uint64(event.ID)}); err != nil {
+			return fmt.Errorf("deleting event(%d): %w", event.ID, err)
+		}
+	}
+	// there'll be nobody to restore them, so everything in their trash goes
+	// along with the events just put there
+	trashed, err := a.db.GetTrashedEvents(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	for _, event := range trashed {
+		if event.UserID != user.ID {
+			continue
+		}
+		if transferred, err := a.transferToCoOwner(ctx, event); err != nil {
+			return err
+		} else if transferred {
+			continue
+		}
+		event, err := a.db.GetTrashedEvent(ctx, uint64(event.ID))
+		if err != nil {
+			return fmt.Errorf("getting trashed event: %w", err)
+		}
+		if err := purgeEvent(ctx, a.db, a.log, a.audit, event, deleteMedia); err != nil {
+			return fmt.Errorf("purging event(%d): %w", event.ID, err)
+		}
+	}
+	if err := a.db.DeleteUser(ctx, user.ID); err != nil {
+		return err
+	}
+	a.audit.Record(ctx, Audit{Action: AuditAccountDelete, Target: UserTarget(user.ID), Before: map[string]any{"username": user.Username}})
+	return nil
+}
+
+// transferToCoOwner hands the event to the first of its other owners, if it has any
+func (a *Accounts) transferToCoOwner(ctx context.Context, event *db.Event) (bool, error) {
+	members, err := a.db.GetEventMembers(ctx, event.ID)
+	if err != nil {
+		return false, fmt.Errorf("getting event(%d) members: %w", event.ID, err)
+	}
+	owner, ok := lo.Find(members, func(m *db.EventMember) bool { return m.Role == db.RoleOwner && m.Accepted })
+	if !ok {
+		return false, nil
+	}
+	if err := a.db.TransferEvent(ctx, uint64(event.ID), owner.UserID); err != nil {
+		return false, fmt.Errorf("transferring event(%d): %w", event.ID, err)
+	}
+	a.audit.Record(ctx, Audit{Action: AuditEventTransfer, EventID: &event.ID, After: map[string]any{"userId": owner.UserID}})
+	return true, nil
+}
+
+// deleteEventMedia deletes what it can of the events uploads and thumbnails from its storage,
+// logging what it can't.
+func deleteEventMedia(ctx context.Context, log *zap.SugaredLogger, event *db.Event) {
+	if event.Storage == nil {
+		log.Warnf("event(%d) has no storage to delete media from", event.ID)
+		return
+	}
+	ids := make([]string, 0, len(event.FileInfos)+len(event.ThumbnailInfos))
+	for _, fi := range event.FileInfos {
+		ids = append(ids, fi.ID)
+	}
+	for _, ti := range event.ThumbnailInfos {
+		ids = append(ids, ti.ID)
+	}
+	for _, id := range ids {
+		deleted, err := storage.Delete(ctx, event.Storage, id)
+		if err != nil {
+			log.Errorf("deleting %s from event(%d) storage: %v", id, event.ID, err)
+			continue
+		}
+		if !deleted {
+			log.Warnf("event(%d) storage can't delete media, it has been left there", event.ID)
+			return
+		}
+	}
+}
+
+// users who only sign in with a provider don't have a password to confirm
+func (a *Accounts) confirmPassword(user *db.User, password string) bool {
+	return user.Password == "" || auth.ComparePassword(password, user.Password)
+}
@@ -0,0 +1,191 @@
+package service_test
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	"github.com/jj-style/eventpix/internal/data/storage"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	mockmailer "github.com/jj-style/eventpix/internal/pkg/mailer/mocks"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/service"
+	mockservice "github.com/jj-style/eventpix/internal/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+func TestAccounts(t *testing.T) {
+	t.Parallel()
+
+	hash, err := auth.EncryptPassword("hunter2hunter2")
+	require.NoError(t, err)
+	user := &db.User{Model: gorm.Model{ID: 1}, Username: "bob", Email: "bob@example.com", Password: hash}
+
+	newAccounts := func(t *testing.T) (*service.Accounts, *mockdb.MockDB, *mockservice.MockEventpixService, *mockmailer.MockMailer) {
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+		msvc := mockservice.NewMockEventpixService(t)
+		mmailer := mockmailer.NewMockMailer(t)
+		cfg := &config.Config{Server: &config.Server{SecretKey: "secret", ServerUrl: "https://pix.example.com/"}}
+		return service.NewAccounts(cfg, mdb, msvc, mmailer, zap.NewNop(), service.NewAuditor(mdb, zap.NewNop())), mdb, msvc, mmailer
+	}
+
+	t.Run("change password", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		accounts, mdb, _, _ := newAccounts(t)
+
+		is.ErrorIs(accounts.ChangePassword(t.Context(), user, "wrong password", "correct horse battery"), service.ErrWrongPassword)
+
+		mdb.EXPECT().ResetUserPassword(mock.Anything, uint(1), "correct horse battery").Return(nil)
+		is.NoError(accounts.ChangePassword(t.Context(), user, "hunter2hunter2", "correct horse battery"))
+	})
+
+	t.Run("update profile", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		accounts, mdb, _, _ := newAccounts(t)
+
+		is.ErrorIs(accounts.UpdateProfile(t.Context(), user, " ", ""), service.ErrInvalidProfile)
+		is.ErrorIs(accounts.UpdateProfile(t.Context(), user, "bob", "Bob <bob@example.com>"), service.ErrInvalidProfile)
+
+		mdb.EXPECT().UpdateUser(mock.Anything, uint(1), "robert", "robert@example.com").Return(nil)
+		is.NoError(accounts.UpdateProfile(t.Context(), user, " robert ", "robert@example.com"))
+	})
+
+	t.Run("reset password", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		accounts, mdb, _, mmailer := newAccounts(t)
+
+		var body string
+		mdb.EXPECT().FindUser(mock.Anything, "bob@example.com").Return(user, nil)
+		mmailer.EXPECT().
+			Send(mock.Anything, "bob@example.com", mock.Anything, mock.Anything).
+			Run(func(_ context.Context, _, _, b string) { body = b }).
+			Return(nil)
+		is.NoError(accounts.RequestPasswordReset(t.Context(), "bob@example.com"))
+
+		link := regexp.MustCompile(`https://pix.example.com/reset-password\?token=\S+`).FindString(body)
+		is.NotEmpty(link)
+		u, err := url.Parse(link)
+		is.NoError(err)
+		token := u.Query().Get("token")
+
+		mdb.EXPECT().GetUserByID(mock.Anything, uint(1)).Return(user, nil).Once()
+		mdb.EXPECT().ResetUserPassword(mock.Anything, uint(1), "correct horse battery").Return(nil)
+		got, err := accounts.ResetPassword(t.Context(), token, "correct horse battery")
+		is.NoError(err)
+		is.Equal("bob", got.Username)
+
+		// the password has changed, so the link can't be used again
+		changed := *user
+		changed.Password = "new hash"
+		mdb.EXPECT().GetUserByID(mock.Anything, uint(1)).Return(&changed, nil).Once()
+		_, err = accounts.ResetPassword(t.Context(), token, "another password")
+		is.ErrorIs(err, service.ErrInvalidResetToken)
+
+		_, err = accounts.ResetPassword(t.Context(), "not a token", "another password")
+		is.ErrorIs(err, service.ErrInvalidResetToken)
+	})
+
+	t.Run("reset password without email or account", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		accounts, mdb, _, _ := newAccounts(t)
+
+		// logged rather than mailed
+		mdb.EXPECT().FindUser(mock.Anything, "alice").Return(&db.User{Model: gorm.Model{ID: 2}, Username: "alice"}, nil)
+		is.NoError(accounts.RequestPasswordReset(t.Context(), "alice"))
+
+		// doesn't give away who has an account
+		mdb.EXPECT().FindUser(mock.Anything, "nobody").Return(nil, gorm.ErrRecordNotFound)
+		is.NoError(accounts.RequestPasswordReset(t.Context(), "nobody"))
+	})
+
+	t.Run("delete account", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		accounts, mdb, msvc, _ := newAccounts(t)
+
+		is.ErrorIs(accounts.DeleteAccount(t.Context(), user, "wrong password", true), service.ErrWrongPassword)
+
+		store := storage.NewMemStore()
+		for _, id := range []string{"photo", "thumb", "cover", "old"} {
+			_, err := store.Store(t.Context(), id, strings.NewReader(id))
+			is.NoError(err)
+		}
+		mdb.EXPECT().GetEvents(mock.Anything, uint(1)).Return([]*db.Event{
+			{Model: gorm.Model{ID: 3}, UserID: 1},
+			// only a member, so it's kept
+			{Model: gorm.Model{ID: 4}, UserID: 2, Role: db.RoleManager},
+		}, nil)
+		msvc.EXPECT().DeleteEvent(mock.Anything, &picturev1.DeleteEventRequest{Id: 3}).Return(&emptypb.Empty{}, nil)
+		mdb.EXPECT().GetTrashedEvents(mock.Anything, uint(1)).Return([]*db.Event{
+			{Model: gorm.Model{ID: 3}, UserID: 1},
+			// deleted before, and would otherwise be left behind
+			{Model: gorm.Model{ID: 5}, UserID: 1},
+			// they're an owner of, but didn't create
+			{Model: gorm.Model{ID: 6}, UserID: 2},
+		}, nil)
+		mdb.EXPECT().GetTrashedEvent(mock.Anything, uint64(3)).Return(&db.Event{
+			Model:          gorm.Model{ID: 3},
+			UserID:         1,
+			FileInfos:      []db.FileInfo{{ID: "photo"}},
+			ThumbnailInfos: []db.ThumbnailInfo{{ID: "thumb"}},
+			Branding:       &db.EventBranding{CoverImage: "cover"},
+			Storage:        store,
+		}, nil)
+		mdb.EXPECT().GetTrashedEvent(mock.Anything, uint64(5)).Return(&db.Event{
+			Model:     gorm.Model{ID: 5},
+			UserID:    1,
+			FileInfos: []db.FileInfo{{ID: "old"}},
+			Storage:   store,
+		}, nil)
+		// nobody else has accepted owning them
+		mdb.EXPECT().GetEventMembers(mock.Anything, uint(3)).Return([]*db.EventMember{
+			{EventID: 3, UserID: 7, Role: db.RoleManager, Accepted: true},
+			{EventID: 3, UserID: 8, Role: db.RoleOwner},
+		}, nil).Twice()
+		mdb.EXPECT().GetEventMembers(mock.Anything, uint(5)).Return(nil, nil)
+		mdb.EXPECT().PurgeEvent(mock.Anything, uint64(3)).Return(nil)
+		mdb.EXPECT().PurgeEvent(mock.Anything, uint64(5)).Return(nil)
+		mdb.EXPECT().DeleteUser(mock.Anything, uint(1)).Return(nil)
+
+		is.NoError(accounts.DeleteAccount(t.Context(), user, "hunter2hunter2", true))
+		for _, id := range []string{"photo", "thumb", "cover", "old"} {
+			_, err := store.Get(t.Context(), id)
+			is.ErrorIs(err, storage.ErrFileNotFound)
+		}
+	})
+
+	t.Run("delete account hands events to co-owners", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		accounts, mdb, _, _ := newAccounts(t)
+
+		coOwners := []*db.EventMember{
+			{UserID: 7, Role: db.RoleModerator, Accepted: true},
+			{UserID: 9, Role: db.RoleOwner, Accepted: true},
+		}
+		mdb.EXPECT().GetEvents(mock.Anything, uint(1)).Return([]*db.Event{{Model: gorm.Model{ID: 3}, UserID: 1}}, nil)
+		mdb.EXPECT().GetEventMembers(mock.Anything, uint(3)).Return(coOwners, nil)
+		mdb.EXPECT().TransferEvent(mock.Anything, uint64(3), uint(9)).Return(nil)
+		// still theirs to restore
+		mdb.EXPECT().GetTrashedEvents(mock.Anything, uint(1)).Return([]*db.Event{{Model: gorm.Model{ID: 5}, UserID: 1}}, nil)
+		mdb.EXPECT().GetEventMembers(mock.Anything, uint(5)).Return(coOwners, nil)
+		mdb.EXPECT().TransferEvent(mock.Anything, uint64(5), uint(9)).Return(nil)
+		mdb.EXPECT().DeleteUser(mock.Anything, uint(1)).Return(nil)
+
+		is.NoError(accounts.DeleteAccount(t.Context(), user, "hunter2hunter2", true))
+	})
+}
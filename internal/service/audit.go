@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"go.uber.org/zap"
+)
+
+// Actions recorded in the audit log
+const (
+	AuditEventCreate      = "event.create"
+	AuditEventUpdate      = "event.update"
+	AuditEventDelete      = "event.delete"
+	AuditEventRestore     = "event.restore"
+	AuditEventPurge       = "event.purge"
+	AuditEventLive        = "event.live"
+	AuditEventSchedule    = "event.schedule"
+	AuditEventActive      = "event.active"
+	AuditEventArchive     = "event.archive"
+	AuditEventMigrate     = "event.storage.migrate"
+	AuditEventTransfer    = "event.transfer"
+	AuditEventBranding    = "event.branding"
+	AuditMemberInvite     = "event.member.invite"
+	AuditMemberRole       = "event.member.role"
+	AuditMemberRemove     = "event.member.remove"
+	AuditGuestCreate      = "event.guest.create"
+	AuditGuestRotate      = "event.guest.rotate"
+	AuditGuestRevoke      = "event.guest.revoke"
+	AuditShortLinkCreate  = "event.link.create"
+	AuditShortLinkDelete  = "event.link.delete"
+	AuditMediaHide        = "event.media.hide"
+	AuditMediaShow        = "event.media.show"
+	AuditMediaDelete      = "event.media.delete"
+	AuditTemplateSave     = "user.template.save"
+	AuditTemplateDelete   = "user.template.delete"
+	AuditLogin            = "user.login"
+	AuditLoginFailed      = "user.login.failed"
+	AuditOidcLink         = "user.oidc.link"
+	AuditOidcUnlink       = "user.oidc.unlink"
+	AuditGoogleConnect    = "user.google.connect"
+	AuditGoogleDisconnect = "user.google.disconnect"
+	AuditProfileUpdate    = "user.update"
+	AuditPasswordChange   = "user.password.change"
+	AuditAccountDelete    = "user.delete"
+	AuditUserDisable      = "admin.user.disable"
+	AuditUserEnable       = "admin.user.enable"
+	AuditUserPassword     = "admin.user.password"
+	AuditUserDelete       = "admin.user.delete"
+	AuditSignups          = "admin.signups"
+)
+
+// Formats the audit log can be exported in
+const (
+	AuditFormatCSV  = "csv"
+	AuditFormatJSON = "json"
+)
+
+// Actor is who's making a request, for the audit log
+type Actor struct {
+	UserID   uint
+	Username string
+	IP       string
+}
+
+type actorContextKey struct{}
+
+// WithActor sets who's making requests with the context, for requests which
+// don't come through gin (which has the logged in user and address already)
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext gets who's making the request, from WithActor or the gin request
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorContextKey{}).(Actor); ok {
+		return actor
+	}
+	var actor Actor
+	if c, ok := ctx.(*gin.Context); ok {
+		actor.IP = c.ClientIP()
+		if user, ok := c.Get(gin.AuthUserKey); ok {
+			actor.UserID = user.(*db.User).ID
+			actor.Username = user.(*db.User).Username
+		}
+	}
+	return actor
+}
+
+// Audit is an action to add to the audit log
+type Audit struct {
+	Action string
+	// what it was done to, the event if not set
+	Target string
+	// event it was done to, if any
+	EventID *uint
+	// what changed, marshalled to JSON
+	Before any
+	After  any
+}
+
+// EventTarget is the audit log target for an event
+func EventTarget(eventId uint) string {
+	return "event:" + strconv.FormatUint(uint64(eventId), 10)
+}
+
+// UserTarget is the audit log target for a user
+func UserTarget(userId uint) string {
+	return "user:" + strconv.FormatUint(uint64(userId), 10)
+}
+
+// Auditor records who did what in the audit log, which is only ever added to
+type Auditor struct {
+	db  db.DB
+	log *zap.SugaredLogger
+}
+
+func NewAuditor(d db.DB, logger *zap.Logger) *Auditor {
+	return &Auditor{db: d, log: logger.Sugar()}
+}
+
+// Record adds what the actor in the context did to the audit log, with no actor it was the system.
+// Failing to record it is only logged, what was done has already been done.
+func (a *Auditor) Record(ctx context.Context, audit Audit) {
+	actor := ActorFromContext(ctx)
+	if actor.UserID == 0 && actor.Username == "" && actor.IP == "" {
+		actor.Username = "system"
+	}
+	entry := &db.AuditLog{
+		ActorID:   actor.UserID,
+		ActorName: actor.Username,
+		IP:        actor.IP,
+		Action:    audit.Action,
+		Target:    audit.Target,
+		EventID:   audit.EventID,
+		Before:    auditJSON(audit.Before),
+		After:     auditJSON(audit.After),
+	}
+	if entry.Target == "" && audit.EventID != nil {
+		entry.Target = EventTarget(*audit.EventID)
+	}
+	if err := a.db.CreateAuditLog(ctx, entry); err != nil {
+		a.log.Errorf("recording %s in audit log: %v", audit.Action, err)
+	}
+}
+
+// GetAuditLogs gets the latest entries in the audit log, only for the event if it's set
+func (a *Auditor) GetAuditLogs(ctx context.Context, eventId *uint, limit int) ([]*db.AuditLog, error) {
+	return a.db.GetAuditLogs(ctx, eventId, limit)
+}
+
+// Export writes the whole audit log, or only the events, as CSV or JSON
+func (a *Auditor) Export(ctx context.Context, w io.Writer, format string, eventId *uint) error {
+	entries, err := a.db.GetAuditLogs(ctx, eventId, 0)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case AuditFormatCSV:
+		return writeAuditCSV(w, entries)
+	case AuditFormatJSON:
+		return writeAuditJSON(w, entries)
+	default:
+		return fmt.Errorf("unknown audit log format %q", format)
+	}
+}
+
+// AuditExportContentType is the content type of an audit log export in the format
+func AuditExportContentType(format string) string {
+	if format == AuditFormatCSV {
+		return "text/csv"
+	}
+	return "application/json"
+}
+
+type auditEntry struct {
+	Time      string          `json:"time"`
+	ActorID   uint            `json:"actorId"`
+	ActorName string          `json:"actor"`
+	IP        string          `json:"ip"`
+	Action    string          `json:"action"`
+	Target    string          `json:"target"`
+	EventID   *uint           `json:"eventId,omitempty"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+}
+
+func writeAuditJSON(w io.Writer, entries []*db.AuditLog) error {
+	out := make([]auditEntry, 0, len(entries))
+	for _, e := range entries {
+		entry := auditEntry{
+			Time:      e.CreatedAt.UTC().Format(time.RFC3339),
+			ActorID:   e.ActorID,
+			ActorName: e.ActorName,
+			IP:        e.IP,
+			Action:    e.Action,
+			Target:    e.Target,
+			EventID:   e.EventID,
+		}
+		if e.Before != "" {
+			entry.Before = json.RawMessage(e.Before)
+		}
+		if e.After != "" {
+			entry.After = json.RawMessage(e.After)
+		}
+		out = append(out, entry)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func writeAuditCSV(w io.Writer, entries []*db.AuditLog) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"time", "actor_id", "actor", "ip", "action", "target", "event_id", "before", "after"}); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		eventId := ""
+		if e.EventID != nil {
+			eventId = strconv.FormatUint(uint64(*e.EventID), 10)
+		}
+		if err := cw.Write([]string{
+			e.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.FormatUint(uint64(e.ActorID), 10),
+			e.ActorName,
+			e.IP,
+			e.Action,
+			e.Target,
+			eventId,
+			e.Before,
+			e.After,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func auditJSON(v any) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestAuditor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records who did it from the request", func(t *testing.T) {
+		t.Parallel()
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, &db.AuditLog{
+			ActorID:   4,
+			ActorName: "bob",
+			IP:        "192.0.2.1",
+			Action:    service.AuditEventUpdate,
+			Target:    "event:1",
+			EventID:   lo.ToPtr(uint(1)),
+			Before:    `{"name":"weding"}`,
+			After:     `{"name":"wedding"}`,
+		}).Return(nil)
+
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("PUT", "/event/1", nil)
+		c.Request.RemoteAddr = "192.0.2.1:1234"
+		c.Set(gin.AuthUserKey, &db.User{Model: gorm.Model{ID: 4}, Username: "bob"})
+
+		audit := service.NewAuditor(mdb, zap.NewNop())
+		audit.Record(c, service.Audit{
+			Action:  service.AuditEventUpdate,
+			EventID: lo.ToPtr(uint(1)),
+			Before:  map[string]string{"name": "weding"},
+			After:   map[string]string{"name": "wedding"},
+		})
+	})
+
+	t.Run("records the actor from the context", func(t *testing.T) {
+		t.Parallel()
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, &db.AuditLog{
+			ActorID:   4,
+			ActorName: "bob",
+			IP:        "192.0.2.1",
+			Action:    service.AuditLogin,
+			Target:    "user:4",
+		}).Return(nil)
+
+		ctx := service.WithActor(t.Context(), service.Actor{UserID: 4, Username: "bob", IP: "192.0.2.1"})
+		service.NewAuditor(mdb, zap.NewNop()).Record(ctx, service.Audit{Action: service.AuditLogin, Target: service.UserTarget(4)})
+	})
+
+	t.Run("records the system with no actor", func(t *testing.T) {
+		t.Parallel()
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, &db.AuditLog{
+			ActorName: "system",
+			Action:    service.AuditEventArchive,
+			Target:    "event:2",
+			EventID:   lo.ToPtr(uint(2)),
+		}).Return(nil)
+
+		service.NewAuditor(mdb, zap.NewNop()).Record(t.Context(), service.Audit{Action: service.AuditEventArchive, EventID: lo.ToPtr(uint(2))})
+	})
+
+	entries := []*db.AuditLog{
+		{
+			CreatedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+			ActorID:   4,
+			ActorName: "bob",
+			IP:        "192.0.2.1",
+			Action:    service.AuditEventUpdate,
+			Target:    "event:1",
+			EventID:   lo.ToPtr(uint(1)),
+			Before:    `{"name":"weding"}`,
+			After:     `{"name":"wedding"}`,
+		},
+		{
+			CreatedAt: time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC),
+			ActorName: "system",
+			Action:    service.AuditEventLive,
+			Target:    "event:1",
+			EventID:   lo.ToPtr(uint(1)),
+		},
+	}
+
+	t.Run("exports csv", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().GetAuditLogs(mock.Anything, lo.ToPtr(uint(1)), 0).Return(entries, nil)
+
+		var buf bytes.Buffer
+		is.NoError(service.NewAuditor(mdb, zap.NewNop()).Export(t.Context(), &buf, service.AuditFormatCSV, lo.ToPtr(uint(1))))
+		rows, err := csv.NewReader(&buf).ReadAll()
+		is.NoError(err)
+		is.Equal([][]string{
+			{"time", "actor_id", "actor", "ip", "action", "target", "event_id", "before", "after"},
+			{"2025-06-01T12:00:00Z", "4", "bob", "192.0.2.1", "event.update", "event:1", "1", `{"name":"weding"}`, `{"name":"wedding"}`},
+			{"2025-06-01T11:00:00Z", "0", "system", "", "event.live", "event:1", "1", "", ""},
+		}, rows)
+	})
+
+	t.Run("exports json", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().GetAuditLogs(mock.Anything, (*uint)(nil), 0).Return(entries, nil)
+
+		var buf bytes.Buffer
+		is.NoError(service.NewAuditor(mdb, zap.NewNop()).Export(t.Context(), &buf, service.AuditFormatJSON, nil))
+		var got []map[string]any
+		is.NoError(json.Unmarshal(buf.Bytes(), &got))
+		is.Len(got, 2)
+		is.Equal("bob", got[0]["actor"])
+		is.Equal(map[string]any{"name": "wedding"}, got[0]["after"])
+		is.NotContains(got[1], "before")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().GetAuditLogs(mock.Anything, (*uint)(nil), 0).Return(entries, nil)
+
+		require.Error(t, service.NewAuditor(mdb, zap.NewNop()).Export(t.Context(), &bytes.Buffer{}, "xml", nil))
+	})
+}
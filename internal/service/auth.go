@@ -3,6 +3,7 @@ package service
 import (
 	"errors"
 	"net/http"
+	"sync"
 
 	"github.com/donseba/go-htmx"
 	"github.com/gin-gonic/gin"
@@ -11,15 +12,37 @@ import (
 	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
 )
 
+// ErrInvalidLogin is returned however logging in fails, so it doesn't give away which users exist
+var ErrInvalidLogin = errors.New("invalid username or password")
+
+// compared against when there's no such user, so logging in as them takes as long as a wrong password
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := auth.EncryptPassword("not anybody's password")
+	return hash
+})
+
 type AuthService struct {
-	db          db.DB
-	createToken func(username string) (string, error)
-	htmx        *htmx.HTMX
+	db        db.DB
+	sessions  *auth.Sessions
+	htmx      *htmx.HTMX
+	secretKey string
+	// OpenID Connect providers by name
+	oidc map[string]*oidcProvider
+	// whether users can sign up, or be created when they first sign in with a provider
+	settings *Settings
+	audit    *Auditor
 }
 
-func NewAuthService(cfg *config.Config, db db.DB, htmx *htmx.HTMX) *AuthService {
-	createToken := func(username string) (string, error) { return auth.CreateToken(cfg.Server.SecretKey, username) }
-	return &AuthService{db, createToken, htmx}
+func NewAuthService(cfg *config.Config, db db.DB, sessions *auth.Sessions, settings *Settings, htmx *htmx.HTMX, audit *Auditor) *AuthService {
+	return &AuthService{
+		db:        db,
+		sessions:  sessions,
+		htmx:      htmx,
+		secretKey: cfg.Server.SecretKey,
+		oidc:      newOidcProviders(cfg),
+		settings:  settings,
+		audit:     audit,
+	}
 }
 
 func (x *AuthService) Login(c *gin.Context) {
@@ -37,22 +60,47 @@ func (x *AuthService) Login(c *gin.Context) {
 
 	user, err := x.db.GetUser(c, req.Username)
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, err)
+		// take as long as checking a real users password
+		auth.ComparePassword(req.Password, dummyPasswordHash())
+		c.AbortWithError(http.StatusUnauthorized, ErrInvalidLogin)
+		return
+	}
+	if user.Locked() {
+		// looks the same as a wrong password, or telling locked accounts apart would give away
+		// which users exist
+		auth.ComparePassword(req.Password, dummyPasswordHash())
+		c.AbortWithError(http.StatusUnauthorized, ErrInvalidLogin)
 		return
 	}
 
 	if !auth.ComparePassword(req.Password, user.Password) {
-		c.AbortWithError(http.StatusBadRequest, errors.New("invalid password"))
+		if _, err := x.db.RecordLoginFailure(c, user.ID); err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		x.recordUser(c, user, AuditLoginFailed, nil)
+		c.AbortWithError(http.StatusUnauthorized, ErrInvalidLogin)
+		return
+	}
+	if user.FailedLogins > 0 {
+		if err := x.db.ResetLoginFailures(c, user.ID); err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+	}
+	if user.Disabled {
+		c.AbortWithError(http.StatusForbidden, errors.New("user disabled"))
 		return
 	}
 
-	token, err := x.createToken(user.Username)
+	token, expiresAt, err := x.sessions.Create(user.Username, user.TokenVersion)
 	if err != nil {
 		c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
 
-	c.SetCookie(auth.CookieName, token, 3600, "/", "", false, true)
+	x.sessions.SetCookie(c.Writer, token, expiresAt)
+	x.recordUser(c, user, AuditLogin, nil)
 
 	if h.IsHxRequest() {
 		h.Redirect("/events")
@@ -61,6 +109,10 @@ func (x *AuthService) Login(c *gin.Context) {
 
 func (x *AuthService) Register(c *gin.Context) {
 	h := x.htmx.NewHandler(c.Writer, c.Request)
+	if !x.settings.SignupsEnabled() {
+		c.AbortWithError(http.StatusForbidden, errors.New("signups are disabled"))
+		return
+	}
 	type registerRequest struct {
 		Username string `json:"username" binding:"required"`
 		Password string `json:"password" binding:"required"`
@@ -82,11 +134,35 @@ func (x *AuthService) Register(c *gin.Context) {
 }
 
 func (x *AuthService) Logout(c *gin.Context) {
+	x.logout(c)
+}
+
+// LogoutEverywhere revokes all the users sessions, on every device.
+// API tokens are kept and need revoking separately.
+//
+// Notes
+// Must be used after `AuthRequired`
+func (x *AuthService) LogoutEverywhere(c *gin.Context) {
+	user := c.MustGet(gin.AuthUserKey).(*db.User)
+	if err := x.db.RevokeUserSessions(c, user.ID); err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	x.logout(c)
+}
+
+func (x *AuthService) logout(c *gin.Context) {
 	h := x.htmx.NewHandler(c.Writer, c.Request)
-	c.SetCookie(auth.CookieName, "", -1, "/", "", false, true)
+	x.sessions.ClearCookie(c.Writer)
 	if h.IsHxRequest() {
 		h.Redirect("/login")
 	} else {
 		c.Redirect(http.StatusTemporaryRedirect, "/login")
 	}
 }
+
+// recordUser adds something the user did to the audit log, before they're logged in
+func (x *AuthService) recordUser(c *gin.Context, user *db.User, action string, after any) {
+	ctx := WithActor(c, Actor{UserID: user.ID, Username: user.Username, IP: c.ClientIP()})
+	x.audit.Record(ctx, Audit{Action: action, Target: UserTarget(user.ID), After: after})
+}
@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gin-gonic/gin"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// newAuthService is an auth service on a mock db, with signups left to the config
+func newAuthService(t *testing.T, cfg *config.Config) (*service.AuthService, *mockdb.MockDB) {
+	mdb := mockdb.NewMockDB(t)
+	mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+	mdb.EXPECT().GetSetting(mock.Anything, "disableSignups").Return("", gorm.ErrRecordNotFound)
+	settings, err := service.NewSettings(cfg, mdb)
+	require.NoError(t, err)
+	return service.NewAuthService(cfg, mdb, auth.NewSessions(cfg), settings, htmx.New(), service.NewAuditor(mdb, zap.NewNop())), mdb
+}
+
+func TestLogin(t *testing.T) {
+	t.Parallel()
+
+	hash, err := auth.EncryptPassword("hunter2hunter2")
+	require.NoError(t, err)
+
+	cfg := &config.Config{Server: &config.Server{SecretKey: "secret"}}
+	login := func(router *gin.Engine, username, password string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		body := `{"username":"` + username + `","password":"` + password + `"}`
+		req, _ := http.NewRequest("POST", "/auth/login", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("unknown user and wrong password look the same", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newAuthService(t, cfg)
+		router := gin.New()
+		router.POST("/auth/login", svc.Login)
+
+		mdb.EXPECT().GetUser(mock.Anything, "nobody").Return(nil, gorm.ErrRecordNotFound)
+		mdb.EXPECT().GetUser(mock.Anything, "bob").Return(&db.User{Model: gorm.Model{ID: 1}, Username: "bob", Password: hash}, nil)
+		mdb.EXPECT().RecordLoginFailure(mock.Anything, uint(1)).Return(nil, nil)
+
+		unknown := login(router, "nobody", "hunter2hunter2")
+		wrong := login(router, "bob", "wrong password")
+		is.Equal(http.StatusUnauthorized, unknown.Code)
+		is.Equal(http.StatusUnauthorized, wrong.Code)
+		is.Equal(unknown.Body.String(), wrong.Body.String())
+	})
+
+	t.Run("locked out", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newAuthService(t, cfg)
+		router := gin.New()
+		router.POST("/auth/login", svc.Login)
+
+		lockedUntil := time.Now().Add(2 * time.Minute)
+		mdb.EXPECT().GetUser(mock.Anything, "bob").Return(&db.User{Model: gorm.Model{ID: 1}, Username: "bob", Password: hash, FailedLogins: 6, LockedUntil: &lockedUntil}, nil)
+
+		mdb.EXPECT().GetUser(mock.Anything, "nobody").Return(nil, gorm.ErrRecordNotFound)
+
+		// even with the right password, and looking no different to a user who doesn't exist
+		locked := login(router, "bob", "hunter2hunter2")
+		unknown := login(router, "nobody", "hunter2hunter2")
+		is.Equal(http.StatusUnauthorized, locked.Code)
+		is.Equal(unknown.Code, locked.Code)
+		is.Equal(unknown.Body.String(), locked.Body.String())
+		is.Empty(locked.Header().Get("Retry-After"))
+	})
+
+	t.Run("logging in forgets failures", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newAuthService(t, cfg)
+		router := gin.New()
+		router.POST("/auth/login", svc.Login)
+
+		lockedUntil := time.Now().Add(-time.Minute)
+		mdb.EXPECT().GetUser(mock.Anything, "bob").Return(&db.User{Model: gorm.Model{ID: 1}, Username: "bob", Password: hash, FailedLogins: 5, LockedUntil: &lockedUntil}, nil)
+		mdb.EXPECT().ResetLoginFailures(mock.Anything, uint(1)).Return(nil)
+
+		w := login(router, "bob", "hunter2hunter2")
+		is.Equal(http.StatusOK, w.Code)
+		is.Contains(w.Header().Get("Set-Cookie"), auth.CookieName+"=")
+	})
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/data/storage"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/service/prodto"
+	"github.com/samber/lo"
+)
+
+// MaxCoverSize is the largest cover image an event can have, in bytes
+const MaxCoverSize = 10 << 20
+
+// images events can have as their cover, which every browser can show
+var coverTypes = []string{"image/png", "image/jpeg", "image/webp", "image/gif"}
+
+// ErrNoCover is returned getting the cover image of an event which doesn't have one
+var ErrNoCover = errors.New("event has no cover image")
+
+// SetEventBranding replaces how the events gallery looks, keeping its cover image unless it's removed
+func (p *eventpixSvc) SetEventBranding(ctx context.Context, req *picturev1.SetEventBrandingRequest) (*picturev1.SetEventBrandingResponse, error) {
+	evt, err := p.db.GetEvent(ctx, req.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("getting event: %w", err)
+	}
+
+	branding := &db.EventBranding{EventID: evt.ID}
+	if evt.Branding != nil {
+		branding.Model, branding.CoverImage = evt.Branding.Model, evt.Branding.CoverImage
+	}
+	branding.PrimaryColor = req.GetBranding().GetPrimaryColor()
+	branding.BackgroundColor = req.GetBranding().GetBackgroundColor()
+	branding.Font = req.GetBranding().GetFont()
+	branding.WelcomeText = req.GetBranding().GetWelcomeText()
+	branding.Footer = req.GetBranding().GetFooter()
+	if err := p.validator.ValidateBranding(branding); err != nil {
+		return nil, err
+	}
+
+	oldCover := ""
+	if req.GetRemoveCoverImage() {
+		oldCover, branding.CoverImage = branding.CoverImage, ""
+	}
+	if err := p.db.SaveEventBranding(ctx, branding); err != nil {
+		p.logger.Errorf("saving event(%d) branding: %v", evt.ID, err)
+		return nil, errors.New("saving branding")
+	}
+	p.removeCover(ctx, evt, oldCover)
+
+	before, after := brandingChanges(evt.Branding), brandingChanges(branding)
+	p.audit.Record(ctx, Audit{Action: AuditEventBranding, EventID: &evt.ID, Before: before, After: after})
+	evt.Branding = branding
+	return &picturev1.SetEventBrandingResponse{Event: prodto.Event(evt, false)}, nil
+}
+
+// SetEventCover stores the image in the events storage as its cover, replacing any it had
+func (p *eventpixSvc) SetEventCover(ctx context.Context, eventId uint64, filename string, src io.Reader, contentType string) error {
+	if !lo.Contains(coverTypes, contentType) {
+		return fmt.Errorf("unsupported cover image content-type: '%s'", contentType)
+	}
+	evt, err := p.db.GetEvent(ctx, eventId)
+	if err != nil {
+		return fmt.Errorf("getting event: %w", err)
+	}
+	if evt.Migrating {
+		return errors.New("event storage is being migrated")
+	}
+
+	limited := &io.LimitedReader{R: src, N: MaxCoverSize + 1}
+	id, err := evt.Storage.Store(ctx, db.CoverKey(evt, filename), limited)
+	if err != nil {
+		p.logger.Errorf("storing event(%d) cover: %v", evt.ID, err)
+		return errors.New("storing cover image")
+	}
+	if limited.N == 0 {
+		p.removeCover(ctx, evt, id)
+		return fmt.Errorf("cover image can't be bigger than %d MB", MaxCoverSize>>20)
+	}
+
+	branding := &db.EventBranding{EventID: evt.ID}
+	if evt.Branding != nil {
+		branding = evt.Branding
+	}
+	oldCover := branding.CoverImage
+	branding.CoverImage = id
+	if err := p.db.SaveEventBranding(ctx, branding); err != nil {
+		p.logger.Errorf("saving event(%d) cover: %v", evt.ID, err)
+		p.removeCover(ctx, evt, id)
+		return errors.New("saving cover image")
+	}
+	p.removeCover(ctx, evt, oldCover)
+	p.audit.Record(ctx, Audit{Action: AuditEventBranding, EventID: &evt.ID, After: map[string]any{"coverImage": true}})
+	return nil
+}
+
+// GetEventCover gets the events cover image out of its storage
+func (p *eventpixSvc) GetEventCover(ctx context.Context, eventId uint64) ([]byte, error) {
+	evt, err := p.db.GetEvent(ctx, eventId)
+	if err != nil {
+		return nil, fmt.Errorf("getting event: %w", err)
+	}
+	if evt.Branding == nil || evt.Branding.CoverImage == "" {
+		return nil, ErrNoCover
+	}
+	data, err := evt.Storage.Get(ctx, evt.Branding.CoverImage)
+	if err != nil {
+		p.logger.Errorf("getting event(%d) cover: %v", evt.ID, err)
+		return nil, err
+	}
+	defer data.Close()
+	return io.ReadAll(data)
+}
+
+// removeCover deletes a cover image the event no longer uses from its storage, if the storage can.
+// It's only tidying up, so failing to is logged rather than returned.
+func (p *eventpixSvc) removeCover(ctx context.Context, evt *db.Event, id string) {
+	if id == "" {
+		return
+	}
+	if _, err := storage.Delete(ctx, evt.Storage, id); err != nil {
+		p.logger.Warnf("deleting event(%d) old cover %s: %v", evt.ID, id, err)
+	}
+}
+
+// what's recorded in the audit log about the branding, the text is only recorded as being there
+func brandingChanges(b *db.EventBranding) map[string]any {
+	if b == nil {
+		b = &db.EventBranding{}
+	}
+	return map[string]any{
+		"primaryColor":    b.PrimaryColor,
+		"backgroundColor": b.BackgroundColor,
+		"font":            b.Font,
+		"coverImage":      b.CoverImage != "",
+		"welcomeText":     b.WelcomeText != "",
+		"footer":          b.Footer != "",
+	}
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	db "github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	"github.com/jj-style/eventpix/internal/data/storage"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/validate"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestEventBranding(t *testing.T) {
+	t.Parallel()
+
+	newService := func(t *testing.T) (service.EventpixService, *mockdb.MockDB) {
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+		return service.NewEventpixService(zap.NewNop(), mdb, nil, validate.NewValidator(), nil, service.NewAuditor(mdb, zap.NewNop())), mdb
+	}
+
+	// an event whose storage has a cover image in it
+	withCover := func(t *testing.T) *db.Event {
+		store := storage.NewFilesystem(afero.NewMemMapFs(), "/store")
+		id, err := store.Store(t.Context(), "branding/cover.png", strings.NewReader("old cover"))
+		require.NoError(t, err)
+		return &db.Event{
+			Model:    gorm.Model{ID: 2},
+			Slug:     "wedding",
+			Storage:  store,
+			Branding: &db.EventBranding{Model: gorm.Model{ID: 5}, EventID: 2, Font: "Lora", CoverImage: id},
+		}
+	}
+
+	t.Run("set branding keeps the cover", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newService(t)
+		evt := withCover(t)
+
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(evt, nil)
+		mdb.EXPECT().SaveEventBranding(mock.Anything, &db.EventBranding{
+			Model:        gorm.Model{ID: 5},
+			EventID:      2,
+			PrimaryColor: "#112233",
+			Font:         "Playfair Display",
+			CoverImage:   "branding/cover.png",
+			WelcomeText:  "**hello**",
+		}).Return(nil)
+
+		resp, err := svc.SetEventBranding(t.Context(), &picturev1.SetEventBrandingRequest{
+			Id:       2,
+			Branding: &picturev1.Branding{PrimaryColor: "#112233", Font: "Playfair Display", WelcomeText: "**hello**"},
+		})
+		is.NoError(err)
+		is.True(resp.GetEvent().GetBranding().GetCoverImage())
+		is.Equal("Playfair Display", resp.GetEvent().GetBranding().GetFont())
+	})
+
+	t.Run("remove cover", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newService(t)
+		evt := withCover(t)
+
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(evt, nil)
+		mdb.EXPECT().SaveEventBranding(mock.Anything, mock.MatchedBy(func(b *db.EventBranding) bool { return b.CoverImage == "" })).Return(nil)
+
+		_, err := svc.SetEventBranding(t.Context(), &picturev1.SetEventBrandingRequest{Id: 2, Branding: &picturev1.Branding{}, RemoveCoverImage: true})
+		is.NoError(err)
+		_, err = evt.Storage.Get(t.Context(), "branding/cover.png")
+		is.Error(err)
+	})
+
+	t.Run("invalid branding", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name     string
+			branding *picturev1.Branding
+		}{
+			{name: "colour", branding: &picturev1.Branding{PrimaryColor: "red"}},
+			{name: "css in colour", branding: &picturev1.Branding{BackgroundColor: "#fff;}body{display:none"}},
+			{name: "font", branding: &picturev1.Branding{Font: "Comic Sans"}},
+			{name: "welcome text", branding: &picturev1.Branding{WelcomeText: strings.Repeat("a", 5001)}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+				svc, mdb := newService(t)
+				mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(&db.Event{Model: gorm.Model{ID: 2}}, nil)
+
+				_, err := svc.SetEventBranding(t.Context(), &picturev1.SetEventBrandingRequest{Id: 2, Branding: tt.branding})
+				require.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("set cover replaces the old one", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newService(t)
+		evt := withCover(t)
+
+		var saved *db.EventBranding
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(evt, nil)
+		mdb.EXPECT().SaveEventBranding(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, b *db.EventBranding) error { saved = b; return nil })
+
+		is.NoError(svc.SetEventCover(t.Context(), 2, "new.png", strings.NewReader("new cover"), "image/png"))
+		is.True(strings.HasPrefix(saved.CoverImage, "branding/wedding/"))
+		is.Equal("Lora", saved.Font)
+		_, err := evt.Storage.Get(t.Context(), "branding/cover.png")
+		is.Error(err)
+
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(evt, nil)
+		cover, err := svc.GetEventCover(t.Context(), 2)
+		is.NoError(err)
+		is.Equal("new cover", string(cover))
+	})
+
+	t.Run("cover must be an image", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newService(t)
+
+		err := svc.SetEventCover(t.Context(), 2, "cover.mp4", strings.NewReader("video"), "video/mp4")
+		require.Error(t, err)
+	})
+
+	t.Run("cover too big", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newService(t)
+		evt := withCover(t)
+
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(evt, nil)
+
+		big := io.MultiReader(bytes.NewReader(make([]byte, service.MaxCoverSize)), strings.NewReader("!"))
+		is.Error(svc.SetEventCover(t.Context(), 2, "big.png", big, "image/png"))
+		// the old cover is kept
+		_, err := evt.Storage.Get(t.Context(), evt.Branding.CoverImage)
+		is.NoError(err)
+	})
+
+	t.Run("no cover", func(t *testing.T) {
+		t.Parallel()
+		svc, mdb := newService(t)
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(&db.Event{Model: gorm.Model{ID: 2}}, nil)
+
+		_, err := svc.GetEventCover(t.Context(), 2)
+		require.ErrorIs(t, err, service.ErrNoCover)
+	})
+}
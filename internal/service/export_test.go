@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"time"
+)
+
+// SetWebhookBackoff shortens the wait between webhook retries so tests don't take minutes
+func SetWebhookBackoff(w *WebhookDispatcher, backoff time.Duration) {
+	w.backoff = backoff
+}
+
+// AllowLocalWebhooks lets webhooks be delivered to test servers on the loopback address
+func AllowLocalWebhooks(w *WebhookDispatcher) {
+	w.client = &http.Client{Timeout: webhookTimeout}
+}
+
+// SetWebhookLookup resolves webhook hosts without the network
+func SetWebhookLookup(w *WebhookDispatcher, hosts map[string]string) {
+	w.lookup = func(_ context.Context, host string) ([]netip.Addr, error) {
+		if addr, err := netip.ParseAddr(host); err == nil {
+			return []netip.Addr{addr}, nil
+		}
+		addr, err := netip.ParseAddr(hosts[host])
+		if err != nil {
+			return nil, err
+		}
+		return []netip.Addr{addr}, nil
+	}
+}
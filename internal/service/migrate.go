@@ -0,0 +1,248 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/data/storage"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/service/prodto"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+// StorageMigrator copies all of an events media (files and thumbnails) from
+// its current storage into a new one, then switches the event over to the new
+// storage once every object has been copied and verified.
+type StorageMigrator struct {
+	db    db.DB
+	log   *zap.SugaredLogger
+	audit *Auditor
+	// sets the Storage interface on the event from its storage configuration
+	extractStorage func(*db.Event) error
+}
+
+func NewStorageMigrator(d db.DB, logger *zap.Logger, googleOauthConfig *oauth2.Config, audit *Auditor) *StorageMigrator {
+	return &StorageMigrator{
+		db:    d,
+		log:   logger.Sugar(),
+		audit: audit,
+		extractStorage: func(evt *db.Event) error {
+			return db.ExtractEventStorage(evt, googleOauthConfig)
+		},
+	}
+}
+
+// Start begins migrating the events media in the background, returning the newly created migration.
+func (m *StorageMigrator) Start(ctx context.Context, req *picturev1.MigrateEventStorageRequest) (*picturev1.StorageMigration, error) {
+	migration, src, target, err := m.prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	go m.run(context.Background(), migration, src, target)
+	return prodto.StorageMigration(migration), nil
+}
+
+// Migrate migrates the events media, returning once the migration has finished.
+func (m *StorageMigrator) Migrate(ctx context.Context, req *picturev1.MigrateEventStorageRequest) (*picturev1.StorageMigration, error) {
+	migration, src, target, err := m.prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	err = m.run(ctx, migration, src, target)
+	return prodto.StorageMigration(migration), err
+}
+
+func (m *StorageMigrator) GetStorageMigration(ctx context.Context, req *picturev1.GetStorageMigrationRequest) (*picturev1.StorageMigration, error) {
+	migration, err := m.db.GetStorageMigration(ctx, uint(req.GetEventId()))
+	if err != nil {
+		return nil, err
+	}
+	return prodto.StorageMigration(migration), nil
+}
+
+// Recover fails any migrations which were interrupted so the events can be migrated again.
+func (m *StorageMigrator) Recover(ctx context.Context) error {
+	return m.db.FailRunningStorageMigrations(ctx)
+}
+
+func (m *StorageMigrator) prepare(ctx context.Context, req *picturev1.MigrateEventStorageRequest) (*db.StorageMigration, *db.Event, *db.Event, error) {
+	evt, err := m.db.GetEvent(ctx, req.GetEventId())
+	if err != nil {
+		m.log.Errorf("getting event(%d) to migrate: %v", req.GetEventId(), err)
+		return nil, nil, nil, fmt.Errorf("getting event: %v", err)
+	}
+
+	target := &db.Event{Model: evt.Model, UserID: evt.UserID, User: evt.User, EncryptionKey: evt.EncryptionKey}
+	if err := setEventStorage(target, req.GetFilesystem(), req.GetS3(), req.GetGoogleDrive(), req.GetFtp()); err != nil {
+		return nil, nil, nil, err
+	}
+	if target.GoogleDriveStorage != nil && evt.User.GoogleDriveToken == nil {
+		return nil, nil, nil, errors.New("google drive integration not setup for user")
+	}
+	if sameStorage(evt, target) {
+		return nil, nil, nil, errors.New("event already uses this storage")
+	}
+	if err := m.extractStorage(target); err != nil {
+		return nil, nil, nil, fmt.Errorf("creating storage: %v", err)
+	}
+
+	migration := &db.StorageMigration{EventID: evt.ID, Status: db.MigrationRunning}
+	if err := m.db.CreateStorageMigration(ctx, migration); err != nil {
+		m.log.Errorf("creating storage migration for event(%d): %v", evt.ID, err)
+		return nil, nil, nil, err
+	}
+	m.audit.Record(ctx, Audit{
+		Action:  AuditEventMigrate,
+		EventID: &evt.ID,
+		Before:  map[string]any{"storage": evt.StorageType()},
+		After:   map[string]any{"storage": target.StorageType()},
+	})
+	return migration, evt, target, nil
+}
+
+func (m *StorageMigrator) run(ctx context.Context, migration *db.StorageMigration, src, target *db.Event) error {
+	err := m.copyAll(ctx, migration, src, target)
+	if err != nil {
+		m.log.Errorf("migrating event(%d) storage: %v", migration.EventID, err)
+		migration.Status = db.MigrationFailed
+		migration.Error = err.Error()
+		if err := m.db.UpdateStorageMigration(ctx, migration); err != nil {
+			m.log.Errorf("saving failed storage migration(%d): %v", migration.ID, err)
+		}
+		return err
+	}
+	m.log.Infof("migrated event(%d) storage", migration.EventID)
+	return nil
+}
+
+func (m *StorageMigrator) copyAll(ctx context.Context, migration *db.StorageMigration, src, target *db.Event) error {
+	fileIds := make(map[string]string)
+	thumbnailIds := make(map[string]string)
+
+	// the cover image isn't one of the events media, but has to move with it
+	coverIds := make(map[string]string)
+	if src.Branding != nil && src.Branding.CoverImage != "" {
+		id, err := copyObject(ctx, src.Storage, target.Storage, src.Branding.CoverImage, db.CoverKey(src, "cover"))
+		if err != nil {
+			return fmt.Errorf("copying cover image: %v", err)
+		}
+		coverIds[src.Branding.CoverImage] = id
+	}
+
+	// keep going until there is nothing new to copy, as thumbnails for recent
+	// uploads may still be generated whilst the migration is running, and uploads
+	// which started before it may still be stored in the old storage
+	for {
+		if err := m.copyNew(ctx, migration, src, target, fileIds, thumbnailIds); err != nil {
+			return err
+		}
+		err := m.db.SwitchEventStorage(ctx, migration, target, fileIds, thumbnailIds, coverIds)
+		if errors.Is(err, db.ErrMediaNotMigrated) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("switching storage: %v", err)
+		}
+		return nil
+	}
+}
+
+// copies the events files and thumbnails which aren't in the IDs yet, until there are none left
+func (m *StorageMigrator) copyNew(ctx context.Context, migration *db.StorageMigration, src, target *db.Event, fileIds, thumbnailIds map[string]string) error {
+	for {
+		fileInfos, err := m.db.GetFileInfos(ctx, migration.EventID)
+		if err != nil {
+			return fmt.Errorf("getting files: %v", err)
+		}
+		thumbnails, err := m.db.GetThumbnails(ctx, migration.EventID, -1, -1, true)
+		if err != nil {
+			return fmt.Errorf("getting thumbnails: %v", err)
+		}
+
+		fileInfos = lo.Filter(fileInfos, func(fi *db.FileInfo, _ int) bool { _, ok := fileIds[fi.ID]; return !ok })
+		thumbnails = lo.Filter(thumbnails, func(ti *db.ThumbnailInfo, _ int) bool { _, ok := thumbnailIds[ti.ID]; return !ok })
+		if len(fileInfos) == 0 && len(thumbnails) == 0 {
+			return nil
+		}
+		migration.Total += len(fileInfos) + len(thumbnails)
+
+		for _, fi := range fileInfos {
+			id, err := copyObject(ctx, src.Storage, target.Storage, fi.ID, db.MediaKey(src, fi.Name))
+			if err != nil {
+				return fmt.Errorf("copying file %s: %v", fi.Name, err)
+			}
+			fileIds[fi.ID] = id
+			m.progress(ctx, migration)
+		}
+		for _, ti := range thumbnails {
+			id, err := copyObject(ctx, src.Storage, target.Storage, ti.ID, db.ThumbnailKey(src, ti.Name))
+			if err != nil {
+				return fmt.Errorf("copying thumbnail %s: %v", ti.Name, err)
+			}
+			thumbnailIds[ti.ID] = id
+			m.progress(ctx, migration)
+		}
+	}
+}
+
+func (m *StorageMigrator) progress(ctx context.Context, migration *db.StorageMigration) {
+	migration.Completed++
+	m.log.Infof("migrating event(%d) storage: %d/%d", migration.EventID, migration.Completed, migration.Total)
+	// only progress, not worth failing the migration over
+	if err := m.db.UpdateStorageMigration(ctx, migration); err != nil {
+		m.log.Warnf("saving storage migration(%d) progress: %v", migration.ID, err)
+	}
+}
+
+// Copies the object from one storage to another under the key, reading it back out
+// of the destination to verify it was stored intact. Returns the ID in the destination.
+func copyObject(ctx context.Context, src, dst storage.Storage, id, key string) (string, error) {
+	data, err := src.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	defer data.Close()
+
+	hash := sha256.New()
+	newId, err := dst.Store(ctx, key, io.TeeReader(data, hash))
+	if err != nil {
+		return "", err
+	}
+
+	stored, err := dst.Get(ctx, newId)
+	if err != nil {
+		return "", fmt.Errorf("verifying: %v", err)
+	}
+	defer stored.Close()
+	storedHash := sha256.New()
+	if _, err := io.Copy(storedHash, stored); err != nil {
+		return "", fmt.Errorf("verifying: %v", err)
+	}
+	if !bytes.Equal(hash.Sum(nil), storedHash.Sum(nil)) {
+		return "", errors.New("verifying: stored data does not match")
+	}
+	return newId, nil
+}
+
+// Whether both events are configured with the same storage location
+func sameStorage(a, b *db.Event) bool {
+	switch {
+	case a.FileSystemStorage != nil && b.FileSystemStorage != nil:
+		return filepath.Clean(a.FileSystemStorage.Directory) == filepath.Clean(b.FileSystemStorage.Directory)
+	case a.S3Storage != nil && b.S3Storage != nil:
+		return a.S3Storage.Endpoint == b.S3Storage.Endpoint && a.S3Storage.Bucket == b.S3Storage.Bucket
+	case a.GoogleDriveStorage != nil && b.GoogleDriveStorage != nil:
+		return a.GoogleDriveStorage.DirectoryID == b.GoogleDriveStorage.DirectoryID
+	case a.FtpStorage != nil && b.FtpStorage != nil:
+		return a.FtpStorage.Address == b.FtpStorage.Address && a.FtpStorage.Directory == b.FtpStorage.Directory
+	}
+	return false
+}
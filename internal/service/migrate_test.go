@@ -0,0 +1,237 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	"github.com/jj-style/eventpix/internal/data/storage"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/service"
+	gormcrypto "github.com/pkasila/gorm-crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+func TestStorageMigrator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("happy", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+		migrator := service.NewStorageMigrator(mdb, zap.NewNop(), &oauth2.Config{}, service.NewAuditor(mdb, zap.NewNop()))
+
+		src := storage.NewMemStore()
+		fileId, err := src.Store(ctx, "file.jpg", bytes.NewReader([]byte("picture")))
+		is.NoError(err)
+		thumbId, err := src.Store(ctx, "thumb_file.webp", bytes.NewReader([]byte("thumbnail")))
+		is.NoError(err)
+
+		mdb.EXPECT().
+			GetEvent(ctx, uint64(1)).
+			Return(&db.Event{
+				Model:             gorm.Model{ID: 1},
+				Slug:              "party",
+				FileSystemStorage: &db.FileSystemStorage{Directory: "/old"},
+				Storage:           src,
+			}, nil)
+		mdb.EXPECT().
+			CreateStorageMigration(ctx, mock.Anything).
+			Return(nil)
+		mdb.EXPECT().
+			GetFileInfos(ctx, uint(1)).
+			Return([]*db.FileInfo{{ID: fileId, Name: "file.jpg"}}, nil)
+		mdb.EXPECT().
+			GetThumbnails(ctx, uint(1), -1, -1, true).
+			Return([]*db.ThumbnailInfo{{ID: thumbId, Name: "thumb_file.webp"}}, nil)
+		mdb.EXPECT().
+			UpdateStorageMigration(ctx, mock.Anything).
+			Return(nil)
+		var fileIds, thumbnailIds map[string]string
+		mdb.EXPECT().
+			SwitchEventStorage(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, m *db.StorageMigration, _ *db.Event, fIds, tIds, _ map[string]string) error {
+				fileIds, thumbnailIds = fIds, tIds
+				m.Status = db.MigrationComplete
+				return nil
+			})
+
+		dir := t.TempDir()
+		got, err := migrator.Migrate(ctx, &picturev1.MigrateEventStorageRequest{
+			EventId: 1,
+			Storage: &picturev1.MigrateEventStorageRequest_Filesystem{Filesystem: &picturev1.Filesystem{Directory: dir}},
+		})
+		is.NoError(err)
+		is.Equal(picturev1.StorageMigration_COMPLETE, got.GetStatus())
+		is.Equal(int64(2), got.GetTotal())
+		is.Equal(int64(2), got.GetCompleted())
+
+		// stored under keys from the events key template
+		is.Regexp(`^party/\d{4}/\d{2}/[0-9a-f-]{36}-file\.jpg$`, fileIds[fileId])
+		is.Regexp(`^thumbs/party/\d{4}/\d{2}/[0-9a-f-]{36}-thumb_file\.webp$`, thumbnailIds[thumbId])
+
+		picture, err := os.ReadFile(filepath.Join(dir, fileIds[fileId]))
+		is.NoError(err)
+		is.Equal([]byte("picture"), picture)
+		thumbnail, err := os.ReadFile(filepath.Join(dir, thumbnailIds[thumbId]))
+		is.NoError(err)
+		is.Equal([]byte("thumbnail"), thumbnail)
+	})
+
+	t.Run("happy copies uploads that land part way through", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+		migrator := service.NewStorageMigrator(mdb, zap.NewNop(), &oauth2.Config{}, service.NewAuditor(mdb, zap.NewNop()))
+
+		src := storage.NewMemStore()
+		firstId, err := src.Store(ctx, "first.jpg", bytes.NewReader([]byte("first")))
+		is.NoError(err)
+		lateId, err := src.Store(ctx, "late.jpg", bytes.NewReader([]byte("late")))
+		is.NoError(err)
+		first := &db.FileInfo{ID: firstId, Name: "first.jpg"}
+		late := &db.FileInfo{ID: lateId, Name: "late.jpg"}
+
+		mdb.EXPECT().
+			GetEvent(ctx, uint64(1)).
+			Return(&db.Event{
+				Model:             gorm.Model{ID: 1},
+				Slug:              "party",
+				FileSystemStorage: &db.FileSystemStorage{Directory: "/old"},
+				Storage:           src,
+			}, nil)
+		mdb.EXPECT().CreateStorageMigration(ctx, mock.Anything).Return(nil)
+		mdb.EXPECT().GetThumbnails(ctx, uint(1), -1, -1, true).Return(nil, nil)
+		mdb.EXPECT().UpdateStorageMigration(ctx, mock.Anything).Return(nil)
+		// the late upload is only saved once the files have been listed and copied
+		mdb.EXPECT().GetFileInfos(ctx, uint(1)).Return([]*db.FileInfo{first}, nil).Times(2)
+		mdb.EXPECT().SwitchEventStorage(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(db.ErrMediaNotMigrated).Once()
+		mdb.EXPECT().GetFileInfos(ctx, uint(1)).Return([]*db.FileInfo{first, late}, nil)
+		var fileIds map[string]string
+		mdb.EXPECT().
+			SwitchEventStorage(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, m *db.StorageMigration, _ *db.Event, fIds, _, _ map[string]string) error {
+				fileIds = fIds
+				m.Status = db.MigrationComplete
+				return nil
+			})
+
+		dir := t.TempDir()
+		got, err := migrator.Migrate(ctx, &picturev1.MigrateEventStorageRequest{
+			EventId: 1,
+			Storage: &picturev1.MigrateEventStorageRequest_Filesystem{Filesystem: &picturev1.Filesystem{Directory: dir}},
+		})
+		is.NoError(err)
+		is.Equal(picturev1.StorageMigration_COMPLETE, got.GetStatus())
+		is.Equal(int64(2), got.GetTotal())
+		is.Len(fileIds, 2)
+		picture, err := os.ReadFile(filepath.Join(dir, fileIds[lateId]))
+		is.NoError(err)
+		is.Equal([]byte("late"), picture)
+	})
+
+	t.Run("happy moves cover image", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+		migrator := service.NewStorageMigrator(mdb, zap.NewNop(), &oauth2.Config{}, service.NewAuditor(mdb, zap.NewNop()))
+
+		src := storage.NewMemStore()
+		coverId, err := src.Store(ctx, "cover.png", bytes.NewReader([]byte("cover")))
+		is.NoError(err)
+
+		mdb.EXPECT().
+			GetEvent(ctx, uint64(1)).
+			Return(&db.Event{
+				Model:             gorm.Model{ID: 1},
+				Slug:              "party",
+				FileSystemStorage: &db.FileSystemStorage{Directory: "/old"},
+				Storage:           src,
+				Branding:          &db.EventBranding{EventID: 1, CoverImage: coverId},
+			}, nil)
+		mdb.EXPECT().CreateStorageMigration(ctx, mock.Anything).Return(nil)
+		mdb.EXPECT().GetFileInfos(ctx, uint(1)).Return(nil, nil)
+		mdb.EXPECT().GetThumbnails(ctx, uint(1), -1, -1, true).Return(nil, nil)
+		var coverIds map[string]string
+		mdb.EXPECT().
+			SwitchEventStorage(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ *db.StorageMigration, _ *db.Event, _, _, cIds map[string]string) error {
+				coverIds = cIds
+				return nil
+			})
+
+		dir := t.TempDir()
+		_, err = migrator.Migrate(ctx, &picturev1.MigrateEventStorageRequest{
+			EventId: 1,
+			Storage: &picturev1.MigrateEventStorageRequest_Filesystem{Filesystem: &picturev1.Filesystem{Directory: dir}},
+		})
+		is.NoError(err)
+		// saved along with the rest of the media when the storage is switched
+		is.Regexp(`^branding/party/\d{4}/\d{2}/[0-9a-f-]{36}-cover$`, coverIds[coverId])
+		cover, err := os.ReadFile(filepath.Join(dir, coverIds[coverId]))
+		is.NoError(err)
+		is.Equal([]byte("cover"), cover)
+	})
+
+	t.Run("unhappy same storage", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+		migrator := service.NewStorageMigrator(mdb, zap.NewNop(), &oauth2.Config{}, service.NewAuditor(mdb, zap.NewNop()))
+
+		mdb.EXPECT().
+			GetEvent(ctx, uint64(1)).
+			Return(&db.Event{
+				Model:             gorm.Model{ID: 1},
+				FileSystemStorage: &db.FileSystemStorage{Directory: "/data/event"},
+			}, nil)
+
+		got, err := migrator.Migrate(ctx, &picturev1.MigrateEventStorageRequest{
+			EventId: 1,
+			Storage: &picturev1.MigrateEventStorageRequest_Filesystem{Filesystem: &picturev1.Filesystem{Directory: "/data/event/"}},
+		})
+		is.Error(err)
+		is.Nil(got)
+	})
+
+	t.Run("unhappy encrypted to presigned", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb := mockdb.NewMockDB(t)
+		migrator := service.NewStorageMigrator(mdb, zap.NewNop(), &oauth2.Config{}, service.NewAuditor(mdb, zap.NewNop()))
+
+		mdb.EXPECT().
+			GetEvent(ctx, uint64(1)).
+			Return(&db.Event{
+				Model:             gorm.Model{ID: 1},
+				EncryptionKey:     &gormcrypto.EncryptedValue{Raw: "key"},
+				FileSystemStorage: &db.FileSystemStorage{Directory: "/data/event"},
+			}, nil)
+
+		got, err := migrator.Migrate(ctx, &picturev1.MigrateEventStorageRequest{
+			EventId: 1,
+			Storage: &picturev1.MigrateEventStorageRequest_S3{S3: &picturev1.S3{Bucket: "photos", Presigned: true}},
+		})
+		is.ErrorIs(err, service.ErrEncryptedPresigned)
+		is.Nil(got)
+	})
+}
@@ -26,6 +26,65 @@ func (_m *MockEventpixService) EXPECT() *MockEventpixService_Expecter {
 	return &MockEventpixService_Expecter{mock: &_m.Mock}
 }
 
+// CompleteUpload provides a mock function with given fields: _a0, _a1
+func (_m *MockEventpixService) CompleteUpload(_a0 context.Context, _a1 *picturev1.CompleteUploadRequest) (*picturev1.UploadResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteUpload")
+	}
+
+	var r0 *picturev1.UploadResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.CompleteUploadRequest) (*picturev1.UploadResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.CompleteUploadRequest) *picturev1.UploadResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*picturev1.UploadResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.CompleteUploadRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_CompleteUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteUpload'
+type MockEventpixService_CompleteUpload_Call struct {
+	*mock.Call
+}
+
+// CompleteUpload is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *picturev1.CompleteUploadRequest
+func (_e *MockEventpixService_Expecter) CompleteUpload(_a0 interface{}, _a1 interface{}) *MockEventpixService_CompleteUpload_Call {
+	return &MockEventpixService_CompleteUpload_Call{Call: _e.mock.On("CompleteUpload", _a0, _a1)}
+}
+
+func (_c *MockEventpixService_CompleteUpload_Call) Run(run func(_a0 context.Context, _a1 *picturev1.CompleteUploadRequest)) *MockEventpixService_CompleteUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*picturev1.CompleteUploadRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_CompleteUpload_Call) Return(_a0 *picturev1.UploadResponse, _a1 error) *MockEventpixService_CompleteUpload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_CompleteUpload_Call) RunAndReturn(run func(context.Context, *picturev1.CompleteUploadRequest) (*picturev1.UploadResponse, error)) *MockEventpixService_CompleteUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateEvent provides a mock function with given fields: _a0, _a1, _a2
 func (_m *MockEventpixService) CreateEvent(_a0 context.Context, _a1 uint, _a2 *picturev1.CreateEventRequest) (*picturev1.CreateEventResponse, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -145,6 +204,125 @@ func (_c *MockEventpixService_DeleteEvent_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// DeleteEventTemplate provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockEventpixService) DeleteEventTemplate(_a0 context.Context, _a1 uint, _a2 *picturev1.DeleteEventTemplateRequest) (*emptypb.Empty, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEventTemplate")
+	}
+
+	var r0 *emptypb.Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *picturev1.DeleteEventTemplateRequest) (*emptypb.Empty, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *picturev1.DeleteEventTemplateRequest) *emptypb.Empty); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*emptypb.Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, *picturev1.DeleteEventTemplateRequest) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_DeleteEventTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEventTemplate'
+type MockEventpixService_DeleteEventTemplate_Call struct {
+	*mock.Call
+}
+
+// DeleteEventTemplate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uint
+//   - _a2 *picturev1.DeleteEventTemplateRequest
+func (_e *MockEventpixService_Expecter) DeleteEventTemplate(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockEventpixService_DeleteEventTemplate_Call {
+	return &MockEventpixService_DeleteEventTemplate_Call{Call: _e.mock.On("DeleteEventTemplate", _a0, _a1, _a2)}
+}
+
+func (_c *MockEventpixService_DeleteEventTemplate_Call) Run(run func(_a0 context.Context, _a1 uint, _a2 *picturev1.DeleteEventTemplateRequest)) *MockEventpixService_DeleteEventTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(*picturev1.DeleteEventTemplateRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_DeleteEventTemplate_Call) Return(_a0 *emptypb.Empty, _a1 error) *MockEventpixService_DeleteEventTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_DeleteEventTemplate_Call) RunAndReturn(run func(context.Context, uint, *picturev1.DeleteEventTemplateRequest) (*emptypb.Empty, error)) *MockEventpixService_DeleteEventTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMedia provides a mock function with given fields: _a0, _a1
+func (_m *MockEventpixService) DeleteMedia(_a0 context.Context, _a1 *picturev1.DeleteMediaRequest) (*emptypb.Empty, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMedia")
+	}
+
+	var r0 *emptypb.Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.DeleteMediaRequest) (*emptypb.Empty, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.DeleteMediaRequest) *emptypb.Empty); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*emptypb.Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.DeleteMediaRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_DeleteMedia_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMedia'
+type MockEventpixService_DeleteMedia_Call struct {
+	*mock.Call
+}
+
+// DeleteMedia is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *picturev1.DeleteMediaRequest
+func (_e *MockEventpixService_Expecter) DeleteMedia(_a0 interface{}, _a1 interface{}) *MockEventpixService_DeleteMedia_Call {
+	return &MockEventpixService_DeleteMedia_Call{Call: _e.mock.On("DeleteMedia", _a0, _a1)}
+}
+
+func (_c *MockEventpixService_DeleteMedia_Call) Run(run func(_a0 context.Context, _a1 *picturev1.DeleteMediaRequest)) *MockEventpixService_DeleteMedia_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*picturev1.DeleteMediaRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_DeleteMedia_Call) Return(_a0 *emptypb.Empty, _a1 error) *MockEventpixService_DeleteMedia_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_DeleteMedia_Call) RunAndReturn(run func(context.Context, *picturev1.DeleteMediaRequest) (*emptypb.Empty, error)) *MockEventpixService_DeleteMedia_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetActiveEvent provides a mock function with given fields: _a0, _a1
 func (_m *MockEventpixService) GetActiveEvent(_a0 context.Context, _a1 *picturev1.GetActiveEventRequest) (*picturev1.GetEventResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -263,6 +441,125 @@ func (_c *MockEventpixService_GetEvent_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// GetEventCover provides a mock function with given fields: ctx, eventId
+func (_m *MockEventpixService) GetEventCover(ctx context.Context, eventId uint64) ([]byte, error) {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventCover")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]byte, error)); ok {
+		return rf(ctx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []byte); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_GetEventCover_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventCover'
+type MockEventpixService_GetEventCover_Call struct {
+	*mock.Call
+}
+
+// GetEventCover is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint64
+func (_e *MockEventpixService_Expecter) GetEventCover(ctx interface{}, eventId interface{}) *MockEventpixService_GetEventCover_Call {
+	return &MockEventpixService_GetEventCover_Call{Call: _e.mock.On("GetEventCover", ctx, eventId)}
+}
+
+func (_c *MockEventpixService_GetEventCover_Call) Run(run func(ctx context.Context, eventId uint64)) *MockEventpixService_GetEventCover_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_GetEventCover_Call) Return(_a0 []byte, _a1 error) *MockEventpixService_GetEventCover_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_GetEventCover_Call) RunAndReturn(run func(context.Context, uint64) ([]byte, error)) *MockEventpixService_GetEventCover_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventTemplates provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockEventpixService) GetEventTemplates(_a0 context.Context, _a1 uint, _a2 *picturev1.GetEventTemplatesRequest) (*picturev1.GetEventTemplatesResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventTemplates")
+	}
+
+	var r0 *picturev1.GetEventTemplatesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *picturev1.GetEventTemplatesRequest) (*picturev1.GetEventTemplatesResponse, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *picturev1.GetEventTemplatesRequest) *picturev1.GetEventTemplatesResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*picturev1.GetEventTemplatesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, *picturev1.GetEventTemplatesRequest) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_GetEventTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventTemplates'
+type MockEventpixService_GetEventTemplates_Call struct {
+	*mock.Call
+}
+
+// GetEventTemplates is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uint
+//   - _a2 *picturev1.GetEventTemplatesRequest
+func (_e *MockEventpixService_Expecter) GetEventTemplates(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockEventpixService_GetEventTemplates_Call {
+	return &MockEventpixService_GetEventTemplates_Call{Call: _e.mock.On("GetEventTemplates", _a0, _a1, _a2)}
+}
+
+func (_c *MockEventpixService_GetEventTemplates_Call) Run(run func(_a0 context.Context, _a1 uint, _a2 *picturev1.GetEventTemplatesRequest)) *MockEventpixService_GetEventTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(*picturev1.GetEventTemplatesRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_GetEventTemplates_Call) Return(_a0 *picturev1.GetEventTemplatesResponse, _a1 error) *MockEventpixService_GetEventTemplates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_GetEventTemplates_Call) RunAndReturn(run func(context.Context, uint, *picturev1.GetEventTemplatesRequest) (*picturev1.GetEventTemplatesResponse, error)) *MockEventpixService_GetEventTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetEvents provides a mock function with given fields: _a0, _a1, _a2
 func (_m *MockEventpixService) GetEvents(_a0 context.Context, _a1 *picturev1.GetEventsRequest, _a2 uint) (*picturev1.GetEventsResponse, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -441,29 +738,29 @@ func (_c *MockEventpixService_GetThumbnails_Call) RunAndReturn(run func(context.
 	return _c
 }
 
-// SetActiveEvent provides a mock function with given fields: _a0, _a1
-func (_m *MockEventpixService) SetActiveEvent(_a0 context.Context, _a1 *picturev1.SetActiveEventRequest) (*emptypb.Empty, error) {
-	ret := _m.Called(_a0, _a1)
+// GetTrashedEvents provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockEventpixService) GetTrashedEvents(_a0 context.Context, _a1 *picturev1.GetTrashedEventsRequest, _a2 uint) (*picturev1.GetEventsResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetActiveEvent")
+		panic("no return value specified for GetTrashedEvents")
 	}
 
-	var r0 *emptypb.Empty
+	var r0 *picturev1.GetEventsResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetActiveEventRequest) (*emptypb.Empty, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.GetTrashedEventsRequest, uint) (*picturev1.GetEventsResponse, error)); ok {
+		return rf(_a0, _a1, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetActiveEventRequest) *emptypb.Empty); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.GetTrashedEventsRequest, uint) *picturev1.GetEventsResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*emptypb.Empty)
+			r0 = ret.Get(0).(*picturev1.GetEventsResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.SetActiveEventRequest) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.GetTrashedEventsRequest, uint) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -471,57 +768,58 @@ func (_m *MockEventpixService) SetActiveEvent(_a0 context.Context, _a1 *picturev
 	return r0, r1
 }
 
-// MockEventpixService_SetActiveEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActiveEvent'
-type MockEventpixService_SetActiveEvent_Call struct {
+// MockEventpixService_GetTrashedEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrashedEvents'
+type MockEventpixService_GetTrashedEvents_Call struct {
 	*mock.Call
 }
 
-// SetActiveEvent is a helper method to define mock.On call
+// GetTrashedEvents is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *picturev1.SetActiveEventRequest
-func (_e *MockEventpixService_Expecter) SetActiveEvent(_a0 interface{}, _a1 interface{}) *MockEventpixService_SetActiveEvent_Call {
-	return &MockEventpixService_SetActiveEvent_Call{Call: _e.mock.On("SetActiveEvent", _a0, _a1)}
+//   - _a1 *picturev1.GetTrashedEventsRequest
+//   - _a2 uint
+func (_e *MockEventpixService_Expecter) GetTrashedEvents(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockEventpixService_GetTrashedEvents_Call {
+	return &MockEventpixService_GetTrashedEvents_Call{Call: _e.mock.On("GetTrashedEvents", _a0, _a1, _a2)}
 }
 
-func (_c *MockEventpixService_SetActiveEvent_Call) Run(run func(_a0 context.Context, _a1 *picturev1.SetActiveEventRequest)) *MockEventpixService_SetActiveEvent_Call {
+func (_c *MockEventpixService_GetTrashedEvents_Call) Run(run func(_a0 context.Context, _a1 *picturev1.GetTrashedEventsRequest, _a2 uint)) *MockEventpixService_GetTrashedEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*picturev1.SetActiveEventRequest))
+		run(args[0].(context.Context), args[1].(*picturev1.GetTrashedEventsRequest), args[2].(uint))
 	})
 	return _c
 }
 
-func (_c *MockEventpixService_SetActiveEvent_Call) Return(_a0 *emptypb.Empty, _a1 error) *MockEventpixService_SetActiveEvent_Call {
+func (_c *MockEventpixService_GetTrashedEvents_Call) Return(_a0 *picturev1.GetEventsResponse, _a1 error) *MockEventpixService_GetTrashedEvents_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockEventpixService_SetActiveEvent_Call) RunAndReturn(run func(context.Context, *picturev1.SetActiveEventRequest) (*emptypb.Empty, error)) *MockEventpixService_SetActiveEvent_Call {
+func (_c *MockEventpixService_GetTrashedEvents_Call) RunAndReturn(run func(context.Context, *picturev1.GetTrashedEventsRequest, uint) (*picturev1.GetEventsResponse, error)) *MockEventpixService_GetTrashedEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetEventLive provides a mock function with given fields: _a0, _a1
-func (_m *MockEventpixService) SetEventLive(_a0 context.Context, _a1 *picturev1.SetEventLiveRequest) (*picturev1.SetEventLiveResponse, error) {
+// HideMedia provides a mock function with given fields: _a0, _a1
+func (_m *MockEventpixService) HideMedia(_a0 context.Context, _a1 *picturev1.HideMediaRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetEventLive")
+		panic("no return value specified for HideMedia")
 	}
 
-	var r0 *picturev1.SetEventLiveResponse
+	var r0 *emptypb.Empty
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetEventLiveRequest) (*picturev1.SetEventLiveResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.HideMediaRequest) (*emptypb.Empty, error)); ok {
 		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetEventLiveRequest) *picturev1.SetEventLiveResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.HideMediaRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*picturev1.SetEventLiveResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.SetEventLiveRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.HideMediaRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -530,31 +828,554 @@ func (_m *MockEventpixService) SetEventLive(_a0 context.Context, _a1 *picturev1.
 	return r0, r1
 }
 
-// MockEventpixService_SetEventLive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventLive'
-type MockEventpixService_SetEventLive_Call struct {
+// MockEventpixService_HideMedia_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HideMedia'
+type MockEventpixService_HideMedia_Call struct {
 	*mock.Call
 }
 
-// SetEventLive is a helper method to define mock.On call
+// HideMedia is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *picturev1.SetEventLiveRequest
-func (_e *MockEventpixService_Expecter) SetEventLive(_a0 interface{}, _a1 interface{}) *MockEventpixService_SetEventLive_Call {
-	return &MockEventpixService_SetEventLive_Call{Call: _e.mock.On("SetEventLive", _a0, _a1)}
+//   - _a1 *picturev1.HideMediaRequest
+func (_e *MockEventpixService_Expecter) HideMedia(_a0 interface{}, _a1 interface{}) *MockEventpixService_HideMedia_Call {
+	return &MockEventpixService_HideMedia_Call{Call: _e.mock.On("HideMedia", _a0, _a1)}
 }
 
-func (_c *MockEventpixService_SetEventLive_Call) Run(run func(_a0 context.Context, _a1 *picturev1.SetEventLiveRequest)) *MockEventpixService_SetEventLive_Call {
+func (_c *MockEventpixService_HideMedia_Call) Run(run func(_a0 context.Context, _a1 *picturev1.HideMediaRequest)) *MockEventpixService_HideMedia_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*picturev1.SetEventLiveRequest))
+		run(args[0].(context.Context), args[1].(*picturev1.HideMediaRequest))
 	})
 	return _c
 }
 
-func (_c *MockEventpixService_SetEventLive_Call) Return(_a0 *picturev1.SetEventLiveResponse, _a1 error) *MockEventpixService_SetEventLive_Call {
+func (_c *MockEventpixService_HideMedia_Call) Return(_a0 *emptypb.Empty, _a1 error) *MockEventpixService_HideMedia_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockEventpixService_SetEventLive_Call) RunAndReturn(run func(context.Context, *picturev1.SetEventLiveRequest) (*picturev1.SetEventLiveResponse, error)) *MockEventpixService_SetEventLive_Call {
+func (_c *MockEventpixService_HideMedia_Call) RunAndReturn(run func(context.Context, *picturev1.HideMediaRequest) (*emptypb.Empty, error)) *MockEventpixService_HideMedia_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PresignUpload provides a mock function with given fields: _a0, _a1
+func (_m *MockEventpixService) PresignUpload(_a0 context.Context, _a1 *picturev1.PresignUploadRequest) (*picturev1.PresignUploadResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PresignUpload")
+	}
+
+	var r0 *picturev1.PresignUploadResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.PresignUploadRequest) (*picturev1.PresignUploadResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.PresignUploadRequest) *picturev1.PresignUploadResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*picturev1.PresignUploadResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.PresignUploadRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_PresignUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PresignUpload'
+type MockEventpixService_PresignUpload_Call struct {
+	*mock.Call
+}
+
+// PresignUpload is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *picturev1.PresignUploadRequest
+func (_e *MockEventpixService_Expecter) PresignUpload(_a0 interface{}, _a1 interface{}) *MockEventpixService_PresignUpload_Call {
+	return &MockEventpixService_PresignUpload_Call{Call: _e.mock.On("PresignUpload", _a0, _a1)}
+}
+
+func (_c *MockEventpixService_PresignUpload_Call) Run(run func(_a0 context.Context, _a1 *picturev1.PresignUploadRequest)) *MockEventpixService_PresignUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*picturev1.PresignUploadRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_PresignUpload_Call) Return(_a0 *picturev1.PresignUploadResponse, _a1 error) *MockEventpixService_PresignUpload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_PresignUpload_Call) RunAndReturn(run func(context.Context, *picturev1.PresignUploadRequest) (*picturev1.PresignUploadResponse, error)) *MockEventpixService_PresignUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreEvent provides a mock function with given fields: _a0, _a1
+func (_m *MockEventpixService) RestoreEvent(_a0 context.Context, _a1 *picturev1.RestoreEventRequest) (*picturev1.RestoreEventResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreEvent")
+	}
+
+	var r0 *picturev1.RestoreEventResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.RestoreEventRequest) (*picturev1.RestoreEventResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.RestoreEventRequest) *picturev1.RestoreEventResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*picturev1.RestoreEventResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.RestoreEventRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_RestoreEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreEvent'
+type MockEventpixService_RestoreEvent_Call struct {
+	*mock.Call
+}
+
+// RestoreEvent is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *picturev1.RestoreEventRequest
+func (_e *MockEventpixService_Expecter) RestoreEvent(_a0 interface{}, _a1 interface{}) *MockEventpixService_RestoreEvent_Call {
+	return &MockEventpixService_RestoreEvent_Call{Call: _e.mock.On("RestoreEvent", _a0, _a1)}
+}
+
+func (_c *MockEventpixService_RestoreEvent_Call) Run(run func(_a0 context.Context, _a1 *picturev1.RestoreEventRequest)) *MockEventpixService_RestoreEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*picturev1.RestoreEventRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_RestoreEvent_Call) Return(_a0 *picturev1.RestoreEventResponse, _a1 error) *MockEventpixService_RestoreEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_RestoreEvent_Call) RunAndReturn(run func(context.Context, *picturev1.RestoreEventRequest) (*picturev1.RestoreEventResponse, error)) *MockEventpixService_RestoreEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveEventTemplate provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockEventpixService) SaveEventTemplate(_a0 context.Context, _a1 uint, _a2 *picturev1.SaveEventTemplateRequest) (*picturev1.EventTemplate, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveEventTemplate")
+	}
+
+	var r0 *picturev1.EventTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *picturev1.SaveEventTemplateRequest) (*picturev1.EventTemplate, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *picturev1.SaveEventTemplateRequest) *picturev1.EventTemplate); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*picturev1.EventTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, *picturev1.SaveEventTemplateRequest) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_SaveEventTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveEventTemplate'
+type MockEventpixService_SaveEventTemplate_Call struct {
+	*mock.Call
+}
+
+// SaveEventTemplate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uint
+//   - _a2 *picturev1.SaveEventTemplateRequest
+func (_e *MockEventpixService_Expecter) SaveEventTemplate(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockEventpixService_SaveEventTemplate_Call {
+	return &MockEventpixService_SaveEventTemplate_Call{Call: _e.mock.On("SaveEventTemplate", _a0, _a1, _a2)}
+}
+
+func (_c *MockEventpixService_SaveEventTemplate_Call) Run(run func(_a0 context.Context, _a1 uint, _a2 *picturev1.SaveEventTemplateRequest)) *MockEventpixService_SaveEventTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(*picturev1.SaveEventTemplateRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_SaveEventTemplate_Call) Return(_a0 *picturev1.EventTemplate, _a1 error) *MockEventpixService_SaveEventTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_SaveEventTemplate_Call) RunAndReturn(run func(context.Context, uint, *picturev1.SaveEventTemplateRequest) (*picturev1.EventTemplate, error)) *MockEventpixService_SaveEventTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetActiveEvent provides a mock function with given fields: _a0, _a1
+func (_m *MockEventpixService) SetActiveEvent(_a0 context.Context, _a1 *picturev1.SetActiveEventRequest) (*emptypb.Empty, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetActiveEvent")
+	}
+
+	var r0 *emptypb.Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetActiveEventRequest) (*emptypb.Empty, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetActiveEventRequest) *emptypb.Empty); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*emptypb.Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.SetActiveEventRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_SetActiveEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActiveEvent'
+type MockEventpixService_SetActiveEvent_Call struct {
+	*mock.Call
+}
+
+// SetActiveEvent is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *picturev1.SetActiveEventRequest
+func (_e *MockEventpixService_Expecter) SetActiveEvent(_a0 interface{}, _a1 interface{}) *MockEventpixService_SetActiveEvent_Call {
+	return &MockEventpixService_SetActiveEvent_Call{Call: _e.mock.On("SetActiveEvent", _a0, _a1)}
+}
+
+func (_c *MockEventpixService_SetActiveEvent_Call) Run(run func(_a0 context.Context, _a1 *picturev1.SetActiveEventRequest)) *MockEventpixService_SetActiveEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*picturev1.SetActiveEventRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_SetActiveEvent_Call) Return(_a0 *emptypb.Empty, _a1 error) *MockEventpixService_SetActiveEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_SetActiveEvent_Call) RunAndReturn(run func(context.Context, *picturev1.SetActiveEventRequest) (*emptypb.Empty, error)) *MockEventpixService_SetActiveEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetEventBranding provides a mock function with given fields: _a0, _a1
+func (_m *MockEventpixService) SetEventBranding(_a0 context.Context, _a1 *picturev1.SetEventBrandingRequest) (*picturev1.SetEventBrandingResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEventBranding")
+	}
+
+	var r0 *picturev1.SetEventBrandingResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetEventBrandingRequest) (*picturev1.SetEventBrandingResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetEventBrandingRequest) *picturev1.SetEventBrandingResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*picturev1.SetEventBrandingResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.SetEventBrandingRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_SetEventBranding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventBranding'
+type MockEventpixService_SetEventBranding_Call struct {
+	*mock.Call
+}
+
+// SetEventBranding is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *picturev1.SetEventBrandingRequest
+func (_e *MockEventpixService_Expecter) SetEventBranding(_a0 interface{}, _a1 interface{}) *MockEventpixService_SetEventBranding_Call {
+	return &MockEventpixService_SetEventBranding_Call{Call: _e.mock.On("SetEventBranding", _a0, _a1)}
+}
+
+func (_c *MockEventpixService_SetEventBranding_Call) Run(run func(_a0 context.Context, _a1 *picturev1.SetEventBrandingRequest)) *MockEventpixService_SetEventBranding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*picturev1.SetEventBrandingRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_SetEventBranding_Call) Return(_a0 *picturev1.SetEventBrandingResponse, _a1 error) *MockEventpixService_SetEventBranding_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_SetEventBranding_Call) RunAndReturn(run func(context.Context, *picturev1.SetEventBrandingRequest) (*picturev1.SetEventBrandingResponse, error)) *MockEventpixService_SetEventBranding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetEventCover provides a mock function with given fields: ctx, eventId, filename, src, contentType
+func (_m *MockEventpixService) SetEventCover(ctx context.Context, eventId uint64, filename string, src io.Reader, contentType string) error {
+	ret := _m.Called(ctx, eventId, filename, src, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEventCover")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, io.Reader, string) error); ok {
+		r0 = rf(ctx, eventId, filename, src, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEventpixService_SetEventCover_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventCover'
+type MockEventpixService_SetEventCover_Call struct {
+	*mock.Call
+}
+
+// SetEventCover is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventId uint64
+//   - filename string
+//   - src io.Reader
+//   - contentType string
+func (_e *MockEventpixService_Expecter) SetEventCover(ctx interface{}, eventId interface{}, filename interface{}, src interface{}, contentType interface{}) *MockEventpixService_SetEventCover_Call {
+	return &MockEventpixService_SetEventCover_Call{Call: _e.mock.On("SetEventCover", ctx, eventId, filename, src, contentType)}
+}
+
+func (_c *MockEventpixService_SetEventCover_Call) Run(run func(ctx context.Context, eventId uint64, filename string, src io.Reader, contentType string)) *MockEventpixService_SetEventCover_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(string), args[3].(io.Reader), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_SetEventCover_Call) Return(_a0 error) *MockEventpixService_SetEventCover_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEventpixService_SetEventCover_Call) RunAndReturn(run func(context.Context, uint64, string, io.Reader, string) error) *MockEventpixService_SetEventCover_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetEventLive provides a mock function with given fields: _a0, _a1
+func (_m *MockEventpixService) SetEventLive(_a0 context.Context, _a1 *picturev1.SetEventLiveRequest) (*picturev1.SetEventLiveResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEventLive")
+	}
+
+	var r0 *picturev1.SetEventLiveResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetEventLiveRequest) (*picturev1.SetEventLiveResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetEventLiveRequest) *picturev1.SetEventLiveResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*picturev1.SetEventLiveResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.SetEventLiveRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_SetEventLive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventLive'
+type MockEventpixService_SetEventLive_Call struct {
+	*mock.Call
+}
+
+// SetEventLive is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *picturev1.SetEventLiveRequest
+func (_e *MockEventpixService_Expecter) SetEventLive(_a0 interface{}, _a1 interface{}) *MockEventpixService_SetEventLive_Call {
+	return &MockEventpixService_SetEventLive_Call{Call: _e.mock.On("SetEventLive", _a0, _a1)}
+}
+
+func (_c *MockEventpixService_SetEventLive_Call) Run(run func(_a0 context.Context, _a1 *picturev1.SetEventLiveRequest)) *MockEventpixService_SetEventLive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*picturev1.SetEventLiveRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_SetEventLive_Call) Return(_a0 *picturev1.SetEventLiveResponse, _a1 error) *MockEventpixService_SetEventLive_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_SetEventLive_Call) RunAndReturn(run func(context.Context, *picturev1.SetEventLiveRequest) (*picturev1.SetEventLiveResponse, error)) *MockEventpixService_SetEventLive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetEventSchedule provides a mock function with given fields: _a0, _a1
+func (_m *MockEventpixService) SetEventSchedule(_a0 context.Context, _a1 *picturev1.SetEventScheduleRequest) (*picturev1.SetEventScheduleResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEventSchedule")
+	}
+
+	var r0 *picturev1.SetEventScheduleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetEventScheduleRequest) (*picturev1.SetEventScheduleResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.SetEventScheduleRequest) *picturev1.SetEventScheduleResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*picturev1.SetEventScheduleResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.SetEventScheduleRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_SetEventSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventSchedule'
+type MockEventpixService_SetEventSchedule_Call struct {
+	*mock.Call
+}
+
+// SetEventSchedule is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *picturev1.SetEventScheduleRequest
+func (_e *MockEventpixService_Expecter) SetEventSchedule(_a0 interface{}, _a1 interface{}) *MockEventpixService_SetEventSchedule_Call {
+	return &MockEventpixService_SetEventSchedule_Call{Call: _e.mock.On("SetEventSchedule", _a0, _a1)}
+}
+
+func (_c *MockEventpixService_SetEventSchedule_Call) Run(run func(_a0 context.Context, _a1 *picturev1.SetEventScheduleRequest)) *MockEventpixService_SetEventSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*picturev1.SetEventScheduleRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_SetEventSchedule_Call) Return(_a0 *picturev1.SetEventScheduleResponse, _a1 error) *MockEventpixService_SetEventSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_SetEventSchedule_Call) RunAndReturn(run func(context.Context, *picturev1.SetEventScheduleRequest) (*picturev1.SetEventScheduleResponse, error)) *MockEventpixService_SetEventSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEvent provides a mock function with given fields: _a0, _a1
+func (_m *MockEventpixService) UpdateEvent(_a0 context.Context, _a1 *picturev1.UpdateEventRequest) (*picturev1.UpdateEventResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEvent")
+	}
+
+	var r0 *picturev1.UpdateEventResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.UpdateEventRequest) (*picturev1.UpdateEventResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *picturev1.UpdateEventRequest) *picturev1.UpdateEventResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*picturev1.UpdateEventResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *picturev1.UpdateEventRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEventpixService_UpdateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEvent'
+type MockEventpixService_UpdateEvent_Call struct {
+	*mock.Call
+}
+
+// UpdateEvent is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *picturev1.UpdateEventRequest
+func (_e *MockEventpixService_Expecter) UpdateEvent(_a0 interface{}, _a1 interface{}) *MockEventpixService_UpdateEvent_Call {
+	return &MockEventpixService_UpdateEvent_Call{Call: _e.mock.On("UpdateEvent", _a0, _a1)}
+}
+
+func (_c *MockEventpixService_UpdateEvent_Call) Run(run func(_a0 context.Context, _a1 *picturev1.UpdateEventRequest)) *MockEventpixService_UpdateEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*picturev1.UpdateEventRequest))
+	})
+	return _c
+}
+
+func (_c *MockEventpixService_UpdateEvent_Call) Return(_a0 *picturev1.UpdateEventResponse, _a1 error) *MockEventpixService_UpdateEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEventpixService_UpdateEvent_Call) RunAndReturn(run func(context.Context, *picturev1.UpdateEventRequest) (*picturev1.UpdateEventResponse, error)) *MockEventpixService_UpdateEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
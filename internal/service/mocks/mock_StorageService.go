@@ -21,6 +21,41 @@ func (_m *MockStorageService) EXPECT() *MockStorageService_Expecter {
 	return &MockStorageService_Expecter{mock: &_m.Mock}
 }
 
+// CountView provides a mock function with given fields: ctx, id, download
+func (_m *MockStorageService) CountView(ctx context.Context, id string, download bool) {
+	_m.Called(ctx, id, download)
+}
+
+// MockStorageService_CountView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountView'
+type MockStorageService_CountView_Call struct {
+	*mock.Call
+}
+
+// CountView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - download bool
+func (_e *MockStorageService_Expecter) CountView(ctx interface{}, id interface{}, download interface{}) *MockStorageService_CountView_Call {
+	return &MockStorageService_CountView_Call{Call: _e.mock.On("CountView", ctx, id, download)}
+}
+
+func (_c *MockStorageService_CountView_Call) Run(run func(ctx context.Context, id string, download bool)) *MockStorageService_CountView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockStorageService_CountView_Call) Return() *MockStorageService_CountView_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStorageService_CountView_Call) RunAndReturn(run func(context.Context, string, bool)) *MockStorageService_CountView_Call {
+	_c.Run(run)
+	return _c
+}
+
 // GetPicture provides a mock function with given fields: ctx, id
 func (_m *MockStorageService) GetPicture(ctx context.Context, id string) (string, []byte, error) {
 	ret := _m.Called(ctx, id)
@@ -87,6 +122,63 @@ func (_c *MockStorageService_GetPicture_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// GetPictureURL provides a mock function with given fields: ctx, id
+func (_m *MockStorageService) GetPictureURL(ctx context.Context, id string) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPictureURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorageService_GetPictureURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPictureURL'
+type MockStorageService_GetPictureURL_Call struct {
+	*mock.Call
+}
+
+// GetPictureURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStorageService_Expecter) GetPictureURL(ctx interface{}, id interface{}) *MockStorageService_GetPictureURL_Call {
+	return &MockStorageService_GetPictureURL_Call{Call: _e.mock.On("GetPictureURL", ctx, id)}
+}
+
+func (_c *MockStorageService_GetPictureURL_Call) Run(run func(ctx context.Context, id string)) *MockStorageService_GetPictureURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorageService_GetPictureURL_Call) Return(_a0 string, _a1 error) *MockStorageService_GetPictureURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorageService_GetPictureURL_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockStorageService_GetPictureURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetThumbnail provides a mock function with given fields: ctx, id
 func (_m *MockStorageService) GetThumbnail(ctx context.Context, id string) (string, []byte, error) {
 	ret := _m.Called(ctx, id)
@@ -153,6 +245,63 @@ func (_c *MockStorageService_GetThumbnail_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// GetThumbnailURL provides a mock function with given fields: ctx, id
+func (_m *MockStorageService) GetThumbnailURL(ctx context.Context, id string) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetThumbnailURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorageService_GetThumbnailURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThumbnailURL'
+type MockStorageService_GetThumbnailURL_Call struct {
+	*mock.Call
+}
+
+// GetThumbnailURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStorageService_Expecter) GetThumbnailURL(ctx interface{}, id interface{}) *MockStorageService_GetThumbnailURL_Call {
+	return &MockStorageService_GetThumbnailURL_Call{Call: _e.mock.On("GetThumbnailURL", ctx, id)}
+}
+
+func (_c *MockStorageService_GetThumbnailURL_Call) Run(run func(ctx context.Context, id string)) *MockStorageService_GetThumbnailURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorageService_GetThumbnailURL_Call) Return(_a0 string, _a1 error) *MockStorageService_GetThumbnailURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorageService_GetThumbnailURL_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockStorageService_GetThumbnailURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockStorageService creates a new instance of MockStorageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockStorageService(t interface {
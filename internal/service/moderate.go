@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jj-style/eventpix/internal/data/storage"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+// HideMedia hides the media from guests in the events gallery, or shows it again
+func (p *eventpixSvc) HideMedia(ctx context.Context, req *picturev1.HideMediaRequest) (*emptypb.Empty, error) {
+	eventId := uint(req.GetEventId())
+	if err := p.db.SetFileHidden(ctx, eventId, req.GetFileId(), req.GetHidden()); err != nil {
+		return nil, fmt.Errorf("hiding media: %w", err)
+	}
+	action := AuditMediaShow
+	if req.GetHidden() {
+		action = AuditMediaHide
+	}
+	p.audit.Record(ctx, Audit{Action: action, Target: mediaTarget(req.GetFileId()), EventID: &eventId})
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteMedia permanently deletes the media and its thumbnails from the event and its storage
+func (p *eventpixSvc) DeleteMedia(ctx context.Context, req *picturev1.DeleteMediaRequest) (*emptypb.Empty, error) {
+	evt, err := p.db.GetEvent(ctx, req.GetEventId())
+	if err != nil {
+		return nil, fmt.Errorf("getting event: %w", err)
+	}
+	fi, err := p.db.GetFileInfo(ctx, req.GetFileId())
+	if err != nil {
+		return nil, fmt.Errorf("getting media: %w", err)
+	}
+	// moderators of one event can't delete another events media
+	if fi.EventID != evt.ID {
+		return nil, fmt.Errorf("getting media: %w", gorm.ErrRecordNotFound)
+	}
+	thumbnails, err := p.db.DeleteFileInfo(ctx, evt.ID, fi.ID)
+	if err != nil {
+		return nil, fmt.Errorf("deleting media: %w", err)
+	}
+
+	// it's gone from the event either way, so a failure only leaves it behind in the storage
+	ids := []string{fi.ID}
+	for _, ti := range thumbnails {
+		ids = append(ids, ti.ID)
+	}
+	for _, id := range ids {
+		if _, err := storage.Delete(ctx, evt.Storage, id); err != nil {
+			p.logger.Warnf("deleting %s from event(%d) storage: %v", id, evt.ID, err)
+		}
+	}
+
+	p.audit.Record(ctx, Audit{Action: AuditMediaDelete, Target: mediaTarget(fi.ID), EventID: &evt.ID, Before: map[string]any{"name": fi.Name, "uploader": fi.Uploader}})
+	return &emptypb.Empty{}, nil
+}
+
+func mediaTarget(fileId string) string {
+	return "media:" + fileId
+}
@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	"github.com/jj-style/eventpix/internal/data/storage"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/validate"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestModerateMedia(t *testing.T) {
+	t.Parallel()
+
+	newService := func(t *testing.T) (service.EventpixService, *mockdb.MockDB) {
+		mdb := mockdb.NewMockDB(t)
+		return service.NewEventpixService(zap.NewNop(), mdb, nil, validate.NewValidator(), nil, service.NewAuditor(mdb, zap.NewNop())), mdb
+	}
+
+	t.Run("hide", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newService(t)
+
+		mdb.EXPECT().SetFileHidden(mock.Anything, uint(2), "rude.jpg", true).Return(nil)
+		mdb.EXPECT().
+			CreateAuditLog(mock.Anything, mock.MatchedBy(func(l *db.AuditLog) bool {
+				return l.Action == service.AuditMediaHide && l.Target == "media:rude.jpg" && *l.EventID == 2
+			})).
+			Return(nil)
+
+		_, err := svc.HideMedia(t.Context(), &picturev1.HideMediaRequest{EventId: 2, FileId: "rude.jpg", Hidden: true})
+		is.NoError(err)
+	})
+
+	t.Run("delete removes it from the storage", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newService(t)
+
+		store := storage.NewFilesystem(afero.NewMemMapFs(), "/store")
+		fileId, err := store.Store(t.Context(), "rude.jpg", strings.NewReader("rude"))
+		is.NoError(err)
+		thumbId, err := store.Store(t.Context(), "thumb_rude.webp", strings.NewReader("thumb"))
+		is.NoError(err)
+
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(&db.Event{Model: gorm.Model{ID: 2}, Storage: store}, nil)
+		mdb.EXPECT().GetFileInfo(mock.Anything, fileId).Return(&db.FileInfo{ID: fileId, EventID: 2, Name: "rude.jpg", Uploader: "friends"}, nil)
+		mdb.EXPECT().DeleteFileInfo(mock.Anything, uint(2), fileId).Return([]*db.ThumbnailInfo{{ID: thumbId}}, nil)
+		mdb.EXPECT().
+			CreateAuditLog(mock.Anything, mock.MatchedBy(func(l *db.AuditLog) bool {
+				return l.Action == service.AuditMediaDelete && strings.Contains(l.Before, "friends")
+			})).
+			Return(nil)
+
+		_, err = svc.DeleteMedia(t.Context(), &picturev1.DeleteMediaRequest{EventId: 2, FileId: fileId})
+		is.NoError(err)
+		_, err = store.Get(t.Context(), fileId)
+		is.Error(err)
+		_, err = store.Get(t.Context(), thumbId)
+		is.Error(err)
+	})
+
+	t.Run("delete another events media", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newService(t)
+
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(2)).Return(&db.Event{Model: gorm.Model{ID: 2}}, nil)
+		mdb.EXPECT().GetFileInfo(mock.Anything, "cake.jpg").Return(&db.FileInfo{ID: "cake.jpg", EventID: 3}, nil)
+
+		_, err := svc.DeleteMedia(t.Context(), &picturev1.DeleteMediaRequest{EventId: 2, FileId: "cake.jpg"})
+		is.ErrorIs(err, gorm.ErrRecordNotFound)
+	})
+}
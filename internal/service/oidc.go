@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+const (
+	// cookie the sign in state is kept in whilst the user is at the provider
+	oidcStateCookie = "OidcState"
+	// how long the user has to sign in at the provider
+	oidcStateExpiry = 10 * time.Minute
+	// claim the users groups are in if the provider doesn't say otherwise
+	defaultGroupsClaim = "groups"
+)
+
+// OpenID Connect provider from config, discovered on first use so the server starts without it
+type oidcProvider struct {
+	cfg         *config.OidcProvider
+	redirectUrl string
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (*oidc.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.provider == nil {
+		// the provider keeps the context to fetch its signing keys, so it can't end with the request
+		provider, err := oidc.NewProvider(context.WithoutCancel(ctx), p.cfg.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("discovering %s: %w", p.cfg.Issuer, err)
+		}
+		p.provider = provider
+	}
+	return p.provider, nil
+}
+
+func (p *oidcProvider) oauth2Config(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.cfg.ClientId,
+		ClientSecret: p.cfg.ClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  p.redirectUrl,
+		Scopes:       append([]string{oidc.ScopeOpenID, "profile", "email"}, p.cfg.Scopes...),
+	}
+}
+
+// whether the provider puts the user in its admin group, and if it manages admins at all
+func (p *oidcProvider) admin(idToken *oidc.IDToken) (bool, bool) {
+	if p.cfg.AdminGroup == "" {
+		return false, false
+	}
+	claim := p.cfg.GroupsClaim
+	if claim == "" {
+		claim = defaultGroupsClaim
+	}
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return false, true
+	}
+	switch groups := claims[claim].(type) {
+	case []any:
+		return slices.Contains(groups, any(p.cfg.AdminGroup)), true
+	case string:
+		return groups == p.cfg.AdminGroup, true
+	default:
+		return false, true
+	}
+}
+
+func newOidcProviders(cfg *config.Config) map[string]*oidcProvider {
+	providers := make(map[string]*oidcProvider, len(cfg.Oidc))
+	for _, p := range cfg.Oidc {
+		providers[p.Name] = &oidcProvider{
+			cfg:         p,
+			redirectUrl: fmt.Sprintf("%s/auth/oidc/%s/callback", cfg.Server.ServerUrl, p.Name),
+		}
+	}
+	return providers
+}
+
+// signed so nobody can forge a link to someone else's account
+type oidcState struct {
+	Provider string `json:"prv"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"pkce"`
+	// user linking the identity to their account, 0 when signing in
+	LinkUserID uint `json:"link,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (x *AuthService) oidcStateKey() []byte {
+	return []byte(x.secretKey + ":oidc")
+}
+
+// OidcLogin redirects to the provider to sign in
+func (x *AuthService) OidcLogin(c *gin.Context) {
+	x.oidcRedirect(c, 0)
+}
+
+// OidcLink redirects to the provider to link an identity there to the logged in user
+//
+// Notes
+// Must be used after `AuthRequired`
+func (x *AuthService) OidcLink(c *gin.Context) {
+	user := c.MustGet(gin.AuthUserKey).(*db.User)
+	x.oidcRedirect(c, user.ID)
+}
+
+func (x *AuthService) oidcRedirect(c *gin.Context, linkUserId uint) {
+	p, ok := x.oidc[c.Param("provider")]
+	if !ok {
+		c.AbortWithError(http.StatusNotFound, errors.New("unknown sign in provider"))
+		return
+	}
+	provider, err := p.discover(c)
+	if err != nil {
+		c.AbortWithError(http.StatusBadGateway, err)
+		return
+	}
+
+	state := oidcState{
+		Provider:   p.cfg.Name,
+		Nonce:      rand.Text(),
+		Verifier:   oauth2.GenerateVerifier(),
+		LinkUserID: linkUserId,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        rand.Text(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(oidcStateExpiry)),
+		},
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, state).SignedString(x.oidcStateKey())
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	x.setOidcStateCookie(c, signed, int(oidcStateExpiry.Seconds()))
+
+	url := p.oauth2Config(provider).AuthCodeURL(state.ID, oidc.Nonce(state.Nonce), oauth2.S256ChallengeOption(state.Verifier))
+	c.Redirect(http.StatusFound, url)
+}
+
+// OidcCallback signs the user in, or links the identity to them, when the provider redirects back.
+// Users without a linked identity are created if signups are allowed.
+func (x *AuthService) OidcCallback(c *gin.Context) {
+	p, ok := x.oidc[c.Param("provider")]
+	if !ok {
+		c.AbortWithError(http.StatusNotFound, errors.New("unknown sign in provider"))
+		return
+	}
+	state, err := x.oidcState(c)
+	if err != nil || state.Provider != p.cfg.Name || state.ID != c.Query("state") {
+		c.AbortWithError(http.StatusBadRequest, errors.New("invalid sign in state"))
+		return
+	}
+	if errCode := c.Query("error"); errCode != "" {
+		c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("sign in failed: %s %s", errCode, c.Query("error_description")))
+		return
+	}
+
+	provider, err := p.discover(c)
+	if err != nil {
+		c.AbortWithError(http.StatusBadGateway, err)
+		return
+	}
+	token, err := p.oauth2Config(provider).Exchange(c, c.Query("code"), oauth2.VerifierOption(state.Verifier))
+	if err != nil {
+		c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("exchanging code: %w", err))
+		return
+	}
+	rawIdToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		c.AbortWithError(http.StatusUnauthorized, errors.New("no id token from provider"))
+		return
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: p.cfg.ClientId}).Verify(c, rawIdToken)
+	if err != nil {
+		c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("verifying id token: %w", err))
+		return
+	}
+	if idToken.Nonce != state.Nonce {
+		c.AbortWithError(http.StatusUnauthorized, errors.New("invalid id token nonce"))
+		return
+	}
+	var claims struct {
+		Email             string `json:"email"`
+		PreferredUsername string `json:"preferred_username"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		c.AbortWithError(http.StatusUnauthorized, err)
+		return
+	}
+
+	identity, err := x.db.GetOidcIdentity(c, idToken.Issuer, idToken.Subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	if state.LinkUserID != 0 {
+		if identity != nil {
+			if identity.UserID != state.LinkUserID {
+				c.AbortWithError(http.StatusConflict, fmt.Errorf("%s account is already linked to another user", p.cfg.Name))
+				return
+			}
+		} else if err := x.db.CreateOidcIdentity(c, &db.OidcIdentity{
+			UserID:   state.LinkUserID,
+			Provider: p.cfg.Name,
+			Issuer:   idToken.Issuer,
+			Subject:  idToken.Subject,
+			Email:    claims.Email,
+		}); err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		} else {
+			x.recordUser(c, &db.User{Model: gorm.Model{ID: state.LinkUserID}}, AuditOidcLink, map[string]any{"provider": p.cfg.Name, "email": claims.Email})
+		}
+		c.Redirect(http.StatusFound, "/profile")
+		return
+	}
+
+	var user *db.User
+	if identity != nil {
+		user = &identity.User
+	} else {
+		if !x.settings.SignupsEnabled() {
+			c.AbortWithError(http.StatusForbidden, fmt.Errorf("no user is linked to this %s account, sign in and link it from your profile", p.cfg.Name))
+			return
+		}
+		username := claims.PreferredUsername
+		if username == "" {
+			username = claims.Email
+		}
+		if username == "" {
+			username = p.cfg.Name + "-" + idToken.Subject
+		}
+		user, err = x.db.CreateOidcUser(c, username, &db.OidcIdentity{
+			Provider: p.cfg.Name,
+			Issuer:   idToken.Issuer,
+			Subject:  idToken.Subject,
+			Email:    claims.Email,
+		})
+		if err != nil {
+			c.AbortWithError(http.StatusConflict, fmt.Errorf("creating user %s, if it's yours sign in and link your %s account from your profile: %w", username, p.cfg.Name, err))
+			return
+		}
+	}
+
+	if user.Disabled {
+		c.AbortWithError(http.StatusForbidden, errors.New("user disabled"))
+		return
+	}
+	if admin, managed := p.admin(idToken); managed && admin != user.Admin {
+		if err := x.db.SetUserAdmin(c, user.ID, admin); err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	session, expiresAt, err := x.sessions.Create(user.Username, user.TokenVersion)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	x.sessions.SetCookie(c.Writer, session, expiresAt)
+	x.recordUser(c, user, AuditLogin, map[string]any{"provider": p.cfg.Name})
+	c.Redirect(http.StatusFound, "/events")
+}
+
+// OidcUnlink stops the user signing in with an identity, as long as they can still sign in some other way
+//
+// Notes
+// Must be used after `AuthRequired`
+func (x *AuthService) OidcUnlink(c *gin.Context) {
+	user := c.MustGet(gin.AuthUserKey).(*db.User)
+	identityId, err := strconv.ParseUint(c.Param("identityId"), 10, 64)
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	if user.Password == "" && len(user.OidcIdentities) <= 1 {
+		c.AbortWithError(http.StatusBadRequest, errors.New("can't unlink the only way to sign in"))
+		return
+	}
+	if err := x.db.DeleteOidcIdentity(c, user.ID, uint(identityId)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.AbortWithError(http.StatusNotFound, err)
+		} else {
+			c.AbortWithError(http.StatusInternalServerError, err)
+		}
+		return
+	}
+	x.audit.Record(c, Audit{Action: AuditOidcUnlink, Target: UserTarget(user.ID), Before: map[string]any{"identity": identityId}})
+	c.Status(http.StatusOK)
+}
+
+// gets the state from the cookie, which is only good for one sign in
+func (x *AuthService) oidcState(c *gin.Context) (*oidcState, error) {
+	cookie, err := c.Cookie(oidcStateCookie)
+	if err != nil {
+		return nil, err
+	}
+	x.setOidcStateCookie(c, "", -1)
+
+	var state oidcState
+	_, err = jwt.ParseWithClaims(cookie, &state, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return x.oidcStateKey(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (x *AuthService) setOidcStateCookie(c *gin.Context, value string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    value,
+		Path:     "/auth/oidc",
+		MaxAge:   maxAge,
+		Secure:   x.sessions.Secure(),
+		HttpOnly: true,
+		// lax so it's sent when the provider redirects back
+		SameSite: http.SameSiteLaxMode,
+	})
+}
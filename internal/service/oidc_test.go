@@ -0,0 +1,270 @@
+package service_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// mockIssuer is a minimal OpenID Connect provider, issuing ID tokens for codes registered by the test
+type mockIssuer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]mockGrant
+}
+
+type mockGrant struct {
+	challenge string
+	claims    jwt.MapClaims
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	issuer := &mockIssuer{key: key, codes: map[string]mockGrant{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                issuer.URL,
+			"authorization_endpoint":                issuer.URL + "/authorize",
+			"token_endpoint":                        issuer.URL + "/token",
+			"jwks_uri":                              issuer.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		grant, ok := issuer.codes[r.PostFormValue("code")]
+		issuer.mu.Unlock()
+		verifier := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(verifier[:]) != grant.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, grant.claims)
+		token.Header["kid"] = "test"
+		idToken, err := token.SignedString(key)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+	return issuer
+}
+
+// authorize stands in for the user signing in at the provider, giving back the code it redirects with
+func (i *mockIssuer) authorize(t *testing.T, authUrl string, claims jwt.MapClaims) string {
+	u, err := url.Parse(authUrl)
+	require.NoError(t, err)
+	query := u.Query()
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+
+	now := time.Now()
+	claims["iss"] = i.URL
+	claims["aud"] = query.Get("client_id")
+	claims["nonce"] = query.Get("nonce")
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(time.Hour).Unix()
+
+	code := rand.Text()
+	i.mu.Lock()
+	i.codes[code] = mockGrant{challenge: query.Get("code_challenge"), claims: claims}
+	i.mu.Unlock()
+	return code
+}
+
+func TestOidc(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	issuer := newMockIssuer(t)
+
+	corp := &config.OidcProvider{Name: "corp", Issuer: issuer.URL, ClientId: "eventpix", ClientSecret: "client secret"}
+	cfg := &config.Config{
+		Server: &config.Server{SecretKey: "secret", ServerUrl: "http://eventpix.test"},
+		Oidc:   []*config.OidcProvider{corp},
+	}
+	adminsCfg := &config.Config{
+		Server: cfg.Server,
+		Oidc:   []*config.OidcProvider{{Name: "corp", Issuer: issuer.URL, ClientId: "eventpix", ClientSecret: "client secret", AdminGroup: "eventpix-admins"}},
+	}
+	noSignupsCfg := &config.Config{
+		Server: &config.Server{SecretKey: "secret", ServerUrl: "http://eventpix.test", DisableSignups: true},
+		Oidc:   []*config.OidcProvider{corp},
+	}
+
+	// follows the sign in through the provider, returning the callback response
+	signIn := func(t *testing.T, router *gin.Engine, path string, claims jwt.MapClaims) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusFound, w.Code)
+		authUrl := w.Header().Get("Location")
+		stateCookie := w.Result().Cookies()[0]
+
+		code := issuer.authorize(t, authUrl, claims)
+		u, _ := url.Parse(authUrl)
+		callback := "/auth/oidc/corp/callback?" + url.Values{"code": {code}, "state": {u.Query().Get("state")}}.Encode()
+
+		w = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, callback, nil)
+		req.AddCookie(stateCookie)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	sessionCookie := func(w *httptest.ResponseRecorder) *http.Cookie {
+		for _, c := range w.Result().Cookies() {
+			if c.Name == auth.CookieName {
+				return c
+			}
+		}
+		return nil
+	}
+
+	t.Run("provisions new users and maps admins", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newAuthService(t, adminsCfg)
+		router := gin.New()
+		router.GET("/auth/oidc/:provider/login", svc.OidcLogin)
+		router.GET("/auth/oidc/:provider/callback", svc.OidcCallback)
+
+		mdb.EXPECT().GetOidcIdentity(mock.Anything, issuer.URL, "new-sub").Return(nil, gorm.ErrRecordNotFound)
+		mdb.EXPECT().
+			CreateOidcUser(mock.Anything, "alice", mock.MatchedBy(func(i *db.OidcIdentity) bool {
+				return i.Provider == "corp" && i.Issuer == issuer.URL && i.Subject == "new-sub" && i.Email == "alice@example.com"
+			})).
+			Return(&db.User{Model: gorm.Model{ID: 1}, Username: "alice"}, nil)
+		mdb.EXPECT().SetUserAdmin(mock.Anything, uint(1), true).Return(nil)
+
+		w := signIn(t, router, "/auth/oidc/corp/login", jwt.MapClaims{
+			"sub":                "new-sub",
+			"email":              "alice@example.com",
+			"preferred_username": "alice",
+			"groups":             []string{"staff", "eventpix-admins"},
+		})
+		is.Equal(http.StatusFound, w.Code)
+		is.Equal("/events", w.Header().Get("Location"))
+		cookie := sessionCookie(w)
+		is.NotNil(cookie)
+		claims, err := auth.VerifyToken("secret", cookie.Value)
+		is.NoError(err)
+		is.Equal("alice", claims.Subject)
+	})
+
+	t.Run("signs in linked users", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newAuthService(t, noSignupsCfg)
+		router := gin.New()
+		router.GET("/auth/oidc/:provider/login", svc.OidcLogin)
+		router.GET("/auth/oidc/:provider/callback", svc.OidcCallback)
+
+		mdb.EXPECT().
+			GetOidcIdentity(mock.Anything, issuer.URL, "linked-sub").
+			Return(&db.OidcIdentity{UserID: 2, User: db.User{Model: gorm.Model{ID: 2}, Username: "bob", TokenVersion: 3}}, nil)
+
+		w := signIn(t, router, "/auth/oidc/corp/login", jwt.MapClaims{"sub": "linked-sub"})
+		is.Equal(http.StatusFound, w.Code)
+		claims, err := auth.VerifyToken("secret", sessionCookie(w).Value)
+		is.NoError(err)
+		is.Equal("bob", claims.Subject)
+		is.Equal(uint(3), claims.Version)
+	})
+
+	t.Run("no signups", func(t *testing.T) {
+		t.Parallel()
+		svc, mdb := newAuthService(t, noSignupsCfg)
+		router := gin.New()
+		router.GET("/auth/oidc/:provider/login", svc.OidcLogin)
+		router.GET("/auth/oidc/:provider/callback", svc.OidcCallback)
+
+		mdb.EXPECT().GetOidcIdentity(mock.Anything, issuer.URL, "unknown-sub").Return(nil, gorm.ErrRecordNotFound)
+
+		w := signIn(t, router, "/auth/oidc/corp/login", jwt.MapClaims{"sub": "unknown-sub"})
+		require.Equal(t, http.StatusForbidden, w.Code)
+		require.Nil(t, sessionCookie(w))
+	})
+
+	t.Run("links to logged in user", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newAuthService(t, noSignupsCfg)
+		router := gin.New()
+		router.GET("/auth/oidc/:provider/link", func(c *gin.Context) {
+			c.Set(gin.AuthUserKey, &db.User{Model: gorm.Model{ID: 7}, Username: "linker"})
+		}, svc.OidcLink)
+		router.GET("/auth/oidc/:provider/callback", svc.OidcCallback)
+
+		mdb.EXPECT().GetOidcIdentity(mock.Anything, issuer.URL, "link-sub").Return(nil, gorm.ErrRecordNotFound)
+		mdb.EXPECT().
+			CreateOidcIdentity(mock.Anything, mock.MatchedBy(func(i *db.OidcIdentity) bool { return i.UserID == 7 && i.Subject == "link-sub" })).
+			Return(nil)
+
+		w := signIn(t, router, "/auth/oidc/corp/link", jwt.MapClaims{"sub": "link-sub"})
+		is.Equal(http.StatusFound, w.Code)
+		is.Equal("/profile", w.Header().Get("Location"))
+	})
+
+	t.Run("rejects forged state", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newAuthService(t, cfg)
+		router := gin.New()
+		router.GET("/auth/oidc/:provider/callback", svc.OidcCallback)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/auth/oidc/corp/callback?code=code&state=state", nil)
+		req.AddCookie(&http.Cookie{Name: "OidcState", Value: "forged"})
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newAuthService(t, cfg)
+		router := gin.New()
+		router.GET("/auth/oidc/:provider/login", svc.OidcLogin)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/oidc/other/login", nil))
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -3,15 +3,21 @@ package service
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/jj-style/eventpix/internal/cache"
 	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/data/storage"
 	eventsv1 "github.com/jj-style/eventpix/internal/gen/events/v1"
 	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/encrypt"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
 	"github.com/jj-style/eventpix/internal/pkg/validate"
 	"github.com/jj-style/eventpix/internal/service/prodto"
 	"github.com/nats-io/nats.go"
@@ -19,16 +25,41 @@ import (
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
+// how long clients have to upload a file straight to an events storage
+const presignUploadExpiry = 15 * time.Minute
+
+// ErrUploadNotPresigned is completing an upload to a key which wasn't handed out for the event
+var ErrUploadNotPresigned = errors.New("upload was not started for this event")
+
+// ErrEncryptedPresigned is an encrypted event using presigned S3 urls, which would skip the encryption
+var ErrEncryptedPresigned = errors.New("encrypted events can't use presigned S3 urls")
+
 type EventpixService interface {
 	GetEvent(context.Context, *picturev1.GetEventRequest) (*picturev1.GetEventResponse, error)
 	GetEvents(context.Context, *picturev1.GetEventsRequest, uint) (*picturev1.GetEventsResponse, error)
 	CreateEvent(context.Context, uint, *picturev1.CreateEventRequest) (*picturev1.CreateEventResponse, error)
 	GetThumbnails(context.Context, *picturev1.GetThumbnailsRequest) (*picturev1.GetThumbnailsResponse, error)
+	HideMedia(context.Context, *picturev1.HideMediaRequest) (*emptypb.Empty, error)
+	DeleteMedia(context.Context, *picturev1.DeleteMediaRequest) (*emptypb.Empty, error)
 	SetEventLive(context.Context, *picturev1.SetEventLiveRequest) (*picturev1.SetEventLiveResponse, error)
+	SetEventSchedule(context.Context, *picturev1.SetEventScheduleRequest) (*picturev1.SetEventScheduleResponse, error)
+	UpdateEvent(context.Context, *picturev1.UpdateEventRequest) (*picturev1.UpdateEventResponse, error)
+	SetEventBranding(context.Context, *picturev1.SetEventBrandingRequest) (*picturev1.SetEventBrandingResponse, error)
+	SetEventCover(ctx context.Context, eventId uint64, filename string, src io.Reader, contentType string) error
+	GetEventCover(ctx context.Context, eventId uint64) ([]byte, error)
 	DeleteEvent(context.Context, *picturev1.DeleteEventRequest) (*emptypb.Empty, error)
+	GetTrashedEvents(context.Context, *picturev1.GetTrashedEventsRequest, uint) (*picturev1.GetEventsResponse, error)
+	RestoreEvent(context.Context, *picturev1.RestoreEventRequest) (*picturev1.RestoreEventResponse, error)
+	SaveEventTemplate(context.Context, uint, *picturev1.SaveEventTemplateRequest) (*picturev1.EventTemplate, error)
+	GetEventTemplates(context.Context, uint, *picturev1.GetEventTemplatesRequest) (*picturev1.GetEventTemplatesResponse, error)
+	DeleteEventTemplate(context.Context, uint, *picturev1.DeleteEventTemplateRequest) (*emptypb.Empty, error)
 	Upload(context.Context, uint64, string, io.Reader, string) error
+	PresignUpload(context.Context, *picturev1.PresignUploadRequest) (*picturev1.PresignUploadResponse, error)
+	CompleteUpload(context.Context, *picturev1.CompleteUploadRequest) (*picturev1.UploadResponse, error)
 	GetThumbnailInfo(context.Context, string) (*picturev1.Thumbnail, error)
 	GetActiveEvent(context.Context, *picturev1.GetActiveEventRequest) (*picturev1.GetEventResponse, error)
 	SetActiveEvent(context.Context, *picturev1.SetActiveEventRequest) (*emptypb.Empty, error)
@@ -40,50 +71,48 @@ type eventpixSvc struct {
 	nc        *nats.Conn
 	validator validate.Validator
 	cache     cache.Cache
+	audit     *Auditor
 }
 
-func NewEventpixService(logger *zap.Logger, db db.DB, nc *nats.Conn, validator validate.Validator, cache cache.Cache) EventpixService {
-	return &eventpixSvc{logger: logger.Sugar(), db: db, nc: nc, validator: validator, cache: cache}
+func NewEventpixService(logger *zap.Logger, db db.DB, nc *nats.Conn, validator validate.Validator, cache cache.Cache, audit *Auditor) EventpixService {
+	return &eventpixSvc{logger: logger.Sugar(), db: db, nc: nc, validator: validator, cache: cache, audit: audit}
 }
 
 func (p *eventpixSvc) CreateEvent(ctx context.Context, userId uint, req *picturev1.CreateEventRequest) (*picturev1.CreateEventResponse, error) {
+	req, passwordHash, err := p.startEventFrom(ctx, userId, req)
+	if err != nil {
+		return nil, err
+	}
 	createEvent := &db.Event{
-		Name:   req.GetName(),
-		Slug:   req.GetSlug(),
-		Live:   req.GetLive(),
-		Cache:  req.GetCache(),
-		UserID: userId,
+		Name:         req.GetName(),
+		Slug:         req.GetSlug(),
+		Live:         req.GetLive(),
+		Cache:        req.GetCache(),
+		UserID:       userId,
+		KeyTemplate:  req.GetKeyTemplate(),
+		StartsAt:     optionalTime(req.GetStartsAt()),
+		EndsAt:       optionalTime(req.GetEndsAt()),
+		ExpiresAt:    optionalTime(req.GetExpiresAt()),
+		PasswordHash: passwordHash,
 	}
 	if pwd := req.GetPassword(); pwd != "" {
-		createEvent.Password = &gormcrypto.EncryptedValue{Raw: pwd}
-	}
-	switch st := req.GetStorage().(type) {
-	case *picturev1.CreateEventRequest_Filesystem:
-		createEvent.FileSystemStorage = &db.FileSystemStorage{
-			Directory: st.Filesystem.GetDirectory(),
-		}
-	case *picturev1.CreateEventRequest_S3:
-		createEvent.S3Storage = &db.S3Storage{
-			Bucket:    st.S3.GetBucket(),
-			AccessKey: gormcrypto.EncryptedValue{Raw: st.S3.GetAccessKey()},
-			SecretKey: gormcrypto.EncryptedValue{Raw: st.S3.GetSecretKey()},
-			Region:    st.S3.GetRegion(),
-			Endpoint:  st.S3.GetEndpoint(),
-			Insecure:  st.S3.GetInsecure(),
-		}
-	case *picturev1.CreateEventRequest_GoogleDrive:
-		createEvent.GoogleDriveStorage = &db.GoogleDriveStorage{
-			DirectoryID: st.GoogleDrive.GetFolderId(),
+		hash, err := auth.EncryptPassword(pwd)
+		if err != nil {
+			p.logger.Errorf("hashing event password: %v", err)
+			return nil, errors.New("hashing password")
 		}
-	case *picturev1.CreateEventRequest_Ftp:
-		createEvent.FtpStorage = &db.FtpStorage{
-			Address:   st.Ftp.GetAddress(),
-			Directory: st.Ftp.GetDirectory(),
-			Username:  gormcrypto.EncryptedValue{Raw: st.Ftp.GetUsername()},
-			Password:  gormcrypto.EncryptedValue{Raw: st.Ftp.GetPassword()},
+		createEvent.PasswordHash = &hash
+	}
+	if req.GetEncrypt() {
+		key, err := encrypt.NewKey()
+		if err != nil {
+			p.logger.Errorf("generating event encryption key: %v", err)
+			return nil, errors.New("generating encryption key")
 		}
-	default:
-		return nil, errors.New("unsupported storage type")
+		createEvent.EncryptionKey = &gormcrypto.EncryptedValue{Raw: base64.StdEncoding.EncodeToString(key)}
+	}
+	if err := setEventStorage(createEvent, req.GetFilesystem(), req.GetS3(), req.GetGoogleDrive(), req.GetFtp()); err != nil {
+		return nil, err
 	}
 
 	if err := p.validator.ValidateEvent(createEvent); err != nil {
@@ -97,17 +126,140 @@ func (p *eventpixSvc) CreateEvent(ctx context.Context, userId uint, req *picture
 		return nil, fmt.Errorf("creating event: %v", err)
 	}
 
+	after := map[string]any{"name": createEvent.Name, "slug": createEvent.Slug}
+	switch req.GetFrom().(type) {
+	case *picturev1.CreateEventRequest_TemplateId:
+		after["template"] = req.GetTemplateId()
+	case *picturev1.CreateEventRequest_DuplicateEventId:
+		after["duplicateOf"] = req.GetDuplicateEventId()
+	}
+	p.audit.Record(ctx, Audit{Action: AuditEventCreate, EventID: &id, After: after})
+
 	resp := prodto.CreateEventResponse(id)
 	return resp, nil
 }
 
 func (p *eventpixSvc) SetEventLive(ctx context.Context, req *picturev1.SetEventLiveRequest) (*picturev1.SetEventLiveResponse, error) {
 	evt, err := p.db.SetEventLive(ctx, req.GetId(), req.GetLive())
+	if err == nil {
+		p.audit.Record(ctx, Audit{Action: AuditEventLive, EventID: &evt.ID, After: map[string]any{"live": evt.Live}})
+		p.publishEventChanged(SubjectEventLive, evt)
+	}
 	return &picturev1.SetEventLiveResponse{Event: prodto.Event(evt, false)}, err
 }
 
+// UpdateEvent changes the events settings, recording what changed in the audit log.
+// Passwords and credentials are only recorded as changed, never their values.
+func (p *eventpixSvc) UpdateEvent(ctx context.Context, req *picturev1.UpdateEventRequest) (*picturev1.UpdateEventResponse, error) {
+	evt, err := p.db.GetEvent(ctx, req.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("getting event: %w", err)
+	}
+
+	update := &db.EventUpdate{}
+	before, after := map[string]any{}, map[string]any{}
+	if req.Name != nil && req.GetName() != evt.Name {
+		if strings.TrimSpace(req.GetName()) == "" {
+			return nil, errors.New("name is required")
+		}
+		update.Name = req.Name
+		before["name"], after["name"] = evt.Name, req.GetName()
+	}
+	if req.Slug != nil && req.GetSlug() != evt.Slug {
+		if err := p.validator.ValidateSlug(req.GetSlug()); err != nil {
+			return nil, err
+		}
+		update.Slug = req.Slug
+		before["slug"], after["slug"] = evt.Slug, req.GetSlug()
+	}
+	if req.Password != nil && (req.GetPassword() != "" || evt.PasswordHash != nil) {
+		hash := ""
+		if pwd := req.GetPassword(); pwd != "" {
+			if hash, err = auth.EncryptPassword(pwd); err != nil {
+				p.logger.Errorf("hashing event password: %v", err)
+				return nil, errors.New("hashing password")
+			}
+		}
+		update.PasswordHash = &hash
+		before["password"], after["password"] = evt.PasswordHash != nil, hash != ""
+	}
+	if req.Cache != nil && req.GetCache() != evt.Cache {
+		update.Cache = req.Cache
+		before["cache"], after["cache"] = evt.Cache, req.GetCache()
+	}
+	switch creds := req.GetCredentials().(type) {
+	case *picturev1.UpdateEventRequest_S3Credentials:
+		if evt.S3Storage == nil {
+			return nil, errors.New("event storage isn't S3, migrate it to change storage")
+		}
+		update.AccessKey, update.SecretKey = &creds.S3Credentials.AccessKey, &creds.S3Credentials.SecretKey
+		after["credentials"] = "rotated"
+	case *picturev1.UpdateEventRequest_FtpCredentials:
+		if evt.FtpStorage == nil {
+			return nil, errors.New("event storage isn't FTP, migrate it to change storage")
+		}
+		update.Username, update.Password = &creds.FtpCredentials.Username, &creds.FtpCredentials.Password
+		after["credentials"] = "rotated"
+	}
+
+	if len(after) == 0 {
+		return &picturev1.UpdateEventResponse{Event: prodto.Event(evt, false)}, nil
+	}
+	updated, err := p.db.UpdateEvent(ctx, req.GetId(), update)
+	if err != nil {
+		p.logger.Errorf("updating event %d: %v", req.GetId(), err)
+		return nil, fmt.Errorf("updating event: %w", err)
+	}
+	p.audit.Record(ctx, Audit{Action: AuditEventUpdate, EventID: &updated.ID, Before: before, After: after})
+	return &picturev1.UpdateEventResponse{Event: prodto.Event(updated, false)}, nil
+}
+
+func (p *eventpixSvc) SetEventSchedule(ctx context.Context, req *picturev1.SetEventScheduleRequest) (*picturev1.SetEventScheduleResponse, error) {
+	startsAt, endsAt, expiresAt := optionalTime(req.GetStartsAt()), optionalTime(req.GetEndsAt()), optionalTime(req.GetExpiresAt())
+	if err := p.validator.ValidateSchedule(startsAt, endsAt, expiresAt); err != nil {
+		return nil, err
+	}
+	evt, err := p.db.SetEventSchedule(ctx, req.GetId(), startsAt, endsAt, expiresAt)
+	if err != nil {
+		p.logger.Errorf("setting event %d schedule: %v", req.GetId(), err)
+		return nil, fmt.Errorf("setting event schedule: %w", err)
+	}
+	p.audit.Record(ctx, Audit{Action: AuditEventSchedule, EventID: &evt.ID, After: map[string]any{"startsAt": startsAt, "endsAt": endsAt, "expiresAt": expiresAt}})
+	return &picturev1.SetEventScheduleResponse{Event: prodto.Event(evt, false)}, nil
+}
+
 func (p *eventpixSvc) DeleteEvent(ctx context.Context, req *picturev1.DeleteEventRequest) (*emptypb.Empty, error) {
-	return &emptypb.Empty{}, p.db.DeleteEvent(ctx, req.GetId())
+	// get it first, there's nothing to say about the event once it's gone
+	evt, err := p.db.GetEvent(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.db.DeleteEvent(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	p.audit.Record(ctx, Audit{Action: AuditEventDelete, EventID: &evt.ID, Before: map[string]any{"name": evt.Name, "slug": evt.Slug}})
+	p.publishEventChanged(SubjectEventDeleted, evt)
+	return &emptypb.Empty{}, nil
+}
+
+func (p *eventpixSvc) GetTrashedEvents(ctx context.Context, _ *picturev1.GetTrashedEventsRequest, userId uint) (*picturev1.GetEventsResponse, error) {
+	events, err := p.db.GetTrashedEvents(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("getting trashed events: %v", err)
+	}
+	return &picturev1.GetEventsResponse{
+		Events: lo.Map(events, func(item *db.Event, _ int) *picturev1.Event { return prodto.Event(item, false) }),
+	}, nil
+}
+
+func (p *eventpixSvc) RestoreEvent(ctx context.Context, req *picturev1.RestoreEventRequest) (*picturev1.RestoreEventResponse, error) {
+	evt, err := p.db.RestoreEvent(ctx, req.GetId())
+	if err != nil {
+		p.logger.Errorf("restoring event %d: %v", req.GetId(), err)
+		return nil, fmt.Errorf("restoring event: %w", err)
+	}
+	p.audit.Record(ctx, Audit{Action: AuditEventRestore, EventID: &evt.ID, After: map[string]any{"slug": evt.Slug}})
+	return &picturev1.RestoreEventResponse{Event: prodto.Event(evt, false)}, nil
 }
 
 func (p *eventpixSvc) GetEvent(ctx context.Context, req *picturev1.GetEventRequest) (*picturev1.GetEventResponse, error) {
@@ -143,6 +295,13 @@ func (p *eventpixSvc) SetActiveEvent(ctx context.Context, req *picturev1.SetActi
 		p.logger.Errorf("setting active event: %v", err)
 		return nil, fmt.Errorf("failed to set active event: %v", err)
 	}
+	id := uint(req.GetId())
+	p.audit.Record(ctx, Audit{Action: AuditEventActive, EventID: &id})
+	if evt, err := p.db.GetEvent(ctx, req.GetId()); err != nil {
+		p.logger.Errorf("getting active event to publish: %v", err)
+	} else {
+		p.publishEventChanged(SubjectEventActive, evt)
+	}
 	return &emptypb.Empty{}, nil
 }
 
@@ -160,7 +319,7 @@ func (p *eventpixSvc) GetEvents(ctx context.Context, _ *picturev1.GetEventsReque
 }
 
 func (p *eventpixSvc) GetThumbnails(ctx context.Context, req *picturev1.GetThumbnailsRequest) (*picturev1.GetThumbnailsResponse, error) {
-	thumbnails, err := p.db.GetThumbnails(ctx, uint(req.GetEventId()), int(req.GetLimit()), int(req.GetOffset()))
+	thumbnails, err := p.db.GetThumbnails(ctx, uint(req.GetEventId()), int(req.GetLimit()), int(req.GetOffset()), req.GetIncludeHidden())
 	if err != nil {
 		p.logger.Errorf("getting thumbnails from db: %v", err)
 		return nil, err
@@ -181,26 +340,17 @@ func (p *eventpixSvc) GetThumbnailInfo(ctx context.Context, id string) (*picture
 }
 
 func (p *eventpixSvc) Upload(ctx context.Context, eventId uint64, filename string, src io.Reader, contentType string) error {
-	var mt eventsv1.NewMedia_MediaType
-
-	switch contentType {
-	case "image/png", "image/jpeg", "image/heif":
-		mt = eventsv1.NewMedia_IMAGE
-	case "video/avi", "video/mp4", "video/mpeg", "video/webm", "video/quicktime":
-		mt = eventsv1.NewMedia_VIDEO
-	default:
-		return fmt.Errorf("unsupported content-type: '%s'", contentType)
+	mt, err := mediaType(contentType)
+	if err != nil {
+		return err
 	}
 
-	evt, err := p.db.GetEvent(ctx, eventId)
+	evt, err := p.uploadableEvent(ctx, eventId)
 	if err != nil {
-		p.logger.Errorf("getting event to upload to: %w", err)
 		return err
 	}
 
-	if !evt.Live {
-		return errors.New("event is not live")
-	}
+	filename = storage.SanitiseName(filename)
 
 	// tee read into the cache buf so we don't have to ReadAll
 	// the file contents upfront here, can stream into the storage
@@ -210,20 +360,12 @@ func (p *eventpixSvc) Upload(ctx context.Context, eventId uint64, filename strin
 		src = io.TeeReader(src, cacheBuf)
 	}
 
-	id, err := evt.Storage.Store(ctx, filename, src)
+	counter := &countingReader{r: src}
+	id, err := evt.Storage.Store(ctx, db.MediaKey(evt, filename), counter)
 	if err != nil {
 		p.logger.Errorf("error storing image: %w", err)
 		return err
 	}
-	if err := p.db.AddFileInfo(ctx, &db.FileInfo{
-		ID:      id,
-		EventID: uint(eventId),
-		Name:    filename,
-		Video:   mt == eventsv1.NewMedia_VIDEO,
-	}); err != nil {
-		p.logger.Errorf("error storing file info: %w", err)
-		return err
-	}
 
 	if evt.Cache {
 		if err := p.cache.Set(ctx, fmt.Sprintf("%d:%s", eventId, id), cacheBuf.Bytes()); err != nil {
@@ -231,6 +373,121 @@ func (p *eventpixSvc) Upload(ctx context.Context, eventId uint64, filename strin
 		}
 	}
 
+	return p.addMedia(ctx, eventId, id, filename, mt, counter.n)
+}
+
+func (p *eventpixSvc) PresignUpload(ctx context.Context, req *picturev1.PresignUploadRequest) (*picturev1.PresignUploadResponse, error) {
+	if _, err := mediaType(req.GetContentType()); err != nil {
+		return nil, err
+	}
+
+	evt, err := p.uploadableEvent(ctx, req.GetEventId())
+	if err != nil {
+		return nil, err
+	}
+	presigner, ok := evt.Storage.(storage.Presigner)
+	if !ok {
+		return nil, errors.New("event storage does not support direct uploads")
+	}
+
+	id, url, err := presigner.PresignPut(ctx, db.MediaKey(evt, req.GetName()), presignUploadExpiry)
+	if err != nil {
+		p.logger.Errorf("presigning upload to event(%d): %v", evt.ID, err)
+		return nil, err
+	}
+	// only the key handed out here can be added to the event when the upload completes
+	if err := p.db.AddPendingUpload(ctx, &db.PendingUpload{EventID: evt.ID, ObjectKey: id, ExpiresAt: time.Now().Add(presignUploadExpiry)}); err != nil {
+		p.logger.Errorf("recording upload to event(%d): %v", evt.ID, err)
+		return nil, err
+	}
+	return &picturev1.PresignUploadResponse{Id: id, Url: url}, nil
+}
+
+func (p *eventpixSvc) CompleteUpload(ctx context.Context, req *picturev1.CompleteUploadRequest) (*picturev1.UploadResponse, error) {
+	mt, err := mediaType(req.GetContentType())
+	if err != nil {
+		return nil, err
+	}
+
+	evt, err := p.uploadableEvent(ctx, req.GetEventId())
+	if err != nil {
+		return nil, err
+	}
+	presigner, ok := evt.Storage.(storage.Presigner)
+	if !ok {
+		return nil, errors.New("event storage does not support direct uploads")
+	}
+
+	// make sure the file actually made it into the events storage before adding it to the event,
+	// going by the size stored rather than what the client says
+	size, err := presigner.Size(ctx, req.GetId())
+	if err != nil {
+		p.logger.Errorf("getting uploaded file %s in event(%d): %v", req.GetId(), evt.ID, err)
+		return nil, fmt.Errorf("file not uploaded: %v", err)
+	}
+	// the key must have been handed out for this event, not picked by the client
+	if err := p.db.TakePendingUpload(ctx, evt.ID, req.GetId()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUploadNotPresigned
+		}
+		return nil, err
+	}
+
+	if err := p.addMedia(ctx, req.GetEventId(), req.GetId(), storage.SanitiseName(req.GetName()), mt, size); err != nil {
+		return nil, err
+	}
+	return &picturev1.UploadResponse{}, nil
+}
+
+// Gets the event, checking media can currently be uploaded to it
+func (p *eventpixSvc) uploadableEvent(ctx context.Context, eventId uint64) (*db.Event, error) {
+	evt, err := p.db.GetEvent(ctx, eventId)
+	if err != nil {
+		p.logger.Errorf("getting event to upload to: %w", err)
+		return nil, err
+	}
+
+	if !evt.Live {
+		return nil, errors.New("event is not live")
+	}
+
+	if evt.Migrating {
+		return nil, errors.New("event storage is being migrated")
+	}
+	return evt, nil
+}
+
+// Uploader is the guest uploading media, for the events stats
+type Uploader struct {
+	// random id of the guests browser
+	Session string
+	// name of the guest link they got into the event with, if they did
+	Name string
+}
+
+type uploaderContextKey struct{}
+
+// WithUploader sets the guest uploading media with the context
+func WithUploader(ctx context.Context, uploader Uploader) context.Context {
+	return context.WithValue(ctx, uploaderContextKey{}, uploader)
+}
+
+// Adds the stored media to the event and lets everyone know it's there
+func (p *eventpixSvc) addMedia(ctx context.Context, eventId uint64, id, filename string, mt eventsv1.NewMedia_MediaType, size int64) error {
+	uploader, _ := ctx.Value(uploaderContextKey{}).(Uploader)
+	if err := p.db.AddFileInfo(ctx, &db.FileInfo{
+		ID:           id,
+		EventID:      uint(eventId),
+		Name:         filename,
+		Video:        mt == eventsv1.NewMedia_VIDEO,
+		Size:         size,
+		Uploader:     uploader.Name,
+		GuestSession: uploader.Session,
+	}); err != nil {
+		p.logger.Errorf("error storing file info: %w", err)
+		return err
+	}
+
 	newPhotoMsg := &eventsv1.NewMedia{
 		EventId: uint64(eventId),
 		FileId:  id,
@@ -241,9 +498,102 @@ func (p *eventpixSvc) Upload(ctx context.Context, eventId uint64, filename strin
 		p.logger.Errorf("serializing event message: %v", err)
 		return err
 	}
-	if err := p.nc.Publish("new-photo", payload); err != nil {
+	if err := p.nc.Publish(SubjectNewPhoto, payload); err != nil {
 		p.logger.Errorf("publishing new photo event: %v", err)
 	}
 
 	return nil
 }
+
+// Lets everyone know something happened to the event
+func (p *eventpixSvc) publishEventChanged(subject string, evt *db.Event) {
+	publishEventChanged(p.nc, p.logger, subject, evt)
+}
+
+func publishEventChanged(nc *nats.Conn, logger *zap.SugaredLogger, subject string, evt *db.Event) {
+	payload, err := json.Marshal(&eventsv1.EventChanged{
+		EventId: uint64(evt.ID),
+		UserId:  uint64(evt.UserID),
+		Name:    evt.Name,
+		Slug:    evt.Slug,
+		Live:    evt.Live,
+	})
+	if err != nil {
+		logger.Errorf("serializing %s message: %v", subject, err)
+		return
+	}
+	if err := nc.Publish(subject, payload); err != nil {
+		logger.Errorf("publishing %s message: %v", subject, err)
+	}
+}
+
+// optionalTime gets the time from an optional timestamp
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func mediaType(contentType string) (eventsv1.NewMedia_MediaType, error) {
+	switch contentType {
+	case "image/png", "image/jpeg", "image/heif":
+		return eventsv1.NewMedia_IMAGE, nil
+	case "video/avi", "video/mp4", "video/mpeg", "video/webm", "video/quicktime":
+		return eventsv1.NewMedia_VIDEO, nil
+	default:
+		return eventsv1.NewMedia_UNSPECIFIED, fmt.Errorf("unsupported content-type: '%s'", contentType)
+	}
+}
+
+// Sets the storage configuration on the event from whichever of the
+// storage options in a request is set.
+func setEventStorage(evt *db.Event, fs *picturev1.Filesystem, s3 *picturev1.S3, gd *picturev1.GoogleDrive, ftp *picturev1.Ftp) error {
+	switch {
+	case fs != nil:
+		evt.FileSystemStorage = &db.FileSystemStorage{
+			Directory: fs.GetDirectory(),
+		}
+	case s3 != nil:
+		evt.S3Storage = &db.S3Storage{
+			Bucket:    s3.GetBucket(),
+			AccessKey: gormcrypto.EncryptedValue{Raw: s3.GetAccessKey()},
+			SecretKey: gormcrypto.EncryptedValue{Raw: s3.GetSecretKey()},
+			Region:    s3.GetRegion(),
+			Endpoint:  s3.GetEndpoint(),
+			Insecure:  s3.GetInsecure(),
+			Presigned: s3.GetPresigned(),
+		}
+	case gd != nil:
+		evt.GoogleDriveStorage = &db.GoogleDriveStorage{
+			DirectoryID: gd.GetFolderId(),
+		}
+	case ftp != nil:
+		evt.FtpStorage = &db.FtpStorage{
+			Address:   ftp.GetAddress(),
+			Directory: ftp.GetDirectory(),
+			Username:  gormcrypto.EncryptedValue{Raw: ftp.GetUsername()},
+			Password:  gormcrypto.EncryptedValue{Raw: ftp.GetPassword()},
+		}
+	default:
+		return errors.New("unsupported storage type")
+	}
+	// presigned urls hand guests the objects straight from the bucket, which can't decrypt them
+	if evt.EncryptionKey != nil && evt.S3Storage != nil && evt.S3Storage.Presigned {
+		return ErrEncryptedPresigned
+	}
+	return nil
+}
+
+// counts the bytes read through it, to record the size of uploads
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
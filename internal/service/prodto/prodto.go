@@ -1,37 +1,69 @@
 package prodto
 
 import (
+	"time"
+
 	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/data/storage"
 	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
 	"github.com/samber/lo"
-	"google.golang.org/protobuf/types/known/wrapperspb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func Event(e *db.Event, withFileInfos bool) *picturev1.Event {
 	ret := &picturev1.Event{
-		Id:     uint64(e.ID),
-		Name:   e.Name,
-		Live:   e.Live,
-		Active: e.Active,
-		Cache:  e.Cache,
+		Id:                uint64(e.ID),
+		Name:              e.Name,
+		Slug:              e.Slug,
+		Live:              e.Live,
+		Active:            e.Active,
+		Cache:             e.Cache,
+		Encrypted:         e.EncryptionKey != nil,
+		KeyTemplate:       e.KeyTemplate,
+		PasswordProtected: e.PasswordHash != nil,
+		Role:              e.Role,
+		StartsAt:          optionalTimestamp(e.StartsAt),
+		EndsAt:            optionalTimestamp(e.EndsAt),
+		ExpiresAt:         optionalTimestamp(e.ExpiresAt),
+		Archived:          e.Archived,
+		Branding:          Branding(e.Branding),
+	}
+	if e.DeletedAt.Valid {
+		ret.Slug = e.TrashedSlug
+		ret.DeletedAt = timestamppb.New(e.DeletedAt.Time)
 	}
 	if withFileInfos {
 		ret.FileInfos = &picturev1.FileInfosValue{
 			Value: lo.Map(e.FileInfos, func(item db.FileInfo, _ int) *picturev1.FileInfo { return FileInfo(&item) }),
 		}
 	}
-	if e.Password != nil {
-		ret.Password = wrapperspb.String(e.Password.Raw.(string))
+	if _, ok := e.Storage.(storage.Presigner); ok {
+		ret.Presigned = true
 	}
 	return ret
 }
 
+func Branding(b *db.EventBranding) *picturev1.Branding {
+	if b == nil {
+		return &picturev1.Branding{}
+	}
+	return &picturev1.Branding{
+		PrimaryColor:    b.PrimaryColor,
+		BackgroundColor: b.BackgroundColor,
+		Font:            b.Font,
+		CoverImage:      b.CoverImage != "",
+		WelcomeText:     b.WelcomeText,
+		Footer:          b.Footer,
+	}
+}
+
 func FileInfo(fi *db.FileInfo) *picturev1.FileInfo {
 	return &picturev1.FileInfo{
 		Id:      fi.ID,
 		Name:    fi.Name,
 		Video:   fi.Video,
 		EventId: uint64(fi.EventID),
+		Hidden:  fi.Hidden,
 	}
 }
 
@@ -50,7 +82,34 @@ func Thumbnail(ti *db.ThumbnailInfo) *picturev1.Thumbnail {
 			Name:    ti.FileInfo.Name,
 			Video:   ti.FileInfo.Video,
 			EventId: uint64(ti.FileInfo.EventID),
+			Hidden:  ti.FileInfo.Hidden,
 		},
 		EventId: uint64(ti.EventID),
 	}
 }
+
+func StorageMigration(m *db.StorageMigration) *picturev1.StorageMigration {
+	ret := &picturev1.StorageMigration{
+		Id:        uint64(m.ID),
+		EventId:   uint64(m.EventID),
+		Total:     int64(m.Total),
+		Completed: int64(m.Completed),
+		Error:     m.Error,
+	}
+	switch m.Status {
+	case db.MigrationRunning:
+		ret.Status = picturev1.StorageMigration_RUNNING
+	case db.MigrationComplete:
+		ret.Status = picturev1.StorageMigration_COMPLETE
+	case db.MigrationFailed:
+		ret.Status = picturev1.StorageMigration_FAILED
+	}
+	return ret
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
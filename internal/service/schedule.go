@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+// how often events schedules are checked
+const scheduleInterval = 30 * time.Second
+
+// EventScheduler sets events live, not live and archives them when their schedules say to,
+// publishing the changes like they'd been made by hand.
+type EventScheduler struct {
+	db       db.DB
+	nc       *nats.Conn
+	log      *zap.SugaredLogger
+	audit    *Auditor
+	interval time.Duration
+}
+
+func NewEventScheduler(d db.DB, nc *nats.Conn, logger *zap.Logger, audit *Auditor) *EventScheduler {
+	return &EventScheduler{db: d, nc: nc, log: logger.Sugar(), audit: audit, interval: scheduleInterval}
+}
+
+// Start runs events schedules until the context is cancelled.
+// Every server can run one, each change is only made and published once.
+func (s *EventScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			if err := s.Run(ctx, time.Now()); err != nil {
+				s.log.Errorf("running event schedules: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				s.log.Info("stopping event scheduler")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Run makes the changes events schedules say to by now
+func (s *EventScheduler) Run(ctx context.Context, now time.Time) error {
+	changed, err := s.db.RunEventSchedules(ctx, now)
+	for _, evt := range changed {
+		if evt.Archived {
+			s.audit.Record(ctx, Audit{Action: AuditEventArchive, EventID: &evt.ID})
+			publishEventChanged(s.nc, s.log, SubjectEventArchived, evt)
+		} else {
+			s.audit.Record(ctx, Audit{Action: AuditEventLive, EventID: &evt.ID, After: map[string]any{"live": evt.Live}})
+			publishEventChanged(s.nc, s.log, SubjectEventLive, evt)
+		}
+	}
+	return err
+}
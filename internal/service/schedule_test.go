@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	db "github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	eventsv1 "github.com/jj-style/eventpix/internal/gen/events/v1"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestEventScheduler(t *testing.T) {
+	is := require.New(t)
+
+	ns := natsServer(t)
+	go ns.Start()
+	if !ns.ReadyForConnections(5 * time.Second) {
+		t.Fatal("nats not ready for connection")
+	}
+	t.Cleanup(ns.Shutdown)
+	nc, err := nats.Connect(ns.ClientURL())
+	is.NoError(err)
+	defer nc.Drain()
+
+	live, err := nc.SubscribeSync(service.SubjectEventLive)
+	is.NoError(err)
+	archived, err := nc.SubscribeSync(service.SubjectEventArchived)
+	is.NoError(err)
+
+	now := time.Now()
+	mdb := mockdb.NewMockDB(t)
+	mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+	mdb.EXPECT().RunEventSchedules(mock.Anything, now).Return([]*db.Event{
+		{Model: gorm.Model{ID: 1}, UserID: 2, Name: "wedding", Live: true},
+		{Model: gorm.Model{ID: 3}, UserID: 2, Name: "party", Archived: true},
+	}, nil).Once()
+
+	scheduler := service.NewEventScheduler(mdb, nc, zap.NewNop(), service.NewAuditor(mdb, zap.NewNop()))
+	is.NoError(scheduler.Run(t.Context(), now))
+
+	msg, err := live.NextMsg(time.Second)
+	is.NoError(err)
+	var changed eventsv1.EventChanged
+	is.NoError(json.Unmarshal(msg.Data, &changed))
+	is.Equal(uint64(1), changed.GetEventId())
+	is.True(changed.GetLive())
+
+	msg, err = archived.NextMsg(time.Second)
+	is.NoError(err)
+	var archivedEvent eventsv1.EventChanged
+	is.NoError(json.Unmarshal(msg.Data, &archivedEvent))
+	is.Equal(uint64(3), archivedEvent.GetEventId())
+	is.False(archivedEvent.GetLive())
+
+	// changes made before failing are still published
+	mdb.EXPECT().RunEventSchedules(mock.Anything, now).Return([]*db.Event{
+		{Model: gorm.Model{ID: 1}, UserID: 2, Name: "wedding"},
+	}, errors.New("boom")).Once()
+	is.Error(scheduler.Run(t.Context(), now))
+	msg, err = live.NextMsg(time.Second)
+	is.NoError(err)
+	var ended eventsv1.EventChanged
+	is.NoError(json.Unmarshal(msg.Data, &ended))
+	is.False(ended.GetLive())
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"gorm.io/gorm"
+)
+
+// name of the setting disabling signups in the DB
+const settingDisableSignups = "disableSignups"
+
+// Settings are instance settings admins can change whilst the server is running.
+// They start off from config, and once changed are kept in the DB so they survive restarts.
+type Settings struct {
+	db              db.DB
+	singleEventMode bool
+	disableSignups  atomic.Bool
+}
+
+func NewSettings(cfg *config.Config, d db.DB) (*Settings, error) {
+	s := &Settings{db: d, singleEventMode: cfg.Server.SingleEventMode}
+	s.disableSignups.Store(cfg.Server.DisableSignups)
+
+	value, err := d.GetSetting(context.Background(), settingDisableSignups)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		disabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+		s.disableSignups.Store(disabled)
+	}
+	return s, nil
+}
+
+// SignupsEnabled is whether new users can sign up. Never in single event mode.
+func (s *Settings) SignupsEnabled() bool {
+	return !s.singleEventMode && !s.disableSignups.Load()
+}
+
+// SignupsDisabled is whether an admin, or config, turned signups off
+func (s *Settings) SignupsDisabled() bool {
+	return s.disableSignups.Load()
+}
+
+// SingleEventMode is whether the server runs a single event, where signups can't be enabled
+func (s *Settings) SingleEventMode() bool {
+	return s.singleEventMode
+}
+
+func (s *Settings) SetDisableSignups(ctx context.Context, disabled bool) error {
+	if err := s.db.SetSetting(ctx, settingDisableSignups, strconv.FormatBool(disabled)); err != nil {
+		return err
+	}
+	s.disableSignups.Store(disabled)
+	return nil
+}
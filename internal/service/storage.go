@@ -4,15 +4,28 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/jj-style/eventpix/internal/cache"
 	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/data/storage"
 	"go.uber.org/zap"
 )
 
+// how long presigned URLs to download media from an events storage are valid for
+const PresignDownloadExpiry = time.Hour
+
 type StorageService interface {
 	GetThumbnail(ctx context.Context, id string) (string, []byte, error)
 	GetPicture(ctx context.Context, id string) (string, []byte, error)
+	// Get a URL to download the thumbnail directly from the events storage.
+	// Empty if the events storage doesn't support it.
+	GetThumbnailURL(ctx context.Context, id string) (string, error)
+	// Get a URL to download the picture directly from the events storage.
+	// Empty if the events storage doesn't support it.
+	GetPictureURL(ctx context.Context, id string) (string, error)
+	// Count the picture being viewed or downloaded, for the events stats
+	CountView(ctx context.Context, id string, download bool)
 }
 
 func NewStorageService(db db.DB, log *zap.Logger, cache cache.Cache) StorageService {
@@ -123,3 +136,50 @@ func (s *storageService) GetPicture(ctx context.Context, id string) (string, []b
 	}
 	return fi.Name, buf, nil
 }
+
+func (s *storageService) GetThumbnailURL(ctx context.Context, id string) (string, error) {
+	ti, err := s.db.GetThumbnailInfo(ctx, id)
+	if err != nil {
+		s.log.Sugar().Errorf("getting thumbnail info for %s: %v", id, err)
+		return "", err
+	}
+	return s.presignGet(ctx, &ti.Event, ti.ID, ti.Name)
+}
+
+func (s *storageService) GetPictureURL(ctx context.Context, id string) (string, error) {
+	fi, err := s.db.GetFileInfo(ctx, id)
+	if err != nil {
+		s.log.Sugar().Errorf("getting file info for %s: %v", id, err)
+		return "", err
+	}
+	return s.presignGet(ctx, &fi.Event, fi.ID, fi.Name)
+}
+
+func (s *storageService) CountView(ctx context.Context, id string, download bool) {
+	// a view which isn't counted shouldn't stop anyone seeing the picture
+	if err := s.db.CountFileView(ctx, id, download); err != nil {
+		s.log.Sugar().Warnf("counting view of %s: %v", id, err)
+	}
+}
+
+func (s *storageService) presignGet(ctx context.Context, event *db.Event, id, name string) (string, error) {
+	// only s3 can presign, save getting the events storage when it's not
+	if event.S3Storage == nil || !event.S3Storage.Presigned {
+		return "", nil
+	}
+	evt, err := s.db.GetEvent(ctx, uint64(event.ID))
+	if err != nil {
+		s.log.Sugar().Errorf("getting event for %s: %v", id, err)
+		return "", err
+	}
+	presigner, ok := evt.Storage.(storage.Presigner)
+	if !ok {
+		return "", nil
+	}
+	url, err := presigner.PresignGet(ctx, id, name, PresignDownloadExpiry)
+	if err != nil {
+		s.log.Sugar().Errorf("presigning download of %s: %v", id, err)
+		return "", err
+	}
+	return url, nil
+}
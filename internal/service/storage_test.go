@@ -10,10 +10,12 @@ import (
 	mockCache "github.com/jj-style/eventpix/internal/cache/mocks"
 	"github.com/jj-style/eventpix/internal/data/db"
 	mdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	"github.com/jj-style/eventpix/internal/data/storage"
 	mstorage "github.com/jj-style/eventpix/internal/data/storage/mocks"
 	"github.com/jj-style/eventpix/internal/service"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 func TestStorageService(t *testing.T) {
@@ -82,6 +84,60 @@ func TestStorageService(t *testing.T) {
 		is.Equal([]byte(nil), gotData)
 	})
 
+	t.Run("happy get picture url", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		s3 := &db.S3Storage{Presigned: true}
+		mdb.EXPECT().
+			GetFileInfo(ctx, t.Name()).
+			Return(&db.FileInfo{
+				EventID: 2,
+				Name:    "file.jpg",
+				ID:      t.Name(),
+				Event:   db.Event{Model: gorm.Model{ID: 2}, S3Storage: s3},
+			}, nil)
+
+		mdb.EXPECT().
+			GetEvent(ctx, uint64(2)).
+			Return(&db.Event{
+				S3Storage: s3,
+				Storage: storage.NewS3Store(&storage.S3Config{
+					Region:    "us-east-1",
+					AccessKey: "access",
+					SecretKey: "secret",
+					Bucket:    "bucket",
+					Endpoint:  "s3.example.com",
+					Presigned: true,
+				}),
+			}, nil)
+
+		got, err := svc.GetPictureURL(ctx, t.Name())
+
+		is.NoError(err)
+		is.Contains(got, "https://s3.example.com/bucket/"+t.Name())
+		is.Contains(got, "X-Amz-Signature=")
+	})
+
+	t.Run("get picture url not presigned", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb.EXPECT().
+			GetFileInfo(ctx, t.Name()).
+			Return(&db.FileInfo{
+				EventID: 1,
+				Name:    "file.jpg",
+				ID:      t.Name(),
+				Event:   db.Event{Model: gorm.Model{ID: 1}, S3Storage: &db.S3Storage{}},
+			}, nil)
+
+		got, err := svc.GetPictureURL(ctx, t.Name())
+
+		is.NoError(err)
+		is.Empty(got)
+	})
+
 	t.Run("happy get thumbnail", func(t *testing.T) {
 		t.Parallel()
 		is := require.New(t)
@@ -140,4 +196,14 @@ func TestStorageService(t *testing.T) {
 		is.Equal([]byte(nil), gotData)
 	})
 
+	t.Run("count view", func(t *testing.T) {
+		t.Parallel()
+
+		mdb.EXPECT().CountFileView(ctx, t.Name(), true).Return(nil).Once()
+		svc.CountView(ctx, t.Name(), true)
+
+		// not counting the view isn't an error for whoever's viewing
+		mdb.EXPECT().CountFileView(ctx, t.Name(), false).Return(errors.New("boom")).Once()
+		svc.CountView(ctx, t.Name(), false)
+	})
 }
@@ -0,0 +1,17 @@
+package service
+
+// NATS subjects messages are published on when things happen
+const (
+	// new media stored in an event, eventsv1.NewMedia
+	SubjectNewPhoto = "new-photo"
+	// thumbnail created for media, the thumbnail's ID
+	SubjectNewThumbnail = "new-thumbnail"
+	// event set live or not, eventsv1.EventChanged
+	SubjectEventLive = "event-live"
+	// event set as the active event, eventsv1.EventChanged
+	SubjectEventActive = "event-active"
+	// event deleted, eventsv1.EventChanged
+	SubjectEventDeleted = "event-deleted"
+	// event archived by its schedule, eventsv1.EventChanged
+	SubjectEventArchived = "event-archived"
+)
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jj-style/eventpix/internal/data/db"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	gormcrypto "github.com/pkasila/gorm-crypto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ErrNotEventOwner is returned duplicating an event the user doesn't own
+var ErrNotEventOwner = errors.New("only the events owner can duplicate it")
+
+// SaveEventTemplate saves the events storage, password and cache settings for the user to create events with
+func (p *eventpixSvc) SaveEventTemplate(ctx context.Context, userId uint, req *picturev1.SaveEventTemplateRequest) (*picturev1.EventTemplate, error) {
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	evt, err := p.db.GetEvent(ctx, req.GetEventId())
+	if err != nil {
+		return nil, fmt.Errorf("getting event: %w", err)
+	}
+
+	settings := eventSettings(evt)
+	storage, err := protojson.Marshal(&picturev1.CreateEventRequest{Storage: settings.Storage})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling storage: %v", err)
+	}
+	template := &db.EventTemplate{
+		UserID:       userId,
+		Name:         name,
+		Cache:        settings.GetCache(),
+		PasswordHash: evt.PasswordHash,
+		Encrypt:      settings.GetEncrypt(),
+		KeyTemplate:  settings.GetKeyTemplate(),
+		Storage:      gormcrypto.EncryptedValue{Raw: string(storage)},
+	}
+	if err := p.db.CreateEventTemplate(ctx, template); err != nil {
+		p.logger.Errorf("creating template from event %d: %v", evt.ID, err)
+		return nil, fmt.Errorf("creating template: %v", err)
+	}
+
+	p.audit.Record(ctx, Audit{Action: AuditTemplateSave, Target: templateTarget(template.ID), EventID: &evt.ID, After: map[string]any{"name": name}})
+	return eventTemplate(template, settings), nil
+}
+
+func (p *eventpixSvc) GetEventTemplates(ctx context.Context, userId uint, _ *picturev1.GetEventTemplatesRequest) (*picturev1.GetEventTemplatesResponse, error) {
+	templates, err := p.db.GetEventTemplates(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &picturev1.GetEventTemplatesResponse{}
+	for _, template := range templates {
+		settings, err := templateSettings(template)
+		if err != nil {
+			p.logger.Errorf("reading template %d: %v", template.ID, err)
+			return nil, errors.New("reading template")
+		}
+		resp.Templates = append(resp.Templates, eventTemplate(template, settings))
+	}
+	return resp, nil
+}
+
+func (p *eventpixSvc) DeleteEventTemplate(ctx context.Context, userId uint, req *picturev1.DeleteEventTemplateRequest) (*emptypb.Empty, error) {
+	if err := p.db.DeleteEventTemplate(ctx, userId, uint(req.GetId())); err != nil {
+		return nil, err
+	}
+	p.audit.Record(ctx, Audit{Action: AuditTemplateDelete, Target: templateTarget(uint(req.GetId()))})
+	return &emptypb.Empty{}, nil
+}
+
+// startEventFrom fills in the settings the request to create an event doesn't give from the
+// template or event it's started from, returning the password hash to create it with if
+// the request has no password
+func (p *eventpixSvc) startEventFrom(ctx context.Context, userId uint, req *picturev1.CreateEventRequest) (*picturev1.CreateEventRequest, *string, error) {
+	var (
+		from         *picturev1.CreateEventRequest
+		passwordHash *string
+	)
+	switch req.GetFrom().(type) {
+	case *picturev1.CreateEventRequest_TemplateId:
+		template, err := p.db.GetEventTemplate(ctx, userId, uint(req.GetTemplateId()))
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting template: %w", err)
+		}
+		if from, err = templateSettings(template); err != nil {
+			p.logger.Errorf("reading template %d: %v", template.ID, err)
+			return nil, nil, errors.New("reading template")
+		}
+		passwordHash = template.PasswordHash
+	case *picturev1.CreateEventRequest_DuplicateEventId:
+		owner, err := p.db.UserAuthorizedForEvent(ctx, userId, uint(req.GetDuplicateEventId()), db.RoleOwner)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !owner {
+			return nil, nil, ErrNotEventOwner
+		}
+		evt, err := p.db.GetEvent(ctx, req.GetDuplicateEventId())
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting event: %w", err)
+		}
+		from, passwordHash = eventSettings(evt), evt.PasswordHash
+	default:
+		return req, nil, nil
+	}
+
+	// anything set in the request is used instead
+	proto.Merge(from, req)
+	if req.GetPassword() != "" {
+		passwordHash = nil
+	}
+	return from, passwordHash, nil
+}
+
+// settings of the event a new one can be created with
+func eventSettings(evt *db.Event) *picturev1.CreateEventRequest {
+	settings := &picturev1.CreateEventRequest{
+		Cache:       evt.Cache,
+		Encrypt:     evt.EncryptionKey != nil,
+		KeyTemplate: evt.KeyTemplate,
+	}
+	switch {
+	case evt.FileSystemStorage != nil:
+		settings.Storage = &picturev1.CreateEventRequest_Filesystem{Filesystem: &picturev1.Filesystem{
+			Directory: evt.FileSystemStorage.Directory,
+		}}
+	case evt.S3Storage != nil:
+		settings.Storage = &picturev1.CreateEventRequest_S3{S3: &picturev1.S3{
+			Bucket:    evt.S3Storage.Bucket,
+			AccessKey: evt.S3Storage.AccessKey.Raw.(string),
+			SecretKey: evt.S3Storage.SecretKey.Raw.(string),
+			Region:    evt.S3Storage.Region,
+			Endpoint:  evt.S3Storage.Endpoint,
+			Insecure:  evt.S3Storage.Insecure,
+			Presigned: evt.S3Storage.Presigned,
+		}}
+	case evt.GoogleDriveStorage != nil:
+		settings.Storage = &picturev1.CreateEventRequest_GoogleDrive{GoogleDrive: &picturev1.GoogleDrive{
+			FolderId: evt.GoogleDriveStorage.DirectoryID,
+		}}
+	case evt.FtpStorage != nil:
+		settings.Storage = &picturev1.CreateEventRequest_Ftp{Ftp: &picturev1.Ftp{
+			Address:   evt.FtpStorage.Address,
+			Directory: evt.FtpStorage.Directory,
+			Username:  evt.FtpStorage.Username.Raw.(string),
+			Password:  evt.FtpStorage.Password.Raw.(string),
+		}}
+	}
+	return settings
+}
+
+// settings events are created with from the template
+func templateSettings(template *db.EventTemplate) (*picturev1.CreateEventRequest, error) {
+	settings := &picturev1.CreateEventRequest{}
+	if storage, _ := template.Storage.Raw.(string); storage != "" {
+		if err := protojson.Unmarshal([]byte(storage), settings); err != nil {
+			return nil, err
+		}
+	}
+	settings.Cache = template.Cache
+	settings.Encrypt = template.Encrypt
+	settings.KeyTemplate = template.KeyTemplate
+	return settings, nil
+}
+
+func eventTemplate(template *db.EventTemplate, settings *picturev1.CreateEventRequest) *picturev1.EventTemplate {
+	ret := &picturev1.EventTemplate{
+		Id:                uint64(template.ID),
+		Name:              template.Name,
+		Cache:             template.Cache,
+		PasswordProtected: template.PasswordHash != nil,
+		Encrypted:         template.Encrypt,
+		KeyTemplate:       template.KeyTemplate,
+	}
+	switch settings.GetStorage().(type) {
+	case *picturev1.CreateEventRequest_Filesystem:
+		ret.StorageType = "Filesystem"
+	case *picturev1.CreateEventRequest_S3:
+		ret.StorageType = "S3"
+	case *picturev1.CreateEventRequest_GoogleDrive:
+		ret.StorageType = "Google"
+	case *picturev1.CreateEventRequest_Ftp:
+		ret.StorageType = "Ftp"
+	}
+	return ret
+}
+
+func templateTarget(templateId uint) string {
+	return "template:" + strconv.FormatUint(uint64(templateId), 10)
+}
@@ -0,0 +1,206 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	picturev1 "github.com/jj-style/eventpix/internal/gen/picture/v1"
+	"github.com/jj-style/eventpix/internal/pkg/utils/auth"
+	"github.com/jj-style/eventpix/internal/pkg/validate"
+	"github.com/jj-style/eventpix/internal/service"
+	gormcrypto "github.com/pkasila/gorm-crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestEventTemplates(t *testing.T) {
+	t.Parallel()
+
+	newService := func(t *testing.T) (service.EventpixService, *mockdb.MockDB) {
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+		return service.NewEventpixService(zap.NewNop(), mdb, nil, validate.NewValidator(), nil, service.NewAuditor(mdb, zap.NewNop())), mdb
+	}
+
+	hash, err := auth.EncryptPassword("hunter2hunter2")
+	require.NoError(t, err)
+	wedding := &db.Event{
+		Model:        gorm.Model{ID: 3},
+		Name:         "wedding",
+		Slug:         "wedding",
+		Cache:        true,
+		PasswordHash: &hash,
+		KeyTemplate:  "{event-slug}/{uuid}",
+		S3Storage: &db.S3Storage{
+			Bucket:    "photos",
+			Endpoint:  "s3.example.com",
+			AccessKey: gormcrypto.EncryptedValue{Raw: "access"},
+			SecretKey: gormcrypto.EncryptedValue{Raw: "secret"},
+		},
+	}
+
+	// saves a template from the wedding, returning it as it would be stored
+	saveTemplate := func(t *testing.T) *db.EventTemplate {
+		is := require.New(t)
+		svc, mdb := newService(t)
+
+		var saved *db.EventTemplate
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(3)).Return(wedding, nil)
+		mdb.EXPECT().CreateEventTemplate(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, template *db.EventTemplate) error {
+				template.ID = 2
+				saved = template
+				return nil
+			})
+
+		template, err := svc.SaveEventTemplate(t.Context(), 1, &picturev1.SaveEventTemplateRequest{EventId: 3, Name: "weddings"})
+		is.NoError(err)
+		is.Equal(&picturev1.EventTemplate{
+			Id:                2,
+			Name:              "weddings",
+			Cache:             true,
+			PasswordProtected: true,
+			KeyTemplate:       "{event-slug}/{uuid}",
+			StorageType:       "S3",
+		}, template)
+		is.Equal(uint(1), saved.UserID)
+		is.Equal(&hash, saved.PasswordHash)
+		return saved
+	}
+
+	t.Run("save template needs a name", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newService(t)
+
+		_, err := svc.SaveEventTemplate(t.Context(), 1, &picturev1.SaveEventTemplateRequest{EventId: 3, Name: " "})
+		require.Error(t, err)
+	})
+
+	t.Run("create from template", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		template := saveTemplate(t)
+		svc, mdb := newService(t)
+
+		mdb.EXPECT().GetEventTemplate(mock.Anything, uint(1), uint(2)).Return(template, nil)
+		mdb.EXPECT().CreateEvent(mock.Anything, mock.MatchedBy(func(e *db.Event) bool {
+			return e.Name == "party" && e.Slug == "party" && e.UserID == 1 &&
+				e.Cache && e.PasswordHash == &hash && e.KeyTemplate == "{event-slug}/{uuid}" &&
+				e.S3Storage != nil && e.S3Storage.Bucket == "photos" &&
+				e.S3Storage.AccessKey.Raw == "access" && e.S3Storage.SecretKey.Raw == "secret"
+		})).Return(uint(7), nil)
+
+		resp, err := svc.CreateEvent(t.Context(), 1, &picturev1.CreateEventRequest{
+			Name: "party",
+			Slug: "party",
+			From: &picturev1.CreateEventRequest_TemplateId{TemplateId: 2},
+		})
+		is.NoError(err)
+		is.Equal(uint64(7), resp.GetId())
+	})
+
+	t.Run("settings in the request are used instead", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		template := saveTemplate(t)
+		svc, mdb := newService(t)
+
+		mdb.EXPECT().GetEventTemplate(mock.Anything, uint(1), uint(2)).Return(template, nil)
+		mdb.EXPECT().CreateEvent(mock.Anything, mock.MatchedBy(func(e *db.Event) bool {
+			return e.S3Storage == nil && e.FileSystemStorage != nil && e.FileSystemStorage.Directory == "/photos" &&
+				e.PasswordHash != nil && *e.PasswordHash != hash && e.KeyTemplate == "{uuid}"
+		})).Return(uint(7), nil)
+
+		_, err := svc.CreateEvent(t.Context(), 1, &picturev1.CreateEventRequest{
+			Name:        "party",
+			Slug:        "party",
+			Password:    "different",
+			KeyTemplate: "{uuid}",
+			Storage:     &picturev1.CreateEventRequest_Filesystem{Filesystem: &picturev1.Filesystem{Directory: "/photos"}},
+			From:        &picturev1.CreateEventRequest_TemplateId{TemplateId: 2},
+		})
+		is.NoError(err)
+	})
+
+	t.Run("encrypted events can't be presigned", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newService(t)
+
+		_, err := svc.CreateEvent(t.Context(), 1, &picturev1.CreateEventRequest{
+			Name:    "party",
+			Slug:    "party",
+			Encrypt: true,
+			Storage: &picturev1.CreateEventRequest_S3{S3: &picturev1.S3{Bucket: "photos", Presigned: true}},
+		})
+		require.ErrorIs(t, err, service.ErrEncryptedPresigned)
+	})
+
+	t.Run("template of another user", func(t *testing.T) {
+		t.Parallel()
+		svc, mdb := newService(t)
+
+		mdb.EXPECT().GetEventTemplate(mock.Anything, uint(1), uint(5)).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CreateEvent(t.Context(), 1, &picturev1.CreateEventRequest{
+			Name: "party",
+			Slug: "party",
+			From: &picturev1.CreateEventRequest_TemplateId{TemplateId: 5},
+		})
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("duplicate event", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		svc, mdb := newService(t)
+
+		mdb.EXPECT().UserAuthorizedForEvent(mock.Anything, uint(1), uint(3), db.RoleOwner).Return(true, nil)
+		mdb.EXPECT().GetEvent(mock.Anything, uint64(3)).Return(wedding, nil)
+		mdb.EXPECT().CreateEvent(mock.Anything, mock.MatchedBy(func(e *db.Event) bool {
+			return e.Slug == "wedding-two" && e.Cache && e.PasswordHash == &hash &&
+				e.S3Storage != nil && e.S3Storage != wedding.S3Storage && e.S3Storage.Bucket == "photos" &&
+				len(e.FileInfos) == 0
+		})).Return(uint(8), nil)
+
+		resp, err := svc.CreateEvent(t.Context(), 1, &picturev1.CreateEventRequest{
+			Name: "wedding two",
+			Slug: "wedding-two",
+			From: &picturev1.CreateEventRequest_DuplicateEventId{DuplicateEventId: 3},
+		})
+		is.NoError(err)
+		is.Equal(uint64(8), resp.GetId())
+	})
+
+	t.Run("duplicate needs owner", func(t *testing.T) {
+		t.Parallel()
+		svc, mdb := newService(t)
+
+		mdb.EXPECT().UserAuthorizedForEvent(mock.Anything, uint(2), uint(3), db.RoleOwner).Return(false, nil)
+
+		_, err := svc.CreateEvent(t.Context(), 2, &picturev1.CreateEventRequest{
+			Name: "wedding two",
+			Slug: "wedding-two",
+			From: &picturev1.CreateEventRequest_DuplicateEventId{DuplicateEventId: 3},
+		})
+		require.ErrorIs(t, err, service.ErrNotEventOwner)
+	})
+
+	t.Run("get templates", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+		template := saveTemplate(t)
+		svc, mdb := newService(t)
+
+		mdb.EXPECT().GetEventTemplates(mock.Anything, uint(1)).Return([]*db.EventTemplate{template}, nil)
+
+		resp, err := svc.GetEventTemplates(t.Context(), 1, &picturev1.GetEventTemplatesRequest{})
+		is.NoError(err)
+		is.Len(resp.GetTemplates(), 1)
+		is.Equal("weddings", resp.GetTemplates()[0].GetName())
+		is.Equal("S3", resp.GetTemplates()[0].GetStorageType())
+	})
+}
@@ -48,7 +48,7 @@ func NewThumbnailer(
 func (t *Thumbnailer) Start(ctx context.Context) error {
 	go func() {
 		t.log.Info("thumbnailer subscribing")
-		sub, err := t.nc.QueueSubscribe("new-photo", "thumbnailer", func(msg *nats.Msg) {
+		sub, err := t.nc.QueueSubscribe(SubjectNewPhoto, "thumbnailer", func(msg *nats.Msg) {
 			if err := t.Thumb(ctx, msg); err != nil {
 				t.log.Error("processing thumbnail", zap.Error(err))
 				msg.Nak()
@@ -91,9 +91,9 @@ func (t *Thumbnailer) Thumb(ctx context.Context, msg *nats.Msg) error {
 	var thumbnail io.ReadCloser
 	switch req.GetType() {
 	case eventsv1.NewMedia_IMAGE:
-		thumbnail, err = t.thumber.ThumbImage(fmt.Sprintf("%s/storage/picture/%s", t.serverUrl, req.GetFileId()))
+		thumbnail, err = t.thumber.ThumbImage(fmt.Sprintf("%s/storage/original/%s", t.serverUrl, req.GetFileId()))
 	case eventsv1.NewMedia_VIDEO:
-		thumbnail, err = t.thumber.ThumbVideo(fmt.Sprintf("%s/storage/picture/%s", t.serverUrl, req.GetFileId()))
+		thumbnail, err = t.thumber.ThumbVideo(fmt.Sprintf("%s/storage/original/%s", t.serverUrl, req.GetFileId()))
 	}
 	if err != nil {
 		t.log.Errorf("failed when generating thumbnail: %v", err)
@@ -108,7 +108,7 @@ func (t *Thumbnailer) Thumb(ctx context.Context, msg *nats.Msg) error {
 
 	tname := "thumb_" + strings.TrimRight(fi.Name, filepath.Ext(fi.Name)) + ".webp"
 
-	id, err := evt.Storage.Store(ctx, tname, thumbTee)
+	id, err := evt.Storage.Store(ctx, db.ThumbnailKey(evt, tname), thumbTee)
 	if err != nil {
 		t.log.Errorf("storing thumbnail: %v", err)
 		return err
@@ -128,7 +128,7 @@ func (t *Thumbnailer) Thumb(ctx context.Context, msg *nats.Msg) error {
 		return err
 	}
 
-	if err := t.nc.Publish("new-thumbnail", []byte(id)); err != nil {
+	if err := t.nc.Publish(SubjectNewThumbnail, []byte(id)); err != nil {
 		t.log.Errorf("sending new thumbnail message: %v", err)
 		return err
 	}
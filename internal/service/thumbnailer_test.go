@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -71,8 +72,10 @@ func TestThumbnailer(t *testing.T) {
 	// == setp mocks == //
 	// get event with events mock storage
 	mdb.EXPECT().GetEvent(mock.Anything, uint64(1)).Return(&db.Event{
-		Storage: mstorage,
-		Cache:   true,
+		Slug:        "party",
+		KeyTemplate: "{event-slug}/{name}-{uuid}",
+		Storage:     mstorage,
+		Cache:       true,
 	}, nil)
 
 	// retrieve the file info
@@ -84,7 +87,7 @@ func TestThumbnailer(t *testing.T) {
 	// create thumbnail from original
 	mockThumbnailData := io.NopCloser(bytes.NewReader([]byte("thumbnail file")))
 	mimg.EXPECT().
-		ThumbImage("http://example.com/storage/picture/abc").
+		ThumbImage("http://example.com/storage/original/abc").
 		Return(mockThumbnailData, nil)
 
 	// store thumbnail info with event
@@ -99,7 +102,9 @@ func TestThumbnailer(t *testing.T) {
 
 	// store thumbnail
 	mstorage.EXPECT().
-		Store(mock.Anything, "thumb_file.webp", mock.Anything).
+		Store(mock.Anything, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "thumbs/party/thumb_file.webp-")
+		}), mock.Anything).
 		RunAndReturn(func(ctx context.Context, s string, r io.Reader) (string, error) {
 			// kinda testing implementation here but tee-reader gets the data into the
 			// buffer for the cache set below
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/config"
+	"github.com/jj-style/eventpix/internal/data/db"
+	"github.com/jj-style/eventpix/internal/data/storage"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// how long deleted events are kept in the trash if not configured
+	DefaultTrashRetention = 30 * 24 * time.Hour
+	// how often the trash is checked for events to purge
+	purgeInterval = time.Hour
+)
+
+// TrashRetention is how long deleted events can be restored for before they're purged
+func TrashRetention(cfg *config.Config) time.Duration {
+	if cfg.Trash == nil || cfg.Trash.Retention <= 0 {
+		return DefaultTrashRetention
+	}
+	return cfg.Trash.Retention
+}
+
+// TrashPurger permanently deletes events which have been in the trash longer than
+// the retention, and optionally their media in storage.
+type TrashPurger struct {
+	db          db.DB
+	log         *zap.SugaredLogger
+	audit       *Auditor
+	retention   time.Duration
+	deleteMedia bool
+	interval    time.Duration
+}
+
+func NewTrashPurger(d db.DB, cfg *config.Config, logger *zap.Logger, audit *Auditor) *TrashPurger {
+	return &TrashPurger{
+		db:          d,
+		log:         logger.Sugar(),
+		audit:       audit,
+		retention:   TrashRetention(cfg),
+		deleteMedia: cfg.Trash != nil && cfg.Trash.DeleteMedia,
+		interval:    purgeInterval,
+	}
+}
+
+// Start purges the trash until the context is cancelled.
+// Every server can run one, each event is only purged once.
+func (p *TrashPurger) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			if err := p.Run(ctx, time.Now()); err != nil {
+				p.log.Errorf("purging trash: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				p.log.Info("stopping trash purger")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Run purges the events which have been in the trash for longer than the retention by now
+func (p *TrashPurger) Run(ctx context.Context, now time.Time) error {
+	events, err := p.db.GetTrashedEventsBefore(ctx, now.Add(-p.retention))
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := purgeEvent(ctx, p.db, p.log, p.audit, event, p.deleteMedia); err != nil {
+			// another server may have got to it first
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				p.log.Errorf("purging event(%d): %v", event.ID, err)
+			}
+			continue
+		}
+		p.log.Infof("purged event(%d) from the trash", event.ID)
+	}
+	return nil
+}
+
+// purgeEvent permanently deletes the event in the trash, loaded with its media and storage.
+// Its cover image is deleted from the storage as nothing else uses it, and its media if asked to.
+func purgeEvent(ctx context.Context, d db.DB, log *zap.SugaredLogger, audit *Auditor, event *db.Event, deleteMedia bool) error {
+	if err := d.PurgeEvent(ctx, uint64(event.ID)); err != nil {
+		return err
+	}
+	audit.Record(ctx, Audit{Action: AuditEventPurge, EventID: &event.ID, Before: map[string]any{"name": event.Name, "slug": event.TrashedSlug}})
+	if event.Branding != nil && event.Branding.CoverImage != "" && event.Storage != nil {
+		if _, err := storage.Delete(ctx, event.Storage, event.Branding.CoverImage); err != nil {
+			log.Warnf("deleting event(%d) cover %s: %v", event.ID, event.Branding.CoverImage, err)
+		}
+	}
+	if deleteMedia {
+		deleteEventMedia(ctx, log, event)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package service_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/config"
+	db "github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	"github.com/jj-style/eventpix/internal/data/storage"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestTrashPurger(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	t.Run("purges events past the retention", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		store := storage.NewMemStore()
+		for _, id := range []string{"photo", "thumb"} {
+			_, err := store.Store(t.Context(), id, strings.NewReader("data"))
+			is.NoError(err)
+		}
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+		mdb.EXPECT().GetTrashedEventsBefore(mock.Anything, now.Add(-7*24*time.Hour)).Return([]*db.Event{
+			{
+				Model:          gorm.Model{ID: 1},
+				FileInfos:      []db.FileInfo{{ID: "photo"}},
+				ThumbnailInfos: []db.ThumbnailInfo{{ID: "thumb"}},
+				Storage:        store,
+			},
+			// already purged by another server, so its media is left alone
+			{Model: gorm.Model{ID: 2}, FileInfos: []db.FileInfo{{ID: "other"}}, Storage: store},
+		}, nil)
+		mdb.EXPECT().PurgeEvent(mock.Anything, uint64(1)).Return(nil)
+		mdb.EXPECT().PurgeEvent(mock.Anything, uint64(2)).Return(gorm.ErrRecordNotFound)
+
+		purger := service.NewTrashPurger(mdb, &config.Config{Trash: &config.Trash{Retention: 7 * 24 * time.Hour, DeleteMedia: true}}, zap.NewNop(), service.NewAuditor(mdb, zap.NewNop()))
+		is.NoError(purger.Run(t.Context(), now))
+		for _, id := range []string{"photo", "thumb"} {
+			_, err := store.Get(t.Context(), id)
+			is.ErrorIs(err, storage.ErrFileNotFound)
+		}
+	})
+
+	t.Run("keeps media by default", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		store := storage.NewMemStore()
+		for _, id := range []string{"photo", "cover"} {
+			_, err := store.Store(t.Context(), id, strings.NewReader("data"))
+			is.NoError(err)
+		}
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+		mdb.EXPECT().GetTrashedEventsBefore(mock.Anything, now.Add(-service.DefaultTrashRetention)).Return([]*db.Event{
+			{Model: gorm.Model{ID: 1}, FileInfos: []db.FileInfo{{ID: "photo"}}, Branding: &db.EventBranding{CoverImage: "cover"}, Storage: store},
+		}, nil)
+		mdb.EXPECT().PurgeEvent(mock.Anything, uint64(1)).Return(nil)
+
+		purger := service.NewTrashPurger(mdb, &config.Config{}, zap.NewNop(), service.NewAuditor(mdb, zap.NewNop()))
+		is.NoError(purger.Run(t.Context(), now))
+		_, err := store.Get(t.Context(), "photo")
+		is.NoError(err)
+		// nothing else uses the cover once the event is gone
+		_, err = store.Get(t.Context(), "cover")
+		is.ErrorIs(err, storage.ErrFileNotFound)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		mdb := mockdb.NewMockDB(t)
+		mdb.EXPECT().CreateAuditLog(mock.Anything, mock.Anything).Return(nil).Maybe()
+		mdb.EXPECT().GetTrashedEventsBefore(mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+		purger := service.NewTrashPurger(mdb, &config.Config{}, zap.NewNop(), service.NewAuditor(mdb, zap.NewNop()))
+		require.Error(t, purger.Run(t.Context(), now))
+	})
+}
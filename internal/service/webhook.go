@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/data/db"
+	eventsv1 "github.com/jj-style/eventpix/internal/gen/events/v1"
+	"github.com/nats-io/nats.go"
+	gormcrypto "github.com/pkasila/gorm-crypto"
+	"go.uber.org/zap"
+)
+
+const (
+	// header deliveries are signed in, as `sha256=<hex hmac of the body>`
+	WebhookSignatureHeader = "X-Eventpix-Signature"
+	// header with the subject of the delivery
+	WebhookSubjectHeader = "X-Eventpix-Subject"
+	// header with the ID of the delivery, the same across retries
+	WebhookDeliveryHeader = "X-Eventpix-Delivery"
+
+	// times a delivery is attempted before giving up on it
+	webhookMaxAttempts = 5
+	// how long to wait for the receiver to respond
+	webhookTimeout = 10 * time.Second
+	// wait before the first retry, doubled for each retry after
+	webhookBackoff = 30 * time.Second
+	// messages being delivered at once, later ones wait for one to finish
+	webhookWorkers = 16
+)
+
+// ErrWebhookAddress is a webhook url which is, or resolves to, an address on the servers own network
+var ErrWebhookAddress = errors.New("webhook url must not be a private, loopback or link-local address")
+
+// shared address space carriers use for NAT, which isn't covered by netip.Addr.IsPrivate
+var carrierNat = netip.MustParsePrefix("100.64.0.0/10")
+
+// publicAddr is whether the address is on the internet, rather than the servers own network
+// or the cloud metadata service, which webhooks could otherwise be pointed at
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !carrierNat.Contains(addr)
+}
+
+// newWebhookClient makes a client which refuses to connect to addresses which aren't public.
+// It's checked as the connection is made, so a host can't resolve to somewhere else after
+// the webhook is created.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !publicAddr(addrPort.Addr()) {
+				return ErrWebhookAddress
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: webhookTimeout,
+		// no proxy from the environment, which would be dialled instead of the webhook
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	}
+}
+
+type WebhookSubject struct {
+	Name        string
+	Description string
+}
+
+// WebhookSubjects lists the subjects a webhook can subscribe to
+var WebhookSubjects = []WebhookSubject{
+	{SubjectNewPhoto, "Photo or video uploaded"},
+	{SubjectNewThumbnail, "Thumbnail created for an upload"},
+	{SubjectEventLive, "Event set live or not live"},
+	{SubjectEventActive, "Event set as the active event"},
+	{SubjectEventDeleted, "Event deleted"},
+	{SubjectEventArchived, "Event archived when its gallery expired"},
+}
+
+// WebhookPayload is the JSON body POSTed to webhooks
+type WebhookPayload struct {
+	// summary of what happened, so deliveries can go straight to Slack compatible endpoints
+	Text      string    `json:"text"`
+	Subject   string    `json:"subject"`
+	EventID   uint      `json:"event_id"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data"`
+}
+
+// WebhookDispatcher delivers the messages published on NATS to the webhooks
+// subscribed to them, retrying failed deliveries with exponential backoff.
+type WebhookDispatcher struct {
+	db      db.DB
+	nc      *nats.Conn
+	client  *http.Client
+	log     *zap.SugaredLogger
+	backoff time.Duration
+	// looks up the addresses of webhook hosts when they're created
+	lookup func(ctx context.Context, host string) ([]netip.Addr, error)
+	// taken whilst a message is being delivered, limiting how many are at once
+	workers chan struct{}
+}
+
+func NewWebhookDispatcher(d db.DB, nc *nats.Conn, logger *zap.Logger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		db:      d,
+		nc:      nc,
+		client:  newWebhookClient(),
+		log:     logger.Sugar(),
+		backoff: webhookBackoff,
+		lookup: func(ctx context.Context, host string) ([]netip.Addr, error) {
+			return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		},
+		workers: make(chan struct{}, webhookWorkers),
+	}
+}
+
+// CreateWebhook subscribes the url to the subjects for the user's event, or all their events if eventId is nil.
+// Returns the webhook along with the secret deliveries are signed with.
+func (w *WebhookDispatcher) CreateWebhook(ctx context.Context, userId uint, eventId *uint, rawUrl string, subjects []string) (*db.Webhook, string, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, "", errors.New("webhook url must be an absolute http(s) url")
+	}
+	// checked again when delivering, this is so it's found out about now
+	addrs, err := w.lookup(ctx, u.Hostname())
+	if err != nil {
+		return nil, "", fmt.Errorf("looking up webhook host: %w", err)
+	}
+	if slices.ContainsFunc(addrs, func(addr netip.Addr) bool { return !publicAddr(addr) }) {
+		return nil, "", ErrWebhookAddress
+	}
+	if len(subjects) == 0 {
+		return nil, "", errors.New("webhook must subscribe to at least one subject")
+	}
+	for _, subject := range subjects {
+		if !slices.ContainsFunc(WebhookSubjects, func(s WebhookSubject) bool { return s.Name == subject }) {
+			return nil, "", fmt.Errorf("unknown subject %s", subject)
+		}
+	}
+	if eventId != nil {
+		ok, err := w.db.UserAuthorizedForEvent(ctx, userId, *eventId, db.RoleManager)
+		if err != nil {
+			return nil, "", err
+		}
+		if !ok {
+			return nil, "", errors.New("user not authorized for event")
+		}
+	}
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	secret := "whsec_" + base64.RawURLEncoding.EncodeToString(b)
+
+	webhook := &db.Webhook{
+		UserID:   userId,
+		EventID:  eventId,
+		Url:      u.String(),
+		Secret:   gormcrypto.EncryptedValue{Raw: secret},
+		Subjects: strings.Join(slices.Compact(slices.Sorted(slices.Values(subjects))), ","),
+	}
+	if err := w.db.CreateWebhook(ctx, webhook); err != nil {
+		w.log.Errorf("creating webhook: %v", err)
+		return nil, "", err
+	}
+	return webhook, secret, nil
+}
+
+// Start delivers messages to webhooks until the context is cancelled.
+// Servers share a queue group so each message is only delivered once.
+func (w *WebhookDispatcher) Start(ctx context.Context) error {
+	var subs []*nats.Subscription
+	for _, subject := range WebhookSubjects {
+		sub, err := w.nc.QueueSubscribe(subject.Name, "webhooks", func(msg *nats.Msg) {
+			// retries can take a while, so don't hold up the next message unless
+			// there are already as many being delivered as there are workers
+			select {
+			case w.workers <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			go func() {
+				defer func() { <-w.workers }()
+				if err := w.Dispatch(ctx, msg); err != nil {
+					w.log.Errorf("dispatching %s webhooks: %v", msg.Subject, err)
+				}
+			}()
+		})
+		if err != nil {
+			return fmt.Errorf("subscribing to %s: %w", subject.Name, err)
+		}
+		subs = append(subs, sub)
+	}
+	go func() {
+		<-ctx.Done()
+		w.log.Info("stopping webhook dispatcher")
+		for _, sub := range subs {
+			sub.Drain()
+		}
+	}()
+	return nil
+}
+
+// Recover fails any deliveries which were interrupted whilst being retried.
+func (w *WebhookDispatcher) Recover(ctx context.Context) error {
+	return w.db.FailPendingWebhookDeliveries(ctx)
+}
+
+// Dispatch delivers the message to every webhook subscribed to it, returning once
+// each delivery has either succeeded or run out of attempts.
+func (w *WebhookDispatcher) Dispatch(ctx context.Context, msg *nats.Msg) error {
+	payload, userId, err := w.payload(ctx, msg)
+	if err != nil {
+		return err
+	}
+	webhooks, err := w.db.GetEventWebhooks(ctx, userId, payload.EventID)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, webhook := range webhooks {
+		if !slices.Contains(strings.Split(webhook.Subjects, ","), msg.Subject) {
+			continue
+		}
+		delivery := &db.WebhookDelivery{
+			WebhookID: webhook.ID,
+			Subject:   msg.Subject,
+			Payload:   string(body),
+			Status:    db.DeliveryPending,
+		}
+		if err := w.db.CreateWebhookDelivery(ctx, delivery); err != nil {
+			w.log.Errorf("creating webhook(%d) delivery: %v", webhook.ID, err)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.deliver(ctx, webhook, delivery)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// builds what to send for the message, along with the user who owns the event it's about
+func (w *WebhookDispatcher) payload(ctx context.Context, msg *nats.Msg) (*WebhookPayload, uint, error) {
+	payload := &WebhookPayload{Subject: msg.Subject, Timestamp: time.Now().UTC()}
+	switch msg.Subject {
+	case SubjectNewPhoto:
+		var media eventsv1.NewMedia
+		if err := json.Unmarshal(msg.Data, &media); err != nil {
+			return nil, 0, fmt.Errorf("unmarshaling new media message: %w", err)
+		}
+		evt, err := w.db.GetEvent(ctx, media.GetEventId())
+		if err != nil {
+			return nil, 0, err
+		}
+		kind := "photo"
+		if media.GetType() == eventsv1.NewMedia_VIDEO {
+			kind = "video"
+		}
+		payload.Text = fmt.Sprintf("New %s uploaded to %s", kind, evt.Name)
+		payload.EventID = evt.ID
+		payload.Data = map[string]any{"file_id": media.GetFileId(), "type": kind}
+		return payload, evt.UserID, nil
+	case SubjectNewThumbnail:
+		ti, err := w.db.GetThumbnailInfo(ctx, string(msg.Data))
+		if err != nil {
+			return nil, 0, err
+		}
+		payload.Text = fmt.Sprintf("New thumbnail created in %s", ti.Event.Name)
+		payload.EventID = ti.EventID
+		payload.Data = map[string]any{"thumbnail_id": ti.ID, "file_id": ti.FileInfoID}
+		return payload, ti.Event.UserID, nil
+	case SubjectEventLive, SubjectEventActive, SubjectEventDeleted, SubjectEventArchived:
+		var changed eventsv1.EventChanged
+		if err := json.Unmarshal(msg.Data, &changed); err != nil {
+			return nil, 0, fmt.Errorf("unmarshaling event changed message: %w", err)
+		}
+		switch msg.Subject {
+		case SubjectEventLive:
+			if changed.GetLive() {
+				payload.Text = fmt.Sprintf("%s is now live", changed.GetName())
+			} else {
+				payload.Text = fmt.Sprintf("%s is no longer live", changed.GetName())
+			}
+		case SubjectEventActive:
+			payload.Text = fmt.Sprintf("%s is now the active event", changed.GetName())
+		case SubjectEventDeleted:
+			payload.Text = fmt.Sprintf("%s was deleted", changed.GetName())
+		case SubjectEventArchived:
+			payload.Text = fmt.Sprintf("%s has ended and was archived", changed.GetName())
+		}
+		payload.EventID = uint(changed.GetEventId())
+		payload.Data = map[string]any{"name": changed.GetName(), "slug": changed.GetSlug(), "live": changed.GetLive()}
+		return payload, uint(changed.GetUserId()), nil
+	default:
+		return nil, 0, fmt.Errorf("no webhooks for subject %s", msg.Subject)
+	}
+}
+
+// attempts the delivery until it succeeds, backing off between each attempt
+func (w *WebhookDispatcher) deliver(ctx context.Context, webhook *db.Webhook, delivery *db.WebhookDelivery) {
+	for delivery.Attempts < webhookMaxAttempts {
+		if delivery.Attempts > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(w.backoff << (delivery.Attempts - 1)):
+			}
+		}
+
+		delivery.Attempts++
+		delivery.StatusCode, delivery.Error = 0, ""
+		code, err := w.send(ctx, webhook, delivery)
+		delivery.StatusCode = code
+		switch {
+		case err == nil:
+			delivery.Status = db.DeliverySucceeded
+		case delivery.Attempts == webhookMaxAttempts:
+			delivery.Status = db.DeliveryFailed
+			delivery.Error = err.Error()
+		default:
+			delivery.Error = err.Error()
+		}
+		if err := w.db.UpdateWebhookDelivery(ctx, delivery); err != nil {
+			w.log.Errorf("saving webhook(%d) delivery(%d): %v", webhook.ID, delivery.ID, err)
+		}
+		if err == nil {
+			return
+		}
+	}
+}
+
+func (w *WebhookDispatcher) send(ctx context.Context, webhook *db.Webhook, delivery *db.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "eventpix-webhooks")
+	req.Header.Set(WebhookSubjectHeader, delivery.Subject)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(webhook.Secret.Raw.(string), body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook signs the body with the webhook's secret, in the form sent in the WebhookSignatureHeader
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,180 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jj-style/eventpix/internal/data/db"
+	mockdb "github.com/jj-style/eventpix/internal/data/db/mocks"
+	eventsv1 "github.com/jj-style/eventpix/internal/gen/events/v1"
+	"github.com/jj-style/eventpix/internal/service"
+	"github.com/nats-io/nats.go"
+	gormcrypto "github.com/pkasila/gorm-crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestWebhookDispatcher(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("delivers signed payload after retrying", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		var calls atomic.Int32
+		var body []byte
+		var signature string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// fail the first attempt to make it retry
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			body, _ = io.ReadAll(r.Body)
+			signature = r.Header.Get(service.WebhookSignatureHeader)
+		}))
+		t.Cleanup(srv.Close)
+
+		mdb := mockdb.NewMockDB(t)
+		dispatcher := service.NewWebhookDispatcher(mdb, nil, zap.NewNop())
+		service.SetWebhookBackoff(dispatcher, time.Millisecond)
+		service.AllowLocalWebhooks(dispatcher)
+
+		mdb.EXPECT().
+			GetEvent(ctx, uint64(1)).
+			Return(&db.Event{Model: gorm.Model{ID: 1}, Name: "party", UserID: 2}, nil)
+		mdb.EXPECT().
+			GetEventWebhooks(ctx, uint(2), uint(1)).
+			Return([]*db.Webhook{
+				{Model: gorm.Model{ID: 3}, Url: srv.URL, Secret: gormcrypto.EncryptedValue{Raw: "secret"}, Subjects: "event-live,new-photo"},
+				// not subscribed, so never called
+				{Model: gorm.Model{ID: 4}, Url: "http://localhost:1", Secret: gormcrypto.EncryptedValue{Raw: "secret"}, Subjects: "event-deleted"},
+			}, nil)
+		mdb.EXPECT().
+			CreateWebhookDelivery(ctx, mock.MatchedBy(func(d *db.WebhookDelivery) bool { return d.WebhookID == 3 })).
+			Return(nil).
+			Once()
+		var statuses []string
+		mdb.EXPECT().
+			UpdateWebhookDelivery(ctx, mock.Anything).
+			Run(func(_ context.Context, d *db.WebhookDelivery) { statuses = append(statuses, d.Status) }).
+			Return(nil)
+
+		data, err := json.Marshal(&eventsv1.NewMedia{EventId: 1, FileId: "file", Type: eventsv1.NewMedia_IMAGE})
+		is.NoError(err)
+		is.NoError(dispatcher.Dispatch(ctx, &nats.Msg{Subject: service.SubjectNewPhoto, Data: data}))
+
+		is.Equal(int32(2), calls.Load())
+		is.Equal([]string{db.DeliveryPending, db.DeliverySucceeded}, statuses)
+		is.Equal(service.SignWebhook("secret", body), signature)
+
+		var payload service.WebhookPayload
+		is.NoError(json.Unmarshal(body, &payload))
+		is.Equal("New photo uploaded to party", payload.Text)
+		is.Equal(uint(1), payload.EventID)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(srv.Close)
+
+		mdb := mockdb.NewMockDB(t)
+		dispatcher := service.NewWebhookDispatcher(mdb, nil, zap.NewNop())
+		service.SetWebhookBackoff(dispatcher, time.Millisecond)
+		service.AllowLocalWebhooks(dispatcher)
+
+		mdb.EXPECT().
+			GetEventWebhooks(ctx, uint(2), uint(1)).
+			Return([]*db.Webhook{{Model: gorm.Model{ID: 3}, Url: srv.URL, Secret: gormcrypto.EncryptedValue{Raw: "secret"}, Subjects: "event-deleted"}}, nil)
+		mdb.EXPECT().
+			CreateWebhookDelivery(ctx, mock.Anything).
+			Return(nil)
+		var last *db.WebhookDelivery
+		mdb.EXPECT().
+			UpdateWebhookDelivery(ctx, mock.Anything).
+			Run(func(_ context.Context, d *db.WebhookDelivery) { last = d }).
+			Return(nil)
+
+		data, err := json.Marshal(&eventsv1.EventChanged{EventId: 1, UserId: 2, Name: "party"})
+		is.NoError(err)
+		is.NoError(dispatcher.Dispatch(ctx, &nats.Msg{Subject: service.SubjectEventDeleted, Data: data}))
+
+		is.Equal(db.DeliveryFailed, last.Status)
+		is.Equal(5, last.Attempts)
+		is.Equal(http.StatusInternalServerError, last.StatusCode)
+	})
+
+	t.Run("create validates url", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		mdb := mockdb.NewMockDB(t)
+		dispatcher := service.NewWebhookDispatcher(mdb, nil, zap.NewNop())
+		service.SetWebhookLookup(dispatcher, map[string]string{"example.com": "93.184.215.14", "internal.example.com": "10.0.0.5"})
+
+		// can't be pointed at the servers own network
+		for _, url := range []string{"http://127.0.0.1:8080", "http://[::1]/", "http://169.254.169.254/latest/meta-data", "https://internal.example.com", "http://100.64.0.1"} {
+			_, _, err := dispatcher.CreateWebhook(ctx, 1, nil, url, []string{service.SubjectNewPhoto})
+			is.ErrorIs(err, service.ErrWebhookAddress, url)
+		}
+
+		_, _, err := dispatcher.CreateWebhook(ctx, 1, nil, "ftp://example.com", []string{service.SubjectNewPhoto})
+		is.Error(err)
+		_, _, err = dispatcher.CreateWebhook(ctx, 1, nil, "https://example.com", []string{"unknown"})
+		is.Error(err)
+
+		mdb.EXPECT().
+			CreateWebhook(ctx, mock.MatchedBy(func(w *db.Webhook) bool { return w.Subjects == "event-live,new-photo" })).
+			Return(nil)
+		_, secret, err := dispatcher.CreateWebhook(ctx, 1, nil, "https://example.com", []string{"new-photo", "event-live", "new-photo"})
+		is.NoError(err)
+		is.NotEmpty(secret)
+	})
+
+	t.Run("won't deliver to the servers own network", func(t *testing.T) {
+		t.Parallel()
+		is := require.New(t)
+
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { calls.Add(1) }))
+		t.Cleanup(srv.Close)
+
+		mdb := mockdb.NewMockDB(t)
+		dispatcher := service.NewWebhookDispatcher(mdb, nil, zap.NewNop())
+		service.SetWebhookBackoff(dispatcher, time.Millisecond)
+
+		// e.g. the host resolved somewhere public when it was created, and doesn't any more
+		mdb.EXPECT().
+			GetEventWebhooks(ctx, uint(2), uint(1)).
+			Return([]*db.Webhook{{Model: gorm.Model{ID: 3}, Url: srv.URL, Secret: gormcrypto.EncryptedValue{Raw: "secret"}, Subjects: "event-deleted"}}, nil)
+		mdb.EXPECT().CreateWebhookDelivery(ctx, mock.Anything).Return(nil)
+		var last *db.WebhookDelivery
+		mdb.EXPECT().
+			UpdateWebhookDelivery(ctx, mock.Anything).
+			Run(func(_ context.Context, d *db.WebhookDelivery) { last = d }).
+			Return(nil)
+
+		data, err := json.Marshal(&eventsv1.EventChanged{EventId: 1, UserId: 2, Name: "party"})
+		is.NoError(err)
+		is.NoError(dispatcher.Dispatch(ctx, &nats.Msg{Subject: service.SubjectEventDeleted, Data: data}))
+
+		is.Equal(int32(0), calls.Load())
+		is.Equal(db.DeliveryFailed, last.Status)
+		is.Contains(last.Error, service.ErrWebhookAddress.Error())
+	})
+}